@@ -1,18 +1,39 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	_ "meu-servico-agenda/docs"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"meu-servico-agenda/internal/adapters/http/agendamento"
+	"meu-servico-agenda/internal/adapters/http/auth"
+	"meu-servico-agenda/internal/adapters/http/calendario"
 	"meu-servico-agenda/internal/adapters/http/catalogo"
 	"meu-servico-agenda/internal/adapters/http/cliente"
+	"meu-servico-agenda/internal/adapters/http/lgpd"
+	"meu-servico-agenda/internal/adapters/http/lista_espera"
+	"meu-servico-agenda/internal/adapters/http/middleware"
+	"meu-servico-agenda/internal/adapters/http/modelo_agenda"
 	"meu-servico-agenda/internal/adapters/http/prestador"
+	"meu-servico-agenda/internal/adapters/http/resposta"
+	"meu-servico-agenda/internal/adapters/http/saude"
+	"meu-servico-agenda/internal/infra/config"
 	"meu-servico-agenda/internal/infra/database"
+	"meu-servico-agenda/internal/infra/jwt"
+	"meu-servico-agenda/internal/infra/logging"
+	"meu-servico-agenda/internal/infra/metricas"
 
 	"meu-servico-agenda/internal/adapters/repository"
 	"meu-servico-agenda/internal/core/application/service"
+	"meu-servico-agenda/internal/core/domain"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -21,76 +42,268 @@ import (
 
 // @title API de Agendamentos
 // @version 1.0
-// @description API para gestão de clientes e serviços.
+// @description API para gestão de clientes e serviços. Erros seguem o formato application/problem+json com um code estável; a mensagem em detail é escrita no idioma pedido em Accept-Language (pt-BR, padrão, ou en).
 // @host localhost:8080
 // @BasePath /api/v1
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Token de acesso obtido em /auth/login, no formato "Bearer <token>"
 func main() {
 
+	// "api migrate ..." só cuida do schema e não sobe a API
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		logging.Configurar(os.Stderr, "info")
+		if err := executarMigrate(os.Args[2:]); err != nil {
+			encerrar("erro na migração", err)
+		}
+		return
+	}
+
+	// Configuração vem do ambiente; qualquer valor inválido impede a API de subir
+	cfg, err := config.Carregar()
+	if err != nil {
+		encerrar("configuração inválida", err)
+	}
+
+	// Logs em JSON, com o request_id de cada requisição
+	logging.Configurar(os.Stdout, cfg.LogLevel)
+
 	// 0. Conexão com o banco de dadoss (Infraestrutura)
-	db, err := database.Connect()
+	db, err := database.Connect(cfg.Database)
 	if err != nil {
-		log.Fatal(err)
+		encerrar("erro ao conectar no banco", err)
 	}
 	defer db.Close()
 
+	// As migrações vão embutidas no binário; a API só sobe com o schema em dia
+	if cfg.Database.MigrarAoIniciar {
+		if _, err := migrar(db, false); err != nil {
+			encerrar("erro ao aplicar migrações", err)
+		}
+	}
+
 	// 1. Camada de Repositório (Infraestrutura)
 	clienteRepo := repository.NovoClientePostgresRepositorio(db)
 	prestadorRepo := repository.NewPrestadorPostgresRepository(db)
 	catalogoRepo := repository.NovoCatalogoPostgresRepositorio(db)
 	agendaDiariaRepo := repository.NovoAgendaDiariaPostgresRepository(db)
 	agendamentoRepo := repository.NovoAgendamentoPostgresRepository(db)
+	modeloAgendaRepo := repository.NovoModeloAgendaPostgresRepository(db)
+	idempotenciaRepo := repository.NovoIdempotenciaPostgresRepository(db)
+	listaEsperaRepo := repository.NovoListaEsperaPostgresRepository(db)
+	solicitacaoLGPDRepo := repository.NovoSolicitacaoLGPDPostgresRepository(db)
+	usuarioRepo := repository.NovoUsuarioPostgresRepository(db)
+	refreshTokenRepo := repository.NovoRefreshTokenPostgresRepository(db)
+	tokenCalendarioRepo := repository.NovoTokenCalendarioPostgresRepository(db)
+	emissorToken := jwt.NovoEmissorHS256([]byte(cfg.Auth.JWTSegredo), cfg.Auth.AccessTokenTTL)
 
 	// 2. Camada de Aplicação (Serviços/Casos de Uso)
 	cadastroCliente := service.NovoServiceCliente(clienteRepo)
 	cadastroPrestador := service.NovaPrestadorService(prestadorRepo, catalogoRepo, agendaDiariaRepo)
 	cadastraCatalogo := service.NovoCatalogoService(catalogoRepo)
 	cadastraAgendamento := service.NovaAgendamentoService(prestadorRepo, agendamentoRepo, catalogoRepo, clienteRepo)
+	modeloAgendaService := service.NovoModeloAgendaService(prestadorRepo, modeloAgendaRepo, agendaDiariaRepo)
+	listaEsperaService := service.NovaListaEsperaService(listaEsperaRepo, prestadorRepo, catalogoRepo, clienteRepo, cadastraAgendamento)
+	lgpdService := service.NovaLGPDService(clienteRepo, agendamentoRepo, listaEsperaRepo, solicitacaoLGPDRepo, tokenCalendarioRepo, usuarioRepo, refreshTokenRepo)
+	calendarioService := service.NovaCalendarioService(clienteRepo, prestadorRepo, agendamentoRepo, tokenCalendarioRepo)
+	authService := service.NovaAuthService(usuarioRepo, refreshTokenRepo, clienteRepo, prestadorRepo, emissorToken, cfg.Auth.RefreshTokenTTL)
+
+	paginacao := service.Paginacao{LimitePadrao: cfg.Paginacao.LimitePadrao, LimiteMaximo: cfg.Paginacao.LimiteMaximo}
+	cadastroCliente.DefinirPaginacao(paginacao)
+	cadastroPrestador.DefinirPaginacao(paginacao)
+	cadastraCatalogo.DefinirPaginacao(paginacao)
+
+	// O primeiro admin vem da configuração; os demais usuários são cadastrados por ele
+	if cfg.Auth.AdminEmail != "" {
+		if err := authService.GarantirAdmin(context.Background(), cfg.Auth.AdminEmail, cfg.Auth.AdminSenha); err != nil {
+			encerrar("erro ao criar o usuário admin", err)
+		}
+	}
+
+	// Consultas e gravações da agenda e do agendamento dividem a mesma transação,
+	// e as agendas geradas por um modelo são gravadas juntas
+	unidadeDeTrabalho := repository.NovaUnidadeDeTrabalhoPostgres(db)
+	cadastroPrestador.DefinirUnidadeDeTrabalho(unidadeDeTrabalho)
+	cadastraAgendamento.DefinirUnidadeDeTrabalho(unidadeDeTrabalho)
+	modeloAgendaService.DefinirUnidadeDeTrabalho(unidadeDeTrabalho)
+	listaEsperaService.DefinirUnidadeDeTrabalho(unidadeDeTrabalho)
+	lgpdService.DefinirUnidadeDeTrabalho(unidadeDeTrabalho)
+
+	// Agendamentos criados, rejeitados e cancelados aparecem em /metrics
+	prometheus := metricas.NovoPrometheus(db)
+	cadastraAgendamento.DefinirMetricas(prometheus)
+
+	// Inativar o cliente ou o prestador apaga a URL do seu calendário
+	cadastroCliente.DefinirTokensCalendario(tokenCalendarioRepo)
+	cadastroPrestador.DefinirTokensCalendario(tokenCalendarioRepo)
+
+	// Cancelamentos e novas agendas oferecem a vaga ao primeiro da lista de espera
+	cadastroPrestador.DefinirObservadorDeVagas(listaEsperaService)
+	modeloAgendaService.DefinirObservadorDeVagas(listaEsperaService)
+	pararExpiracao := listaEsperaService.IniciarExpiracaoOfertas(time.Minute)
+	defer pararExpiracao()
 
 	// 3. Camada de Adaptador HTTP (Controller)
 	clienteController := cliente.NovoClienteController(cadastroCliente)
 	prestadorController := prestador.NovoPrestadorController(cadastroPrestador)
 	catalogoController := catalogo.NovoCatalogoController(cadastraCatalogo)
 	agendamentoController := agendamento.NovoAgendamentoController(cadastraAgendamento)
+	modeloAgendaController := modelo_agenda.NovoModeloAgendaController(modeloAgendaService)
+	listaEsperaController := lista_espera.NovoListaEsperaController(listaEsperaService)
+	lgpdController := lgpd.NovoLGPDController(lgpdService)
+	authController := auth.NovoAuthController(authService)
+	calendarioController := calendario.NovoCalendarioController(calendarioService)
+	saudeController := saude.NovoSaudeController(db)
 
 	// --- 4. Inicialização do Servidor Gin ---
-	router := gin.Default()
+	gin.SetMode(cfg.HTTP.ModoGin)
+	router := gin.New()
+	router.Use(
+		middleware.RequestID(),
+		middleware.LogRequisicoes(),
+		gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, recuperado any) {
+			resposta.FalhaInterna(c, fmt.Errorf("panic: %v", recuperado))
+		}),
+	)
+	router.Use(middleware.Metricas(prometheus))
+
+	// O prazo vale para a requisição inteira, até as consultas ao banco
+	router.Use(middleware.Prazo(cfg.HTTP.RequestTimeout))
+
+	// Rotas inexistentes também respondem no formato de problema
+	router.NoRoute(func(c *gin.Context) {
+		resposta.Escrever(c, resposta.NovoProblema(c, http.StatusNotFound, "rota_nao_encontrada", resposta.Mensagem(c, "rota_nao_encontrada")))
+	})
+
+	// Retentativas de POST com a mesma Idempotency-Key recebem a resposta original
+	idempotente := middleware.Idempotencia(idempotenciaRepo, cfg.IdempotenciaTTL)
+	pararLimpeza := middleware.IniciarLimpezaIdempotencia(idempotenciaRepo, time.Hour)
+	defer pararLimpeza()
+
+	// Quem pode acessar cada rota: rotas sem estes handlers são públicas
+	autenticado := middleware.Autenticar(emissorToken)
+	somenteAdmin := middleware.ExigirPapel(domain.PapelAdmin)
+	reservaDoCliente := middleware.ExigirPapel(domain.PapelAdmin, domain.PapelCliente)
+	atendimento := middleware.ExigirPapel(domain.PapelAdmin, domain.PapelPrestador)
+	clienteDoCorpo := middleware.ExigirClienteDoCorpo()
+	proprioCliente := middleware.ExigirDono(middleware.DonoCliente)
+	proprioPrestador := middleware.ExigirDono(middleware.DonoPrestador)
+	donoAgendamento := middleware.ExigirDono(middleware.DonoAgendamento(agendamentoRepo))
+	donoSerie := middleware.ExigirDono(middleware.DonoSerie(agendamentoRepo))
+	donoVisita := middleware.ExigirDono(middleware.DonoVisita(agendamentoRepo))
+	donoEntradaListaEspera := middleware.ExigirDono(middleware.DonoEntradaListaEspera(listaEsperaRepo))
+	// Pedidos LGPD recusados pelas regras de acesso também ficam registrados
+	negacaoExportacao := middleware.RegistrarNegacao(lgpdController.RegistrarNegacao(domain.SolicitacaoExportacao))
+	negacaoAnonimizacao := middleware.RegistrarNegacao(lgpdController.RegistrarNegacao(domain.SolicitacaoAnonimizacao))
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	// 5. Define as Rotas
 	apiV1 := router.Group("/api/v1")
 	{
-		apiV1.POST("/clientes", clienteController.PostCliente)
-		apiV1.GET("/clientes/:id", clienteController.GetCliente)
+		apiV1.POST("/auth/login", authController.PostLogin)
+		apiV1.POST("/auth/refresh", authController.PostRefresh)
+		apiV1.POST("/auth/logout", authController.PostLogout)
+		apiV1.POST("/usuarios", autenticado, somenteAdmin, authController.PostUsuario)
+
+		apiV1.POST("/clientes", autenticado, somenteAdmin, idempotente, clienteController.PostCliente)
+		apiV1.GET("/clientes", autenticado, somenteAdmin, clienteController.GetClientes)
+		apiV1.GET("/clientes/:id", autenticado, proprioCliente, clienteController.GetCliente)
+		apiV1.PUT("/clientes/:id", autenticado, proprioCliente, clienteController.PutCliente)
+		apiV1.PUT("/clientes/:id/inativar", autenticado, somenteAdmin, clienteController.InativarCliente)
+		apiV1.PUT("/clientes/:id/ativar", autenticado, somenteAdmin, clienteController.AtivarCliente)
+		apiV1.GET("/clientes/:id/lgpd/exportacao", autenticado, negacaoExportacao, somenteAdmin, lgpdController.GetExportacao)
+		apiV1.POST("/clientes/:id/lgpd/anonimizar", autenticado, negacaoAnonimizacao, somenteAdmin, lgpdController.PostAnonimizar)
+		apiV1.GET("/clientes/:id/lgpd/solicitacoes", autenticado, somenteAdmin, lgpdController.GetSolicitacoes)
+		apiV1.POST("/clientes/:id/calendario", autenticado, proprioCliente, calendarioController.PostCalendarioCliente)
+		apiV1.DELETE("/clientes/:id/calendario", autenticado, proprioCliente, calendarioController.DeleteCalendarioCliente)
 
-		apiV1.POST("/prestadores", prestadorController.PostPrestador)
+		apiV1.POST("/prestadores", autenticado, somenteAdmin, idempotente, prestadorController.PostPrestador)
 		apiV1.GET("/prestadores/", prestadorController.GetPrestadores)
 		apiV1.GET("/prestadores/disponiveis", prestadorController.GetPrestadoresPorData)
 		apiV1.GET("/prestadores/:id", prestadorController.GetPrestador)
-		apiV1.PUT("/prestadores/:id/agenda", prestadorController.PutAgenda)
-		apiV1.PUT("/prestadores/:id", prestadorController.UpdatePrestador)
-		apiV1.PUT("/prestadores/:id/inativar", prestadorController.InativarPrestador)
-		apiV1.PUT("/prestadores/:id/ativar", prestadorController.AtivarPrestador)
-		apiV1.DELETE("/prestadores/:id/agenda", prestadorController.DeleteAgenda)
+		apiV1.PUT("/prestadores/:id/agenda", autenticado, proprioPrestador, prestadorController.PutAgenda)
+		apiV1.PUT("/prestadores/:id", autenticado, proprioPrestador, prestadorController.UpdatePrestador)
+		apiV1.PUT("/prestadores/:id/inativar", autenticado, somenteAdmin, prestadorController.InativarPrestador)
+		apiV1.PUT("/prestadores/:id/ativar", autenticado, somenteAdmin, prestadorController.AtivarPrestador)
+		apiV1.PUT("/prestadores/:id/tempos-entre-atendimentos", autenticado, proprioPrestador, prestadorController.PutTemposEntreAtendimentos)
+		apiV1.DELETE("/prestadores/:id/agenda", autenticado, proprioPrestador, prestadorController.DeleteAgenda)
+		apiV1.GET("/prestadores/:id/horarios", agendamentoController.GetHorariosDisponiveis)
+		apiV1.POST("/prestadores/:id/modelos-agenda", autenticado, proprioPrestador, modeloAgendaController.PostModeloAgenda)
+		apiV1.GET("/prestadores/:id/modelos-agenda", autenticado, proprioPrestador, modeloAgendaController.GetModelosAgenda)
+		apiV1.DELETE("/prestadores/:id/modelos-agenda/:modeloId", autenticado, proprioPrestador, modeloAgendaController.DeleteModeloAgenda)
+		apiV1.POST("/prestadores/:id/modelos-agenda/:modeloId/gerar", autenticado, proprioPrestador, modeloAgendaController.PostGerarAgendas)
+		apiV1.POST("/prestadores/:id/calendario", autenticado, proprioPrestador, calendarioController.PostCalendarioPrestador)
+		apiV1.DELETE("/prestadores/:id/calendario", autenticado, proprioPrestador, calendarioController.DeleteCalendarioPrestador)
 
-		apiV1.POST("/catalogos", catalogoController.PostCatalogo)
+		apiV1.POST("/catalogos", autenticado, somenteAdmin, idempotente, catalogoController.PostCatalogo)
 		apiV1.GET("/catalogos/:id", catalogoController.GetCatalogoPorID)
 		apiV1.GET("/catalogos", catalogoController.GetCatalogos)
-		apiV1.PUT("/catalogos/:id", catalogoController.Atualizar)
-		apiV1.DELETE(("/catalogos/:id"), catalogoController.Deletar)
+		apiV1.PUT("/catalogos/:id", autenticado, somenteAdmin, catalogoController.Atualizar)
+		apiV1.DELETE(("/catalogos/:id"), autenticado, somenteAdmin, catalogoController.Deletar)
+
+		apiV1.POST("/agendamentos", autenticado, reservaDoCliente, clienteDoCorpo, idempotente, agendamentoController.PostAgendamento)
+		apiV1.GET("/agendamentos/cliente/:id", autenticado, proprioCliente, agendamentoController.GetAgendamentoClienteData)
+		apiV1.GET("/agendamentos/prestador/:id", autenticado, proprioPrestador, agendamentoController.GetAgendamentoPrestadorData)
+		apiV1.PUT("/agendamentos/:id/confirmar", autenticado, atendimento, donoAgendamento, agendamentoController.PutConfirmarAgendamento)
+		apiV1.PUT("/agendamentos/:id/cancelar", autenticado, donoAgendamento, agendamentoController.PutCancelarAgendamento)
+		apiV1.PUT("/agendamentos/:id/concluir", autenticado, atendimento, donoAgendamento, agendamentoController.PutConcluirAgendamento)
+		apiV1.PUT("/agendamentos/:id/reagendar", autenticado, donoAgendamento, agendamentoController.PutReagendarAgendamento)
+		apiV1.GET("/agendamentos/:id/reagendamentos", autenticado, donoAgendamento, agendamentoController.GetReagendamentos)
+		apiV1.POST("/agendamentos/series", autenticado, reservaDoCliente, clienteDoCorpo, idempotente, agendamentoController.PostSerieAgendamento)
+		apiV1.GET("/agendamentos/series/:id", autenticado, donoSerie, agendamentoController.GetSerieAgendamento)
+		apiV1.PUT("/agendamentos/series/:id/cancelar", autenticado, donoSerie, agendamentoController.PutCancelarSerie)
+		apiV1.PUT("/agendamentos/series/:id/reagendar", autenticado, donoSerie, agendamentoController.PutReagendarSerie)
+		apiV1.POST("/agendamentos/visitas", autenticado, reservaDoCliente, clienteDoCorpo, idempotente, agendamentoController.PostVisita)
+		apiV1.GET("/agendamentos/visitas/:id", autenticado, donoVisita, agendamentoController.GetVisita)
 
-		apiV1.POST("/agendamentos", agendamentoController.PostAgendamento)
-		apiV1.GET("/agendamentos/cliente/:id", agendamentoController.GetAgendamentoClienteData)
-		apiV1.GET("/agendamentos/prestador/:id", agendamentoController.GetAgendamentoPrestadorData)
+		apiV1.POST("/lista-espera", autenticado, reservaDoCliente, clienteDoCorpo, idempotente, listaEsperaController.PostListaEspera)
+		apiV1.GET("/lista-espera/:id", autenticado, donoEntradaListaEspera, listaEsperaController.GetListaEspera)
+		apiV1.PUT("/lista-espera/:id/cancelar", autenticado, donoEntradaListaEspera, listaEsperaController.PutCancelarListaEspera)
+		apiV1.PUT("/lista-espera/:id/aceitar", autenticado, donoEntradaListaEspera, listaEsperaController.PutAceitarOferta)
+
+		// Sem login: os aplicativos de calendário só guardam a URL, e o token nela é a credencial
+		apiV1.GET("/calendario/:token", middleware.CaminhoSecreto(), calendarioController.GetFeed)
 	}
 
 	router.GET("/ping", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"mensagem": "Pong"})
 	})
+	router.GET("/healthz", saudeController.GetHealthz)
+	router.GET("/readyz", saudeController.GetReadyz)
+	router.GET("/metrics", gin.WrapH(prometheus.Handler()))
 
 	// 6. Inicia o Servidor
-	log.Println("Servidor Gin rodando na porta 8080...")
-	if err := router.Run(":8080"); err != nil {
-		log.Fatal("Erro ao iniciar o servidor: ", err)
+	servidor := &http.Server{
+		Addr:         cfg.HTTP.Addr,
+		Handler:      router,
+		ReadTimeout:  cfg.HTTP.ReadTimeout,
+		WriteTimeout: cfg.HTTP.WriteTimeout,
+		IdleTimeout:  cfg.HTTP.IdleTimeout,
 	}
+
+	go func() {
+		slog.Info("servidor HTTP iniciado", slog.String("addr", cfg.HTTP.Addr))
+		if err := servidor.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			encerrar("erro ao iniciar o servidor", err)
+		}
+	}()
+
+	// Ao receber SIGINT/SIGTERM, espera as requisições em andamento terminarem
+	sinal := make(chan os.Signal, 1)
+	signal.Notify(sinal, syscall.SIGINT, syscall.SIGTERM)
+	<-sinal
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+	defer cancel()
+	if err := servidor.Shutdown(ctx); err != nil {
+		slog.Error("erro ao encerrar o servidor", slog.Any("erro", err))
+	}
+}
+
+// encerrar registra o erro que impede a API de seguir e finaliza o processo
+func encerrar(mensagem string, err error) {
+	slog.Error(mensagem, slog.Any("erro", err))
+	os.Exit(1)
 }
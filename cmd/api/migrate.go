@@ -0,0 +1,103 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+
+	"meu-servico-agenda/flyway"
+	"meu-servico-agenda/internal/infra/config"
+	"meu-servico-agenda/internal/infra/database"
+	"meu-servico-agenda/internal/infra/migracao"
+)
+
+// executarMigrate trata "api migrate up|status [--dry-run]"
+func executarMigrate(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	simular := flags.Bool("dry-run", false, "mostra as migrações pendentes sem aplicá-las")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "uso: api migrate [up|status] [--dry-run]")
+		flags.PrintDefaults()
+	}
+
+	comando := "up"
+	if len(args) > 0 && args[0] != "" && args[0][0] != '-' {
+		comando, args = args[0], args[1:]
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := config.CarregarDatabase()
+	if err != nil {
+		return err
+	}
+
+	db, err := database.Connect(*cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	switch comando {
+	case "up":
+		_, err := migrar(db, *simular)
+		return err
+	case "status":
+		return imprimirStatus(db)
+	default:
+		flags.Usage()
+		return fmt.Errorf("comando de migração desconhecido: %s", comando)
+	}
+}
+
+// migrar aplica as migrações embutidas no binário e registra o que foi feito
+func migrar(db *sql.DB, simular bool) ([]migracao.Migracao, error) {
+	migracoes, err := migracao.Ler(flyway.Scripts, "sql")
+	if err != nil {
+		return nil, err
+	}
+
+	aplicadas, err := migracao.NovoMigrador(db, migracoes).Aplicar(simular)
+	for _, m := range aplicadas {
+		if simular {
+			slog.Info("migração pendente", slog.String("script", m.Script))
+		} else {
+			slog.Info("migração aplicada", slog.String("script", m.Script))
+		}
+	}
+	if err != nil {
+		return aplicadas, err
+	}
+
+	if len(aplicadas) == 0 {
+		slog.Info("banco de dados já está atualizado")
+	}
+	return aplicadas, nil
+}
+
+func imprimirStatus(db *sql.DB) error {
+	migracoes, err := migracao.Ler(flyway.Scripts, "sql")
+	if err != nil {
+		return err
+	}
+
+	situacoes, err := migracao.NovoMigrador(db, migracoes).Status()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSÃO\tDESCRIÇÃO\tESTADO\tAPLICADA EM")
+	for _, s := range situacoes {
+		aplicadaEm := "-"
+		if s.AplicadaEm != nil {
+			aplicadaEm = s.AplicadaEm.Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Versao, s.Descricao, s.Estado, aplicadaEm)
+	}
+	return w.Flush()
+}
@@ -37,6 +37,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/request_agendamento.AgendamentoRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave para repetir a requisição com segurança; a mesma chave e corpo devolvem a resposta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -49,28 +55,33 @@ const docTemplate = `{
                     "400": {
                         "description": "Dados inválidos ou formato de data incorreto",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "404": {
                         "description": "Cliente, prestador ou serviço não encontrado",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "409": {
-                        "description": "Conflito de agenda (dia ou horário indisponível)",
+                        "description": "Conflito de agenda (dia ou horário indisponível) ou cliente inativo",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "500": {
                         "description": "Erro interno do servidor",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
         "/agendamentos/cliente/{id}": {
@@ -113,22 +124,27 @@ const docTemplate = `{
                     "400": {
                         "description": "Dados inválidos ou formato de data incorreto",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "404": {
                         "description": "Cliente não encontrado",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "500": {
                         "description": "Erro interno do servidor",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
         "/agendamentos/prestador/{id}": {
@@ -171,76 +187,32 @@ const docTemplate = `{
                     "400": {
                         "description": "Dados inválidos ou formato de data incorreto",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "404": {
                         "description": "prestador não encontrado",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "500": {
                         "description": "Erro interno do servidor",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     }
-                }
-            }
-        },
-        "/catalogos": {
-            "get": {
-                "description": "Retorna uma lista de catálogos, com page e limit para paginação",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Catalogos"
-                ],
-                "summary": "Lista todos os catálogos com paginação",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Número da página",
-                        "name": "page",
-                        "in": "query"
-                    },
+                },
+                "security": [
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Quantidade de itens por página",
-                        "name": "limit",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/response_catalogo.CatalogoListResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
+                        "BearerAuth": []
                     }
-                }
-            },
+                ]
+            }
+        },
+        "/agendamentos/series": {
             "post": {
-                "description": "Cadastra um serviço que pode ser oferecido por um prestador",
+                "description": "Agenda o mesmo serviço a cada N semanas. Cada ocorrência passa pelas regras da criação de agendamento; as que falharem são listadas com o motivo",
                 "consumes": [
                     "application/json"
                 ],
@@ -248,71 +220,79 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Catalogos"
+                    "Agendamentos"
                 ],
-                "summary": "Cria um novo catálogo de serviços",
+                "summary": "Cria uma série de agendamentos recorrentes",
                 "parameters": [
                     {
-                        "description": "Dados do Catálogo",
-                        "name": "catalogo",
+                        "description": "Dados da série",
+                        "name": "serie",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request_catalogo.CatalogoRequest"
+                            "$ref": "#/definitions/request_agendamento.SerieAgendamentoRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave para repetir a requisição com segurança; a mesma chave e corpo devolvem a resposta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Catálogo criado com sucesso",
+                        "description": "Série criada com as ocorrências agendadas e as falhas",
                         "schema": {
-                            "$ref": "#/definitions/response_catalogo.CatalogoResponse"
+                            "$ref": "#/definitions/response_agendamento.SerieAgendamentoResponse"
                         }
                     },
                     "400": {
-                        "description": "Dados inválidos",
+                        "description": "Dados inválidos ou formato de data incorreto",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "404": {
-                        "description": "Prestador não encontrado",
+                        "description": "Cliente, prestador ou serviço não encontrado",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "409": {
-                        "description": "Catálogo já existente",
+                        "description": "Nenhuma ocorrência pôde ser agendada ou cliente inativo",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/response_agendamento.SerieAgendamentoResponse"
                         }
                     },
                     "500": {
-                        "description": "Erro interno",
+                        "description": "Erro interno do servidor",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/catalogos/{id}": {
+        "/agendamentos/series/{id}": {
             "get": {
-                "description": "Retorna os dados de um catálogo específico usando seu ID.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retorna todos os agendamentos da série, em ordem cronológica e em qualquer status",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Catalogos"
+                    "Agendamentos"
                 ],
-                "summary": "Busca um catálogo pelo ID",
+                "summary": "Lista as ocorrências de uma série",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID do Catálogo",
+                        "description": "ID da série",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -320,87 +300,97 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Catálogo encontrado com sucesso",
+                        "description": "Ocorrências da série",
                         "schema": {
-                            "$ref": "#/definitions/response_catalogo.CatalogoResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "ID inválido fornecido",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/response_agendamento.BuscaDataResponse"
                         }
                     },
                     "404": {
-                        "description": "Catálogo não encontrado",
+                        "description": "Série não encontrada",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "500": {
-                        "description": "Erro interno do servidor ou falha de infraestrutura",
+                        "description": "Erro interno do servidor",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     }
-                }
-            },
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/agendamentos/series/{id}/cancelar": {
             "put": {
-                "description": "Atualiza os dados de um catálogo pelo ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Cancela as ocorrências pendentes ou confirmadas da série. Com escopo \"restantes\" apenas as que ainda não começaram são afetadas",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Catalogos"
+                    "Agendamentos"
                 ],
-                "summary": "Atualiza um catálogo existente",
+                "summary": "Cancela uma série de agendamentos",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID do Catálogo",
+                        "description": "ID da série",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Dados atualizados do Catálogo",
-                        "name": "catalogo",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request_catalogo.CatalogoUpdateRequest"
-                        }
+                        "enum": [
+                            "todas",
+                            "restantes"
+                        ],
+                        "type": "string",
+                        "description": "todas ou restantes",
+                        "name": "escopo",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Catálogo atualizado com sucesso"
+                    "200": {
+                        "description": "Ocorrências canceladas e falhas",
+                        "schema": {
+                            "$ref": "#/definitions/response_agendamento.SerieAgendamentoResponse"
+                        }
                     },
                     "400": {
-                        "description": "Dados inválidos",
+                        "description": "Escopo inválido",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "404": {
-                        "description": "Catálogo não encontrado",
+                        "description": "Série não encontrada",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "500": {
                         "description": "Erro interno do servidor",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     }
-                }
-            },
-            "delete": {
-                "description": "Remove um catálogo pelo ID",
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/agendamentos/series/{id}/reagendar": {
+            "put": {
+                "description": "Desloca as ocorrências do escopo para que a primeira delas comece no novo horário, mantendo o espaçamento da série. Cada ocorrência passa pelas regras do reagendamento",
                 "consumes": [
                     "application/json"
                 ],
@@ -408,46 +398,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Catalogos"
+                    "Agendamentos"
                 ],
-                "summary": "Deleta um catálogo existente",
+                "summary": "Reagenda uma série de agendamentos",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID do Catálogo",
+                        "description": "ID da série",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Novo início e escopo",
+                        "name": "reagendamento",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request_agendamento.ReagendarSerieRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Catálogo deletado com sucesso"
+                    "200": {
+                        "description": "Ocorrências reagendadas e falhas",
+                        "schema": {
+                            "$ref": "#/definitions/response_agendamento.SerieAgendamentoResponse"
+                        }
                     },
                     "400": {
-                        "description": "ID inválido",
+                        "description": "Dados inválidos",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "404": {
-                        "description": "Catálogo não encontrado",
+                        "description": "Série não encontrada",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "500": {
                         "description": "Erro interno do servidor",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/clientes": {
+        "/agendamentos/visitas": {
             "post": {
-                "description": "Recebe dados de nome, email e telefone para registrar um novo cliente.",
+                "description": "Agenda os serviços na ordem da lista, cada um começando quando o anterior termina. Cada item pode ter um prestador diferente; quando o mesmo prestador atende mais de um item, o seguinte aguarda a limpeza e o preparo. Todos os itens são validados juntos e a visita só é criada se todos couberem na agenda",
                 "consumes": [
                     "application/json"
                 ],
@@ -455,65 +462,79 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Clientes"
+                    "Agendamentos"
                 ],
-                "summary": "Cadastra um novo cliente",
+                "summary": "Agenda vários serviços em uma visita",
                 "parameters": [
                     {
-                        "description": "Dados do Cliente",
-                        "name": "cliente",
+                        "description": "Cliente, início e serviços da visita",
+                        "name": "visita",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.ClienteRequest"
+                            "$ref": "#/definitions/request_agendamento.VisitaRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave para repetir a requisição com segurança; a mesma chave e corpo devolvem a resposta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Cliente criado com sucesso",
+                        "description": "Visita criada com os itens, preço e duração totais",
                         "schema": {
-                            "$ref": "#/definitions/domain.Cliente"
+                            "$ref": "#/definitions/response_agendamento.VisitaResponse"
                         }
                     },
                     "400": {
-                        "description": "Dados inválidos (erro de validação do binding)",
+                        "description": "Dados inválidos, formato de data incorreto ou serviço repetido",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Cliente, prestador ou serviço não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "409": {
-                        "description": "Cliente já cadastrado (Email ou Telefone já existe)",
+                        "description": "Algum item não cabe na agenda ou cliente inativo; nenhum agendamento é criado",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "500": {
-                        "description": "Falha na persistência de dados ou erro interno",
+                        "description": "Erro interno do servidor",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/clientes/{id}": {
+        "/agendamentos/visitas/{id}": {
             "get": {
-                "description": "Retorna os dados de um cliente específico usando seu ID.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retorna os serviços da visita em ordem cronológica, com preço e duração totais",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Clientes"
+                    "Agendamentos"
                 ],
-                "summary": "Busca um cliente pelo ID",
+                "summary": "Busca uma visita",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID do Cliente",
+                        "description": "ID da visita",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -521,205 +542,192 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Cliente encontrado com sucesso",
-                        "schema": {
-                            "$ref": "#/definitions/domain.Cliente"
-                        }
-                    },
-                    "400": {
-                        "description": "ID inválido fornecido (ex: formato incorreto se houver validação de formato de ID)",
+                        "description": "Visita encontrada",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/response_agendamento.VisitaResponse"
                         }
                     },
                     "404": {
-                        "description": "Cliente não encontrado",
+                        "description": "Visita não encontrada",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "500": {
-                        "description": "Erro interno do servidor ou falha de infraestrutura",
+                        "description": "Erro interno do servidor",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/prestadores": {
-            "get": {
-                "description": "Retorna lista paginada de prestadores ativos ou inativos",
-                "consumes": [
-                    "application/json"
-                ],
+        "/agendamentos/{id}/cancelar": {
+            "put": {
+                "description": "Cancela um agendamento pendente ou confirmado, liberando o horário do prestador",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Prestadores"
+                    "Agendamentos"
                 ],
-                "summary": "Lista prestadores filtrados por status",
+                "summary": "Cancela um agendamento",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Número da página (padrão: 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Itens por página (padrão: 10, máximo: 100)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Status do prestador (obrigatório)",
-                        "name": "ativo",
-                        "in": "query",
+                        "type": "string",
+                        "description": "ID do agendamento",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "Agendamento cancelado com sucesso"
+                    },
+                    "404": {
+                        "description": "Agendamento não encontrado",
                         "schema": {
-                            "$ref": "#/definitions/response_prestador.PrestadorListResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
-                    "400": {
-                        "description": "Parâmetro 'ativo' é obrigatório",
+                    "409": {
+                        "description": "Transição de status inválida",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     }
-                }
-            },
-            "post": {
-                "description": "Recebe os dados necessários para registrar um novo prestador.",
-                "consumes": [
-                    "application/json"
-                ],
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/agendamentos/{id}/concluir": {
+            "put": {
+                "description": "Marca como concluído um agendamento que já estava confirmado",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Prestadores"
+                    "Agendamentos"
                 ],
-                "summary": "Cadastra um novo prestador",
+                "summary": "Conclui um agendamento",
                 "parameters": [
                     {
-                        "description": "Dados do Prestador",
-                        "name": "prestador",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request_prestador.PrestadorRequest"
-                        }
+                        "type": "string",
+                        "description": "ID do agendamento",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Prestador criado com sucesso",
-                        "schema": {
-                            "$ref": "#/definitions/response_prestador.PrestadorPostResponse"
-                        }
+                    "204": {
+                        "description": "Agendamento concluído com sucesso"
                     },
-                    "400": {
-                        "description": "Dados inválidos (erro de validação do binding)",
+                    "404": {
+                        "description": "Agendamento não encontrado",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "409": {
-                        "description": "Prestador já cadastrado ou conflito de dados",
+                        "description": "Transição de status inválida",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "500": {
-                        "description": "Falha na persistência de dados ou erro interno",
+                        "description": "Erro interno do servidor",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/prestadores/disponiveis": {
-            "get": {
-                "description": "Retorna lista paginada de prestadores ativos que possuem agenda configurada para a data informada",
-                "consumes": [
-                    "application/json"
-                ],
+        "/agendamentos/{id}/confirmar": {
+            "put": {
+                "description": "Move um agendamento pendente para o status confirmado",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Prestadores"
+                    "Agendamentos"
                 ],
-                "summary": "Lista prestadores disponíveis em uma data específica",
+                "summary": "Confirma um agendamento",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Data da disponibilidade (formato: YYYY-MM-DD)",
-                        "name": "data",
-                        "in": "query",
+                        "description": "ID do agendamento",
+                        "name": "id",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Número da página (padrão: 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Itens por página (padrão: 10, máximo: 100)",
-                        "name": "limit",
-                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Lista de prestadores disponíveis",
+                    "204": {
+                        "description": "Agendamento confirmado com sucesso"
+                    },
+                    "404": {
+                        "description": "Agendamento não encontrado",
                         "schema": {
-                            "$ref": "#/definitions/response_prestador.PrestadorListResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
-                    "400": {
-                        "description": "Parâmetro 'data' é obrigatório ou formato inválido",
+                    "409": {
+                        "description": "Transição de status inválida",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "500": {
-                        "description": "Erro interno ao buscar prestadores",
+                        "description": "Erro interno do servidor",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/prestadores/{id}": {
+        "/agendamentos/{id}/reagendamentos": {
             "get": {
-                "description": "Retorna informações do prestador, incluindo catálogo de serviços e agenda diária.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retorna os horários anteriores e novos de cada reagendamento do agendamento, do mais antigo para o mais recente",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Prestadores"
+                    "Agendamentos"
                 ],
-                "summary": "Consulta prestador pelo ID",
+                "summary": "Lista o histórico de reagendamentos",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID do prestador",
+                        "description": "ID do agendamento",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -727,27 +735,37 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Prestador encontrado com sucesso",
+                        "description": "Histórico de reagendamentos",
                         "schema": {
-                            "$ref": "#/definitions/response_prestador.PrestadorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response_agendamento.ReagendamentoResponse"
+                            }
                         }
                     },
                     "404": {
-                        "description": "Prestador não encontrado",
+                        "description": "Agendamento não encontrado",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "500": {
-                        "description": "Erro interno",
+                        "description": "Erro interno do servidor",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     }
-                }
-            },
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/agendamentos/{id}/reagendar": {
             "put": {
-                "description": "Atualiza os dados cadastrais de um prestador, incluindo nome, email, telefone, imagem e catálogos de serviços associados. O CPF não pode ser alterado.",
+                "description": "Move um agendamento pendente ou confirmado para um novo horário, mantendo a duração original. As mesmas regras da criação são aplicadas, desconsiderando o próprio agendamento",
                 "consumes": [
                     "application/json"
                 ],
@@ -755,55 +773,69 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Prestadores"
+                    "Agendamentos"
                 ],
-                "summary": "Atualiza um prestador existente",
+                "summary": "Reagenda um agendamento",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID do prestador",
+                        "description": "ID do agendamento",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Dados atualizados do prestador",
-                        "name": "prestador",
+                        "description": "Novo horário de início",
+                        "name": "reagendamento",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request_prestador.PrestadorUpdateRequest"
+                            "$ref": "#/definitions/request_agendamento.ReagendarAgendamentoRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Prestador atualizado com sucesso"
+                    "200": {
+                        "description": "Agendamento reagendado com sucesso",
+                        "schema": {
+                            "$ref": "#/definitions/response_agendamento.AgendamentoResponse"
+                        }
                     },
                     "400": {
-                        "description": "Dados inválidos ou catálogo não existe",
+                        "description": "Dados inválidos ou formato de data incorreto",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "404": {
-                        "description": "Prestador não encontrado",
+                        "description": "Agendamento não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflito de agenda ou agendamento não pode ser reagendado",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "500": {
                         "description": "Erro interno do servidor",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/prestadores/{id}/agenda": {
-            "put": {
-                "description": "Cria uma nova agenda ou atualiza uma existente para a data especificada",
+        "/auth/login": {
+            "post": {
+                "description": "Confere email e senha e devolve um token de acesso de curta duração e um refresh token para renová-lo. Envie o token de acesso no cabeçalho Authorization: Bearer \u003ctoken\u003e",
                 "consumes": [
                     "application/json"
                 ],
@@ -811,186 +843,3185 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Prestadores"
+                    "Autenticação"
                 ],
-                "summary": "Cria ou atualiza uma agenda",
+                "summary": "Entra na API",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID do prestador",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Dados da agenda",
-                        "name": "agenda",
+                        "description": "Email e senha",
+                        "name": "credenciais",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request_prestador.AgendaDiariaRequest"
+                            "$ref": "#/definitions/request_auth.LoginRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Agenda criada ou atualizada com sucesso"
+                        "description": "Tokens emitidos",
+                        "schema": {
+                            "$ref": "#/definitions/response_auth.TokensResponse"
+                        }
                     },
                     "400": {
                         "description": "Dados inválidos",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
-                    "404": {
-                        "description": "Prestador não encontrado",
+                    "401": {
+                        "description": "Email ou senha inválidos",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
-                    "409": {
-                        "description": "Prestador inativo",
+                    "500": {
+                        "description": "Erro interno do servidor",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Remove uma agenda de um prestador em uma data específica",
-                "produces": [
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "description": "Revoga o refresh token. O token de acesso continua válido até expirar",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Prestadores"
+                    "Autenticação"
                 ],
-                "summary": "Deleta uma agenda",
+                "summary": "Sai da API",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID do prestador",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Data da agenda (formato: 2006-01-02)",
-                        "name": "data",
-                        "in": "query",
-                        "required": true
+                        "description": "Refresh token da sessão",
+                        "name": "refresh",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request_auth.RefreshTokenRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "204": {
-                        "description": "Agenda deletada com sucesso"
+                        "description": "Sessão encerrada"
                     },
                     "400": {
-                        "description": "Data inválida",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Prestador ou agenda não encontrada",
+                        "description": "Dados inválidos",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
-                    "409": {
-                        "description": "Prestador inativo",
+                    "500": {
+                        "description": "Erro interno do servidor",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     }
                 }
             }
         },
-        "/prestadores/{id}/ativar": {
-            "put": {
-                "description": "Ativa um prestador, permitindo que ele receba novos agendamentos",
+        "/auth/refresh": {
+            "post": {
+                "description": "Troca o refresh token por um novo par de tokens. Cada refresh token só pode ser usado uma vez; reutilizar um token já trocado encerra todas as sessões do usuário",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Prestadores"
+                    "Autenticação"
                 ],
-                "summary": "Ativa um prestador",
+                "summary": "Renova o token de acesso",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID do prestador",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Refresh token recebido no login",
+                        "name": "refresh",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request_auth.RefreshTokenRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Prestador ativado com sucesso"
+                    "200": {
+                        "description": "Tokens emitidos",
+                        "schema": {
+                            "$ref": "#/definitions/response_auth.TokensResponse"
+                        }
                     },
-                    "404": {
-                        "description": "Prestador não encontrado",
+                    "400": {
+                        "description": "Dados inválidos",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "401": {
+                        "description": "Refresh token inválido, expirado ou revogado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "500": {
-                        "description": "Erro interno",
+                        "description": "Erro interno do servidor",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     }
                 }
             }
         },
-        "/prestadores/{id}/inativar": {
-            "put": {
-                "description": "Inativa um prestador, impedindo que ele receba novos agendamentos",
+        "/calendario/{token}": {
+            "get": {
+                "description": "Agendamentos do dono do token a partir de 30 dias atrás, no formato iCalendar (RFC 5545). O título traz o serviço e a outra parte, e a descrição traz as notas. Cancelamentos aparecem com STATUS:CANCELLED para que os calendários assinantes se atualizem. Não exige login: o token da URL é a credencial. A URL deixa de funcionar quando é revogada ou substituída e quando o dono é inativado ou anonimizado",
                 "produces": [
-                    "application/json"
+                    "text/calendar"
                 ],
                 "tags": [
-                    "Prestadores"
+                    "Calendário"
                 ],
-                "summary": "Inativa um prestador",
+                "summary": "Feed iCalendar",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID do prestador",
-                        "name": "id",
+                        "description": "Token da URL, com ou sem .ics",
+                        "name": "token",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Prestador inativado com sucesso"
+                    "200": {
+                        "description": "Feed iCalendar",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
                     "404": {
-                        "description": "Prestador não encontrado",
+                        "description": "Calendário não encontrado",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     },
                     "500": {
-                        "description": "Erro interno",
+                        "description": "Erro interno do servidor",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/resposta.Problema"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.Cliente": {
-            "type": "object",
-            "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "id": {
+        },
+        "/catalogos": {
+            "get": {
+                "description": "Retorna uma lista de catálogos, com page e limit para paginação",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalogos"
+                ],
+                "summary": "Lista todos os catálogos com paginação",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Número da página",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Quantidade de itens por página",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response_catalogo.CatalogoListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Cadastra um serviço que pode ser oferecido por um prestador",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalogos"
+                ],
+                "summary": "Cria um novo catálogo de serviços",
+                "parameters": [
+                    {
+                        "description": "Dados do Catálogo",
+                        "name": "catalogo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request_catalogo.CatalogoRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave para repetir a requisição com segurança; a mesma chave e corpo devolvem a resposta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Catálogo criado com sucesso",
+                        "schema": {
+                            "$ref": "#/definitions/response_catalogo.CatalogoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Prestador não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Catálogo já existente",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/catalogos/{id}": {
+            "get": {
+                "description": "Retorna os dados de um catálogo específico usando seu ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalogos"
+                ],
+                "summary": "Busca um catálogo pelo ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do Catálogo",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Catálogo encontrado com sucesso",
+                        "schema": {
+                            "$ref": "#/definitions/response_catalogo.CatalogoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido fornecido",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Catálogo não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor ou falha de infraestrutura",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Atualiza os dados de um catálogo pelo ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalogos"
+                ],
+                "summary": "Atualiza um catálogo existente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do Catálogo",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados atualizados do Catálogo",
+                        "name": "catalogo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request_catalogo.CatalogoUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Catálogo atualizado com sucesso"
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Catálogo não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Remove um catálogo pelo ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalogos"
+                ],
+                "summary": "Deleta um catálogo existente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do Catálogo",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Catálogo deletado com sucesso"
+                    },
+                    "400": {
+                        "description": "ID inválido",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Catálogo não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/clientes": {
+            "get": {
+                "description": "Retorna clientes paginados e ordenados por nome. A busca procura parte do nome, do email ou do telefone.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Clientes"
+                ],
+                "summary": "Lista clientes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Número da página (padrão: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Itens por página (padrão: 10, máximo: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Parte do nome, email ou telefone",
+                        "name": "busca",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filtra por status; omitido lista ativos e inativos",
+                        "name": "ativo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ClienteListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Parâmetros inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Recebe dados de nome, email e telefone para registrar um novo cliente.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Clientes"
+                ],
+                "summary": "Cadastra um novo cliente",
+                "parameters": [
+                    {
+                        "description": "Dados do Cliente",
+                        "name": "cliente",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ClienteRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave para repetir a requisição com segurança; a mesma chave e corpo devolvem a resposta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Cliente criado com sucesso",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Cliente"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos (erro de validação do binding)",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Email já cadastrado para outro cliente",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Falha na persistência de dados ou erro interno",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/clientes/{id}": {
+            "get": {
+                "description": "Retorna os dados de um cliente específico usando seu ID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Clientes"
+                ],
+                "summary": "Busca um cliente pelo ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do Cliente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cliente encontrado com sucesso",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Cliente"
+                        }
+                    },
+                    "400": {
+                        "description": "ID inválido fornecido (ex: formato incorreto se houver validação de formato de ID)",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Cliente não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor ou falha de infraestrutura",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Substitui nome, email e telefone do cliente. O email não pode pertencer a outro cliente.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Clientes"
+                ],
+                "summary": "Atualiza os dados de contato de um cliente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do Cliente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados atualizados do cliente",
+                        "name": "cliente",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ClienteUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cliente atualizado",
+                        "schema": {
+                            "$ref": "#/definitions/domain.Cliente"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Cliente não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Email já cadastrado para outro cliente ou cliente anonimizado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/clientes/{id}/ativar": {
+            "put": {
+                "description": "Reativa um cliente, permitindo novos agendamentos",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Clientes"
+                ],
+                "summary": "Ativa um cliente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do Cliente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Cliente ativado com sucesso"
+                    },
+                    "404": {
+                        "description": "Cliente não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Cliente anonimizado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/clientes/{id}/calendario": {
+            "post": {
+                "description": "Cria a URL secreta do feed iCalendar com os agendamentos do cliente, para assinar no Google Agenda, Outlook ou Apple Calendar. A URL anterior deixa de funcionar. O token só é mostrado nesta resposta",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Calendário"
+                ],
+                "summary": "Gera a URL do calendário do cliente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do cliente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "URL do feed",
+                        "schema": {
+                            "$ref": "#/definitions/response_calendario.TokenCalendarioResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cliente não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Apaga a URL secreta do feed do cliente, que deixa de funcionar. Nenhuma outra é criada",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Calendário"
+                ],
+                "summary": "Revoga a URL do calendário do cliente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do cliente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "URL revogada"
+                    },
+                    "404": {
+                        "description": "Cliente não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/clientes/{id}/inativar": {
+            "put": {
+                "description": "Inativa um cliente, impedindo novos agendamentos. Os agendamentos existentes são mantidos.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Clientes"
+                ],
+                "summary": "Inativa um cliente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do Cliente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Cliente inativado com sucesso"
+                    },
+                    "404": {
+                        "description": "Cliente não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/clientes/{id}/lgpd/anonimizar": {
+            "post": {
+                "description": "Substitui nome, email e telefone por valores que não identificam o titular, apaga as notas dos agendamentos e inativa o cliente. Os agendamentos são mantidos para o histórico financeiro. O pedido fica registrado com o usuário autenticado, a data e o resultado, inclusive quando é negado ou falha",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LGPD"
+                ],
+                "summary": "Anonimiza um cliente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do cliente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Observação de quem atende o pedido do titular (Ex: nome do atendente)",
+                        "name": "X-Solicitante",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Cliente anonimizado"
+                    },
+                    "400": {
+                        "description": "Observação longa demais",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "403": {
+                        "description": "Usuário sem permissão",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Cliente não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Cliente já anonimizado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/clientes/{id}/lgpd/exportacao": {
+            "get": {
+                "description": "Devolve em JSON tudo o que o sistema guarda sobre o cliente: cadastro, todos os agendamentos com as notas, séries, visitas e entradas na lista de espera. O pedido fica registrado com o usuário autenticado, a data e o resultado, inclusive quando é negado ou falha",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LGPD"
+                ],
+                "summary": "Exporta os dados de um cliente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do cliente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Observação de quem atende o pedido do titular (Ex: nome do atendente)",
+                        "name": "X-Solicitante",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dados do cliente",
+                        "schema": {
+                            "$ref": "#/definitions/response_lgpd.ExportacaoClienteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Observação longa demais",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "403": {
+                        "description": "Usuário sem permissão",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Cliente não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/clientes/{id}/lgpd/solicitacoes": {
+            "get": {
+                "description": "Retorna os pedidos de exportação e de anonimização atendidos, negados ou falhos, do mais recente para o mais antigo",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "LGPD"
+                ],
+                "summary": "Lista as solicitações LGPD de um cliente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do cliente",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Solicitações registradas",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response_lgpd.SolicitacaoLGPDResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Cliente não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/lista-espera": {
+            "post": {
+                "description": "Registra o cliente aguardando um horário para o serviço dentro da janela informada. Quando uma vaga compatível é liberada por cancelamento, reagendamento ou nova agenda, ela é oferecida ao primeiro da fila, que tem 30 minutos para aceitá-la",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lista de Espera"
+                ],
+                "summary": "Entra na lista de espera",
+                "parameters": [
+                    {
+                        "description": "Dados da entrada",
+                        "name": "entrada",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request_lista_espera.ListaEsperaRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave para repetir a requisição com segurança; a mesma chave e corpo devolvem a resposta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Cliente incluído na lista de espera",
+                        "schema": {
+                            "$ref": "#/definitions/response_lista_espera.ListaEsperaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos ou janela inválida",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Cliente, prestador ou serviço não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Prestador ou cliente inativo",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/lista-espera/{id}": {
+            "get": {
+                "description": "Retorna a entrada com o status atual e, quando houver, a vaga oferecida e o prazo para aceitá-la",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lista de Espera"
+                ],
+                "summary": "Busca uma entrada da lista de espera",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da entrada",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Entrada encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/response_lista_espera.ListaEsperaResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Entrada não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/lista-espera/{id}/aceitar": {
+            "put": {
+                "description": "Agenda o horário oferecido ao cliente com as mesmas regras de um agendamento comum. Se o prazo expirou, a vaga segue para o próximo da fila",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lista de Espera"
+                ],
+                "summary": "Aceita a vaga oferecida",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da entrada",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Vaga aceita; agendamento_id traz o agendamento criado",
+                        "schema": {
+                            "$ref": "#/definitions/response_lista_espera.ListaEsperaResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Entrada não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Sem vaga oferecida, prazo expirado ou conflito de agenda",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/lista-espera/{id}/cancelar": {
+            "put": {
+                "description": "Cancela a entrada. Uma vaga que estivesse oferecida ao cliente passa ao próximo da fila",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lista de Espera"
+                ],
+                "summary": "Sai da lista de espera",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID da entrada",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Entrada cancelada com sucesso"
+                    },
+                    "404": {
+                        "description": "Entrada não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Entrada já encerrada",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/prestadores": {
+            "get": {
+                "description": "Retorna lista paginada de prestadores ativos ou inativos",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prestadores"
+                ],
+                "summary": "Lista prestadores filtrados por status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Número da página (padrão: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Itens por página (padrão: 10, máximo: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Status do prestador (obrigatório)",
+                        "name": "ativo",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response_prestador.PrestadorListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Parâmetro 'ativo' é obrigatório",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Recebe os dados necessários para registrar um novo prestador.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prestadores"
+                ],
+                "summary": "Cadastra um novo prestador",
+                "parameters": [
+                    {
+                        "description": "Dados do Prestador",
+                        "name": "prestador",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request_prestador.PrestadorRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Chave para repetir a requisição com segurança; a mesma chave e corpo devolvem a resposta original",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Prestador criado com sucesso",
+                        "schema": {
+                            "$ref": "#/definitions/response_prestador.PrestadorPostResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos (erro de validação do binding ou fuso horário inválido)",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Catálogo informado não existe",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Prestador já cadastrado ou conflito de dados",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Falha na persistência de dados ou erro interno",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/prestadores/disponiveis": {
+            "get": {
+                "description": "Retorna lista paginada de prestadores ativos que possuem agenda configurada para a data informada",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prestadores"
+                ],
+                "summary": "Lista prestadores disponíveis em uma data específica",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Data da disponibilidade (formato: YYYY-MM-DD)",
+                        "name": "data",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Número da página (padrão: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Itens por página (padrão: 10, máximo: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Lista de prestadores disponíveis",
+                        "schema": {
+                            "$ref": "#/definitions/response_prestador.PrestadorListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Parâmetro 'data' é obrigatório ou formato inválido",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno ao buscar prestadores",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                }
+            }
+        },
+        "/prestadores/{id}": {
+            "get": {
+                "description": "Retorna informações do prestador, incluindo catálogo de serviços e agenda diária.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prestadores"
+                ],
+                "summary": "Consulta prestador pelo ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do prestador",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Prestador encontrado com sucesso",
+                        "schema": {
+                            "$ref": "#/definitions/response_prestador.PrestadorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Prestador não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Atualiza os dados cadastrais de um prestador, incluindo nome, email, telefone, imagem e catálogos de serviços associados. O CPF não pode ser alterado. Em condicoes_servico o prestador pode praticar preço e duração próprios em serviços de catalogo_ids; os demais voltam aos valores do catálogo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prestadores"
+                ],
+                "summary": "Atualiza um prestador existente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do prestador",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados atualizados do prestador",
+                        "name": "prestador",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request_prestador.PrestadorUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Prestador atualizado com sucesso"
+                    },
+                    "400": {
+                        "description": "Dados inválidos, fuso horário inválido ou condição de serviço inválida",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Prestador ou catálogo não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/prestadores/{id}/agenda": {
+            "put": {
+                "description": "Cria uma nova agenda ou atualiza uma existente para a data especificada",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prestadores"
+                ],
+                "summary": "Cria ou atualiza uma agenda",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do prestador",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados da agenda",
+                        "name": "agenda",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request_prestador.AgendaDiariaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Agenda criada ou atualizada com sucesso"
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Prestador não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Prestador inativo",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Remove uma agenda de um prestador em uma data específica",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prestadores"
+                ],
+                "summary": "Deleta uma agenda",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do prestador",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data da agenda (formato: 2006-01-02)",
+                        "name": "data",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Agenda deletada com sucesso"
+                    },
+                    "400": {
+                        "description": "Data inválida",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Prestador ou agenda não encontrada",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Prestador inativo",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/prestadores/{id}/ativar": {
+            "put": {
+                "description": "Ativa um prestador, permitindo que ele receba novos agendamentos",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prestadores"
+                ],
+                "summary": "Ativa um prestador",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do prestador",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Prestador ativado com sucesso"
+                    },
+                    "404": {
+                        "description": "Prestador não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/prestadores/{id}/calendario": {
+            "post": {
+                "description": "Cria a URL secreta do feed iCalendar com os agendamentos do prestador, para assinar no Google Agenda, Outlook ou Apple Calendar. A URL anterior deixa de funcionar. O token só é mostrado nesta resposta",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Calendário"
+                ],
+                "summary": "Gera a URL do calendário do prestador",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do prestador",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "URL do feed",
+                        "schema": {
+                            "$ref": "#/definitions/response_calendario.TokenCalendarioResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Prestador não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Apaga a URL secreta do feed do prestador, que deixa de funcionar. Nenhuma outra é criada",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Calendário"
+                ],
+                "summary": "Revoga a URL do calendário do prestador",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do prestador",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "URL revogada"
+                    },
+                    "404": {
+                        "description": "Prestador não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/prestadores/{id}/horarios": {
+            "get": {
+                "description": "Calcula todos os horários de início válidos para o serviço informado, considerando a agenda do dia e os agendamentos existentes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Agendamentos"
+                ],
+                "summary": "Lista horários livres de um prestador",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do prestador",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2030-01-03",
+                        "description": "Data desejada (formato: YYYY-MM-DD)",
+                        "name": "data",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do serviço do catálogo",
+                        "name": "catalogo_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Passo da grade em minutos (padrão: 15)",
+                        "name": "intervalo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Horários disponíveis",
+                        "schema": {
+                            "$ref": "#/definitions/response_agendamento.HorariosDisponiveisResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos ou data no passado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Prestador ou serviço não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Prestador inativo",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                }
+            }
+        },
+        "/prestadores/{id}/inativar": {
+            "put": {
+                "description": "Inativa um prestador, impedindo que ele receba novos agendamentos",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prestadores"
+                ],
+                "summary": "Inativa um prestador",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do prestador",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Prestador inativado com sucesso"
+                    },
+                    "404": {
+                        "description": "Prestador não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/prestadores/{id}/modelos-agenda": {
+            "get": {
+                "description": "Retorna os modelos semanais cadastrados, ordenados pelo início da vigência",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Modelos de Agenda"
+                ],
+                "summary": "Lista os modelos de agenda de um prestador",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do prestador",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Modelos do prestador",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response_modelo_agenda.ModeloAgendaResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Prestador não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Define dias da semana e intervalos que se repetem dentro do período de vigência",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Modelos de Agenda"
+                ],
+                "summary": "Cadastra um modelo semanal de agenda",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do prestador",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados do modelo",
+                        "name": "modelo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request_modelo_agenda.ModeloAgendaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Modelo criado com sucesso",
+                        "schema": {
+                            "$ref": "#/definitions/response_modelo_agenda.ModeloAgendaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Prestador não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Prestador inativo",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/prestadores/{id}/modelos-agenda/{modeloId}": {
+            "delete": {
+                "description": "Remove o modelo. Agendas diárias já geradas a partir dele são mantidas",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Modelos de Agenda"
+                ],
+                "summary": "Remove um modelo de agenda",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do prestador",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do modelo",
+                        "name": "modeloId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Modelo removido com sucesso"
+                    },
+                    "404": {
+                        "description": "Modelo não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/prestadores/{id}/modelos-agenda/{modeloId}/gerar": {
+            "post": {
+                "description": "Cria as agendas do período para os dias do modelo dentro da vigência. Dias que já possuem agenda não são alterados e voltam em \"ignoradas\"",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Modelos de Agenda"
+                ],
+                "summary": "Gera agendas diárias a partir de um modelo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do prestador",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID do modelo",
+                        "name": "modeloId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Período a gerar",
+                        "name": "periodo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request_modelo_agenda.GerarAgendasRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Datas criadas e ignoradas",
+                        "schema": {
+                            "$ref": "#/definitions/response_modelo_agenda.GeracaoAgendaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos ou período inválido",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Prestador ou modelo não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Prestador inativo",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/prestadores/{id}/tempos-entre-atendimentos": {
+            "put": {
+                "description": "Substitui, para todos os serviços do prestador, os minutos de preparo antes e de limpeza depois de cada atendimento. Campos nulos ou ausentes voltam a usar os tempos de cada serviço. O horário combinado com o cliente não muda; apenas o período em que o prestador fica ocupado",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prestadores"
+                ],
+                "summary": "Define o preparo e a limpeza do prestador",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID do prestador",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tempos em minutos, de 0 a 240",
+                        "name": "tempos",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request_prestador.TemposEntreAtendimentosRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Tempos atualizados com sucesso"
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Prestador não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/usuarios": {
+            "post": {
+                "description": "Cria a conta de acesso de um admin, de um prestador ou de um cliente já cadastrado. Somente admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Autenticação"
+                ],
+                "summary": "Cadastra um usuário",
+                "parameters": [
+                    {
+                        "description": "Dados do usuário",
+                        "name": "usuario",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request_auth.UsuarioRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Usuário criado",
+                        "schema": {
+                            "$ref": "#/definitions/response_auth.UsuarioResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Dados inválidos",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "401": {
+                        "description": "Token ausente ou inválido",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "403": {
+                        "description": "Usuário sem permissão",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "404": {
+                        "description": "Cliente ou prestador não encontrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "409": {
+                        "description": "Email já cadastrado",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor",
+                        "schema": {
+                            "$ref": "#/definitions/resposta.Problema"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        }
+    },
+    "definitions": {
+        "domain.Cliente": {
+            "type": "object",
+            "properties": {
+                "anonimizadoEm": {
+                    "description": "AnonimizadoEm é preenchido quando os dados pessoais foram apagados a pedido do titular (LGPD)",
+                    "type": "string"
+                },
+                "ativo": {
+                    "description": "Ativo false impede novos agendamentos; o histórico do cliente é mantido",
+                    "type": "boolean"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "telefone": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.StatusDoAgendamento": {
+            "type": "integer",
+            "enum": [
+                1,
+                2,
+                3,
+                4
+            ],
+            "x-enum-varnames": [
+                "Pendente",
+                "Confirmado",
+                "Cancelado",
+                "Concluido"
+            ]
+        },
+        "domain.StatusListaEspera": {
+            "type": "integer",
+            "enum": [
+                1,
+                2,
+                3,
+                4,
+                5
+            ],
+            "x-enum-varnames": [
+                "AguardandoVaga",
+                "VagaOferecida",
+                "VagaAceita",
+                "EsperaCancelada",
+                "OfertaExpirada"
+            ]
+        },
+        "output.AgendaDiariaOutput": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "intervalos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/output.IntervaloDiarioOutput"
+                    }
+                }
+            }
+        },
+        "output.BuscarPrestadorOutput": {
+            "type": "object",
+            "properties": {
+                "agenda": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/output.AgendaDiariaOutput"
+                    }
+                },
+                "ativo": {
+                    "type": "boolean"
+                },
+                "catalogo": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/output.CatalogoOutput"
+                    }
+                },
+                "condicoes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/output.CondicaoDoServicoOutput"
+                    }
+                },
+                "cpf": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "fusoHorario": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imagemUrl": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "telefone": {
+                    "type": "string"
+                },
+                "tempoLimpeza": {
+                    "type": "integer"
+                },
+                "tempoPreparo": {
+                    "description": "TempoPreparo e TempoLimpeza nulos indicam que valem os tempos de cada serviço",
+                    "type": "integer"
+                }
+            }
+        },
+        "output.CatalogoOutput": {
+            "type": "object",
+            "properties": {
+                "categoria": {
+                    "type": "string"
+                },
+                "duracaoPadrao": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imagemUrl": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "preco": {
+                    "type": "integer"
+                },
+                "tempoLimpeza": {
+                    "type": "integer"
+                },
+                "tempoPreparo": {
+                    "type": "integer"
+                }
+            }
+        },
+        "output.CondicaoDoServicoOutput": {
+            "type": "object",
+            "properties": {
+                "catalogoID": {
+                    "type": "string"
+                },
+                "duracaoPadrao": {
+                    "type": "integer"
+                },
+                "preco": {
+                    "type": "integer"
+                }
+            }
+        },
+        "output.IntervaloDiarioOutput": {
+            "type": "object",
+            "properties": {
+                "horaFim": {
+                    "type": "string"
+                },
+                "horaInicio": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.ClienteRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "nome",
+                "telefone"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "joao@email.com"
+                },
+                "nome": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3,
+                    "example": "João da Silva"
+                },
+                "telefone": {
+                    "type": "string",
+                    "maxLength": 15,
+                    "minLength": 8,
+                    "example": "62999677481"
+                }
+            }
+        },
+        "request.ClienteUpdateRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "nome",
+                "telefone"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "joao@email.com"
+                },
+                "nome": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3,
+                    "example": "João da Silva"
+                },
+                "telefone": {
+                    "type": "string",
+                    "maxLength": 15,
+                    "minLength": 8,
+                    "example": "62999677481"
+                }
+            }
+        },
+        "request_agendamento.AgendamentoRequest": {
+            "type": "object",
+            "required": [
+                "catalogo_id",
+                "cliente_id",
+                "data_hora_inicio",
+                "prestador_id"
+            ],
+            "properties": {
+                "catalogo_id": {
+                    "type": "string"
+                },
+                "cliente_id": {
+                    "type": "string"
+                },
+                "data_hora_inicio": {
+                    "type": "string",
+                    "example": "2025-01-03T08:00:00Z"
+                },
+                "notas": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "prestador_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request_agendamento.ItemVisitaRequest": {
+            "type": "object",
+            "required": [
+                "catalogo_id",
+                "prestador_id"
+            ],
+            "properties": {
+                "catalogo_id": {
+                    "type": "string"
+                },
+                "prestador_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request_agendamento.ReagendarAgendamentoRequest": {
+            "type": "object",
+            "required": [
+                "data_hora_inicio"
+            ],
+            "properties": {
+                "data_hora_inicio": {
+                    "type": "string",
+                    "example": "2025-01-03T10:00:00Z"
+                }
+            }
+        },
+        "request_agendamento.ReagendarSerieRequest": {
+            "type": "object",
+            "required": [
+                "data_hora_inicio",
+                "escopo"
+            ],
+            "properties": {
+                "data_hora_inicio": {
+                    "type": "string",
+                    "example": "2030-01-09T10:00:00Z"
+                },
+                "escopo": {
+                    "type": "string",
+                    "enum": [
+                        "todas",
+                        "restantes"
+                    ],
+                    "example": "restantes"
+                }
+            }
+        },
+        "request_agendamento.SerieAgendamentoRequest": {
+            "type": "object",
+            "required": [
+                "catalogo_id",
+                "cliente_id",
+                "data_hora_inicio",
+                "intervalo_semanas",
+                "ocorrencias",
+                "prestador_id"
+            ],
+            "properties": {
+                "catalogo_id": {
+                    "type": "string"
+                },
+                "cliente_id": {
+                    "type": "string"
+                },
+                "data_hora_inicio": {
+                    "type": "string",
+                    "example": "2030-01-08T10:00:00Z"
+                },
+                "intervalo_semanas": {
+                    "type": "integer",
+                    "maximum": 4,
+                    "minimum": 1,
+                    "example": 1
+                },
+                "notas": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "ocorrencias": {
+                    "type": "integer",
+                    "maximum": 52,
+                    "minimum": 2,
+                    "example": 8
+                },
+                "prestador_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "request_agendamento.VisitaRequest": {
+            "type": "object",
+            "required": [
+                "cliente_id",
+                "data_hora_inicio",
+                "itens"
+            ],
+            "properties": {
+                "cliente_id": {
+                    "type": "string"
+                },
+                "data_hora_inicio": {
+                    "type": "string",
+                    "example": "2030-01-08T10:00:00Z"
+                },
+                "itens": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/request_agendamento.ItemVisitaRequest"
+                    }
+                },
+                "notas": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "request_auth.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "senha"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "senha": {
+                    "type": "string"
+                }
+            }
+        },
+        "request_auth.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "request_auth.UsuarioRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "papel",
+                "senha"
+            ],
+            "properties": {
+                "cliente_id": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "papel": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "prestador",
+                        "cliente"
+                    ],
+                    "example": "cliente"
+                },
+                "prestador_id": {
+                    "type": "string"
+                },
+                "senha": {
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8
+                }
+            }
+        },
+        "request_catalogo.CatalogoRequest": {
+            "type": "object",
+            "required": [
+                "categoria",
+                "duracao_padrao",
+                "image_url",
+                "nome",
+                "preco"
+            ],
+            "properties": {
+                "categoria": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3,
+                    "example": "Redes"
+                },
+                "duracao_padrao": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://tdfuderuzpylkctxbysu.supabase.co/storage/v1/object/public/imagens/bb515383d2f6ef76.jpg"
+                },
+                "nome": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3,
+                    "example": "Tecnico de Redes"
+                },
+                "preco": {
+                    "type": "integer",
+                    "example": 10000
+                },
+                "tempo_limpeza": {
+                    "type": "integer",
+                    "maximum": 240,
+                    "minimum": 0,
+                    "example": 15
+                },
+                "tempo_preparo": {
+                    "type": "integer",
+                    "maximum": 240,
+                    "minimum": 0,
+                    "example": 10
+                }
+            }
+        },
+        "request_catalogo.CatalogoUpdateRequest": {
+            "type": "object",
+            "required": [
+                "categoria",
+                "duracao_padrao",
+                "image_url",
+                "nome",
+                "preco"
+            ],
+            "properties": {
+                "categoria": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3,
+                    "example": "Estética Facial"
+                },
+                "duracao_padrao": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://tdfuderuzpylkctxbysu.supabase.co/storage/v1/object/public/imagens/bb515383d2f6ef76.jpg"
+                },
+                "nome": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3,
+                    "example": "Limpesa de Peele"
+                },
+                "preco": {
+                    "type": "integer",
+                    "example": 10000
+                },
+                "tempo_limpeza": {
+                    "type": "integer",
+                    "maximum": 240,
+                    "minimum": 0,
+                    "example": 15
+                },
+                "tempo_preparo": {
+                    "type": "integer",
+                    "maximum": 240,
+                    "minimum": 0,
+                    "example": 10
+                }
+            }
+        },
+        "request_lista_espera.ListaEsperaRequest": {
+            "type": "object",
+            "required": [
+                "catalogo_id",
+                "cliente_id",
+                "janela_fim",
+                "janela_inicio"
+            ],
+            "properties": {
+                "catalogo_id": {
+                    "type": "string"
+                },
+                "cliente_id": {
+                    "type": "string"
+                },
+                "janela_fim": {
+                    "type": "string",
+                    "example": "2030-01-08T18:00:00Z"
+                },
+                "janela_inicio": {
+                    "type": "string",
+                    "example": "2030-01-08T08:00:00Z"
+                },
+                "prestador_id": {
+                    "description": "Opcional: sem prestador, qualquer um que ofereça o serviço pode liberar a vaga",
+                    "type": "string"
+                }
+            }
+        },
+        "request_modelo_agenda.GerarAgendasRequest": {
+            "type": "object",
+            "required": [
+                "data_fim",
+                "data_inicio"
+            ],
+            "properties": {
+                "data_fim": {
+                    "type": "string",
+                    "example": "2030-01-31"
+                },
+                "data_inicio": {
+                    "type": "string",
+                    "example": "2030-01-01"
+                }
+            }
+        },
+        "request_modelo_agenda.IntervaloModeloRequest": {
+            "type": "object",
+            "required": [
+                "hora_fim",
+                "hora_inicio"
+            ],
+            "properties": {
+                "hora_fim": {
+                    "type": "string",
+                    "example": "12:00"
+                },
+                "hora_inicio": {
+                    "type": "string",
+                    "example": "08:00"
+                }
+            }
+        },
+        "request_modelo_agenda.ModeloAgendaRequest": {
+            "type": "object",
+            "required": [
+                "dias_semana",
+                "intervalos",
+                "vigencia_fim",
+                "vigencia_inicio"
+            ],
+            "properties": {
+                "dias_semana": {
+                    "description": "0 = domingo ... 6 = sábado",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2,
+                        3,
+                        4,
+                        5
+                    ]
+                },
+                "intervalos": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/request_modelo_agenda.IntervaloModeloRequest"
+                    }
+                },
+                "vigencia_fim": {
+                    "type": "string",
+                    "example": "2030-06-30"
+                },
+                "vigencia_inicio": {
+                    "type": "string",
+                    "example": "2030-01-01"
+                }
+            }
+        },
+        "request_prestador.AgendaDiariaRequest": {
+            "type": "object",
+            "required": [
+                "data",
+                "intervalos"
+            ],
+            "properties": {
+                "data": {
+                    "type": "string",
+                    "example": "2025-01-03"
+                },
+                "intervalos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/request_prestador.IntervaloDiarioRequest"
+                    }
+                }
+            }
+        },
+        "request_prestador.CondicaoDoServicoRequest": {
+            "type": "object",
+            "required": [
+                "catalogo_id"
+            ],
+            "properties": {
+                "catalogo_id": {
+                    "type": "string",
+                    "example": "d4hq3kbs3g3h7ra0k1b0"
+                },
+                "duracao_padrao": {
+                    "type": "integer",
+                    "minimum": 2,
+                    "example": 45
+                },
+                "preco": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 15000
+                }
+            }
+        },
+        "request_prestador.IntervaloDiarioRequest": {
+            "type": "object",
+            "required": [
+                "hora_fim",
+                "hora_inicio"
+            ],
+            "properties": {
+                "hora_fim": {
+                    "type": "string",
+                    "example": "12:00"
+                },
+                "hora_inicio": {
+                    "type": "string",
+                    "example": "08:00"
+                }
+            }
+        },
+        "request_prestador.PrestadorRequest": {
+            "type": "object",
+            "required": [
+                "catalogo_ids",
+                "cpf",
+                "image_url",
+                "nome",
+                "telefone"
+            ],
+            "properties": {
+                "catalogo_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cpf": {
+                    "type": "string",
+                    "example": "04423258196"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "joao@email.com"
+                },
+                "fuso_horario": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "America/Sao_Paulo"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://tdfuderuzpylkctxbysu.supabase.co/storage/v1/object/public/imagens/bb515383d2f6ef76.jpg"
+                },
+                "nome": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3,
+                    "example": "joao"
+                },
+                "telefone": {
+                    "type": "string",
+                    "maxLength": 15,
+                    "minLength": 8,
+                    "example": "62999677481"
+                }
+            }
+        },
+        "request_prestador.PrestadorUpdateRequest": {
+            "type": "object",
+            "required": [
+                "catalogo_ids",
+                "image_url",
+                "nome",
+                "telefone"
+            ],
+            "properties": {
+                "catalogo_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "condicoes_servico": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/request_prestador.CondicaoDoServicoRequest"
+                    }
+                },
+                "email": {
+                    "type": "string",
+                    "example": "joao@email.com"
+                },
+                "fuso_horario": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "America/Sao_Paulo"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://tdfuderuzpylkctxbysu.supabase.co/storage/v1/object/public/imagens/bb515383d2f6ef76.jpg"
+                },
+                "nome": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3,
+                    "example": "joao"
+                },
+                "telefone": {
+                    "type": "string",
+                    "maxLength": 15,
+                    "minLength": 8,
+                    "example": "62999677481"
+                }
+            }
+        },
+        "request_prestador.TemposEntreAtendimentosRequest": {
+            "type": "object",
+            "properties": {
+                "tempo_limpeza": {
+                    "type": "integer",
+                    "maximum": 240,
+                    "minimum": 0,
+                    "example": 15
+                },
+                "tempo_preparo": {
+                    "type": "integer",
+                    "maximum": 240,
+                    "minimum": 0,
+                    "example": 10
+                }
+            }
+        },
+        "response.ClienteListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Cliente"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response_agendamento.AgendamentoResponse": {
+            "type": "object",
+            "properties": {
+                "cliente": {
+                    "$ref": "#/definitions/response_agendamento.ClienteInfo"
+                },
+                "data_fim": {
+                    "type": "string"
+                },
+                "data_inicio": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notas": {
+                    "type": "string"
+                },
+                "prestador": {
+                    "$ref": "#/definitions/response_agendamento.PrestadorInfo"
+                },
+                "serie_id": {
+                    "type": "string"
+                },
+                "servico": {
+                    "$ref": "#/definitions/response_agendamento.ServicoInfo"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.StatusDoAgendamento"
+                },
+                "visita_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "response_agendamento.BuscaDataResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response_agendamento.AgendamentoResponse"
+                    }
+                }
+            }
+        },
+        "response_agendamento.ClienteInfo": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "string"
                 },
                 "nome": {
@@ -1001,74 +4032,62 @@ const docTemplate = `{
                 }
             }
         },
-        "domain.ErrorResponse": {
+        "response_agendamento.FalhaOcorrenciaResponse": {
             "type": "object",
             "properties": {
-                "error": {
+                "agendamento_id": {
+                    "type": "string"
+                },
+                "data_inicio": {
+                    "type": "string"
+                },
+                "motivo": {
                     "type": "string"
                 }
             }
         },
-        "domain.StatusDoAgendamento": {
-            "type": "integer",
-            "enum": [
-                1,
-                2,
-                3,
-                4
-            ],
-            "x-enum-varnames": [
-                "Pendente",
-                "Confirmado",
-                "Cancelado",
-                "Concluido"
-            ]
-        },
-        "output.AgendaDiariaOutput": {
+        "response_agendamento.HorariosDisponiveisResponse": {
             "type": "object",
             "properties": {
+                "catalogo_id": {
+                    "type": "string"
+                },
                 "data": {
                     "type": "string"
                 },
-                "id": {
+                "duracao": {
+                    "type": "integer"
+                },
+                "fuso_horario": {
                     "type": "string"
                 },
-                "intervalos": {
+                "horarios": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/output.IntervaloDiarioOutput"
+                        "type": "string"
                     }
+                },
+                "prestador_id": {
+                    "type": "string"
                 }
             }
         },
-        "output.BuscarPrestadorOutput": {
+        "response_agendamento.PrestadorInfo": {
             "type": "object",
             "properties": {
-                "agenda": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/output.AgendaDiariaOutput"
-                    }
-                },
                 "ativo": {
                     "type": "boolean"
                 },
-                "catalogo": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/output.CatalogoOutput"
-                    }
-                },
                 "cpf": {
                     "type": "string"
                 },
                 "email": {
                     "type": "string"
                 },
-                "id": {
+                "fuso_horario": {
                     "type": "string"
                 },
-                "imagemUrl": {
+                "id": {
                     "type": "string"
                 },
                 "nome": {
@@ -1079,425 +4098,439 @@ const docTemplate = `{
                 }
             }
         },
-        "output.CatalogoOutput": {
+        "response_agendamento.ReagendamentoResponse": {
             "type": "object",
             "properties": {
-                "categoria": {
+                "criado_em": {
                     "type": "string"
                 },
-                "duracaoPadrao": {
-                    "type": "integer"
+                "data_fim_anterior": {
+                    "type": "string"
                 },
-                "id": {
+                "data_fim_nova": {
                     "type": "string"
                 },
-                "imagemUrl": {
+                "data_inicio_anterior": {
                     "type": "string"
                 },
-                "nome": {
+                "data_inicio_nova": {
                     "type": "string"
                 },
-                "preco": {
-                    "type": "integer"
+                "id": {
+                    "type": "string"
                 }
             }
         },
-        "output.IntervaloDiarioOutput": {
+        "response_agendamento.SerieAgendamentoResponse": {
             "type": "object",
             "properties": {
-                "horaFim": {
-                    "type": "string"
+                "agendados": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response_agendamento.AgendamentoResponse"
+                    }
                 },
-                "horaInicio": {
-                    "type": "string"
+                "falhas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response_agendamento.FalhaOcorrenciaResponse"
+                    }
                 },
-                "id": {
+                "serie_id": {
                     "type": "string"
                 }
             }
         },
-        "request.ClienteRequest": {
+        "response_agendamento.ServicoInfo": {
             "type": "object",
-            "required": [
-                "email",
-                "nome",
-                "telefone"
-            ],
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "joao@email.com"
+                "categoria": {
+                    "type": "string"
+                },
+                "duracao": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
                 },
                 "nome": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3,
-                    "example": "João da Silva"
+                    "type": "string"
                 },
-                "telefone": {
-                    "type": "string",
-                    "maxLength": 15,
-                    "minLength": 8,
-                    "example": "62999677481"
+                "preco": {
+                    "type": "integer"
                 }
             }
         },
-        "request_agendamento.AgendamentoRequest": {
+        "response_agendamento.VisitaResponse": {
             "type": "object",
-            "required": [
-                "catalogo_id",
-                "cliente_id",
-                "data_hora_inicio",
-                "prestador_id"
-            ],
             "properties": {
-                "catalogo_id": {
+                "cliente_id": {
                     "type": "string"
                 },
-                "cliente_id": {
+                "data_fim": {
                     "type": "string"
                 },
-                "data_hora_inicio": {
-                    "type": "string",
-                    "example": "2025-01-03T08:00:00Z"
+                "data_inicio": {
+                    "type": "string"
                 },
-                "notas": {
-                    "type": "string",
-                    "maxLength": 500
+                "duracao_total": {
+                    "type": "integer"
                 },
-                "prestador_id": {
+                "id": {
                     "type": "string"
+                },
+                "itens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response_agendamento.AgendamentoResponse"
+                    }
+                },
+                "preco_total": {
+                    "type": "integer"
                 }
             }
         },
-        "request_catalogo.CatalogoRequest": {
+        "response_auth.TokensResponse": {
             "type": "object",
-            "required": [
-                "categoria",
-                "duracao_padrao",
-                "image_url",
-                "nome",
-                "preco"
-            ],
             "properties": {
-                "categoria": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 3,
-                    "example": "Redes"
+                "access_token": {
+                    "type": "string"
                 },
-                "duracao_padrao": {
-                    "type": "integer",
-                    "example": 20
+                "expira_em": {
+                    "type": "string"
                 },
-                "image_url": {
-                    "type": "string",
-                    "example": "https://tdfuderuzpylkctxbysu.supabase.co/storage/v1/object/public/imagens/bb515383d2f6ef76.jpg"
+                "refresh_token": {
+                    "type": "string"
                 },
-                "nome": {
+                "token_type": {
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3,
-                    "example": "Tecnico de Redes"
-                },
-                "preco": {
-                    "type": "integer",
-                    "example": 10000
+                    "example": "Bearer"
                 }
             }
         },
-        "request_catalogo.CatalogoUpdateRequest": {
+        "response_auth.UsuarioResponse": {
             "type": "object",
-            "required": [
-                "categoria",
-                "duracao_padrao",
-                "image_url",
-                "nome",
-                "preco"
-            ],
             "properties": {
-                "categoria": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 3,
-                    "example": "Estética Facial"
+                "ativo": {
+                    "type": "boolean"
                 },
-                "duracao_padrao": {
-                    "type": "integer",
-                    "example": 40
+                "cliente_id": {
+                    "type": "string"
                 },
-                "image_url": {
-                    "type": "string",
-                    "example": "https://tdfuderuzpylkctxbysu.supabase.co/storage/v1/object/public/imagens/bb515383d2f6ef76.jpg"
+                "criado_em": {
+                    "type": "string"
                 },
-                "nome": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3,
-                    "example": "Limpesa de Peele"
+                "email": {
+                    "type": "string"
                 },
-                "preco": {
-                    "type": "integer",
-                    "example": 10000
+                "id": {
+                    "type": "string"
+                },
+                "papel": {
+                    "type": "string"
+                },
+                "prestador_id": {
+                    "type": "string"
                 }
             }
         },
-        "request_prestador.AgendaDiariaRequest": {
+        "response_calendario.TokenCalendarioResponse": {
             "type": "object",
-            "required": [
-                "data",
-                "intervalos"
-            ],
             "properties": {
-                "data": {
-                    "type": "string",
-                    "example": "2025-01-03"
+                "criado_em": {
+                    "type": "string"
                 },
-                "intervalos": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/request_prestador.IntervaloDiarioRequest"
-                    }
+                "token": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "/api/v1/calendario/3q2-7wEjJ0v9kq1Xyo8cTg1y2sWcB7hA5rJtU4mNpQk.ics"
                 }
             }
         },
-        "request_prestador.IntervaloDiarioRequest": {
+        "response_catalogo.CatalogoListResponse": {
             "type": "object",
-            "required": [
-                "hora_fim",
-                "hora_inicio"
-            ],
             "properties": {
-                "hora_fim": {
-                    "type": "string",
-                    "example": "12:00"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/output.CatalogoOutput"
+                    }
                 },
-                "hora_inicio": {
-                    "type": "string",
-                    "example": "08:00"
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "request_prestador.PrestadorRequest": {
+        "response_catalogo.CatalogoResponse": {
+            "type": "object",
+            "properties": {
+                "categoria": {
+                    "type": "string"
+                },
+                "duracao_padrao": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "preco": {
+                    "type": "integer"
+                },
+                "tempo_limpeza": {
+                    "type": "integer"
+                },
+                "tempo_preparo": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response_lgpd.ClienteExportado": {
             "type": "object",
-            "required": [
-                "catalogo_ids",
-                "cpf",
-                "image_url",
-                "nome",
-                "telefone"
-            ],
             "properties": {
-                "catalogo_ids": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "string"
-                    }
+                "anonimizado_em": {
+                    "type": "string"
                 },
-                "cpf": {
-                    "type": "string",
-                    "example": "04423258196"
+                "ativo": {
+                    "type": "boolean"
                 },
                 "email": {
-                    "type": "string",
-                    "example": "joao@email.com"
+                    "type": "string"
                 },
-                "image_url": {
-                    "type": "string",
-                    "example": "https://tdfuderuzpylkctxbysu.supabase.co/storage/v1/object/public/imagens/bb515383d2f6ef76.jpg"
+                "id": {
+                    "type": "string"
                 },
                 "nome": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3,
-                    "example": "joao"
+                    "type": "string"
                 },
                 "telefone": {
-                    "type": "string",
-                    "maxLength": 15,
-                    "minLength": 8,
-                    "example": "62999677481"
+                    "type": "string"
                 }
             }
         },
-        "request_prestador.PrestadorUpdateRequest": {
+        "response_lgpd.ExportacaoClienteResponse": {
             "type": "object",
-            "required": [
-                "catalogo_ids",
-                "image_url",
-                "nome",
-                "telefone"
-            ],
             "properties": {
-                "catalogo_ids": {
+                "agendamentos": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/response_agendamento.AgendamentoResponse"
                     }
                 },
-                "email": {
-                    "type": "string",
-                    "example": "joao@email.com"
+                "cliente": {
+                    "$ref": "#/definitions/response_lgpd.ClienteExportado"
                 },
-                "image_url": {
-                    "type": "string",
-                    "example": "https://tdfuderuzpylkctxbysu.supabase.co/storage/v1/object/public/imagens/bb515383d2f6ef76.jpg"
+                "exportado_em": {
+                    "type": "string"
                 },
-                "nome": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3,
-                    "example": "joao"
+                "lista_espera": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response_lista_espera.ListaEsperaResponse"
+                    }
                 },
-                "telefone": {
-                    "type": "string",
-                    "maxLength": 15,
-                    "minLength": 8,
-                    "example": "62999677481"
+                "series": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response_lgpd.SerieExportada"
+                    }
+                },
+                "visitas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response_lgpd.VisitaExportada"
+                    }
                 }
             }
         },
-        "response_agendamento.AgendamentoResponse": {
+        "response_lgpd.SerieExportada": {
             "type": "object",
             "properties": {
-                "cliente": {
-                    "$ref": "#/definitions/response_agendamento.ClienteInfo"
-                },
-                "data_fim": {
+                "catalogo_id": {
                     "type": "string"
                 },
-                "data_inicio": {
+                "data_hora_inicio": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
+                "intervalo_semanas": {
+                    "type": "integer"
+                },
                 "notas": {
                     "type": "string"
                 },
-                "prestador": {
-                    "$ref": "#/definitions/response_agendamento.PrestadorInfo"
-                },
-                "servico": {
-                    "$ref": "#/definitions/response_agendamento.ServicoInfo"
+                "ocorrencias": {
+                    "type": "integer"
                 },
-                "status": {
-                    "$ref": "#/definitions/domain.StatusDoAgendamento"
+                "prestador_id": {
+                    "type": "string"
                 }
             }
         },
-        "response_agendamento.BuscaDataResponse": {
+        "response_lgpd.SolicitacaoLGPDResponse": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/response_agendamento.AgendamentoResponse"
-                    }
+                "criado_em": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "observacao": {
+                    "type": "string"
+                },
+                "resultado": {
+                    "type": "string",
+                    "example": "atendida"
+                },
+                "tipo": {
+                    "type": "string"
+                },
+                "usuario_id": {
+                    "type": "string"
                 }
             }
         },
-        "response_agendamento.ClienteInfo": {
+        "response_lgpd.VisitaExportada": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "string"
                 },
-                "nome": {
-                    "type": "string"
-                },
-                "telefone": {
+                "notas": {
                     "type": "string"
                 }
             }
         },
-        "response_agendamento.PrestadorInfo": {
+        "response_lista_espera.ListaEsperaResponse": {
             "type": "object",
             "properties": {
-                "ativo": {
-                    "type": "boolean"
+                "agendamento_id": {
+                    "type": "string"
                 },
-                "cpf": {
+                "catalogo_id": {
                     "type": "string"
                 },
-                "email": {
+                "cliente_id": {
+                    "type": "string"
+                },
+                "criado_em": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "nome": {
+                "janela_fim": {
                     "type": "string"
                 },
-                "telefone": {
+                "janela_inicio": {
+                    "type": "string"
+                },
+                "oferta": {
+                    "$ref": "#/definitions/response_lista_espera.OfertaVagaResponse"
+                },
+                "prestador_id": {
                     "type": "string"
+                },
+                "status": {
+                    "description": "1 = aguardando, 2 = vaga oferecida, 3 = vaga aceita, 4 = cancelada, 5 = oferta expirada",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.StatusListaEspera"
+                        }
+                    ]
                 }
             }
         },
-        "response_agendamento.ServicoInfo": {
+        "response_lista_espera.OfertaVagaResponse": {
             "type": "object",
             "properties": {
-                "categoria": {
+                "data_hora_fim": {
                     "type": "string"
                 },
-                "duracao": {
-                    "type": "integer"
-                },
-                "id": {
+                "data_hora_inicio": {
                     "type": "string"
                 },
-                "nome": {
+                "expira_em": {
                     "type": "string"
                 },
-                "preco": {
-                    "type": "integer"
+                "prestador_id": {
+                    "type": "string"
                 }
             }
         },
-        "response_catalogo.CatalogoListResponse": {
+        "response_modelo_agenda.GeracaoAgendaResponse": {
             "type": "object",
             "properties": {
-                "data": {
+                "criadas": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/output.CatalogoOutput"
+                        "type": "string"
                     }
                 },
-                "limit": {
-                    "type": "integer"
-                },
-                "page": {
-                    "type": "integer"
-                },
-                "total": {
-                    "type": "integer"
+                "ignoradas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "response_catalogo.CatalogoResponse": {
+        "response_modelo_agenda.IntervaloModeloResponse": {
             "type": "object",
             "properties": {
-                "categoria": {
+                "hora_fim": {
                     "type": "string"
                 },
-                "duracao_padrao": {
-                    "type": "integer"
+                "hora_inicio": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "string"
+                }
+            }
+        },
+        "response_modelo_agenda.ModeloAgendaResponse": {
+            "type": "object",
+            "properties": {
+                "dias_semana": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "image_url": {
+                "id": {
                     "type": "string"
                 },
-                "nome": {
+                "intervalos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response_modelo_agenda.IntervaloModeloResponse"
+                    }
+                },
+                "prestador_id": {
                     "type": "string"
                 },
-                "preco": {
-                    "type": "integer"
+                "vigencia_fim": {
+                    "type": "string"
+                },
+                "vigencia_inicio": {
+                    "type": "string"
                 }
             }
         },
@@ -1518,6 +4551,20 @@ const docTemplate = `{
                 }
             }
         },
+        "response_prestador.CondicaoDoServicoResponse": {
+            "type": "object",
+            "properties": {
+                "catalogo_id": {
+                    "type": "string"
+                },
+                "duracao_padrao": {
+                    "type": "integer"
+                },
+                "preco": {
+                    "type": "integer"
+                }
+            }
+        },
         "response_prestador.IntervaloDiarioResponse": {
             "type": "object",
             "properties": {
@@ -1567,6 +4614,9 @@ const docTemplate = `{
                 "email": {
                     "type": "string"
                 },
+                "fuso_horario": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
@@ -1599,12 +4649,22 @@ const docTemplate = `{
                         "$ref": "#/definitions/response_catalogo.CatalogoResponse"
                     }
                 },
+                "condicoes_servico": {
+                    "description": "Condicoes lista os serviços em que o prestador pratica preço ou duração próprios",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response_prestador.CondicaoDoServicoResponse"
+                    }
+                },
                 "cpf": {
                     "type": "string"
                 },
                 "email": {
                     "type": "string"
                 },
+                "fuso_horario": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
@@ -1616,9 +4676,81 @@ const docTemplate = `{
                 },
                 "telefone": {
                     "type": "string"
+                },
+                "tempo_limpeza": {
+                    "type": "integer"
+                },
+                "tempo_preparo": {
+                    "description": "TempoPreparo e TempoLimpeza só aparecem quando o prestador substitui os do serviço",
+                    "type": "integer"
+                }
+            }
+        },
+        "resposta.ParametroInvalido": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "email"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "email"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "deve ser um email válido"
+                }
+            }
+        },
+        "resposta.Problema": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "cliente_nao_encontrado"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "cliente não encontrado"
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/clientes/8f14e45f"
+                },
+                "invalid_params": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/resposta.ParametroInvalido"
+                    }
+                },
+                "request_id": {
+                    "description": "RequestID é o mesmo registrado no log, para o suporte encontrar a ocorrência",
+                    "type": "string",
+                    "example": "9b2c6e1f4a7d3e58"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Not Found"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "about:blank"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "Token de acesso obtido em /auth/login, no formato \"Bearer \u003ctoken\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
@@ -1629,7 +4761,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "/api/v1",
 	Schemes:          []string{},
 	Title:            "API de Agendamentos",
-	Description:      "API para gestão de clientes e serviços.",
+	Description:      "API para gestão de clientes e serviços. Erros seguem o formato application/problem+json com um code estável; a mensagem em detail é escrita no idioma pedido em Accept-Language (pt-BR, padrão, ou en).",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
@@ -0,0 +1,8 @@
+// Package flyway embute os scripts de flyway/sql no binário. Os arquivos
+// continuam no mesmo lugar para que o container do Flyway siga funcionando
+package flyway
+
+import "embed"
+
+//go:embed sql/*.sql
+var Scripts embed.FS
@@ -0,0 +1,33 @@
+-- Fuso horário IANA do prestador. Os intervalos da agenda são horários de parede
+-- nesse fuso. Prestadores existentes ficam em UTC, preservando o comportamento anterior.
+ALTER TABLE prestadores
+    ADD COLUMN fuso_horario VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+-- Os horários passam a ser instantes absolutos. Os valores gravados até aqui estavam em UTC.
+-- A restrição de sobreposição usa tsrange e precisa ser recriada com tstzrange.
+ALTER TABLE agendamentos
+    DROP CONSTRAINT excl_agendamentos_prestador_periodo;
+
+ALTER TABLE agendamentos
+    ALTER COLUMN data_hora_inicio TYPE TIMESTAMP WITH TIME ZONE USING data_hora_inicio AT TIME ZONE 'UTC',
+    ALTER COLUMN data_hora_fim    TYPE TIMESTAMP WITH TIME ZONE USING data_hora_fim AT TIME ZONE 'UTC',
+    ALTER COLUMN created_at       TYPE TIMESTAMP WITH TIME ZONE USING created_at AT TIME ZONE 'UTC';
+
+ALTER TABLE agendamentos
+    ADD CONSTRAINT excl_agendamentos_prestador_periodo
+    EXCLUDE USING gist (
+        prestador_id WITH =,
+        tstzrange(data_hora_inicio, data_hora_fim) WITH &&
+    )
+    WHERE (status <> 3); -- domain.Cancelado não ocupa horário
+
+ALTER TABLE agendamento_reagendamentos
+    ALTER COLUMN data_hora_inicio_anterior TYPE TIMESTAMP WITH TIME ZONE USING data_hora_inicio_anterior AT TIME ZONE 'UTC',
+    ALTER COLUMN data_hora_fim_anterior    TYPE TIMESTAMP WITH TIME ZONE USING data_hora_fim_anterior AT TIME ZONE 'UTC',
+    ALTER COLUMN data_hora_inicio_nova     TYPE TIMESTAMP WITH TIME ZONE USING data_hora_inicio_nova AT TIME ZONE 'UTC',
+    ALTER COLUMN data_hora_fim_nova        TYPE TIMESTAMP WITH TIME ZONE USING data_hora_fim_nova AT TIME ZONE 'UTC',
+    ALTER COLUMN created_at                TYPE TIMESTAMP WITH TIME ZONE USING created_at AT TIME ZONE 'UTC';
+
+ALTER TABLE agendamento_series
+    ALTER COLUMN data_hora_inicio TYPE TIMESTAMP WITH TIME ZONE USING data_hora_inicio AT TIME ZONE 'UTC',
+    ALTER COLUMN created_at       TYPE TIMESTAMP WITH TIME ZONE USING created_at AT TIME ZONE 'UTC';
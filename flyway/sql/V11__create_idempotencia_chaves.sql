@@ -0,0 +1,13 @@
+-- Respostas já dadas para cada Idempotency-Key. status_code nulo indica que a
+-- requisição original ainda está em andamento.
+CREATE TABLE idempotencia_chaves (
+    chave           VARCHAR(400) PRIMARY KEY,
+    hash_requisicao CHAR(64) NOT NULL,
+    status_code     INTEGER,
+    corpo           BYTEA,
+    criado_em       TIMESTAMP WITH TIME ZONE NOT NULL,
+    expira_em       TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_idempotencia_chaves_expira_em
+ON idempotencia_chaves (expira_em);
@@ -0,0 +1,34 @@
+-- Clientes aguardando vaga para um serviço dentro de uma janela de datas.
+-- status: 1 aguardando, 2 vaga oferecida, 3 aceita, 4 cancelada, 5 oferta expirada.
+CREATE TABLE lista_espera (
+    id           VARCHAR(20) PRIMARY KEY,
+    cliente_id   VARCHAR(20) NOT NULL REFERENCES clientes (id) ON DELETE RESTRICT,
+    catalogo_id  VARCHAR(20) NOT NULL REFERENCES catalogos (id) ON DELETE RESTRICT,
+    prestador_id VARCHAR(20) REFERENCES prestadores (id) ON DELETE RESTRICT,
+
+    janela_inicio TIMESTAMP WITH TIME ZONE NOT NULL,
+    janela_fim    TIMESTAMP WITH TIME ZONE NOT NULL,
+    status        INTEGER NOT NULL,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    oferta_prestador_id VARCHAR(20) REFERENCES prestadores (id) ON DELETE RESTRICT,
+    oferta_inicio       TIMESTAMP WITH TIME ZONE,
+    oferta_fim          TIMESTAMP WITH TIME ZONE,
+    oferta_expira_em    TIMESTAMP WITH TIME ZONE,
+
+    agendamento_id VARCHAR(20) REFERENCES agendamentos (id) ON DELETE SET NULL,
+
+    CONSTRAINT chk_lista_espera_janela
+        CHECK (janela_inicio < janela_fim),
+
+    CONSTRAINT chk_lista_espera_status
+        CHECK (status IN (1, 2, 3, 4, 5))
+);
+
+CREATE INDEX idx_lista_espera_aguardando
+ON lista_espera (created_at)
+WHERE status = 1;
+
+CREATE INDEX idx_lista_espera_ofertas
+ON lista_espera (oferta_prestador_id, oferta_inicio)
+WHERE status = 2;
@@ -0,0 +1,41 @@
+-- Minutos de preparo antes e de limpeza depois de cada atendimento do serviço
+ALTER TABLE catalogos
+    ADD COLUMN tempo_preparo INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN tempo_limpeza INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_catalogo_tempo_preparo CHECK (tempo_preparo BETWEEN 0 AND 240),
+    ADD CONSTRAINT chk_catalogo_tempo_limpeza CHECK (tempo_limpeza BETWEEN 0 AND 240);
+
+-- Quando preenchidos, substituem os tempos do serviço para o prestador
+ALTER TABLE prestadores
+    ADD COLUMN tempo_preparo INTEGER,
+    ADD COLUMN tempo_limpeza INTEGER,
+    ADD CONSTRAINT chk_prestador_tempo_preparo CHECK (tempo_preparo BETWEEN 0 AND 240),
+    ADD CONSTRAINT chk_prestador_tempo_limpeza CHECK (tempo_limpeza BETWEEN 0 AND 240);
+
+-- Período em que o prestador fica ocupado, incluindo preparo e limpeza.
+-- Os agendamentos existentes não tinham esses tempos e bloqueiam apenas o atendimento.
+ALTER TABLE agendamentos
+    ADD COLUMN bloqueio_inicio TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN bloqueio_fim    TIMESTAMP WITH TIME ZONE;
+
+UPDATE agendamentos
+SET bloqueio_inicio = data_hora_inicio,
+    bloqueio_fim    = data_hora_fim;
+
+ALTER TABLE agendamentos
+    ALTER COLUMN bloqueio_inicio SET NOT NULL,
+    ALTER COLUMN bloqueio_fim    SET NOT NULL,
+    ADD CONSTRAINT chk_agendamento_bloqueio
+        CHECK (bloqueio_inicio <= data_hora_inicio AND bloqueio_fim >= data_hora_fim);
+
+-- A restrição de sobreposição passa a considerar o período bloqueado
+ALTER TABLE agendamentos
+    DROP CONSTRAINT excl_agendamentos_prestador_periodo;
+
+ALTER TABLE agendamentos
+    ADD CONSTRAINT excl_agendamentos_prestador_periodo
+    EXCLUDE USING gist (
+        prestador_id WITH =,
+        tstzrange(bloqueio_inicio, bloqueio_fim) WITH &&
+    )
+    WHERE (status <> 3); -- domain.Cancelado não ocupa horário
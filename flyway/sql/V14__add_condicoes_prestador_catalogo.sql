@@ -0,0 +1,6 @@
+-- Preço e duração que o prestador pratica em cada serviço. Nulos mantêm os valores do catálogo
+ALTER TABLE prestador_catalogos
+    ADD COLUMN preco          INTEGER,
+    ADD COLUMN duracao_padrao INTEGER,
+    ADD CONSTRAINT chk_prestador_catalogo_preco CHECK (preco >= 0),
+    ADD CONSTRAINT chk_prestador_catalogo_duracao CHECK (duracao_padrao > 1);
@@ -0,0 +1,29 @@
+-- Cópia do serviço no momento da reserva, para que alterações no catálogo não
+-- reescrevam agendamentos já feitos
+ALTER TABLE agendamentos
+    ADD COLUMN servico_nome      VARCHAR(100),
+    ADD COLUMN servico_preco     INTEGER,
+    ADD COLUMN servico_duracao   INTEGER,
+    ADD COLUMN servico_categoria VARCHAR(50);
+
+-- Agendamentos existentes recebem os valores atuais do catálogo, com o preço próprio
+-- do prestador quando houver. A duração é a que de fato foi reservada
+UPDATE agendamentos a
+SET servico_nome      = cat.nome,
+    servico_preco     = COALESCE(
+                            (SELECT pc.preco
+                             FROM prestador_catalogos pc
+                             WHERE pc.prestador_id = a.prestador_id
+                               AND pc.catalogo_id = a.catalogo_id),
+                            cat.preco
+                        ),
+    servico_duracao   = (EXTRACT(EPOCH FROM (a.data_hora_fim - a.data_hora_inicio)) / 60)::INTEGER,
+    servico_categoria = cat.categoria
+FROM catalogos cat
+WHERE cat.id = a.catalogo_id;
+
+ALTER TABLE agendamentos
+    ALTER COLUMN servico_nome      SET NOT NULL,
+    ALTER COLUMN servico_preco     SET NOT NULL,
+    ALTER COLUMN servico_duracao   SET NOT NULL,
+    ALTER COLUMN servico_categoria SET NOT NULL;
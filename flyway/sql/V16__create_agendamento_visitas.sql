@@ -0,0 +1,22 @@
+CREATE TABLE agendamento_visitas (
+    id VARCHAR(20) PRIMARY KEY,
+
+    cliente_id VARCHAR(20) NOT NULL,
+    notas TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_visita_cliente
+        FOREIGN KEY (cliente_id)
+        REFERENCES clientes (id)
+        ON DELETE RESTRICT
+);
+
+ALTER TABLE agendamentos
+    ADD COLUMN visita_id VARCHAR(20)
+    REFERENCES agendamento_visitas (id)
+    ON DELETE SET NULL;
+
+CREATE INDEX idx_agendamentos_visita
+ON agendamentos (visita_id)
+WHERE visita_id IS NOT NULL;
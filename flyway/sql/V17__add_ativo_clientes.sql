@@ -0,0 +1,3 @@
+-- Clientes inativos não recebem novos agendamentos; o histórico é mantido
+ALTER TABLE clientes
+    ADD COLUMN ativo BOOLEAN NOT NULL DEFAULT TRUE;
@@ -0,0 +1,25 @@
+-- Data em que os dados pessoais do cliente foram anonimizados a pedido do titular
+ALTER TABLE clientes
+    ADD COLUMN anonimizado_em TIMESTAMP WITH TIME ZONE;
+
+-- Registro de cada pedido de exportação ou de anonimização dos dados de um cliente:
+-- quem o fez, pelo usuário autenticado, e se foi atendido, negado ou falhou.
+-- cliente_id não referencia clientes para que pedidos sobre IDs inexistentes
+-- também fiquem registrados
+CREATE TABLE lgpd_solicitacoes (
+    id          VARCHAR(20) PRIMARY KEY,
+    cliente_id  VARCHAR(20) NOT NULL,
+    tipo        VARCHAR(20) NOT NULL,
+    usuario_id  VARCHAR(20) NOT NULL,
+    observacao  VARCHAR(100) NOT NULL DEFAULT '',
+    resultado   VARCHAR(20) NOT NULL,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    CONSTRAINT chk_lgpd_solicitacao_tipo
+        CHECK (tipo IN ('exportacao', 'anonimizacao')),
+    CONSTRAINT chk_lgpd_solicitacao_resultado
+        CHECK (resultado IN ('atendida', 'negada', 'falhou'))
+);
+
+CREATE INDEX idx_lgpd_solicitacoes_cliente
+ON lgpd_solicitacoes (cliente_id, created_at);
@@ -0,0 +1,38 @@
+-- Contas de acesso à API. Cliente e prestador apontam para o próprio cadastro; admin não tem vínculo
+CREATE TABLE usuarios (
+    id           VARCHAR(20) PRIMARY KEY,
+    email        VARCHAR(255) NOT NULL,
+    senha_hash   VARCHAR(100) NOT NULL,
+    papel        VARCHAR(20) NOT NULL,
+    cliente_id   VARCHAR(20) REFERENCES clientes (id) ON DELETE RESTRICT,
+    prestador_id VARCHAR(20) REFERENCES prestadores (id) ON DELETE RESTRICT,
+    ativo        BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    CONSTRAINT uq_usuarios_email UNIQUE (email),
+
+    CONSTRAINT chk_usuario_papel
+        CHECK (papel IN ('admin', 'prestador', 'cliente')),
+
+    CONSTRAINT chk_usuario_vinculo CHECK (
+        (papel = 'admin' AND cliente_id IS NULL AND prestador_id IS NULL) OR
+        (papel = 'cliente' AND cliente_id IS NOT NULL AND prestador_id IS NULL) OR
+        (papel = 'prestador' AND prestador_id IS NOT NULL AND cliente_id IS NULL)
+    )
+);
+
+-- Só o hash do refresh token é guardado
+CREATE TABLE refresh_tokens (
+    id          VARCHAR(20) PRIMARY KEY,
+    usuario_id  VARCHAR(20) NOT NULL REFERENCES usuarios (id) ON DELETE CASCADE,
+    token_hash  CHAR(64) NOT NULL,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL,
+    expira_em   TIMESTAMP WITH TIME ZONE NOT NULL,
+    revogado_em TIMESTAMP WITH TIME ZONE,
+
+    CONSTRAINT uq_refresh_tokens_hash UNIQUE (token_hash)
+);
+
+CREATE INDEX idx_refresh_tokens_usuario
+ON refresh_tokens (usuario_id)
+WHERE revogado_em IS NULL;
@@ -0,0 +1,16 @@
+-- Tokens das URLs secretas dos feeds iCalendar. Só o hash é guardado, e cada
+-- cliente ou prestador tem no máximo um token: gerar outro invalida o anterior
+CREATE TABLE calendario_tokens (
+    token_hash   CHAR(64) PRIMARY KEY,
+    cliente_id   VARCHAR(20) REFERENCES clientes (id) ON DELETE CASCADE,
+    prestador_id VARCHAR(20) REFERENCES prestadores (id) ON DELETE CASCADE,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    CONSTRAINT uq_calendario_tokens_cliente UNIQUE (cliente_id),
+    CONSTRAINT uq_calendario_tokens_prestador UNIQUE (prestador_id),
+
+    CONSTRAINT chk_calendario_token_dono CHECK (
+        (cliente_id IS NOT NULL AND prestador_id IS NULL) OR
+        (prestador_id IS NOT NULL AND cliente_id IS NULL)
+    )
+);
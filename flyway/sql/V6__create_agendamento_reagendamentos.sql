@@ -0,0 +1,20 @@
+CREATE TABLE agendamento_reagendamentos (
+    id VARCHAR(20) PRIMARY KEY,
+
+    agendamento_id VARCHAR(20) NOT NULL,
+
+    data_hora_inicio_anterior TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    data_hora_fim_anterior    TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    data_hora_inicio_nova     TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    data_hora_fim_nova        TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_reagendamento_agendamento
+        FOREIGN KEY (agendamento_id)
+        REFERENCES agendamentos (id)
+        ON DELETE CASCADE
+);
+
+CREATE INDEX idx_reagendamentos_agendamento
+ON agendamento_reagendamentos (agendamento_id, created_at);
@@ -0,0 +1,41 @@
+-- Garante no banco que um prestador não tenha dois agendamentos ativos sobrepostos.
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Já existem reservas duplicadas em produção, e a constraint não pode ser criada
+-- com elas. A migração não escolhe qual cancelar: ela falha listando os pares
+-- sobrepostos para que o atendimento resolva cada um (cancelando ou remarcando pela
+-- API) e a migração seja aplicada de novo. O status 3 é domain.Cancelado
+DO $$
+DECLARE
+    quantidade INTEGER;
+    conflitos  TEXT;
+BEGIN
+    SELECT count(*),
+           string_agg(format('prestador %s: agendamento %s (%s a %s) sobrepõe %s (%s a %s)',
+                             a.prestador_id,
+                             a.id, a.data_hora_inicio, a.data_hora_fim,
+                             b.id, b.data_hora_inicio, b.data_hora_fim),
+                      E'\n' ORDER BY a.prestador_id, a.data_hora_inicio, a.id, b.id)
+    INTO quantidade, conflitos
+    FROM agendamentos a
+    JOIN agendamentos b
+      ON b.prestador_id = a.prestador_id
+     AND b.id > a.id
+     AND tsrange(b.data_hora_inicio, b.data_hora_fim) && tsrange(a.data_hora_inicio, a.data_hora_fim)
+    WHERE a.status <> 3 -- domain.Cancelado
+      AND b.status <> 3;
+
+    IF quantidade > 0 THEN
+        -- A lista vai na mensagem: é o que o comando de migração mostra
+        RAISE EXCEPTION E'% par(es) de agendamentos ativos sobrepostos impedem a constraint excl_agendamentos_prestador_periodo:\n%', quantidade, conflitos
+            USING HINT = 'Cancele ou remarque um agendamento de cada par e aplique a migração de novo';
+    END IF;
+END $$;
+
+ALTER TABLE agendamentos
+    ADD CONSTRAINT excl_agendamentos_prestador_periodo
+    EXCLUDE USING gist (
+        prestador_id WITH =,
+        tsrange(data_hora_inicio, data_hora_fim) WITH &&
+    )
+    WHERE (status <> 3); -- domain.Cancelado não ocupa horário
@@ -0,0 +1,32 @@
+CREATE TABLE modelos_agenda (
+    id VARCHAR(20) PRIMARY KEY,
+    prestador_id VARCHAR(20) NOT NULL,
+
+    -- 0 = domingo ... 6 = sábado (time.Weekday)
+    dias_semana SMALLINT[] NOT NULL,
+
+    vigencia_inicio DATE NOT NULL,
+    vigencia_fim    DATE NOT NULL,
+
+    created_at TIMESTAMP DEFAULT NOW(),
+
+    CONSTRAINT fk_modelo_prestador
+        FOREIGN KEY(prestador_id) REFERENCES prestadores(id)
+        ON DELETE CASCADE,
+    CONSTRAINT chk_vigencia_valida CHECK (vigencia_inicio <= vigencia_fim),
+    CONSTRAINT chk_dias_semana CHECK (dias_semana <@ ARRAY[0,1,2,3,4,5,6]::SMALLINT[])
+);
+
+CREATE TABLE modelos_agenda_intervalos (
+    id VARCHAR(20) PRIMARY KEY,
+    modelo_id VARCHAR(20) NOT NULL,
+    hora_inicio TIME NOT NULL,
+    hora_fim TIME NOT NULL,
+    CONSTRAINT fk_modelo
+        FOREIGN KEY(modelo_id) REFERENCES modelos_agenda(id)
+        ON DELETE CASCADE,
+    CONSTRAINT chk_modelo_horario_valido CHECK (hora_inicio < hora_fim)
+);
+
+CREATE INDEX idx_modelos_agenda_prestador
+ON modelos_agenda (prestador_id);
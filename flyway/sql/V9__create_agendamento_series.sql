@@ -0,0 +1,41 @@
+CREATE TABLE agendamento_series (
+    id VARCHAR(20) PRIMARY KEY,
+
+    cliente_id   VARCHAR(20) NOT NULL,
+    prestador_id VARCHAR(20) NOT NULL,
+    catalogo_id  VARCHAR(20) NOT NULL,
+
+    data_hora_inicio  TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    intervalo_semanas INTEGER NOT NULL,
+    ocorrencias       INTEGER NOT NULL,
+    notas TEXT,
+
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_serie_cliente
+        FOREIGN KEY (cliente_id)
+        REFERENCES clientes (id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT fk_serie_prestador
+        FOREIGN KEY (prestador_id)
+        REFERENCES prestadores (id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT fk_serie_catalogo
+        FOREIGN KEY (catalogo_id)
+        REFERENCES catalogos (id)
+        ON DELETE RESTRICT,
+
+    CONSTRAINT chk_serie_intervalo CHECK (intervalo_semanas BETWEEN 1 AND 4),
+    CONSTRAINT chk_serie_ocorrencias CHECK (ocorrencias BETWEEN 2 AND 52)
+);
+
+ALTER TABLE agendamentos
+    ADD COLUMN serie_id VARCHAR(20)
+    REFERENCES agendamento_series (id)
+    ON DELETE SET NULL;
+
+CREATE INDEX idx_agendamentos_serie
+ON agendamentos (serie_id)
+WHERE serie_id IS NOT NULL;
@@ -1,11 +1,12 @@
 package agendamento
 
 import (
-	"errors"
+	"context"
 	"meu-servico-agenda/internal/adapters/http/agendamento/request_agendamento"
 	"meu-servico-agenda/internal/adapters/http/agendamento/response_agendamento"
+	"meu-servico-agenda/internal/adapters/http/resposta"
+	"meu-servico-agenda/internal/core/application/input"
 	"meu-servico-agenda/internal/core/application/service"
-	"meu-servico-agenda/internal/core/domain"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -26,54 +27,27 @@ func NovoAgendamentoController(ag *service.AgendamentoService) *AgendamentoContr
 // @Tags Agendamentos
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param agendamento body request_agendamento.AgendamentoRequest true "Dados do agendamento"
+// @Param Idempotency-Key header string false "Chave para repetir a requisição com segurança; a mesma chave e corpo devolvem a resposta original"
 // @Success 201 {object} response_agendamento.AgendamentoResponse "Agendamento criado com sucesso"
-// @Failure 400 {object} domain.ErrorResponse "Dados inválidos ou formato de data incorreto"
-// @Failure 404 {object} domain.ErrorResponse "Cliente, prestador ou serviço não encontrado"
-// @Failure 409 {object} domain.ErrorResponse "Conflito de agenda (dia ou horário indisponível)"
-// @Failure 500 {object} domain.ErrorResponse "Erro interno do servidor"
+// @Failure 400 {object} resposta.Problema "Dados inválidos ou formato de data incorreto"
+// @Failure 404 {object} resposta.Problema "Cliente, prestador ou serviço não encontrado"
+// @Failure 409 {object} resposta.Problema "Conflito de agenda (dia ou horário indisponível) ou cliente inativo"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
 // @Router /agendamentos [post]
 func (ag *AgendamentoController) PostAgendamento(c *gin.Context) {
 	var input request_agendamento.AgendamentoRequest
 	// 1️⃣ Validação estrutural (JSON)
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":  "dados inválidos",
-			"detail": err.Error(),
-		})
+		resposta.DadosInvalidos(c, err)
 		return
 	}
 
 	// 2️⃣ Chamada da service
-	agendamento, err := ag.agendamentoService.CadastraAgendamento(input)
+	agendamento, err := ag.agendamentoService.CadastraAgendamento(c.Request.Context(), input)
 	if err != nil {
-
-		switch {
-
-		// 404 — recurso inexistente
-		case errors.Is(err, service.ErrClienteNaoExiste),
-			errors.Is(err, service.ErrPrestadorNaoExiste),
-			errors.Is(err, service.ErrCatalogoNaoExiste):
-
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-
-		// 409 — conflito de agenda
-		case errors.Is(err, service.ErrDiaIndisponivel),
-			errors.Is(err, service.ErrHorarioIndisponivel),
-			errors.Is(err, service.ErrPrestadorOcupado),
-			errors.Is(err, domain.ErrDataEstaNoPassado),
-			errors.Is(err, service.ErrAgendamentoDuplo),
-			errors.Is(err, service.ErrClienteOcupado):
-
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-
-		// 500 — erro inesperado
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": service.ErrFalhaInfraestrutura.Error(),
-			})
-		}
-
+		resposta.Erro(c, err)
 		return
 	}
 
@@ -88,12 +62,13 @@ func (ag *AgendamentoController) PostAgendamento(c *gin.Context) {
 // @Tags Agendamentos
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path string true "ID do cliente"
 // @Param data query string true "Data de início da busca (formato: YYYY-MM-DD)" example(2025-01-03)
 // @Success 200 {object} response_agendamento.BuscaDataResponse "Lista de agendamentos encontrados"
-// @Failure 400 {object} domain.ErrorResponse "Dados inválidos ou formato de data incorreto"
-// @Failure 404 {object} domain.ErrorResponse "Cliente não encontrado"
-// @Failure 500 {object} domain.ErrorResponse "Erro interno do servidor"
+// @Failure 400 {object} resposta.Problema "Dados inválidos ou formato de data incorreto"
+// @Failure 404 {object} resposta.Problema "Cliente não encontrado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
 // @Router /agendamentos/cliente/{id} [get]
 func (ag *AgendamentoController) GetAgendamentoClienteData(c *gin.Context) {
 	id := c.Param("id")
@@ -102,37 +77,21 @@ func (ag *AgendamentoController) GetAgendamentoClienteData(c *gin.Context) {
 
 	// 1️⃣ Validação estrutural (Query params)
 	if err := c.ShouldBindQuery(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":  "dados inválidos",
-			"detail": err.Error(),
-		})
+		resposta.DadosInvalidos(c, err)
 		return
 	}
 
 	// 2️⃣ Conversão para input
 	req, err := input.ToAgendamentoDataInput()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":  "formato de data inválido",
-			"detail": err.Error(),
-		})
+		resposta.DadosInvalidos(c, err)
 		return
 	}
 
 	// 3️⃣ Chamada da service
-	agendamentos, err := ag.agendamentoService.ConsultaAgendamentoClienteData(*req, id)
+	agendamentos, err := ag.agendamentoService.ConsultaAgendamentoClienteData(c.Request.Context(), *req, id)
 	if err != nil {
-		switch {
-		// 404 — cliente não existe
-		case errors.Is(err, service.ErrClienteNaoEncontrado):
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-
-		// 500 — erro inesperado
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": service.ErrFalhaInfraestrutura.Error(),
-			})
-		}
+		resposta.Erro(c, err)
 		return
 	}
 
@@ -146,12 +105,13 @@ func (ag *AgendamentoController) GetAgendamentoClienteData(c *gin.Context) {
 // @Tags Agendamentos
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path string true "ID do prestador"
 // @Param data query string true "Data de início da busca (formato: YYYY-MM-DD)" example(2025-01-03)
 // @Success 200 {object} response_agendamento.BuscaDataResponse "Lista de agendamentos encontrados"
-// @Failure 400 {object} domain.ErrorResponse "Dados inválidos ou formato de data incorreto"
-// @Failure 404 {object} domain.ErrorResponse "prestador não encontrado"
-// @Failure 500 {object} domain.ErrorResponse "Erro interno do servidor"
+// @Failure 400 {object} resposta.Problema "Dados inválidos ou formato de data incorreto"
+// @Failure 404 {object} resposta.Problema "prestador não encontrado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
 // @Router /agendamentos/prestador/{id} [get]
 func (ag *AgendamentoController) GetAgendamentoPrestadorData(c *gin.Context) {
 	id := c.Param("id")
@@ -160,40 +120,358 @@ func (ag *AgendamentoController) GetAgendamentoPrestadorData(c *gin.Context) {
 
 	// 1️⃣ Validação estrutural (Query params)
 	if err := c.ShouldBindQuery(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":  "dados inválidos",
-			"detail": err.Error(),
-		})
+		resposta.DadosInvalidos(c, err)
 		return
 	}
 
 	// 2️⃣ Conversão para input
 	req, err := input.ToAgendamentoDataInput()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":  "formato de data inválido",
-			"detail": err.Error(),
-		})
+		resposta.DadosInvalidos(c, err)
 		return
 	}
 
 	// 3️⃣ Chamada da service
-	agendamentos, err := ag.agendamentoService.ConsultaAgendamentoPrestadorData(*req, id)
+	agendamentos, err := ag.agendamentoService.ConsultaAgendamentoPrestadorData(c.Request.Context(), *req, id)
 	if err != nil {
-		switch {
-		// 404 — cliente não existe
-		case errors.Is(err, service.ErrClienteNaoEncontrado):
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-
-		// 500 — erro inesperado
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": service.ErrFalhaInfraestrutura.Error(),
-			})
-		}
+		resposta.Erro(c, err)
 		return
 	}
 
 	response := response_agendamento.ToBuscaDataResponse(agendamentos)
 	c.JSON(http.StatusOK, response)
-}
\ No newline at end of file
+}
+
+// @Summary Confirma um agendamento
+// @Description Move um agendamento pendente para o status confirmado
+// @Tags Agendamentos
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do agendamento"
+// @Success 204 "Agendamento confirmado com sucesso"
+// @Failure 404 {object} resposta.Problema "Agendamento não encontrado"
+// @Failure 409 {object} resposta.Problema "Transição de status inválida"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /agendamentos/{id}/confirmar [put]
+func (ag *AgendamentoController) PutConfirmarAgendamento(c *gin.Context) {
+	ag.alterarStatus(c, ag.agendamentoService.ConfirmarAgendamento)
+}
+
+// @Summary Cancela um agendamento
+// @Description Cancela um agendamento pendente ou confirmado, liberando o horário do prestador
+// @Tags Agendamentos
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do agendamento"
+// @Success 204 "Agendamento cancelado com sucesso"
+// @Failure 404 {object} resposta.Problema "Agendamento não encontrado"
+// @Failure 409 {object} resposta.Problema "Transição de status inválida"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /agendamentos/{id}/cancelar [put]
+func (ag *AgendamentoController) PutCancelarAgendamento(c *gin.Context) {
+	ag.alterarStatus(c, ag.agendamentoService.CancelarAgendamento)
+}
+
+// @Summary Conclui um agendamento
+// @Description Marca como concluído um agendamento que já estava confirmado
+// @Tags Agendamentos
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do agendamento"
+// @Success 204 "Agendamento concluído com sucesso"
+// @Failure 404 {object} resposta.Problema "Agendamento não encontrado"
+// @Failure 409 {object} resposta.Problema "Transição de status inválida"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /agendamentos/{id}/concluir [put]
+func (ag *AgendamentoController) PutConcluirAgendamento(c *gin.Context) {
+	ag.alterarStatus(c, ag.agendamentoService.ConcluirAgendamento)
+}
+
+func (ag *AgendamentoController) alterarStatus(c *gin.Context, acao func(ctx context.Context, id string) error) {
+	id := c.Param("id")
+
+	if err := acao(c.Request.Context(), id); err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Lista horários livres de um prestador
+// @Description Calcula todos os horários de início válidos para o serviço informado, considerando a agenda do dia e os agendamentos existentes
+// @Tags Agendamentos
+// @Produce json
+// @Param id path string true "ID do prestador"
+// @Param data query string true "Data desejada (formato: YYYY-MM-DD)" example(2030-01-03)
+// @Param catalogo_id query string true "ID do serviço do catálogo"
+// @Param intervalo query int false "Passo da grade em minutos (padrão: 15)"
+// @Success 200 {object} response_agendamento.HorariosDisponiveisResponse "Horários disponíveis"
+// @Failure 400 {object} resposta.Problema "Dados inválidos ou data no passado"
+// @Failure 404 {object} resposta.Problema "Prestador ou serviço não encontrado"
+// @Failure 409 {object} resposta.Problema "Prestador inativo"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /prestadores/{id}/horarios [get]
+func (ag *AgendamentoController) GetHorariosDisponiveis(c *gin.Context) {
+	prestadorID := c.Param("id")
+
+	var req request_agendamento.HorariosDisponiveisRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	in, err := req.ToHorariosDisponiveisInput(prestadorID)
+	if err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	out, err := ag.agendamentoService.BuscarHorariosDisponiveis(c.Request.Context(), *in)
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response_agendamento.NovoHorariosDisponiveisResponse(out))
+}
+
+// @Summary Reagenda um agendamento
+// @Description Move um agendamento pendente ou confirmado para um novo horário, mantendo a duração original. As mesmas regras da criação são aplicadas, desconsiderando o próprio agendamento
+// @Tags Agendamentos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do agendamento"
+// @Param reagendamento body request_agendamento.ReagendarAgendamentoRequest true "Novo horário de início"
+// @Success 200 {object} response_agendamento.AgendamentoResponse "Agendamento reagendado com sucesso"
+// @Failure 400 {object} resposta.Problema "Dados inválidos ou formato de data incorreto"
+// @Failure 404 {object} resposta.Problema "Agendamento não encontrado"
+// @Failure 409 {object} resposta.Problema "Conflito de agenda ou agendamento não pode ser reagendado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /agendamentos/{id}/reagendar [put]
+func (ag *AgendamentoController) PutReagendarAgendamento(c *gin.Context) {
+	id := c.Param("id")
+
+	var req request_agendamento.ReagendarAgendamentoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	in, err := req.ToReagendarAgendamentoInput(id)
+	if err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	agendamento, err := ag.agendamentoService.ReagendarAgendamento(c.Request.Context(), *in)
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response_agendamento.NovoAgendamentoResponse(agendamento))
+}
+
+// @Summary Lista o histórico de reagendamentos
+// @Description Retorna os horários anteriores e novos de cada reagendamento do agendamento, do mais antigo para o mais recente
+// @Tags Agendamentos
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do agendamento"
+// @Success 200 {array} response_agendamento.ReagendamentoResponse "Histórico de reagendamentos"
+// @Failure 404 {object} resposta.Problema "Agendamento não encontrado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /agendamentos/{id}/reagendamentos [get]
+func (ag *AgendamentoController) GetReagendamentos(c *gin.Context) {
+	id := c.Param("id")
+
+	historico, err := ag.agendamentoService.ListarReagendamentos(c.Request.Context(), id)
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response_agendamento.NovoReagendamentosResponse(historico))
+}
+
+// @Summary Cria uma série de agendamentos recorrentes
+// @Description Agenda o mesmo serviço a cada N semanas. Cada ocorrência passa pelas regras da criação de agendamento; as que falharem são listadas com o motivo
+// @Tags Agendamentos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param serie body request_agendamento.SerieAgendamentoRequest true "Dados da série"
+// @Param Idempotency-Key header string false "Chave para repetir a requisição com segurança; a mesma chave e corpo devolvem a resposta original"
+// @Success 201 {object} response_agendamento.SerieAgendamentoResponse "Série criada com as ocorrências agendadas e as falhas"
+// @Failure 400 {object} resposta.Problema "Dados inválidos ou formato de data incorreto"
+// @Failure 404 {object} resposta.Problema "Cliente, prestador ou serviço não encontrado"
+// @Failure 409 {object} response_agendamento.SerieAgendamentoResponse "Nenhuma ocorrência pôde ser agendada ou cliente inativo"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /agendamentos/series [post]
+func (ag *AgendamentoController) PostSerieAgendamento(c *gin.Context) {
+	var req request_agendamento.SerieAgendamentoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	in, err := req.ToCadastrarSerieInput()
+	if err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	serie, err := ag.agendamentoService.CadastraSerie(c.Request.Context(), *in)
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	status := http.StatusCreated
+	if len(serie.Agendados) == 0 {
+		status = http.StatusConflict
+	}
+
+	c.JSON(status, response_agendamento.NovoSerieAgendamentoResponse(serie))
+}
+
+// @Summary Lista as ocorrências de uma série
+// @Description Retorna todos os agendamentos da série, em ordem cronológica e em qualquer status
+// @Tags Agendamentos
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da série"
+// @Success 200 {object} response_agendamento.BuscaDataResponse "Ocorrências da série"
+// @Failure 404 {object} resposta.Problema "Série não encontrada"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /agendamentos/series/{id} [get]
+func (ag *AgendamentoController) GetSerieAgendamento(c *gin.Context) {
+	ocorrencias, err := ag.agendamentoService.ListarSerie(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response_agendamento.ToBuscaDataResponse(ocorrencias))
+}
+
+// @Summary Cancela uma série de agendamentos
+// @Description Cancela as ocorrências pendentes ou confirmadas da série. Com escopo "restantes" apenas as que ainda não começaram são afetadas
+// @Tags Agendamentos
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da série"
+// @Param escopo query string true "todas ou restantes" Enums(todas, restantes)
+// @Success 200 {object} response_agendamento.SerieAgendamentoResponse "Ocorrências canceladas e falhas"
+// @Failure 400 {object} resposta.Problema "Escopo inválido"
+// @Failure 404 {object} resposta.Problema "Série não encontrada"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /agendamentos/series/{id}/cancelar [put]
+func (ag *AgendamentoController) PutCancelarSerie(c *gin.Context) {
+	var req request_agendamento.EscopoSerieRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	resultado, err := ag.agendamentoService.CancelarSerie(c.Request.Context(), c.Param("id"), input.EscopoSerie(req.Escopo))
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response_agendamento.NovoSerieAgendamentoResponse(resultado))
+}
+
+// @Summary Reagenda uma série de agendamentos
+// @Description Desloca as ocorrências do escopo para que a primeira delas comece no novo horário, mantendo o espaçamento da série. Cada ocorrência passa pelas regras do reagendamento
+// @Tags Agendamentos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da série"
+// @Param reagendamento body request_agendamento.ReagendarSerieRequest true "Novo início e escopo"
+// @Success 200 {object} response_agendamento.SerieAgendamentoResponse "Ocorrências reagendadas e falhas"
+// @Failure 400 {object} resposta.Problema "Dados inválidos"
+// @Failure 404 {object} resposta.Problema "Série não encontrada"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /agendamentos/series/{id}/reagendar [put]
+func (ag *AgendamentoController) PutReagendarSerie(c *gin.Context) {
+	var req request_agendamento.ReagendarSerieRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	in, err := req.ToReagendarSerieInput(c.Param("id"))
+	if err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	resultado, err := ag.agendamentoService.ReagendarSerie(c.Request.Context(), *in)
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response_agendamento.NovoSerieAgendamentoResponse(resultado))
+}
+
+// @Summary Agenda vários serviços em uma visita
+// @Description Agenda os serviços na ordem da lista, cada um começando quando o anterior termina. Cada item pode ter um prestador diferente; quando o mesmo prestador atende mais de um item, o seguinte aguarda a limpeza e o preparo. Todos os itens são validados juntos e a visita só é criada se todos couberem na agenda
+// @Tags Agendamentos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param visita body request_agendamento.VisitaRequest true "Cliente, início e serviços da visita"
+// @Param Idempotency-Key header string false "Chave para repetir a requisição com segurança; a mesma chave e corpo devolvem a resposta original"
+// @Success 201 {object} response_agendamento.VisitaResponse "Visita criada com os itens, preço e duração totais"
+// @Failure 400 {object} resposta.Problema "Dados inválidos, formato de data incorreto ou serviço repetido"
+// @Failure 404 {object} resposta.Problema "Cliente, prestador ou serviço não encontrado"
+// @Failure 409 {object} resposta.Problema "Algum item não cabe na agenda ou cliente inativo; nenhum agendamento é criado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /agendamentos/visitas [post]
+func (ag *AgendamentoController) PostVisita(c *gin.Context) {
+	var req request_agendamento.VisitaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	in, err := req.ToCadastrarVisitaInput()
+	if err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	visita, err := ag.agendamentoService.CadastraVisita(c.Request.Context(), *in)
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response_agendamento.NovaVisitaResponse(visita))
+}
+
+// @Summary Busca uma visita
+// @Description Retorna os serviços da visita em ordem cronológica, com preço e duração totais
+// @Tags Agendamentos
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da visita"
+// @Success 200 {object} response_agendamento.VisitaResponse "Visita encontrada"
+// @Failure 404 {object} resposta.Problema "Visita não encontrada"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /agendamentos/visitas/{id} [get]
+func (ag *AgendamentoController) GetVisita(c *gin.Context) {
+	visita, err := ag.agendamentoService.BuscarVisita(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response_agendamento.NovaVisitaResponse(visita))
+}
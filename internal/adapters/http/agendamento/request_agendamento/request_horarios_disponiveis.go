@@ -0,0 +1,27 @@
+package request_agendamento
+
+import (
+	"fmt"
+	"meu-servico-agenda/internal/core/application/input"
+	"time"
+)
+
+type HorariosDisponiveisRequest struct {
+	Data       string `form:"data" binding:"required,datetime=2006-01-02" example:"2030-01-03"`
+	CatalogoID string `form:"catalogo_id" binding:"required" example:"d5l3m0ak2bsg00d1fceg"`
+	Intervalo  int    `form:"intervalo" binding:"omitempty,min=5,max=240" example:"15"`
+}
+
+func (r *HorariosDisponiveisRequest) ToHorariosDisponiveisInput(prestadorID string) (*input.HorariosDisponiveisInput, error) {
+	data, err := time.Parse("2006-01-02", r.Data)
+	if err != nil {
+		return nil, fmt.Errorf("data inválida: %w", err)
+	}
+
+	return &input.HorariosDisponiveisInput{
+		PrestadorID:      prestadorID,
+		CatalogoID:       r.CatalogoID,
+		Data:             data,
+		IntervaloMinutos: r.Intervalo,
+	}, nil
+}
@@ -0,0 +1,23 @@
+package request_agendamento
+
+import (
+	"errors"
+	"meu-servico-agenda/internal/core/application/input"
+	"time"
+)
+
+type ReagendarAgendamentoRequest struct {
+	DataHoraInicio string `json:"data_hora_inicio" binding:"required,datetime=2006-01-02T15:04:05Z07:00" example:"2025-01-03T10:00:00Z"`
+}
+
+func (r *ReagendarAgendamentoRequest) ToReagendarAgendamentoInput(agendamentoID string) (*input.ReagendarAgendamentoInput, error) {
+	dataHoraInicio, err := time.Parse(time.RFC3339, r.DataHoraInicio)
+	if err != nil {
+		return nil, errors.New("formato de data/hora inválido")
+	}
+
+	return &input.ReagendarAgendamentoInput{
+		AgendamentoID:  agendamentoID,
+		DataHoraInicio: dataHoraInicio,
+	}, nil
+}
@@ -0,0 +1,56 @@
+package request_agendamento
+
+import (
+	"errors"
+	"meu-servico-agenda/internal/core/application/input"
+	"time"
+)
+
+type SerieAgendamentoRequest struct {
+	ClienteID        string `json:"cliente_id" binding:"required"`
+	PrestadorID      string `json:"prestador_id" binding:"required"`
+	CatalogoID       string `json:"catalogo_id" binding:"required"`
+	DataHoraInicio   string `json:"data_hora_inicio" binding:"required,datetime=2006-01-02T15:04:05Z07:00" example:"2030-01-08T10:00:00Z"`
+	IntervaloSemanas int    `json:"intervalo_semanas" binding:"required,min=1,max=4" example:"1"`
+	Ocorrencias      int    `json:"ocorrencias" binding:"required,min=2,max=52" example:"8"`
+	Notas            string `json:"notas,omitempty" binding:"omitempty,max=500"`
+}
+
+func (r *SerieAgendamentoRequest) ToCadastrarSerieInput() (*input.CadastrarSerieInput, error) {
+	dataHoraInicio, err := time.Parse(time.RFC3339, r.DataHoraInicio)
+	if err != nil {
+		return nil, errors.New("formato de data/hora inválido")
+	}
+
+	return &input.CadastrarSerieInput{
+		ClienteID:        r.ClienteID,
+		PrestadorID:      r.PrestadorID,
+		CatalogoID:       r.CatalogoID,
+		DataHoraInicio:   dataHoraInicio,
+		IntervaloSemanas: r.IntervaloSemanas,
+		Ocorrencias:      r.Ocorrencias,
+		Notas:            r.Notas,
+	}, nil
+}
+
+type EscopoSerieRequest struct {
+	Escopo string `form:"escopo" binding:"required,oneof=todas restantes" example:"restantes"`
+}
+
+type ReagendarSerieRequest struct {
+	DataHoraInicio string `json:"data_hora_inicio" binding:"required,datetime=2006-01-02T15:04:05Z07:00" example:"2030-01-09T10:00:00Z"`
+	Escopo         string `json:"escopo" binding:"required,oneof=todas restantes" example:"restantes"`
+}
+
+func (r *ReagendarSerieRequest) ToReagendarSerieInput(serieID string) (*input.ReagendarSerieInput, error) {
+	dataHoraInicio, err := time.Parse(time.RFC3339, r.DataHoraInicio)
+	if err != nil {
+		return nil, errors.New("formato de data/hora inválido")
+	}
+
+	return &input.ReagendarSerieInput{
+		SerieID:        serieID,
+		DataHoraInicio: dataHoraInicio,
+		Escopo:         input.EscopoSerie(r.Escopo),
+	}, nil
+}
@@ -0,0 +1,42 @@
+package request_agendamento
+
+import (
+	"errors"
+	"meu-servico-agenda/internal/core/application/input"
+	"time"
+)
+
+type VisitaRequest struct {
+	ClienteID      string              `json:"cliente_id" binding:"required"`
+	DataHoraInicio string              `json:"data_hora_inicio" binding:"required,datetime=2006-01-02T15:04:05Z07:00" example:"2030-01-08T10:00:00Z"`
+	Itens          []ItemVisitaRequest `json:"itens" binding:"required,min=1,max=10,dive"`
+	Notas          string              `json:"notas,omitempty" binding:"omitempty,max=500"`
+}
+
+// ItemVisitaRequest é um serviço da visita; os itens são atendidos na ordem da lista
+type ItemVisitaRequest struct {
+	PrestadorID string `json:"prestador_id" binding:"required"`
+	CatalogoID  string `json:"catalogo_id" binding:"required"`
+}
+
+func (r *VisitaRequest) ToCadastrarVisitaInput() (*input.CadastrarVisitaInput, error) {
+	dataHoraInicio, err := time.Parse(time.RFC3339, r.DataHoraInicio)
+	if err != nil {
+		return nil, errors.New("formato de data/hora inválido")
+	}
+
+	itens := make([]input.ItemVisitaInput, len(r.Itens))
+	for i, item := range r.Itens {
+		itens[i] = input.ItemVisitaInput{
+			PrestadorID: item.PrestadorID,
+			CatalogoID:  item.CatalogoID,
+		}
+	}
+
+	return &input.CadastrarVisitaInput{
+		ClienteID:      r.ClienteID,
+		DataHoraInicio: dataHoraInicio,
+		Itens:          itens,
+		Notas:          r.Notas,
+	}, nil
+}
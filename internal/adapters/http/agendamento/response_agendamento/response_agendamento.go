@@ -7,12 +7,13 @@ import (
 )
 
 type PrestadorInfo struct {
-	ID       string `json:"id"`
-	Nome     string `json:"nome"`
-	CPF      string `json:"cpf"`
-	Email    string `json:"email,omitempty"`
-	Telefone string `json:"telefone"`
-	Ativo    bool   `json:"ativo"`
+	ID          string `json:"id"`
+	Nome        string `json:"nome"`
+	CPF         string `json:"cpf"`
+	Email       string `json:"email,omitempty"`
+	Telefone    string `json:"telefone"`
+	Ativo       bool   `json:"ativo"`
+	FusoHorario string `json:"fuso_horario"`
 }
 
 type ClienteInfo struct {
@@ -39,6 +40,8 @@ type AgendamentoResponse struct {
 	DataFim    time.Time                  `json:"data_fim"`
 	Status     domain.StatusDoAgendamento `json:"status"`
 	Notas      string                     `json:"notas,omitempty"`
+	SerieID    string                     `json:"serie_id,omitempty"`
+	VisitaID   string                     `json:"visita_id,omitempty"`
 }
 
 func NovoAgendamentoResponse(a *output.AgendamentoOutput) *AgendamentoResponse {
@@ -51,23 +54,27 @@ func NovoAgendamentoResponse(a *output.AgendamentoOutput) *AgendamentoResponse {
 			Telefone: a.Cliente.Telefone,
 		},
 		Prestador: PrestadorInfo{
-			ID:       a.Prestador.ID,
-			Nome:     a.Prestador.Nome,
-			CPF:      a.Prestador.Cpf,
-			Email:    a.Prestador.Email,
-			Telefone: a.Prestador.Telefone,
-			Ativo:    a.Prestador.Ativo,
+			ID:          a.Prestador.ID,
+			Nome:        a.Prestador.Nome,
+			CPF:         a.Prestador.Cpf,
+			Email:       a.Prestador.Email,
+			Telefone:    a.Prestador.Telefone,
+			Ativo:       a.Prestador.Ativo,
+			FusoHorario: a.Prestador.FusoHorario,
 		},
+		// Os dados do serviço vêm da cópia feita na reserva, não do catálogo atual
 		Servico: ServicoInfo{
 			ID:        a.Catalogo.ID,
-			Nome:      a.Catalogo.Nome,
-			Duracao:   a.Catalogo.DuracaoPadrao,
-			Preco:     a.Catalogo.Preco,
-			Categoria: a.Catalogo.Categoria,
+			Nome:      a.Servico.Nome,
+			Duracao:   a.Servico.DuracaoPadrao,
+			Preco:     a.Servico.Preco,
+			Categoria: a.Servico.Categoria,
 		},
 		DataInicio: a.DataHoraInicio,
 		DataFim:    a.DataHoraFim,
 		Status:     a.Status,
 		Notas:      a.Notas,
+		SerieID:    a.SerieID,
+		VisitaID:   a.VisitaID,
 	}
-}
\ No newline at end of file
+}
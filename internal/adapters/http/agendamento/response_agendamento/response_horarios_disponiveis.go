@@ -0,0 +1,26 @@
+package response_agendamento
+
+import (
+	"meu-servico-agenda/internal/core/application/output"
+	"time"
+)
+
+type HorariosDisponiveisResponse struct {
+	PrestadorID string      `json:"prestador_id"`
+	CatalogoID  string      `json:"catalogo_id"`
+	Data        string      `json:"data"`
+	FusoHorario string      `json:"fuso_horario"`
+	Duracao     int         `json:"duracao"`
+	Horarios    []time.Time `json:"horarios"`
+}
+
+func NovoHorariosDisponiveisResponse(o *output.HorariosDisponiveisOutput) *HorariosDisponiveisResponse {
+	return &HorariosDisponiveisResponse{
+		PrestadorID: o.PrestadorID,
+		CatalogoID:  o.CatalogoID,
+		Data:        o.Data,
+		FusoHorario: o.FusoHorario,
+		Duracao:     o.DuracaoMinutos,
+		Horarios:    o.Horarios,
+	}
+}
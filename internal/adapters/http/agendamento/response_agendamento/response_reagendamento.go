@@ -0,0 +1,30 @@
+package response_agendamento
+
+import (
+	"meu-servico-agenda/internal/core/application/output"
+	"time"
+)
+
+type ReagendamentoResponse struct {
+	ID                 string    `json:"id"`
+	DataInicioAnterior time.Time `json:"data_inicio_anterior"`
+	DataFimAnterior    time.Time `json:"data_fim_anterior"`
+	DataInicioNova     time.Time `json:"data_inicio_nova"`
+	DataFimNova        time.Time `json:"data_fim_nova"`
+	CriadoEm           time.Time `json:"criado_em"`
+}
+
+func NovoReagendamentosResponse(historico []*output.ReagendamentoOutput) []ReagendamentoResponse {
+	resp := make([]ReagendamentoResponse, len(historico))
+	for i, r := range historico {
+		resp[i] = ReagendamentoResponse{
+			ID:                 r.ID,
+			DataInicioAnterior: r.DataHoraInicioAnterior,
+			DataFimAnterior:    r.DataHoraFimAnterior,
+			DataInicioNova:     r.DataHoraInicioNova,
+			DataFimNova:        r.DataHoraFimNova,
+			CriadoEm:           r.CriadoEm,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,40 @@
+package response_agendamento
+
+import (
+	"meu-servico-agenda/internal/core/application/output"
+	"time"
+)
+
+type FalhaOcorrenciaResponse struct {
+	AgendamentoID string    `json:"agendamento_id,omitempty"`
+	DataInicio    time.Time `json:"data_inicio"`
+	Motivo        string    `json:"motivo"`
+}
+
+type SerieAgendamentoResponse struct {
+	SerieID   string                    `json:"serie_id,omitempty"`
+	Agendados []*AgendamentoResponse    `json:"agendados"`
+	Falhas    []FalhaOcorrenciaResponse `json:"falhas"`
+}
+
+func NovoSerieAgendamentoResponse(o *output.SerieAgendamentoOutput) *SerieAgendamentoResponse {
+	agendados := make([]*AgendamentoResponse, len(o.Agendados))
+	for i, a := range o.Agendados {
+		agendados[i] = NovoAgendamentoResponse(a)
+	}
+
+	falhas := make([]FalhaOcorrenciaResponse, len(o.Falhas))
+	for i, f := range o.Falhas {
+		falhas[i] = FalhaOcorrenciaResponse{
+			AgendamentoID: f.AgendamentoID,
+			DataInicio:    f.DataHoraInicio,
+			Motivo:        f.Motivo,
+		}
+	}
+
+	return &SerieAgendamentoResponse{
+		SerieID:   o.SerieID,
+		Agendados: agendados,
+		Falhas:    falhas,
+	}
+}
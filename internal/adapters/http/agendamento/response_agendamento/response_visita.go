@@ -0,0 +1,33 @@
+package response_agendamento
+
+import (
+	"meu-servico-agenda/internal/core/application/output"
+	"time"
+)
+
+type VisitaResponse struct {
+	ID           string                 `json:"id"`
+	ClienteID    string                 `json:"cliente_id"`
+	DataInicio   time.Time              `json:"data_inicio"`
+	DataFim      time.Time              `json:"data_fim"`
+	PrecoTotal   int                    `json:"preco_total"`
+	DuracaoTotal int                    `json:"duracao_total"`
+	Itens        []*AgendamentoResponse `json:"itens"`
+}
+
+func NovaVisitaResponse(o *output.VisitaOutput) *VisitaResponse {
+	itens := make([]*AgendamentoResponse, len(o.Itens))
+	for i, a := range o.Itens {
+		itens[i] = NovoAgendamentoResponse(a)
+	}
+
+	return &VisitaResponse{
+		ID:           o.ID,
+		ClienteID:    o.ClienteID,
+		DataInicio:   o.DataHoraInicio,
+		DataFim:      o.DataHoraFim,
+		PrecoTotal:   o.PrecoTotal,
+		DuracaoTotal: o.DuracaoTotal,
+		Itens:        itens,
+	}
+}
@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"meu-servico-agenda/internal/adapters/http/auth/request_auth"
+	"meu-servico-agenda/internal/adapters/http/auth/response_auth"
+	"meu-servico-agenda/internal/adapters/http/resposta"
+	"meu-servico-agenda/internal/core/application/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuthController struct {
+	authService *service.AuthService
+}
+
+func NovoAuthController(as *service.AuthService) *AuthController {
+	return &AuthController{
+		authService: as,
+	}
+}
+
+// @Summary Entra na API
+// @Description Confere email e senha e devolve um token de acesso de curta duração e um refresh token para renová-lo. Envie o token de acesso no cabeçalho Authorization: Bearer <token>
+// @Tags Autenticação
+// @Accept json
+// @Produce json
+// @Param credenciais body request_auth.LoginRequest true "Email e senha"
+// @Success 200 {object} response_auth.TokensResponse "Tokens emitidos"
+// @Failure 400 {object} resposta.Problema "Dados inválidos"
+// @Failure 401 {object} resposta.Problema "Email ou senha inválidos"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /auth/login [post]
+func (ac *AuthController) PostLogin(c *gin.Context) {
+	var req request_auth.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	tokens, err := ac.authService.Login(c.Request.Context(), req.Email, req.Senha)
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response_auth.NovoTokensResponse(tokens))
+}
+
+// @Summary Renova o token de acesso
+// @Description Troca o refresh token por um novo par de tokens. Cada refresh token só pode ser usado uma vez; reutilizar um token já trocado encerra todas as sessões do usuário
+// @Tags Autenticação
+// @Accept json
+// @Produce json
+// @Param refresh body request_auth.RefreshTokenRequest true "Refresh token recebido no login"
+// @Success 200 {object} response_auth.TokensResponse "Tokens emitidos"
+// @Failure 400 {object} resposta.Problema "Dados inválidos"
+// @Failure 401 {object} resposta.Problema "Refresh token inválido, expirado ou revogado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /auth/refresh [post]
+func (ac *AuthController) PostRefresh(c *gin.Context) {
+	var req request_auth.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	tokens, err := ac.authService.Renovar(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response_auth.NovoTokensResponse(tokens))
+}
+
+// @Summary Sai da API
+// @Description Revoga o refresh token. O token de acesso continua válido até expirar
+// @Tags Autenticação
+// @Accept json
+// @Param refresh body request_auth.RefreshTokenRequest true "Refresh token da sessão"
+// @Success 204 "Sessão encerrada"
+// @Failure 400 {object} resposta.Problema "Dados inválidos"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /auth/logout [post]
+func (ac *AuthController) PostLogout(c *gin.Context) {
+	var req request_auth.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	if err := ac.authService.Sair(c.Request.Context(), req.RefreshToken); err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Cadastra um usuário
+// @Description Cria a conta de acesso de um admin, de um prestador ou de um cliente já cadastrado. Somente admin
+// @Tags Autenticação
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param usuario body request_auth.UsuarioRequest true "Dados do usuário"
+// @Success 201 {object} response_auth.UsuarioResponse "Usuário criado"
+// @Failure 400 {object} resposta.Problema "Dados inválidos"
+// @Failure 401 {object} resposta.Problema "Token ausente ou inválido"
+// @Failure 403 {object} resposta.Problema "Usuário sem permissão"
+// @Failure 404 {object} resposta.Problema "Cliente ou prestador não encontrado"
+// @Failure 409 {object} resposta.Problema "Email já cadastrado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /usuarios [post]
+func (ac *AuthController) PostUsuario(c *gin.Context) {
+	var req request_auth.UsuarioRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	usuario, err := ac.authService.CadastrarUsuario(c.Request.Context(), req.ToCadastrarUsuarioInput())
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response_auth.NovoUsuarioResponse(usuario))
+}
@@ -0,0 +1,30 @@
+package request_auth
+
+import "meu-servico-agenda/internal/core/application/input"
+
+type LoginRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Senha string `json:"senha" binding:"required"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type UsuarioRequest struct {
+	Email       string `json:"email" binding:"required,email,max=255"`
+	Senha       string `json:"senha" binding:"required,min=8,max=72"`
+	Papel       string `json:"papel" binding:"required,oneof=admin prestador cliente" example:"cliente"`
+	ClienteID   string `json:"cliente_id,omitempty" swagger:"desc('Obrigatório para o papel cliente')"`
+	PrestadorID string `json:"prestador_id,omitempty" swagger:"desc('Obrigatório para o papel prestador')"`
+}
+
+func (r *UsuarioRequest) ToCadastrarUsuarioInput() input.CadastrarUsuarioInput {
+	return input.CadastrarUsuarioInput{
+		Email:       r.Email,
+		Senha:       r.Senha,
+		Papel:       r.Papel,
+		ClienteID:   r.ClienteID,
+		PrestadorID: r.PrestadorID,
+	}
+}
@@ -0,0 +1,46 @@
+package response_auth
+
+import (
+	"meu-servico-agenda/internal/core/application/output"
+	"meu-servico-agenda/internal/core/domain"
+	"time"
+)
+
+type TokensResponse struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type" example:"Bearer"`
+	ExpiraEm     time.Time `json:"expira_em"`
+	RefreshToken string    `json:"refresh_token"`
+}
+
+func NovoTokensResponse(o *output.TokensOutput) *TokensResponse {
+	return &TokensResponse{
+		AccessToken:  o.AccessToken,
+		TokenType:    "Bearer",
+		ExpiraEm:     o.ExpiraEm,
+		RefreshToken: o.RefreshToken,
+	}
+}
+
+// UsuarioResponse nunca inclui o hash da senha
+type UsuarioResponse struct {
+	ID          string    `json:"id"`
+	Email       string    `json:"email"`
+	Papel       string    `json:"papel"`
+	ClienteID   string    `json:"cliente_id,omitempty"`
+	PrestadorID string    `json:"prestador_id,omitempty"`
+	Ativo       bool      `json:"ativo"`
+	CriadoEm    time.Time `json:"criado_em"`
+}
+
+func NovoUsuarioResponse(u *domain.Usuario) *UsuarioResponse {
+	return &UsuarioResponse{
+		ID:          u.ID,
+		Email:       u.Email,
+		Papel:       string(u.Papel),
+		ClienteID:   u.ClienteID,
+		PrestadorID: u.PrestadorID,
+		Ativo:       u.Ativo,
+		CriadoEm:    u.CriadoEm,
+	}
+}
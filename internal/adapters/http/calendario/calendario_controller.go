@@ -0,0 +1,130 @@
+package calendario
+
+import (
+	"meu-servico-agenda/internal/adapters/http/calendario/response_calendario"
+	"meu-servico-agenda/internal/adapters/http/resposta"
+	"meu-servico-agenda/internal/core/application/service"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExtensaoFeed acompanha o token na URL; alguns aplicativos de calendário só
+// aceitam assinar URLs terminadas em .ics
+const ExtensaoFeed = ".ics"
+
+// CaminhoFeed é o prefixo das URLs dos feeds, igual ao registrado nas rotas
+const CaminhoFeed = "/api/v1/calendario/"
+
+type CalendarioController struct {
+	calendarioService *service.CalendarioService
+}
+
+func NovoCalendarioController(cs *service.CalendarioService) *CalendarioController {
+	return &CalendarioController{
+		calendarioService: cs,
+	}
+}
+
+// @Summary Gera a URL do calendário do cliente
+// @Description Cria a URL secreta do feed iCalendar com os agendamentos do cliente, para assinar no Google Agenda, Outlook ou Apple Calendar. A URL anterior deixa de funcionar. O token só é mostrado nesta resposta
+// @Tags Calendário
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do cliente"
+// @Success 201 {object} response_calendario.TokenCalendarioResponse "URL do feed"
+// @Failure 404 {object} resposta.Problema "Cliente não encontrado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /clientes/{id}/calendario [post]
+func (cc *CalendarioController) PostCalendarioCliente(c *gin.Context) {
+	token, err := cc.calendarioService.GerarTokenCliente(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response_calendario.NovoTokenCalendarioResponse(token, CaminhoFeed+token.Token+ExtensaoFeed))
+}
+
+// @Summary Gera a URL do calendário do prestador
+// @Description Cria a URL secreta do feed iCalendar com os agendamentos do prestador, para assinar no Google Agenda, Outlook ou Apple Calendar. A URL anterior deixa de funcionar. O token só é mostrado nesta resposta
+// @Tags Calendário
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do prestador"
+// @Success 201 {object} response_calendario.TokenCalendarioResponse "URL do feed"
+// @Failure 404 {object} resposta.Problema "Prestador não encontrado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /prestadores/{id}/calendario [post]
+func (cc *CalendarioController) PostCalendarioPrestador(c *gin.Context) {
+	token, err := cc.calendarioService.GerarTokenPrestador(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response_calendario.NovoTokenCalendarioResponse(token, CaminhoFeed+token.Token+ExtensaoFeed))
+}
+
+// @Summary Revoga a URL do calendário do cliente
+// @Description Apaga a URL secreta do feed do cliente, que deixa de funcionar. Nenhuma outra é criada
+// @Tags Calendário
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do cliente"
+// @Success 204 "URL revogada"
+// @Failure 404 {object} resposta.Problema "Cliente não encontrado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /clientes/{id}/calendario [delete]
+func (cc *CalendarioController) DeleteCalendarioCliente(c *gin.Context) {
+	if err := cc.calendarioService.RevogarTokenCliente(c.Request.Context(), c.Param("id")); err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Revoga a URL do calendário do prestador
+// @Description Apaga a URL secreta do feed do prestador, que deixa de funcionar. Nenhuma outra é criada
+// @Tags Calendário
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do prestador"
+// @Success 204 "URL revogada"
+// @Failure 404 {object} resposta.Problema "Prestador não encontrado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /prestadores/{id}/calendario [delete]
+func (cc *CalendarioController) DeleteCalendarioPrestador(c *gin.Context) {
+	if err := cc.calendarioService.RevogarTokenPrestador(c.Request.Context(), c.Param("id")); err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Feed iCalendar
+// @Description Agendamentos do dono do token a partir de 30 dias atrás, no formato iCalendar (RFC 5545). O título traz o serviço e a outra parte, e a descrição traz as notas. Cancelamentos aparecem com STATUS:CANCELLED para que os calendários assinantes se atualizem. Não exige login: o token da URL é a credencial. A URL deixa de funcionar quando é revogada ou substituída e quando o dono é inativado ou anonimizado
+// @Tags Calendário
+// @Produce text/calendar
+// @Param token path string true "Token da URL, com ou sem .ics"
+// @Success 200 {string} string "Feed iCalendar"
+// @Failure 404 {object} resposta.Problema "Calendário não encontrado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /calendario/{token} [get]
+func (cc *CalendarioController) GetFeed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ExtensaoFeed)
+
+	feed, err := cc.calendarioService.Feed(c.Request.Context(), token)
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	// O feed muda a cada agendamento; os assinantes devem sempre buscar de novo
+	c.Header("Cache-Control", "no-cache, private")
+	c.Data(http.StatusOK, response_calendario.ContentTypeICalendar, response_calendario.NovoICalendar(feed, time.Now()))
+}
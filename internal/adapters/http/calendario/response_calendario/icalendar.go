@@ -0,0 +1,114 @@
+package response_calendario
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"meu-servico-agenda/internal/core/application/output"
+	"meu-servico-agenda/internal/core/domain"
+)
+
+// ContentTypeICalendar é o tipo de mídia dos feeds (RFC 5545)
+const ContentTypeICalendar = "text/calendar; charset=utf-8"
+
+const (
+	formatoDataHora = "20060102T150405Z"
+	// tamanhoMaximoLinha é o limite de octetos por linha; linhas maiores continuam
+	// na seguinte, começando com um espaço
+	tamanhoMaximoLinha = 75
+)
+
+// NovoICalendar monta o feed com um VEVENT por agendamento. O título leva o
+// serviço e a outra parte: o cliente no feed do prestador e o prestador no do
+// cliente. Agendamentos cancelados continuam no feed com STATUS:CANCELLED para
+// que os calendários assinantes removam o evento
+func NovoICalendar(o *output.CalendarioOutput, agora time.Time) []byte {
+	var b strings.Builder
+	linha := func(conteudo string) {
+		escreverDobrado(&b, conteudo)
+	}
+
+	linha("BEGIN:VCALENDAR")
+	linha("VERSION:2.0")
+	linha("PRODID:-//meu-servico-agenda//agendamentos//PT")
+	linha("CALSCALE:GREGORIAN")
+	linha("METHOD:PUBLISH")
+	linha("X-WR-CALNAME:" + escaparTexto("Agendamentos"))
+
+	for _, a := range o.Agendamentos {
+		linha("BEGIN:VEVENT")
+		linha("UID:" + a.ID + "@meu-servico-agenda")
+		linha("DTSTAMP:" + agora.UTC().Format(formatoDataHora))
+		linha("DTSTART:" + a.DataHoraInicio.UTC().Format(formatoDataHora))
+		linha("DTEND:" + a.DataHoraFim.UTC().Format(formatoDataHora))
+		linha("SUMMARY:" + escaparTexto(titulo(o, a)))
+		if a.Notas != "" {
+			linha("DESCRIPTION:" + escaparTexto(a.Notas))
+		}
+		linha("STATUS:" + statusICalendar(a.Status))
+		linha("END:VEVENT")
+	}
+
+	linha("END:VCALENDAR")
+	return []byte(b.String())
+}
+
+func titulo(o *output.CalendarioOutput, a *output.AgendamentoOutput) string {
+	var outraParte string
+	switch {
+	case o.PrestadorID != "" && a.Cliente != nil:
+		outraParte = a.Cliente.Nome
+	case o.ClienteID != "" && a.Prestador != nil:
+		outraParte = a.Prestador.Nome
+	}
+	if outraParte == "" {
+		return a.Servico.Nome
+	}
+	return a.Servico.Nome + " - " + outraParte
+}
+
+// statusICalendar traduz o status do agendamento. Pendente ainda pode não
+// acontecer, por isso TENTATIVE; concluído aconteceu como confirmado
+func statusICalendar(s domain.StatusDoAgendamento) string {
+	switch s {
+	case domain.Confirmado, domain.Concluido:
+		return "CONFIRMED"
+	case domain.Cancelado:
+		return "CANCELLED"
+	default:
+		return "TENTATIVE"
+	}
+}
+
+// escaparTexto aplica o escape dos valores TEXT: barra invertida, ponto e
+// vírgula, vírgula e quebras de linha
+func escaparTexto(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
+
+// escreverDobrado termina a linha com CRLF e a dobra a cada tamanhoMaximoLinha
+// octetos, sem partir um caractere UTF-8 ao meio
+func escreverDobrado(b *strings.Builder, conteudo string) {
+	limite := tamanhoMaximoLinha
+	for len(conteudo) > limite {
+		corte := limite
+		for corte > 0 && !utf8.RuneStart(conteudo[corte]) {
+			corte--
+		}
+		b.WriteString(conteudo[:corte])
+		b.WriteString("\r\n ")
+		conteudo = conteudo[corte:]
+		// O espaço da continuação conta no limite da linha seguinte
+		limite = tamanhoMaximoLinha - 1
+	}
+	b.WriteString(conteudo)
+	b.WriteString("\r\n")
+}
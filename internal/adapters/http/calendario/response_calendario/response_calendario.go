@@ -0,0 +1,22 @@
+package response_calendario
+
+import (
+	"meu-servico-agenda/internal/core/application/output"
+	"time"
+)
+
+// TokenCalendarioResponse traz a URL secreta do feed. O token não é guardado e
+// não pode ser consultado depois: quem o perder gera outro
+type TokenCalendarioResponse struct {
+	Token    string    `json:"token"`
+	URL      string    `json:"url" example:"/api/v1/calendario/3q2-7wEjJ0v9kq1Xyo8cTg1y2sWcB7hA5rJtU4mNpQk.ics"`
+	CriadoEm time.Time `json:"criado_em"`
+}
+
+func NovoTokenCalendarioResponse(o *output.TokenCalendarioOutput, url string) *TokenCalendarioResponse {
+	return &TokenCalendarioResponse{
+		Token:    o.Token,
+		URL:      url,
+		CriadoEm: o.CriadoEm,
+	}
+}
@@ -4,8 +4,8 @@ import (
 	"meu-servico-agenda/internal/adapters/http/catalogo/request_catalogo"
 	"meu-servico-agenda/internal/adapters/http/catalogo/response_catalogo"
 
+	"meu-servico-agenda/internal/adapters/http/resposta"
 	"meu-servico-agenda/internal/core/application/service"
-	"meu-servico-agenda/internal/core/domain"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -24,38 +24,29 @@ func NovoCatalogoController(criarCatalogoService *service.CatalogoService) *Cata
 // @Tags Catalogos
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param catalogo body request_catalogo.CatalogoRequest true "Dados do Catálogo"
+// @Param Idempotency-Key header string false "Chave para repetir a requisição com segurança; a mesma chave e corpo devolvem a resposta original"
 // @Success 201 {object} response_catalogo.CatalogoResponse "Catálogo criado com sucesso"
-// @Failure 400 {object} domain.ErrorResponse "Dados inválidos"
-// @Failure 404 {object} domain.ErrorResponse "Prestador não encontrado"
-// @Failure 409 {object} domain.ErrorResponse "Catálogo já existente"
-// @Failure 500 {object} domain.ErrorResponse "Erro interno"
+// @Failure 400 {object} resposta.Problema "Dados inválidos"
+// @Failure 404 {object} resposta.Problema "Prestador não encontrado"
+// @Failure 409 {object} resposta.Problema "Catálogo já existente"
+// @Failure 500 {object} resposta.Problema "Erro interno"
 // @Router /catalogos [post]
 func (ctl *CatalogoController) PostCatalogo(c *gin.Context) {
 	var req request_catalogo.CatalogoRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		resposta.DadosInvalidos(c, err)
 		return
 	}
 
 	cmd := req.ToCatalogoInput()
 
-	out, err := ctl.criarCatalogoService.Cadastra(cmd)
+	out, err := ctl.criarCatalogoService.Cadastra(c.Request.Context(), cmd)
 	if err != nil {
-		// Erros de validação do domínio retornam 400
-		switch err {
-		case domain.ErrNomeInvalido,
-			domain.ErrDuracaoInvalida,
-			domain.ErrPrecoInvalido,
-			domain.ErrCategoriaInvalida:
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		default:
-			// Erros de infraestrutura ou inesperados retornam 500
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro interno ao criar catálogo"})
-			return
-		}
+		resposta.Erro(c, err)
+		return
 	}
 
 	resp := response_catalogo.FromCatalogoResponse(*out)
@@ -70,23 +61,17 @@ func (ctl *CatalogoController) PostCatalogo(c *gin.Context) {
 // @Produce json
 // @Param id path string true "ID do Catálogo"
 // @Success 200 {object} response_catalogo.CatalogoResponse "Catálogo encontrado com sucesso"
-// @Failure 400 {object} domain.ErrorResponse "ID inválido fornecido"
-// @Failure 404 {object} domain.ErrorResponse "Catálogo não encontrado"
-// @Failure 500 {object} domain.ErrorResponse "Erro interno do servidor ou falha de infraestrutura"
+// @Failure 400 {object} resposta.Problema "ID inválido fornecido"
+// @Failure 404 {object} resposta.Problema "Catálogo não encontrado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor ou falha de infraestrutura"
 // @Router /catalogos/{id} [get]
 func (ctl *CatalogoController) GetCatalogoPorID(c *gin.Context) {
 	id := c.Param("id")
 
-	catalogo, err := ctl.criarCatalogoService.BuscarPorId(id)
+	catalogo, err := ctl.criarCatalogoService.BuscarPorId(c.Request.Context(), id)
 	if err != nil {
-		switch err {
-		case service.ErrCatalogoNaoEncontrado:
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro interno ao buscar catálogo"})
-			return
-		}
+		resposta.Erro(c, err)
+		return
 	}
 
 	resp := response_catalogo.FromCatalogoResponse(*catalogo)
@@ -102,21 +87,21 @@ func (ctl *CatalogoController) GetCatalogoPorID(c *gin.Context) {
 // @Param page query int false "Número da página" default(1)
 // @Param limit query int false "Quantidade de itens por página" default(10)
 // @Success 200 {object} response_catalogo.CatalogoListResponse
-// @Failure 400 {object} domain.ErrorResponse
-// @Failure 500 {object} domain.ErrorResponse
+// @Failure 400 {object} resposta.Problema
+// @Failure 500 {object} resposta.Problema
 // @Router /catalogos [get]
 func (ctl *CatalogoController) GetCatalogos(c *gin.Context) {
 	var req request_catalogo.CatalogoListRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		resposta.DadosInvalidos(c, err)
 		return
 	}
 
 	in := req.ToInputCatalogo()
 
-	out, total, err := ctl.criarCatalogoService.Listar(in)
+	out, total, err := ctl.criarCatalogoService.Listar(c.Request.Context(), in)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro interno ao listar catálogos"})
+		resposta.Erro(c, err)
 		return
 	}
 
@@ -136,41 +121,30 @@ func (ctl *CatalogoController) GetCatalogos(c *gin.Context) {
 // @Tags Catalogos
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path string true "ID do Catálogo"
 // @Param catalogo body request_catalogo.CatalogoUpdateRequest true "Dados atualizados do Catálogo"
 // @Success 204 "Catálogo atualizado com sucesso"
-// @Failure 400 {object} domain.ErrorResponse "Dados inválidos"
-// @Failure 404 {object} domain.ErrorResponse "Catálogo não encontrado"
-// @Failure 500 {object} domain.ErrorResponse "Erro interno do servidor"
+// @Failure 400 {object} resposta.Problema "Dados inválidos"
+// @Failure 404 {object} resposta.Problema "Catálogo não encontrado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
 // @Router /catalogos/{id} [put]
 func (ctl *CatalogoController) Atualizar(c *gin.Context) {
 	id := c.Param("id")
 
 	var req request_catalogo.CatalogoUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		resposta.DadosInvalidos(c, err)
 		return
 	}
 
 	input := req.ToCatalogoUpdateInput()
 	input.ID = id
 
-	err := ctl.criarCatalogoService.Atualizar(input)
+	err := ctl.criarCatalogoService.Atualizar(c.Request.Context(), input)
 	if err != nil {
-		switch err {
-		case service.ErrCatalogoNaoEncontrado:
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		case domain.ErrDuracaoInvalida,
-			domain.ErrPrecoInvalido,
-			domain.ErrNomeInvalido,
-			domain.ErrCategoriaInvalida:
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro interno ao atualizar catálogo"})
-			return
-		}
+		resposta.Erro(c, err)
+		return
 	}
 
 	c.Status(http.StatusNoContent)
@@ -182,28 +156,20 @@ func (ctl *CatalogoController) Atualizar(c *gin.Context) {
 // @Tags Catalogos
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path string true "ID do Catálogo"
 // @Success 204 "Catálogo deletado com sucesso"
-// @Failure 400 {object} domain.ErrorResponse "ID inválido"
-// @Failure 404 {object} domain.ErrorResponse "Catálogo não encontrado"
-// @Failure 500 {object} domain.ErrorResponse "Erro interno do servidor"
+// @Failure 400 {object} resposta.Problema "ID inválido"
+// @Failure 404 {object} resposta.Problema "Catálogo não encontrado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
 // @Router /catalogos/{id} [delete]
 func (ctl *CatalogoController) Deletar(c *gin.Context) {
 	id := c.Param("id")
 
-	err := ctl.criarCatalogoService.Deletar(id)
+	err := ctl.criarCatalogoService.Deletar(c.Request.Context(), id)
 	if err != nil {
-		switch err {
-		case service.ErrCatalogoNaoEncontrado:
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		case service.ErrFalhaInfraestrutura:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro interno ao deletar catálogo"})
-			return
-		}
+		resposta.Erro(c, err)
+		return
 	}
 
 	c.Status(http.StatusNoContent)
@@ -8,6 +8,8 @@ type CatalogoRequest struct {
 	Preco         int    `json:"preco" binding:"required" example:"10000" swagger:"desc('Preço do serviço em centavos')"`
 	ImagemUrl     string `json:"image_url" binding:"required,url" example:"https://tdfuderuzpylkctxbysu.supabase.co/storage/v1/object/public/imagens/bb515383d2f6ef76.jpg"`
 	Categoria     string `json:"categoria" binding:"required,min=3,max=50" example:"Redes" swagger:"desc('Categoria do serviço')"`
+	TempoPreparo  int    `json:"tempo_preparo" binding:"min=0,max=240" example:"10" swagger:"desc('Minutos de preparo antes do atendimento')"`
+	TempoLimpeza  int    `json:"tempo_limpeza" binding:"min=0,max=240" example:"15" swagger:"desc('Minutos de limpeza depois do atendimento')"`
 }
 
 func (cr *CatalogoRequest) ToCatalogoInput() *input.CatalogoInput {
@@ -17,5 +19,7 @@ func (cr *CatalogoRequest) ToCatalogoInput() *input.CatalogoInput {
 		Preco:         cr.Preco,
 		Categoria:     cr.Categoria,
 		ImagemUrl:     cr.ImagemUrl,
+		TempoPreparo:  cr.TempoPreparo,
+		TempoLimpeza:  cr.TempoLimpeza,
 	}
 }
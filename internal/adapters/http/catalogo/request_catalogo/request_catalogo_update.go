@@ -8,6 +8,8 @@ type CatalogoUpdateRequest struct {
 	Preco         int    `json:"preco" binding:"required" example:"10000" swagger:"desc('Preço do serviço em centavos')"`
 	ImagemUrl     string `json:"image_url" binding:"required,url" example:"https://tdfuderuzpylkctxbysu.supabase.co/storage/v1/object/public/imagens/bb515383d2f6ef76.jpg"`
 	Categoria     string `json:"categoria" binding:"required,min=3,max=50" example:"Estética Facial" swagger:"desc('Categoria do serviço')"`
+	TempoPreparo  *int   `json:"tempo_preparo" binding:"omitempty,min=0,max=240" example:"10" swagger:"desc('Minutos de preparo antes do atendimento; ausente mantém o atual')"`
+	TempoLimpeza  *int   `json:"tempo_limpeza" binding:"omitempty,min=0,max=240" example:"15" swagger:"desc('Minutos de limpeza depois do atendimento; ausente mantém o atual')"`
 }
 
 func (cr *CatalogoUpdateRequest) ToCatalogoUpdateInput() *input.CatalogoUpdateInput {
@@ -17,5 +19,7 @@ func (cr *CatalogoUpdateRequest) ToCatalogoUpdateInput() *input.CatalogoUpdateIn
 		Preco:         cr.Preco,
 		Categoria:     cr.Categoria,
 		ImagemUrl:     cr.ImagemUrl,
+		TempoPreparo:  cr.TempoPreparo,
+		TempoLimpeza:  cr.TempoLimpeza,
 	}
 }
@@ -9,6 +9,8 @@ type CatalogoResponse struct {
 	Preco         int    `json:"preco"`
 	Categoria     string `json:"categoria"`
 	ImagemUrl     string `json:"image_url"`
+	TempoPreparo  int    `json:"tempo_preparo"`
+	TempoLimpeza  int    `json:"tempo_limpeza"`
 }
 
 func FromCatalogoResponse(o output.CatalogoOutput) CatalogoResponse {
@@ -19,5 +21,7 @@ func FromCatalogoResponse(o output.CatalogoOutput) CatalogoResponse {
 		Preco:         o.Preco,
 		Categoria:     o.Categoria,
 		ImagemUrl:     o.ImagemUrl,
+		TempoPreparo:  o.TempoPreparo,
+		TempoLimpeza:  o.TempoLimpeza,
 	}
 }
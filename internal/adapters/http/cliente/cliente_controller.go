@@ -1,7 +1,10 @@
 package cliente
 
 import (
+	"context"
 	"meu-servico-agenda/internal/adapters/http/cliente/request"
+	"meu-servico-agenda/internal/adapters/http/cliente/response"
+	"meu-servico-agenda/internal/adapters/http/resposta"
 	"meu-servico-agenda/internal/core/application/service"
 
 	"net/http"
@@ -23,31 +26,33 @@ func NovoClienteController(novoCliente *service.ServiceCliente) *ClienteControll
 // @Tags Clientes
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param cliente body request.ClienteRequest true "Dados do Cliente"
+// @Param Idempotency-Key header string false "Chave para repetir a requisição com segurança; a mesma chave e corpo devolvem a resposta original"
 // @Success 201 {object} domain.Cliente "Cliente criado com sucesso"
-// @Failure 400 {object} domain.ErrorResponse "Dados inválidos (erro de validação do binding)"
-// @Failure 409 {object} domain.ErrorResponse "Cliente já cadastrado (Email ou Telefone já existe)"
-// @Failure 500 {object} domain.ErrorResponse "Falha na persistência de dados ou erro interno"
+// @Failure 400 {object} resposta.Problema "Dados inválidos (erro de validação do binding)"
+// @Failure 409 {object} resposta.Problema "Email já cadastrado para outro cliente"
+// @Failure 500 {object} resposta.Problema "Falha na persistência de dados ou erro interno"
 // @Router /clientes [post]
 func (ctrl *ClienteController) PostCliente(c *gin.Context) {
 	var input request.ClienteRequest
 
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos: " + err.Error()})
+		resposta.DadosInvalidos(c, err)
 		return
 	}
 
 	// Cria domínio e valida regras de negócio
 	clienteDomain, err := input.ToCliente()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		resposta.DadosInvalidos(c, err)
 		return
 	}
 
 	// Persiste usando service
-	cliente, err := ctrl.novoCliente.Cadastra(clienteDomain)
+	cliente, err := ctrl.novoCliente.Cadastra(c.Request.Context(), clienteDomain)
 	if err != nil {
-		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		resposta.Erro(c, err)
 		return
 	}
 
@@ -59,36 +64,132 @@ func (ctrl *ClienteController) PostCliente(c *gin.Context) {
 // @Tags Clientes
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path string true "ID do Cliente"
 // @Success 200 {object} domain.Cliente "Cliente encontrado com sucesso"
-// @Failure 400 {object} domain.ErrorResponse "ID inválido fornecido (ex: formato incorreto se houver validação de formato de ID)"
-// @Failure 404 {object} domain.ErrorResponse "Cliente não encontrado"
-// @Failure 500 {object} domain.ErrorResponse "Erro interno do servidor ou falha de infraestrutura"
+// @Failure 400 {object} resposta.Problema "ID inválido fornecido (ex: formato incorreto se houver validação de formato de ID)"
+// @Failure 404 {object} resposta.Problema "Cliente não encontrado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor ou falha de infraestrutura"
 // @Router /clientes/{id} [get]
 func (ctrl *ClienteController) GetCliente(c *gin.Context) {
 	id := c.Param("id")
 
-	cliente, err := ctrl.novoCliente.BuscarPorId(id)
+	cliente, err := ctrl.novoCliente.BuscarPorId(c.Request.Context(), id)
 
 	if err != nil {
-		errorMessage := err.Error()
-
-		// 1. TRATAMENTO DO 404: Se o erro for a mensagem específica de "não encontrado"
-		if errorMessage == "cliente não encontrado" {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Cliente não encontrado"})
-			return
-		}
-
-		// 2. TRATAMENTO DO 500: Qualquer outro erro é tratado como falha de infraestrutura
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro interno ao buscar cliente: " + errorMessage})
+		resposta.Erro(c, err)
 		return
 	}
 
 	// Se o serviço não retorna erro, mas retorna nil (caso o serviço seja simplificado)
 	if cliente == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Cliente não encontrado"})
+		resposta.Erro(c, service.ErrClienteNaoEncontrado)
+		return
+	}
+
+	c.JSON(http.StatusOK, cliente)
+}
+
+// @Summary Atualiza os dados de contato de um cliente
+// @Description Substitui nome, email e telefone do cliente. O email não pode pertencer a outro cliente.
+// @Tags Clientes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do Cliente"
+// @Param cliente body request.ClienteUpdateRequest true "Dados atualizados do cliente"
+// @Success 200 {object} domain.Cliente "Cliente atualizado"
+// @Failure 400 {object} resposta.Problema "Dados inválidos"
+// @Failure 404 {object} resposta.Problema "Cliente não encontrado"
+// @Failure 409 {object} resposta.Problema "Email já cadastrado para outro cliente ou cliente anonimizado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /clientes/{id} [put]
+func (ctrl *ClienteController) PutCliente(c *gin.Context) {
+	var req request.ClienteUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	cliente, err := ctrl.novoCliente.Atualizar(c.Request.Context(), req.ToAlterarClienteInput(c.Param("id")))
+	if err != nil {
+		resposta.Erro(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, cliente)
 }
+
+// @Summary Lista clientes
+// @Description Retorna clientes paginados e ordenados por nome. A busca procura parte do nome, do email ou do telefone.
+// @Tags Clientes
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Número da página (padrão: 1)"
+// @Param limit query int false "Itens por página (padrão: 10, máximo: 100)"
+// @Param busca query string false "Parte do nome, email ou telefone"
+// @Param ativo query boolean false "Filtra por status; omitido lista ativos e inativos"
+// @Success 200 {object} response.ClienteListResponse
+// @Failure 400 {object} resposta.Problema "Parâmetros inválidos"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /clientes [get]
+func (ctrl *ClienteController) GetClientes(c *gin.Context) {
+	var req request.ClienteListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	in := req.ToClienteListInput()
+
+	clientes, total, err := ctrl.novoCliente.Listar(c.Request.Context(), in)
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.ClienteListResponse{
+		Data:  clientes,
+		Page:  in.Page,
+		Limit: in.Limit,
+		Total: total,
+	})
+}
+
+// @Summary Inativa um cliente
+// @Description Inativa um cliente, impedindo novos agendamentos. Os agendamentos existentes são mantidos.
+// @Tags Clientes
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do Cliente"
+// @Success 204 "Cliente inativado com sucesso"
+// @Failure 404 {object} resposta.Problema "Cliente não encontrado"
+// @Failure 500 {object} resposta.Problema "Erro interno"
+// @Router /clientes/{id}/inativar [put]
+func (ctrl *ClienteController) InativarCliente(c *gin.Context) {
+	ctrl.alterarStatus(c, ctrl.novoCliente.Inativar)
+}
+
+// @Summary Ativa um cliente
+// @Description Reativa um cliente, permitindo novos agendamentos
+// @Tags Clientes
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do Cliente"
+// @Success 204 "Cliente ativado com sucesso"
+// @Failure 404 {object} resposta.Problema "Cliente não encontrado"
+// @Failure 409 {object} resposta.Problema "Cliente anonimizado"
+// @Failure 500 {object} resposta.Problema "Erro interno"
+// @Router /clientes/{id}/ativar [put]
+func (ctrl *ClienteController) AtivarCliente(c *gin.Context) {
+	ctrl.alterarStatus(c, ctrl.novoCliente.Ativar)
+}
+
+func (ctrl *ClienteController) alterarStatus(c *gin.Context, acao func(ctx context.Context, id string) error) {
+	if err := acao(c.Request.Context(), c.Param("id")); err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
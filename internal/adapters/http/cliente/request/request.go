@@ -1,7 +1,9 @@
 package request
 
 import (
+	"meu-servico-agenda/internal/core/application/input"
 	"meu-servico-agenda/internal/core/domain"
+	"strings"
 )
 
 type ClienteRequest struct {
@@ -13,3 +15,37 @@ type ClienteRequest struct {
 func (r *ClienteRequest) ToCliente() (*domain.Cliente, error) {
 	return domain.NovoCliente(r.Nome, r.Email, r.Telefone)
 }
+
+// ClienteUpdateRequest substitui todos os dados de contato do cliente
+type ClienteUpdateRequest struct {
+	Nome     string `json:"nome" binding:"required,min=3,max=100" example:"João da Silva"`
+	Email    string `json:"email" binding:"required,email" example:"joao@email.com"`
+	Telefone string `json:"telefone" binding:"required,min=8,max=15" example:"62999677481"`
+}
+
+func (r *ClienteUpdateRequest) ToAlterarClienteInput(id string) *input.AlterarClienteInput {
+	return &input.AlterarClienteInput{
+		Id:       id,
+		Nome:     r.Nome,
+		Email:    r.Email,
+		Telefone: r.Telefone,
+	}
+}
+
+type ClienteListRequest struct {
+	Page  int    `form:"page" binding:"omitempty,min=1"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Busca string `form:"busca" binding:"omitempty,max=100"`
+	Ativo *bool  `form:"ativo"`
+}
+
+func (r *ClienteListRequest) ToClienteListInput() *input.ClienteListInput {
+	in := &input.ClienteListInput{
+		Page:  r.Page,
+		Limit: r.Limit,
+		Busca: strings.TrimSpace(r.Busca),
+		Ativo: r.Ativo,
+	}
+
+	return in
+}
@@ -0,0 +1,10 @@
+package response
+
+import "meu-servico-agenda/internal/core/domain"
+
+type ClienteListResponse struct {
+	Data  []*domain.Cliente `json:"data"`
+	Page  int               `json:"page"`
+	Limit int               `json:"limit"`
+	Total int               `json:"total"`
+}
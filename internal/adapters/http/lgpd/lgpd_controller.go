@@ -0,0 +1,122 @@
+package lgpd
+
+import (
+	"log/slog"
+	"meu-servico-agenda/internal/adapters/http/lgpd/request_lgpd"
+	"meu-servico-agenda/internal/adapters/http/lgpd/response_lgpd"
+	"meu-servico-agenda/internal/adapters/http/middleware"
+	"meu-servico-agenda/internal/adapters/http/resposta"
+	"meu-servico-agenda/internal/core/application/service"
+	"meu-servico-agenda/internal/core/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LGPDController struct {
+	lgpdService *service.LGPDService
+}
+
+func NovoLGPDController(ls *service.LGPDService) *LGPDController {
+	return &LGPDController{
+		lgpdService: ls,
+	}
+}
+
+// @Summary Exporta os dados de um cliente
+// @Description Devolve em JSON tudo o que o sistema guarda sobre o cliente: cadastro, todos os agendamentos com as notas, séries, visitas e entradas na lista de espera. O pedido fica registrado com o usuário autenticado, a data e o resultado, inclusive quando é negado ou falha
+// @Tags LGPD
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do cliente"
+// @Param X-Solicitante header string false "Observação de quem atende o pedido do titular (Ex: nome do atendente)"
+// @Success 200 {object} response_lgpd.ExportacaoClienteResponse "Dados do cliente"
+// @Failure 400 {object} resposta.Problema "Observação longa demais"
+// @Failure 403 {object} resposta.Problema "Usuário sem permissão"
+// @Failure 404 {object} resposta.Problema "Cliente não encontrado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /clientes/{id}/lgpd/exportacao [get]
+func (lc *LGPDController) GetExportacao(c *gin.Context) {
+	var req request_lgpd.SolicitanteRequest
+	if err := c.ShouldBindHeader(&req); err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	exportacao, err := lc.lgpdService.ExportarDados(c.Request.Context(), c.Param("id"), usuarioDaRequisicao(c), req.Observacao)
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response_lgpd.NovaExportacaoClienteResponse(exportacao))
+}
+
+// @Summary Anonimiza um cliente
+// @Description Substitui nome, email e telefone por valores que não identificam o titular, apaga as notas dos agendamentos e inativa o cliente. Os agendamentos são mantidos para o histórico financeiro. O pedido fica registrado com o usuário autenticado, a data e o resultado, inclusive quando é negado ou falha
+// @Tags LGPD
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do cliente"
+// @Param X-Solicitante header string false "Observação de quem atende o pedido do titular (Ex: nome do atendente)"
+// @Success 204 "Cliente anonimizado"
+// @Failure 400 {object} resposta.Problema "Observação longa demais"
+// @Failure 403 {object} resposta.Problema "Usuário sem permissão"
+// @Failure 404 {object} resposta.Problema "Cliente não encontrado"
+// @Failure 409 {object} resposta.Problema "Cliente já anonimizado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /clientes/{id}/lgpd/anonimizar [post]
+func (lc *LGPDController) PostAnonimizar(c *gin.Context) {
+	var req request_lgpd.SolicitanteRequest
+	if err := c.ShouldBindHeader(&req); err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	if err := lc.lgpdService.Anonimizar(c.Request.Context(), c.Param("id"), usuarioDaRequisicao(c), req.Observacao); err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Lista as solicitações LGPD de um cliente
+// @Description Retorna os pedidos de exportação e de anonimização atendidos, negados ou falhos, do mais recente para o mais antigo
+// @Tags LGPD
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do cliente"
+// @Success 200 {array} response_lgpd.SolicitacaoLGPDResponse "Solicitações registradas"
+// @Failure 404 {object} resposta.Problema "Cliente não encontrado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /clientes/{id}/lgpd/solicitacoes [get]
+func (lc *LGPDController) GetSolicitacoes(c *gin.Context) {
+	solicitacoes, err := lc.lgpdService.ListarSolicitacoes(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response_lgpd.NovasSolicitacoesLGPDResponse(solicitacoes))
+}
+
+// RegistrarNegacao devolve o registro, para middleware.RegistrarNegacao, dos pedidos do
+// tipo informado que as regras de acesso recusaram
+func (lc *LGPDController) RegistrarNegacao(tipo domain.TipoSolicitacaoLGPD) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		err := lc.lgpdService.RegistrarNegacao(ctx, c.Param("id"), tipo, usuarioDaRequisicao(c), c.GetHeader("X-Solicitante"))
+		if err != nil {
+			slog.ErrorContext(ctx, "erro ao registrar solicitação LGPD negada", slog.Any("erro", err))
+		}
+	}
+}
+
+// usuarioDaRequisicao é quem responde pelo pedido no registro da solicitação
+func usuarioDaRequisicao(c *gin.Context) string {
+	if identidade := middleware.IdentidadeDaRequisicao(c); identidade != nil {
+		return identidade.UsuarioID
+	}
+	return ""
+}
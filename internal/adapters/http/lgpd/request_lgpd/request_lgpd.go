@@ -0,0 +1,7 @@
+package request_lgpd
+
+// SolicitanteRequest traz uma nota opcional de quem atende o pedido do titular (Ex: nome
+// do atendente ou do DPO). Quem fez o pedido é sempre o usuário autenticado
+type SolicitanteRequest struct {
+	Observacao string `header:"X-Solicitante" binding:"max=100"`
+}
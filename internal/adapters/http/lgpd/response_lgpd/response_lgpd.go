@@ -0,0 +1,114 @@
+package response_lgpd
+
+import (
+	"meu-servico-agenda/internal/adapters/http/agendamento/response_agendamento"
+	"meu-servico-agenda/internal/adapters/http/lista_espera/response_lista_espera"
+	"meu-servico-agenda/internal/core/application/output"
+	"meu-servico-agenda/internal/core/domain"
+	"time"
+)
+
+type ClienteExportado struct {
+	ID            string     `json:"id"`
+	Nome          string     `json:"nome"`
+	Email         string     `json:"email"`
+	Telefone      string     `json:"telefone"`
+	Ativo         bool       `json:"ativo"`
+	AnonimizadoEm *time.Time `json:"anonimizado_em,omitempty"`
+}
+
+// SerieExportada é a regra de repetição; as ocorrências estão em agendamentos, pelo serie_id
+type SerieExportada struct {
+	ID               string    `json:"id"`
+	PrestadorID      string    `json:"prestador_id"`
+	CatalogoID       string    `json:"catalogo_id"`
+	DataHoraInicio   time.Time `json:"data_hora_inicio"`
+	IntervaloSemanas int       `json:"intervalo_semanas"`
+	Ocorrencias      int       `json:"ocorrencias"`
+	Notas            string    `json:"notas,omitempty"`
+}
+
+// VisitaExportada traz as notas da visita; os itens estão em agendamentos, pelo visita_id
+type VisitaExportada struct {
+	ID    string `json:"id"`
+	Notas string `json:"notas,omitempty"`
+}
+
+type ExportacaoClienteResponse struct {
+	ExportadoEm  time.Time                                    `json:"exportado_em"`
+	Cliente      ClienteExportado                             `json:"cliente"`
+	Agendamentos []*response_agendamento.AgendamentoResponse  `json:"agendamentos"`
+	Series       []SerieExportada                             `json:"series"`
+	Visitas      []VisitaExportada                            `json:"visitas"`
+	ListaEspera  []*response_lista_espera.ListaEsperaResponse `json:"lista_espera"`
+}
+
+func NovaExportacaoClienteResponse(o *output.ExportacaoClienteOutput) *ExportacaoClienteResponse {
+	agendamentos := make([]*response_agendamento.AgendamentoResponse, len(o.Agendamentos))
+	for i, a := range o.Agendamentos {
+		agendamentos[i] = response_agendamento.NovoAgendamentoResponse(a)
+	}
+
+	series := make([]SerieExportada, len(o.Series))
+	for i, s := range o.Series {
+		series[i] = SerieExportada{
+			ID:               s.ID,
+			PrestadorID:      s.Prestador.ID,
+			CatalogoID:       s.Catalogo.ID,
+			DataHoraInicio:   s.DataHoraInicio,
+			IntervaloSemanas: s.IntervaloSemanas,
+			Ocorrencias:      s.Ocorrencias,
+			Notas:            s.Notas,
+		}
+	}
+
+	visitas := make([]VisitaExportada, len(o.Visitas))
+	for i, v := range o.Visitas {
+		visitas[i] = VisitaExportada{ID: v.ID, Notas: v.Notas}
+	}
+
+	listaEspera := make([]*response_lista_espera.ListaEsperaResponse, len(o.ListaEspera))
+	for i, e := range o.ListaEspera {
+		listaEspera[i] = response_lista_espera.NovoListaEsperaResponse(e)
+	}
+
+	return &ExportacaoClienteResponse{
+		ExportadoEm: o.ExportadoEm,
+		Cliente: ClienteExportado{
+			ID:            o.Cliente.ID,
+			Nome:          o.Cliente.Nome,
+			Email:         o.Cliente.Email,
+			Telefone:      o.Cliente.Telefone,
+			Ativo:         o.Cliente.Ativo,
+			AnonimizadoEm: o.Cliente.AnonimizadoEm,
+		},
+		Agendamentos: agendamentos,
+		Series:       series,
+		Visitas:      visitas,
+		ListaEspera:  listaEspera,
+	}
+}
+
+type SolicitacaoLGPDResponse struct {
+	ID         string    `json:"id"`
+	Tipo       string    `json:"tipo"`
+	UsuarioID  string    `json:"usuario_id"`
+	Observacao string    `json:"observacao,omitempty"`
+	Resultado  string    `json:"resultado" example:"atendida"`
+	CriadoEm   time.Time `json:"criado_em"`
+}
+
+func NovasSolicitacoesLGPDResponse(solicitacoes []*domain.SolicitacaoLGPD) []SolicitacaoLGPDResponse {
+	resp := make([]SolicitacaoLGPDResponse, len(solicitacoes))
+	for i, s := range solicitacoes {
+		resp[i] = SolicitacaoLGPDResponse{
+			ID:         s.ID,
+			Tipo:       string(s.Tipo),
+			UsuarioID:  s.UsuarioID,
+			Observacao: s.Observacao,
+			Resultado:  string(s.Resultado),
+			CriadoEm:   s.CriadoEm,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,118 @@
+package lista_espera
+
+import (
+	"meu-servico-agenda/internal/adapters/http/lista_espera/request_lista_espera"
+	"meu-servico-agenda/internal/adapters/http/lista_espera/response_lista_espera"
+	"meu-servico-agenda/internal/adapters/http/resposta"
+	"meu-servico-agenda/internal/core/application/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ListaEsperaController struct {
+	listaEsperaService *service.ListaEsperaService
+}
+
+func NovoListaEsperaController(ls *service.ListaEsperaService) *ListaEsperaController {
+	return &ListaEsperaController{
+		listaEsperaService: ls,
+	}
+}
+
+// @Summary Entra na lista de espera
+// @Description Registra o cliente aguardando um horário para o serviço dentro da janela informada. Quando uma vaga compatível é liberada por cancelamento, reagendamento ou nova agenda, ela é oferecida ao primeiro da fila, que tem 30 minutos para aceitá-la
+// @Tags Lista de Espera
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param entrada body request_lista_espera.ListaEsperaRequest true "Dados da entrada"
+// @Param Idempotency-Key header string false "Chave para repetir a requisição com segurança; a mesma chave e corpo devolvem a resposta original"
+// @Success 201 {object} response_lista_espera.ListaEsperaResponse "Cliente incluído na lista de espera"
+// @Failure 400 {object} resposta.Problema "Dados inválidos ou janela inválida"
+// @Failure 404 {object} resposta.Problema "Cliente, prestador ou serviço não encontrado"
+// @Failure 409 {object} resposta.Problema "Prestador ou cliente inativo"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /lista-espera [post]
+func (lc *ListaEsperaController) PostListaEspera(c *gin.Context) {
+	var req request_lista_espera.ListaEsperaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	cmd, err := req.ToCadastrarListaEsperaInput()
+	if err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	entrada, err := lc.listaEsperaService.Cadastrar(c.Request.Context(), *cmd)
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response_lista_espera.NovoListaEsperaResponse(entrada))
+}
+
+// @Summary Busca uma entrada da lista de espera
+// @Description Retorna a entrada com o status atual e, quando houver, a vaga oferecida e o prazo para aceitá-la
+// @Tags Lista de Espera
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da entrada"
+// @Success 200 {object} response_lista_espera.ListaEsperaResponse "Entrada encontrada"
+// @Failure 404 {object} resposta.Problema "Entrada não encontrada"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /lista-espera/{id} [get]
+func (lc *ListaEsperaController) GetListaEspera(c *gin.Context) {
+	entrada, err := lc.listaEsperaService.BuscarPorId(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response_lista_espera.NovoListaEsperaResponse(entrada))
+}
+
+// @Summary Sai da lista de espera
+// @Description Cancela a entrada. Uma vaga que estivesse oferecida ao cliente passa ao próximo da fila
+// @Tags Lista de Espera
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da entrada"
+// @Success 204 "Entrada cancelada com sucesso"
+// @Failure 404 {object} resposta.Problema "Entrada não encontrada"
+// @Failure 409 {object} resposta.Problema "Entrada já encerrada"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /lista-espera/{id}/cancelar [put]
+func (lc *ListaEsperaController) PutCancelarListaEspera(c *gin.Context) {
+	if err := lc.listaEsperaService.Cancelar(c.Request.Context(), c.Param("id")); err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Aceita a vaga oferecida
+// @Description Agenda o horário oferecido ao cliente com as mesmas regras de um agendamento comum. Se o prazo expirou, a vaga segue para o próximo da fila
+// @Tags Lista de Espera
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da entrada"
+// @Success 200 {object} response_lista_espera.ListaEsperaResponse "Vaga aceita; agendamento_id traz o agendamento criado"
+// @Failure 404 {object} resposta.Problema "Entrada não encontrada"
+// @Failure 409 {object} resposta.Problema "Sem vaga oferecida, prazo expirado ou conflito de agenda"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /lista-espera/{id}/aceitar [put]
+func (lc *ListaEsperaController) PutAceitarOferta(c *gin.Context) {
+	entrada, err := lc.listaEsperaService.AceitarOferta(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response_lista_espera.NovoListaEsperaResponse(entrada))
+}
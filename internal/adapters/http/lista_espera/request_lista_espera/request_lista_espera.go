@@ -0,0 +1,36 @@
+package request_lista_espera
+
+import (
+	"errors"
+	"meu-servico-agenda/internal/core/application/input"
+	"time"
+)
+
+type ListaEsperaRequest struct {
+	ClienteID string `json:"cliente_id" binding:"required"`
+	// Opcional: sem prestador, qualquer um que ofereça o serviço pode liberar a vaga
+	PrestadorID  string `json:"prestador_id,omitempty"`
+	CatalogoID   string `json:"catalogo_id" binding:"required"`
+	JanelaInicio string `json:"janela_inicio" binding:"required,datetime=2006-01-02T15:04:05Z07:00" example:"2030-01-08T08:00:00Z"`
+	JanelaFim    string `json:"janela_fim" binding:"required,datetime=2006-01-02T15:04:05Z07:00" example:"2030-01-08T18:00:00Z"`
+}
+
+func (r *ListaEsperaRequest) ToCadastrarListaEsperaInput() (*input.CadastrarListaEsperaInput, error) {
+	inicio, err := time.Parse(time.RFC3339, r.JanelaInicio)
+	if err != nil {
+		return nil, errors.New("formato de data/hora inválido")
+	}
+
+	fim, err := time.Parse(time.RFC3339, r.JanelaFim)
+	if err != nil {
+		return nil, errors.New("formato de data/hora inválido")
+	}
+
+	return &input.CadastrarListaEsperaInput{
+		ClienteID:    r.ClienteID,
+		CatalogoID:   r.CatalogoID,
+		PrestadorID:  r.PrestadorID,
+		JanelaInicio: inicio,
+		JanelaFim:    fim,
+	}, nil
+}
@@ -0,0 +1,53 @@
+package response_lista_espera
+
+import (
+	"meu-servico-agenda/internal/core/application/output"
+	"meu-servico-agenda/internal/core/domain"
+	"time"
+)
+
+type OfertaVagaResponse struct {
+	PrestadorID    string    `json:"prestador_id"`
+	DataHoraInicio time.Time `json:"data_hora_inicio"`
+	DataHoraFim    time.Time `json:"data_hora_fim"`
+	ExpiraEm       time.Time `json:"expira_em"`
+}
+
+type ListaEsperaResponse struct {
+	ID           string    `json:"id"`
+	ClienteID    string    `json:"cliente_id"`
+	CatalogoID   string    `json:"catalogo_id"`
+	PrestadorID  string    `json:"prestador_id,omitempty"`
+	JanelaInicio time.Time `json:"janela_inicio"`
+	JanelaFim    time.Time `json:"janela_fim"`
+	// 1 = aguardando, 2 = vaga oferecida, 3 = vaga aceita, 4 = cancelada, 5 = oferta expirada
+	Status        domain.StatusListaEspera `json:"status"`
+	CriadoEm      time.Time                `json:"criado_em"`
+	Oferta        *OfertaVagaResponse      `json:"oferta,omitempty"`
+	AgendamentoID string                   `json:"agendamento_id,omitempty"`
+}
+
+func NovoListaEsperaResponse(o *output.ListaEsperaOutput) *ListaEsperaResponse {
+	resp := &ListaEsperaResponse{
+		ID:            o.ID,
+		ClienteID:     o.ClienteID,
+		CatalogoID:    o.CatalogoID,
+		PrestadorID:   o.PrestadorID,
+		JanelaInicio:  o.JanelaInicio,
+		JanelaFim:     o.JanelaFim,
+		Status:        o.Status,
+		CriadoEm:      o.CriadoEm,
+		AgendamentoID: o.AgendamentoID,
+	}
+
+	if o.Oferta != nil {
+		resp.Oferta = &OfertaVagaResponse{
+			PrestadorID:    o.Oferta.PrestadorID,
+			DataHoraInicio: o.Oferta.DataHoraInicio,
+			DataHoraFim:    o.Oferta.DataHoraFim,
+			ExpiraEm:       o.Oferta.ExpiraEm,
+		}
+	}
+
+	return resp
+}
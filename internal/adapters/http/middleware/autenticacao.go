@@ -0,0 +1,181 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"meu-servico-agenda/internal/adapters/http/resposta"
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// chaveIdentidade guarda no contexto do Gin quem fez a requisição
+const chaveIdentidade = "identidade"
+
+var (
+	ErrNaoAutenticado = errors.New("informe o token de acesso no cabeçalho Authorization: Bearer <token>")
+	ErrTokenInvalido  = errors.New("token de acesso inválido ou expirado")
+	ErrAcessoNegado   = errors.New("usuário sem permissão para esta operação")
+)
+
+// DonoRecurso informa a qual cliente e a qual prestador pertence o recurso do parâmetro
+// :id. Devolve strings vazias quando o recurso não existe
+type DonoRecurso func(ctx context.Context, id string) (clienteID, prestadorID string, err error)
+
+// Autenticar exige um token de acesso válido e guarda a identidade no contexto
+func Autenticar(emissor port.EmissorToken) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			recusar(c, http.StatusUnauthorized, "nao_autenticado")
+			return
+		}
+
+		identidade, err := emissor.Validar(token)
+		if err != nil {
+			recusar(c, http.StatusUnauthorized, "token_invalido")
+			return
+		}
+
+		c.Set(chaveIdentidade, identidade)
+		c.Next()
+	}
+}
+
+// IdentidadeDaRequisicao devolve quem fez a requisição; nil fora das rotas autenticadas
+func IdentidadeDaRequisicao(c *gin.Context) *domain.Identidade {
+	valor, ok := c.Get(chaveIdentidade)
+	if !ok {
+		return nil
+	}
+	identidade, _ := valor.(*domain.Identidade)
+	return identidade
+}
+
+// ExigirPapel libera a rota só para os papéis informados. Deve vir depois de Autenticar
+func ExigirPapel(papeis ...domain.Papel) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identidade := IdentidadeDaRequisicao(c)
+		if identidade == nil {
+			recusar(c, http.StatusUnauthorized, "nao_autenticado")
+			return
+		}
+
+		for _, papel := range papeis {
+			if identidade.Papel == papel {
+				c.Next()
+				return
+			}
+		}
+
+		recusar(c, http.StatusForbidden, "acesso_negado")
+	}
+}
+
+// ExigirDono libera a rota para o admin, para o cliente dono do recurso e para o
+// prestador que o atende. Recursos inexistentes seguem para o controller responder 404
+func ExigirDono(dono DonoRecurso) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identidade := IdentidadeDaRequisicao(c)
+		if identidade == nil {
+			recusar(c, http.StatusUnauthorized, "nao_autenticado")
+			return
+		}
+
+		if identidade.Papel == domain.PapelAdmin {
+			c.Next()
+			return
+		}
+
+		clienteID, prestadorID, err := dono(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			resposta.FalhaInterna(c, err)
+			return
+		}
+
+		if clienteID == "" && prestadorID == "" {
+			c.Next()
+			return
+		}
+
+		if !identidade.Representa(clienteID, prestadorID) {
+			recusar(c, http.StatusForbidden, "acesso_negado")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// ExigirClienteDoCorpo libera a criação de recursos só para o admin ou para o próprio
+// cliente informado em cliente_id no corpo da requisição
+func ExigirClienteDoCorpo() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identidade := IdentidadeDaRequisicao(c)
+		if identidade == nil {
+			recusar(c, http.StatusUnauthorized, "nao_autenticado")
+			return
+		}
+
+		if identidade.Papel == domain.PapelAdmin {
+			c.Next()
+			return
+		}
+
+		corpo, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			resposta.DadosInvalidos(c, err)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(corpo))
+
+		// Corpo malformado segue para o controller, que responde com o erro de validação
+		var req struct {
+			ClienteID string `json:"cliente_id"`
+		}
+		_ = json.Unmarshal(corpo, &req)
+
+		if req.ClienteID != "" && !identidade.Representa(req.ClienteID, "") {
+			recusar(c, http.StatusForbidden, "acesso_negado")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RegistrarNegacao chama registrar quando a requisição termina negada por falta de
+// permissão. Deve vir depois de Autenticar e antes das regras de acesso que observa
+func RegistrarNegacao(registrar func(c *gin.Context)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if c.Writer.Status() == http.StatusForbidden {
+			registrar(c)
+		}
+	}
+}
+
+// DonoCliente é o DonoRecurso das rotas em que :id já é o ID do cliente
+func DonoCliente(_ context.Context, id string) (string, string, error) {
+	return id, "", nil
+}
+
+// DonoPrestador é o DonoRecurso das rotas em que :id já é o ID do prestador
+func DonoPrestador(_ context.Context, id string) (string, string, error) {
+	return "", id, nil
+}
+
+// recusar interrompe a requisição com o erro de um middleware. Os erros daqui não
+// passam pelo mapeamento central, então o código vem junto e a mensagem sai do
+// catálogo do idioma da requisição
+func recusar(c *gin.Context, status int, codigo string) {
+	resposta.Escrever(c, resposta.NovoProblema(c, status, codigo, resposta.Mensagem(c, codigo)))
+}
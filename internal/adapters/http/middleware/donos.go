@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"context"
+
+	"meu-servico-agenda/internal/core/application/port"
+)
+
+// DonoAgendamento identifica o cliente e o prestador de um agendamento
+func DonoAgendamento(repo port.AgendamentoRepositorio) DonoRecurso {
+	return func(ctx context.Context, id string) (string, string, error) {
+		agendamento, err := repo.BuscarPorId(ctx, id)
+		if err != nil || agendamento == nil {
+			return "", "", err
+		}
+		return agendamento.Cliente.ID, agendamento.Prestador.ID, nil
+	}
+}
+
+func DonoSerie(repo port.AgendamentoRepositorio) DonoRecurso {
+	return func(ctx context.Context, id string) (string, string, error) {
+		serie, err := repo.BuscarSeriePorId(ctx, id)
+		if err != nil || serie == nil {
+			return "", "", err
+		}
+		return serie.Cliente.ID, serie.Prestador.ID, nil
+	}
+}
+
+// DonoVisita considera só o cliente: cada serviço da visita pode ter outro prestador
+func DonoVisita(repo port.AgendamentoRepositorio) DonoRecurso {
+	return func(ctx context.Context, id string) (string, string, error) {
+		visita, err := repo.BuscarVisitaPorId(ctx, id)
+		if err != nil || visita == nil {
+			return "", "", err
+		}
+		return visita.Cliente.ID, "", nil
+	}
+}
+
+func DonoEntradaListaEspera(repo port.ListaEsperaRepositorio) DonoRecurso {
+	return func(ctx context.Context, id string) (string, string, error) {
+		entrada, err := repo.BuscarPorId(ctx, id)
+		if err != nil || entrada == nil {
+			return "", "", err
+		}
+		return entrada.ClienteID, entrada.PrestadorID, nil
+	}
+}
@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"meu-servico-agenda/internal/adapters/http/resposta"
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// HeaderIdempotencyKey é o cabeçalho enviado pelo cliente em cada tentativa
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderIdempotentReplayed marca respostas devolvidas a partir do registro salvo
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	TamanhoMaximoChaveIdempotencia = 255
+	// TTLPadraoIdempotencia é por quanto tempo uma resposta pode ser repetida
+	TTLPadraoIdempotencia = 24 * time.Hour
+)
+
+var (
+	ErrChaveIdempotenciaInvalida    = errors.New("Idempotency-Key deve ter entre 1 e 255 caracteres")
+	ErrChaveIdempotenciaReutilizada = errors.New("Idempotency-Key já utilizada com outro corpo de requisição")
+	ErrRequisicaoEmAndamento        = errors.New("requisição com esta Idempotency-Key ainda está em andamento")
+)
+
+// Idempotencia repete a primeira resposta dada a uma Idempotency-Key quando o cliente
+// reenvia a mesma requisição. A chave vale por usuário, método e rota, e o corpo é
+// comparado por hash: o mesmo corpo recebe a resposta salva, outro corpo é recusado
+// com 422. Requisições sem o cabeçalho seguem sem alteração. Só respostas de sucesso
+// são guardadas: uma requisição recusada não alterou nada, e a nova tentativa com a
+// mesma chave é atendida de novo, com a resposta no idioma que ela pedir
+func Idempotencia(repo port.IdempotenciaRepositorio, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		chaveCliente := c.GetHeader(HeaderIdempotencyKey)
+		if chaveCliente == "" {
+			c.Next()
+			return
+		}
+
+		if len(chaveCliente) > TamanhoMaximoChaveIdempotencia {
+			recusar(c, http.StatusBadRequest, "chave_idempotencia_invalida")
+			return
+		}
+
+		corpo, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			resposta.DadosInvalidos(c, err)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(corpo))
+
+		// Usuários diferentes podem gerar a mesma chave sem enxergar a resposta do outro
+		var usuarioID string
+		if identidade := IdentidadeDaRequisicao(c); identidade != nil {
+			usuarioID = identidade.UsuarioID
+		}
+		chave := usuarioID + " " + c.Request.Method + " " + c.FullPath() + " " + chaveCliente
+		registro := domain.NovoRegistroIdempotencia(chave, hashRequisicao(c, corpo), ttl)
+
+		existente, err := repo.Reservar(c.Request.Context(), registro)
+		if err != nil {
+			resposta.FalhaInterna(c, err)
+			return
+		}
+
+		if existente != nil {
+			switch {
+			case existente.HashRequisicao != registro.HashRequisicao:
+				recusar(c, http.StatusUnprocessableEntity, "chave_idempotencia_reutilizada")
+			case existente.EmAndamento():
+				recusar(c, http.StatusConflict, "requisicao_em_andamento")
+			default:
+				c.Header(HeaderIdempotentReplayed, "true")
+				c.Data(existente.StatusCode, "application/json; charset=utf-8", existente.Corpo)
+				c.Abort()
+			}
+			return
+		}
+
+		gravador := &gravadorResposta{ResponseWriter: c.Writer}
+		c.Writer = gravador
+
+		// A chave precisa ser liberada ou concluída mesmo que o prazo da
+		// requisição tenha acabado durante o handler
+		semPrazo := context.WithoutCancel(c.Request.Context())
+
+		// Um panic no handler não pode deixar a chave presa como em andamento
+		defer func() {
+			if r := recover(); r != nil {
+				if err := repo.Liberar(semPrazo, chave); err != nil {
+					slog.ErrorContext(c.Request.Context(), "erro ao liberar chave de idempotência", slog.Any("erro", err))
+				}
+				panic(r)
+			}
+		}()
+
+		c.Next()
+
+		status := gravador.Status()
+		if status >= http.StatusBadRequest {
+			if err := repo.Liberar(semPrazo, chave); err != nil {
+				slog.ErrorContext(c.Request.Context(), "erro ao liberar chave de idempotência", slog.Any("erro", err))
+			}
+			return
+		}
+
+		if err := repo.Concluir(semPrazo, chave, status, gravador.corpo.Bytes()); err != nil {
+			slog.ErrorContext(c.Request.Context(), "erro ao concluir chave de idempotência", slog.Any("erro", err))
+		}
+	}
+}
+
+// hashRequisicao identifica a requisição pelo método, caminho real e corpo
+func hashRequisicao(c *gin.Context, corpo []byte) string {
+	h := sha256.New()
+	h.Write([]byte(c.Request.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(c.Request.URL.Path))
+	h.Write([]byte{0})
+	h.Write(corpo)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// gravadorResposta copia o corpo escrito pelo handler para poder salvá-lo
+type gravadorResposta struct {
+	gin.ResponseWriter
+	corpo bytes.Buffer
+}
+
+func (w *gravadorResposta) Write(b []byte) (int, error) {
+	w.corpo.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *gravadorResposta) WriteString(s string) (int, error) {
+	w.corpo.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"meu-servico-agenda/internal/core/application/port"
+)
+
+// IniciarLimpezaIdempotencia remove periodicamente as chaves expiradas.
+// A função devolvida interrompe a limpeza. Cada rodada tem no máximo o
+// próprio intervalo para terminar
+func IniciarLimpezaIdempotencia(repo port.IdempotenciaRepositorio, intervalo time.Duration) func() {
+	ticker := time.NewTicker(intervalo)
+	parar := make(chan struct{})
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				ctx, cancelar := context.WithTimeout(context.Background(), intervalo)
+				removidas, err := repo.RemoverExpirados(ctx, time.Now().UTC())
+				cancelar()
+				if err != nil {
+					slog.Error("erro na limpeza de chaves de idempotência", slog.Any("erro", err))
+					continue
+				}
+				if removidas > 0 {
+					slog.Info("chaves de idempotência expiradas removidas", slog.Int64("quantidade", removidas))
+				}
+			case <-parar:
+				return
+			}
+		}
+	}()
+
+	return func() { close(parar) }
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RotaNaoEncontrada agrupa as requisições sem rota para não criar uma série por URL
+const RotaNaoEncontrada = "nao_encontrada"
+
+// MetodoNaoPadrao agrupa os métodos fora da RFC 9110 para não criar uma série por método inventado
+const MetodoNaoPadrao = "OTHER"
+
+var metodosPadrao = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodConnect: true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+}
+
+// RegistradorRequisicoes recebe o resultado de cada requisição atendida
+type RegistradorRequisicoes interface {
+	RequisicaoAtendida(metodo, rota string, status int, duracao time.Duration)
+}
+
+// Metricas mede cada requisição pelo padrão da rota do Gin (Ex: /api/v1/clientes/:id),
+// nunca pela URL com os ids
+func Metricas(r RegistradorRequisicoes) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		inicio := time.Now()
+		c.Next()
+
+		rota := c.FullPath()
+		if rota == "" {
+			rota = RotaNaoEncontrada
+		}
+		metodo := c.Request.Method
+		if !metodosPadrao[metodo] {
+			metodo = MetodoNaoPadrao
+		}
+		r.RequisicaoAtendida(metodo, rota, c.Writer.Status(), time.Since(inicio))
+	}
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"meu-servico-agenda/internal/adapters/http/resposta"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Prazo limita a duração de cada requisição. O contexto com prazo segue do
+// handler até as consultas ao banco, que são canceladas quando ele acaba ou
+// quando o cliente desconecta
+func Prazo(duracao time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancelar := context.WithTimeout(c.Request.Context(), duracao)
+		defer cancelar()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Writer = &escritorComPrazo{ResponseWriter: c.Writer, c: c}
+		c.Next()
+	}
+}
+
+// escritorComPrazo troca respostas de erro escritas depois do fim do prazo por
+// 504 ou 503. Sem isso, uma busca interrompida que o serviço traduz para "não
+// encontrado" chegaria ao cliente como 404
+type escritorComPrazo struct {
+	gin.ResponseWriter
+	c *gin.Context
+
+	substituto []byte
+	escrito    bool
+}
+
+func (w *escritorComPrazo) WriteHeader(code int) {
+	if code < http.StatusBadRequest || code == http.StatusServiceUnavailable || code == http.StatusGatewayTimeout {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+
+	ctx := w.c.Request.Context()
+	motivo, ok := resposta.PrazoEsgotado(ctx, nil)
+	if !ok {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+
+	problema := resposta.Interrompida(w.c, motivo)
+	slog.WarnContext(ctx, "requisição interrompida",
+		slog.String("method", w.c.Request.Method),
+		slog.String("route", w.c.FullPath()),
+		slog.Int("status", problema.Status),
+		slog.Int("status_original", code),
+	)
+	w.substituto, _ = json.Marshal(problema)
+	w.Header().Set("Content-Type", resposta.ContentTypeProblema)
+	w.ResponseWriter.WriteHeader(problema.Status)
+}
+
+func (w *escritorComPrazo) Write(dados []byte) (int, error) {
+	if w.substituto == nil {
+		return w.ResponseWriter.Write(dados)
+	}
+	if !w.escrito {
+		w.escrito = true
+		if _, err := w.ResponseWriter.Write(w.substituto); err != nil {
+			return 0, err
+		}
+	}
+	return len(dados), nil
+}
+
+func (w *escritorComPrazo) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
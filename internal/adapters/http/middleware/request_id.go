@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	"meu-servico-agenda/internal/infra/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/xid"
+)
+
+const (
+	// HeaderRequestID identifica a requisição nos logs e nas respostas de erro
+	HeaderRequestID = "X-Request-ID"
+
+	TamanhoMaximoRequestID = 128
+)
+
+// RequestID reaproveita o X-Request-ID enviado pelo cliente ou pelo proxy, ou gera
+// um novo, e o coloca no contexto da requisição e no cabeçalho da resposta
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(HeaderRequestID)
+		if !requestIDValido(id) {
+			id = xid.New().String()
+		}
+
+		c.Request = c.Request.WithContext(logging.ComRequestID(c.Request.Context(), id))
+		c.Header(HeaderRequestID, id)
+		c.Next()
+	}
+}
+
+// requestIDValido recusa valores vazios, longos demais ou com caracteres que
+// poderiam quebrar o log
+func requestIDValido(id string) bool {
+	if id == "" || len(id) > TamanhoMaximoRequestID {
+		return false
+	}
+	for _, r := range id {
+		if r < '!' || r > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// LogRequisicoes registra cada requisição atendida em JSON, no lugar do logger
+// de texto do Gin. Respostas 5xx saem como erro e 4xx como aviso
+func LogRequisicoes() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		inicio := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		nivel := slog.LevelInfo
+		switch {
+		case status >= 500:
+			nivel = slog.LevelError
+		case status >= 400:
+			nivel = slog.LevelWarn
+		}
+
+		caminho := c.Request.URL.Path
+		if c.GetBool(chaveCaminhoSecreto) {
+			caminho = c.FullPath()
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("path", caminho),
+			slog.String("route", c.FullPath()),
+			slog.Int("status", status),
+			slog.Int64("duracao_ms", time.Since(inicio).Milliseconds()),
+			slog.String("ip", c.ClientIP()),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("erros", c.Errors.String()))
+		}
+
+		slog.LogAttrs(c.Request.Context(), nivel, "requisição atendida", attrs...)
+	}
+}
+
+const chaveCaminhoSecreto = "caminho_secreto"
+
+// CaminhoSecreto marca rotas que levam um segredo na URL, como os feeds de
+// calendário: o log registra a rota, com o parâmetro, no lugar do caminho
+func CaminhoSecreto() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(chaveCaminhoSecreto, true)
+		c.Next()
+	}
+}
@@ -0,0 +1,138 @@
+package modelo_agenda
+
+import (
+	"meu-servico-agenda/internal/adapters/http/modelo_agenda/request_modelo_agenda"
+	"meu-servico-agenda/internal/adapters/http/modelo_agenda/response_modelo_agenda"
+	"meu-servico-agenda/internal/adapters/http/resposta"
+	"meu-servico-agenda/internal/core/application/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ModeloAgendaController struct {
+	modeloAgendaService *service.ModeloAgendaService
+}
+
+func NovoModeloAgendaController(ms *service.ModeloAgendaService) *ModeloAgendaController {
+	return &ModeloAgendaController{
+		modeloAgendaService: ms,
+	}
+}
+
+// @Summary Cadastra um modelo semanal de agenda
+// @Description Define dias da semana e intervalos que se repetem dentro do período de vigência
+// @Tags Modelos de Agenda
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do prestador"
+// @Param modelo body request_modelo_agenda.ModeloAgendaRequest true "Dados do modelo"
+// @Success 201 {object} response_modelo_agenda.ModeloAgendaResponse "Modelo criado com sucesso"
+// @Failure 400 {object} resposta.Problema "Dados inválidos"
+// @Failure 404 {object} resposta.Problema "Prestador não encontrado"
+// @Failure 409 {object} resposta.Problema "Prestador inativo"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /prestadores/{id}/modelos-agenda [post]
+func (mc *ModeloAgendaController) PostModeloAgenda(c *gin.Context) {
+	prestadorID := c.Param("id")
+
+	var req request_modelo_agenda.ModeloAgendaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	cmd, err := req.ToCadastrarModeloAgendaInput(prestadorID)
+	if err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	modelo, err := mc.modeloAgendaService.Cadastrar(c.Request.Context(), cmd)
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response_modelo_agenda.NovoModeloAgendaResponse(modelo))
+}
+
+// @Summary Lista os modelos de agenda de um prestador
+// @Description Retorna os modelos semanais cadastrados, ordenados pelo início da vigência
+// @Tags Modelos de Agenda
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do prestador"
+// @Success 200 {array} response_modelo_agenda.ModeloAgendaResponse "Modelos do prestador"
+// @Failure 404 {object} resposta.Problema "Prestador não encontrado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /prestadores/{id}/modelos-agenda [get]
+func (mc *ModeloAgendaController) GetModelosAgenda(c *gin.Context) {
+	prestadorID := c.Param("id")
+
+	modelos, err := mc.modeloAgendaService.Listar(c.Request.Context(), prestadorID)
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response_modelo_agenda.NovoModelosAgendaResponse(modelos))
+}
+
+// @Summary Remove um modelo de agenda
+// @Description Remove o modelo. Agendas diárias já geradas a partir dele são mantidas
+// @Tags Modelos de Agenda
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do prestador"
+// @Param modeloId path string true "ID do modelo"
+// @Success 204 "Modelo removido com sucesso"
+// @Failure 404 {object} resposta.Problema "Modelo não encontrado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /prestadores/{id}/modelos-agenda/{modeloId} [delete]
+func (mc *ModeloAgendaController) DeleteModeloAgenda(c *gin.Context) {
+	if err := mc.modeloAgendaService.Deletar(c.Request.Context(), c.Param("id"), c.Param("modeloId")); err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Gera agendas diárias a partir de um modelo
+// @Description Cria as agendas do período para os dias do modelo dentro da vigência. Dias que já possuem agenda não são alterados e voltam em "ignoradas"
+// @Tags Modelos de Agenda
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do prestador"
+// @Param modeloId path string true "ID do modelo"
+// @Param periodo body request_modelo_agenda.GerarAgendasRequest true "Período a gerar"
+// @Success 200 {object} response_modelo_agenda.GeracaoAgendaResponse "Datas criadas e ignoradas"
+// @Failure 400 {object} resposta.Problema "Dados inválidos ou período inválido"
+// @Failure 404 {object} resposta.Problema "Prestador ou modelo não encontrado"
+// @Failure 409 {object} resposta.Problema "Prestador inativo"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /prestadores/{id}/modelos-agenda/{modeloId}/gerar [post]
+func (mc *ModeloAgendaController) PostGerarAgendas(c *gin.Context) {
+	var req request_modelo_agenda.GerarAgendasRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	cmd, err := req.ToGerarAgendasInput(c.Param("id"), c.Param("modeloId"))
+	if err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	resultado, err := mc.modeloAgendaService.GerarAgendas(c.Request.Context(), cmd)
+	if err != nil {
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response_modelo_agenda.NovoGeracaoAgendaResponse(resultado))
+}
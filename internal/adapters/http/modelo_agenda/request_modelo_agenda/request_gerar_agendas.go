@@ -0,0 +1,31 @@
+package request_modelo_agenda
+
+import (
+	"fmt"
+	"meu-servico-agenda/internal/core/application/input"
+	"time"
+)
+
+type GerarAgendasRequest struct {
+	DataInicio string `json:"data_inicio" example:"2030-01-01" binding:"required,datetime=2006-01-02"`
+	DataFim    string `json:"data_fim" example:"2030-01-31" binding:"required,datetime=2006-01-02"`
+}
+
+func (r *GerarAgendasRequest) ToGerarAgendasInput(prestadorID, modeloID string) (*input.GerarAgendasInput, error) {
+	inicio, err := time.Parse("2006-01-02", r.DataInicio)
+	if err != nil {
+		return nil, fmt.Errorf("data_inicio inválida: %w", err)
+	}
+
+	fim, err := time.Parse("2006-01-02", r.DataFim)
+	if err != nil {
+		return nil, fmt.Errorf("data_fim inválida: %w", err)
+	}
+
+	return &input.GerarAgendasInput{
+		PrestadorID: prestadorID,
+		ModeloID:    modeloID,
+		DataInicio:  inicio,
+		DataFim:     fim,
+	}, nil
+}
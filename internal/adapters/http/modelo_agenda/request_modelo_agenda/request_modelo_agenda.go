@@ -0,0 +1,63 @@
+package request_modelo_agenda
+
+import (
+	"fmt"
+	"meu-servico-agenda/internal/core/application/input"
+	"time"
+)
+
+type IntervaloModeloRequest struct {
+	HoraInicio string `json:"hora_inicio" example:"08:00" binding:"required,datetime=15:04"`
+	HoraFim    string `json:"hora_fim" example:"12:00" binding:"required,datetime=15:04"`
+}
+
+type ModeloAgendaRequest struct {
+	// 0 = domingo ... 6 = sábado
+	DiasDaSemana   []int                    `json:"dias_semana" example:"1,2,3,4,5" binding:"required,min=1,dive,min=0,max=6"`
+	Intervalos     []IntervaloModeloRequest `json:"intervalos" binding:"required,min=1,dive"`
+	VigenciaInicio string                   `json:"vigencia_inicio" example:"2030-01-01" binding:"required,datetime=2006-01-02"`
+	VigenciaFim    string                   `json:"vigencia_fim" example:"2030-06-30" binding:"required,datetime=2006-01-02"`
+}
+
+func (r *ModeloAgendaRequest) ToCadastrarModeloAgendaInput(prestadorID string) (*input.CadastrarModeloAgendaInput, error) {
+	vigenciaInicio, err := time.Parse("2006-01-02", r.VigenciaInicio)
+	if err != nil {
+		return nil, fmt.Errorf("vigencia_inicio inválida: %w", err)
+	}
+
+	vigenciaFim, err := time.Parse("2006-01-02", r.VigenciaFim)
+	if err != nil {
+		return nil, fmt.Errorf("vigencia_fim inválida: %w", err)
+	}
+
+	dias := make([]time.Weekday, len(r.DiasDaSemana))
+	for i, d := range r.DiasDaSemana {
+		dias[i] = time.Weekday(d)
+	}
+
+	intervalos := make([]input.IntervaloInput, 0, len(r.Intervalos))
+	for _, i := range r.Intervalos {
+		inicio, err := time.Parse("15:04", i.HoraInicio)
+		if err != nil {
+			return nil, fmt.Errorf("hora_inicio inválida: %w", err)
+		}
+
+		fim, err := time.Parse("15:04", i.HoraFim)
+		if err != nil {
+			return nil, fmt.Errorf("hora_fim inválida: %w", err)
+		}
+
+		intervalos = append(intervalos, input.IntervaloInput{
+			Inicio: inicio,
+			Fim:    fim,
+		})
+	}
+
+	return &input.CadastrarModeloAgendaInput{
+		PrestadorID:    prestadorID,
+		DiasDaSemana:   dias,
+		Intervalos:     intervalos,
+		VigenciaInicio: vigenciaInicio,
+		VigenciaFim:    vigenciaFim,
+	}, nil
+}
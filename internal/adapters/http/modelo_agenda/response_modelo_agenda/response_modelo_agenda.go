@@ -0,0 +1,58 @@
+package response_modelo_agenda
+
+import "meu-servico-agenda/internal/core/application/output"
+
+type IntervaloModeloResponse struct {
+	ID         string `json:"id"`
+	HoraInicio string `json:"hora_inicio"`
+	HoraFim    string `json:"hora_fim"`
+}
+
+type ModeloAgendaResponse struct {
+	ID             string                    `json:"id"`
+	PrestadorID    string                    `json:"prestador_id"`
+	DiasDaSemana   []int                     `json:"dias_semana"`
+	Intervalos     []IntervaloModeloResponse `json:"intervalos"`
+	VigenciaInicio string                    `json:"vigencia_inicio"`
+	VigenciaFim    string                    `json:"vigencia_fim"`
+}
+
+type GeracaoAgendaResponse struct {
+	Criadas   []string `json:"criadas"`
+	Ignoradas []string `json:"ignoradas"`
+}
+
+func NovoModeloAgendaResponse(o *output.ModeloAgendaOutput) ModeloAgendaResponse {
+	intervalos := make([]IntervaloModeloResponse, len(o.Intervalos))
+	for i, it := range o.Intervalos {
+		intervalos[i] = IntervaloModeloResponse{
+			ID:         it.ID,
+			HoraInicio: it.HoraInicio,
+			HoraFim:    it.HoraFim,
+		}
+	}
+
+	return ModeloAgendaResponse{
+		ID:             o.ID,
+		PrestadorID:    o.PrestadorID,
+		DiasDaSemana:   o.DiasDaSemana,
+		Intervalos:     intervalos,
+		VigenciaInicio: o.VigenciaInicio,
+		VigenciaFim:    o.VigenciaFim,
+	}
+}
+
+func NovoModelosAgendaResponse(modelos []*output.ModeloAgendaOutput) []ModeloAgendaResponse {
+	resp := make([]ModeloAgendaResponse, len(modelos))
+	for i, m := range modelos {
+		resp[i] = NovoModeloAgendaResponse(m)
+	}
+	return resp
+}
+
+func NovoGeracaoAgendaResponse(o *output.GeracaoAgendaOutput) GeracaoAgendaResponse {
+	return GeracaoAgendaResponse{
+		Criadas:   o.Criadas,
+		Ignoradas: o.Ignoradas,
+	}
+}
@@ -1,15 +1,13 @@
 package prestador
 
 import (
-	"errors"
-	"fmt"
 	"time"
 
 	"meu-servico-agenda/internal/adapters/http/prestador/request_prestador"
 	"meu-servico-agenda/internal/adapters/http/prestador/response_prestador"
 
+	"meu-servico-agenda/internal/adapters/http/resposta"
 	"meu-servico-agenda/internal/core/application/service"
-	"meu-servico-agenda/internal/core/domain"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -30,45 +28,35 @@ func NovoPrestadorController(ps *service.PrestadorService) *PrestadorController
 // @Tags Prestadores
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param prestador body request_prestador.PrestadorRequest true "Dados do Prestador"
+// @Param Idempotency-Key header string false "Chave para repetir a requisição com segurança; a mesma chave e corpo devolvem a resposta original"
 // @Success 201 {object} response_prestador.PrestadorPostResponse "Prestador criado com sucesso"
-// @Failure 400 {object} domain.ErrorResponse "Dados inválidos (erro de validação do binding)"
-// @Failure 409 {object} domain.ErrorResponse "Prestador já cadastrado ou conflito de dados"
-// @Failure 500 {object} domain.ErrorResponse "Falha na persistência de dados ou erro interno"
+// @Failure 400 {object} resposta.Problema "Dados inválidos (erro de validação do binding ou fuso horário inválido)"
+// @Failure 404 {object} resposta.Problema "Catálogo informado não existe"
+// @Failure 409 {object} resposta.Problema "Prestador já cadastrado ou conflito de dados"
+// @Failure 500 {object} resposta.Problema "Falha na persistência de dados ou erro interno"
 // @Router /prestadores [post]
 func (prc *PrestadorController) PostPrestador(c *gin.Context) {
 	var input request_prestador.PrestadorRequest
 
 	// 1️⃣ Validação de binding / formato (continua igual)
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Dados inválidos: " + err.Error(),
-		})
+		resposta.DadosInvalidos(c, err)
 		return
 	}
 
 	// 2️⃣ Adapter → Command (mantém validações existentes)
 	cmd, err := input.ToCadastrarPrestadorInput()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+		resposta.DadosInvalidos(c, err)
 		return
 	}
 
 	// 3️⃣ Chamada do caso de uso
-	prestador, err := prc.prestadorService.Cadastra(cmd)
+	prestador, err := prc.prestadorService.Cadastra(c.Request.Context(), cmd)
 	if err != nil {
-		switch {
-		case errors.Is(err, service.ErrCPFJaCadastrado):
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-
-		case errors.Is(err, service.ErrCatalogoNaoExiste):
-			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
-
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		}
+		resposta.Erro(c, err)
 		return
 	}
 
@@ -84,27 +72,16 @@ func (prc *PrestadorController) PostPrestador(c *gin.Context) {
 // @Produce json
 // @Param id path string true "ID do prestador"
 // @Success 200 {object} response_prestador.PrestadorResponse "Prestador encontrado com sucesso"
-// @Failure 404 {object} domain.ErrorResponse "Prestador não encontrado"
-// @Failure 500 {object} domain.ErrorResponse "Erro interno"
+// @Failure 404 {object} resposta.Problema "Prestador não encontrado"
+// @Failure 500 {object} resposta.Problema "Erro interno"
 // @Router /prestadores/{id} [get]
 func (prc *PrestadorController) GetPrestador(c *gin.Context) {
 	id := c.Param("id")
 
-	out, err := prc.prestadorService.BuscarPorId(id)
+	out, err := prc.prestadorService.BuscarPorId(c.Request.Context(), id)
 	if err != nil {
-		switch {
-		case errors.Is(err, service.ErrPrestadorNaoEncontrado):
-			c.JSON(http.StatusNotFound, gin.H{
-				"error": err.Error(),
-			})
-			return
-
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "erro interno",
-			})
-			return
-		}
+		resposta.Erro(c, err)
+		return
 	}
 
 	resp := response_prestador.FromPrestadorOutput(*out)
@@ -113,46 +90,34 @@ func (prc *PrestadorController) GetPrestador(c *gin.Context) {
 
 // UpdatePrestador godoc
 // @Summary Atualiza um prestador existente
-// @Description Atualiza os dados cadastrais de um prestador, incluindo nome, email, telefone, imagem e catálogos de serviços associados. O CPF não pode ser alterado.
+// @Description Atualiza os dados cadastrais de um prestador, incluindo nome, email, telefone, imagem e catálogos de serviços associados. O CPF não pode ser alterado. Em condicoes_servico o prestador pode praticar preço e duração próprios em serviços de catalogo_ids; os demais voltam aos valores do catálogo.
 // @Tags Prestadores
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path string true "ID do prestador"
 // @Param prestador body request_prestador.PrestadorUpdateRequest true "Dados atualizados do prestador"
 // @Success 204 "Prestador atualizado com sucesso"
-// @Failure 400 {object} domain.ErrorResponse "Dados inválidos ou catálogo não existe"
-// @Failure 404 {object} domain.ErrorResponse "Prestador não encontrado"
-// @Failure 500 {object} domain.ErrorResponse "Erro interno do servidor"
+// @Failure 400 {object} resposta.Problema "Dados inválidos, fuso horário inválido ou condição de serviço inválida"
+// @Failure 404 {object} resposta.Problema "Prestador ou catálogo não encontrado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
 // @Router /prestadores/{id} [put]
 func (prc *PrestadorController) UpdatePrestador(c *gin.Context) {
 	id := c.Param("id")
 
 	var req request_prestador.PrestadorUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		// ✅ Já captura erros de validação (nome, email, telefone, url)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		resposta.DadosInvalidos(c, err)
 		return
 	}
 
 	input := req.ToAlterarPrestadorInput()
 	input.Id = id
 
-	err := prc.prestadorService.Atualizar(input)
+	err := prc.prestadorService.Atualizar(c.Request.Context(), input)
 	if err != nil {
-		switch err {
-		case service.ErrPrestadorNaoEncontrado:
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		case service.ErrCatalogoNaoExiste:
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		case domain.ErrPrestadorDeveTerCatalogo: // ✅ NOVO
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro interno ao atualizar prestador"})
-			return
-		}
+		resposta.Erro(c, err)
+		return
 	}
 
 	c.Status(http.StatusNoContent)
@@ -168,25 +133,21 @@ func (prc *PrestadorController) UpdatePrestador(c *gin.Context) {
 // @Param limit query int false "Itens por página (padrão: 10, máximo: 100)"
 // @Param ativo query boolean true "Status do prestador (obrigatório)"
 // @Success 200 {object} response_prestador.PrestadorListResponse
-// @Failure 400 {object} domain.ErrorResponse "Parâmetro 'ativo' é obrigatório"
+// @Failure 400 {object} resposta.Problema "Parâmetro 'ativo' é obrigatório"
 // @Router /prestadores [get]
 func (prc *PrestadorController) GetPrestadores(c *gin.Context) {
 	var req request_prestador.PrestadorListRequest
 
 	if err := c.ShouldBindQuery(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": fmt.Sprintf("Parâmetro 'ativo' é obrigatório: %s", err.Error()),
-		})
+		resposta.DadosInvalidos(c, err)
 		return
 	}
 
 	input := req.ToInputPrestador()
 
-	prestadores, total, err := prc.prestadorService.ListarPrestadores(input)
+	prestadores, total, err := prc.prestadorService.ListarPrestadores(c.Request.Context(), input)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Erro ao listar prestadores",
-		})
+		resposta.Erro(c, err)
 		return
 	}
 
@@ -204,24 +165,19 @@ func (prc *PrestadorController) GetPrestadores(c *gin.Context) {
 // @Description Inativa um prestador, impedindo que ele receba novos agendamentos
 // @Tags Prestadores
 // @Produce json
+// @Security BearerAuth
 // @Param id path string true "ID do prestador"
 // @Success 204 "Prestador inativado com sucesso"
-// @Failure 404 {object} domain.ErrorResponse "Prestador não encontrado"
-// @Failure 500 {object} domain.ErrorResponse "Erro interno"
+// @Failure 404 {object} resposta.Problema "Prestador não encontrado"
+// @Failure 500 {object} resposta.Problema "Erro interno"
 // @Router /prestadores/{id}/inativar [put]
 func (prc *PrestadorController) InativarPrestador(c *gin.Context) {
 	id := c.Param("id")
 
-	err := prc.prestadorService.Inativar(id)
+	err := prc.prestadorService.Inativar(c.Request.Context(), id)
 	if err != nil {
-		switch err {
-		case service.ErrPrestadorNaoEncontrado:
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro interno"})
-			return
-		}
+		resposta.Erro(c, err)
+		return
 	}
 
 	c.Status(http.StatusNoContent)
@@ -231,24 +187,19 @@ func (prc *PrestadorController) InativarPrestador(c *gin.Context) {
 // @Description Ativa um prestador, permitindo que ele receba novos agendamentos
 // @Tags Prestadores
 // @Produce json
+// @Security BearerAuth
 // @Param id path string true "ID do prestador"
 // @Success 204 "Prestador ativado com sucesso"
-// @Failure 404 {object} domain.ErrorResponse "Prestador não encontrado"
-// @Failure 500 {object} domain.ErrorResponse "Erro interno"
+// @Failure 404 {object} resposta.Problema "Prestador não encontrado"
+// @Failure 500 {object} resposta.Problema "Erro interno"
 // @Router /prestadores/{id}/ativar [put]
 func (prc *PrestadorController) AtivarPrestador(c *gin.Context) {
 	id := c.Param("id")
 
-	err := prc.prestadorService.Ativar(id)
+	err := prc.prestadorService.Ativar(c.Request.Context(), id)
 	if err != nil {
-		switch err {
-		case service.ErrPrestadorNaoEncontrado:
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro interno"})
-			return
-		}
+		resposta.Erro(c, err)
+		return
 	}
 
 	c.Status(http.StatusNoContent)
@@ -259,53 +210,63 @@ func (prc *PrestadorController) AtivarPrestador(c *gin.Context) {
 // @Tags Prestadores
 // @Accept json
 // @Produce json
+// @Security BearerAuth
 // @Param id path string true "ID do prestador"
 // @Param agenda body request_prestador.AgendaDiariaRequest true "Dados da agenda"
 // @Success 200 "Agenda criada ou atualizada com sucesso"
-// @Failure 400 {object} domain.ErrorResponse "Dados inválidos"
-// @Failure 404 {object} domain.ErrorResponse "Prestador não encontrado"
-// @Failure 409 {object} domain.ErrorResponse "Prestador inativo"
+// @Failure 400 {object} resposta.Problema "Dados inválidos"
+// @Failure 404 {object} resposta.Problema "Prestador não encontrado"
+// @Failure 409 {object} resposta.Problema "Prestador inativo"
 // @Router /prestadores/{id}/agenda [put]
 func (prc *PrestadorController) PutAgenda(c *gin.Context) {
 	prestadorID := c.Param("id")
 
 	var input request_prestador.AgendaDiariaRequest
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": fmt.Sprintf("Dados inválidos: %s", err.Error()),
-		})
+		resposta.DadosInvalidos(c, err)
 		return
 	}
 
 	cmd, err := input.ToAdicionarAgendaInput() // Um método só
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		resposta.DadosInvalidos(c, err)
 		return
 	}
 
 	cmd.PrestadorID = prestadorID
 
-	err = prc.prestadorService.SalvarAgenda(cmd)
+	err = prc.prestadorService.SalvarAgenda(c.Request.Context(), cmd)
 	if err != nil {
-		switch err {
-		case service.ErrPrestadorNaoEncontrado:
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		case service.ErrPrestadorInativo:
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-			return
-		case domain.ErrIntervaloHorarioInvalido,
-			domain.ErrAgendaSemIntervalos,
-			domain.ErrDataEstaNoPassado,
-			domain.ErrIntervalosSesobrepoe: // ✅ NOVO
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Erro ao salvar agenda",
-			})
-			return
-		}
+		resposta.Erro(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Define o preparo e a limpeza do prestador
+// @Description Substitui, para todos os serviços do prestador, os minutos de preparo antes e de limpeza depois de cada atendimento. Campos nulos ou ausentes voltam a usar os tempos de cada serviço. O horário combinado com o cliente não muda; apenas o período em que o prestador fica ocupado
+// @Tags Prestadores
+// @Accept json
+// @Security BearerAuth
+// @Param id path string true "ID do prestador"
+// @Param tempos body request_prestador.TemposEntreAtendimentosRequest true "Tempos em minutos, de 0 a 240"
+// @Success 204 "Tempos atualizados com sucesso"
+// @Failure 400 {object} resposta.Problema "Dados inválidos"
+// @Failure 404 {object} resposta.Problema "Prestador não encontrado"
+// @Failure 500 {object} resposta.Problema "Erro interno do servidor"
+// @Router /prestadores/{id}/tempos-entre-atendimentos [put]
+func (prc *PrestadorController) PutTemposEntreAtendimentos(c *gin.Context) {
+	var req request_prestador.TemposEntreAtendimentosRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resposta.DadosInvalidos(c, err)
+		return
+	}
+
+	err := prc.prestadorService.DefinirTemposEntreAtendimentos(c.Request.Context(), req.ToTemposEntreAtendimentosInput(c.Param("id")))
+	if err != nil {
+		resposta.Erro(c, err)
+		return
 	}
 
 	c.Status(http.StatusNoContent)
@@ -316,12 +277,13 @@ func (prc *PrestadorController) PutAgenda(c *gin.Context) {
 // @Description Remove uma agenda de um prestador em uma data específica
 // @Tags Prestadores
 // @Produce json
+// @Security BearerAuth
 // @Param id path string true "ID do prestador"
 // @Param data query string true "Data da agenda (formato: 2006-01-02)"
 // @Success 204 "Agenda deletada com sucesso"
-// @Failure 400 {object} domain.ErrorResponse "Data inválida"
-// @Failure 404 {object} domain.ErrorResponse "Prestador ou agenda não encontrada"
-// @Failure 409 {object} domain.ErrorResponse "Prestador inativo"
+// @Failure 400 {object} resposta.Problema "Data inválida"
+// @Failure 404 {object} resposta.Problema "Prestador ou agenda não encontrada"
+// @Failure 409 {object} resposta.Problema "Prestador inativo"
 // @Router /prestadores/{id}/agenda [delete]
 func (prc *PrestadorController) DeleteAgenda(c *gin.Context) {
 	prestadorID := c.Param("id")
@@ -330,28 +292,14 @@ func (prc *PrestadorController) DeleteAgenda(c *gin.Context) {
 	// Validar formato da data
 	_, err := time.Parse("2006-01-02", data)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Data inválida. Use o formato YYYY-MM-DD",
-		})
+		resposta.DadosInvalidos(c, err)
 		return
 	}
 
-	err = prc.prestadorService.DeletarAgenda(prestadorID, data)
+	err = prc.prestadorService.DeletarAgenda(c.Request.Context(), prestadorID, data)
 	if err != nil {
-		switch err {
-		case service.ErrPrestadorNaoEncontrado,
-			service.ErrAgendaNaoEncontrada:
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		case service.ErrPrestadorInativo:
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-			return
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Erro ao deletar agenda",
-			})
-			return
-		}
+		resposta.Erro(c, err)
+		return
 	}
 
 	c.Status(http.StatusNoContent)
@@ -367,37 +315,23 @@ func (prc *PrestadorController) DeleteAgenda(c *gin.Context) {
 // @Param page query int false "Número da página (padrão: 1)"
 // @Param limit query int false "Itens por página (padrão: 10, máximo: 100)"
 // @Success 200 {object} response_prestador.PrestadorListResponse "Lista de prestadores disponíveis"
-// @Failure 400 {object} domain.ErrorResponse "Parâmetro 'data' é obrigatório ou formato inválido"
-// @Failure 500 {object} domain.ErrorResponse "Erro interno ao buscar prestadores"
+// @Failure 400 {object} resposta.Problema "Parâmetro 'data' é obrigatório ou formato inválido"
+// @Failure 500 {object} resposta.Problema "Erro interno ao buscar prestadores"
 // @Router /prestadores/disponiveis [get]
 func (prc *PrestadorController) GetPrestadoresPorData(c *gin.Context) {
 	var req request_prestador.BuscarPrestadoresDataRequest
 
 	if err := c.ShouldBindQuery(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": fmt.Sprintf("Parâmetro 'data' é obrigatório: %s", err.Error()),
-		})
+		resposta.DadosInvalidos(c, err)
 		return
 	}
 
 	input := req.ToInputPrestador()
 
-	prestadores, total, err := prc.prestadorService.BuscarPrestadoresDisponiveisPorData(input)
+	prestadores, total, err := prc.prestadorService.BuscarPrestadoresDisponiveisPorData(c.Request.Context(), input)
 	if err != nil {
-		switch err {
-		case service.ErrAoBuscarPrestadoresDisponiveis,
-			service.ErrAoContarPrestadoresDisponiveis:
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		case service.ErrFormatoDataInvalido, domain.ErrDataEstaNoPassado:
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "Erro ao listar prestadores",
-			})
-			return
-		}
+		resposta.Erro(c, err)
+		return
 	}
 
 	response := response_prestador.PrestadorListResponse{
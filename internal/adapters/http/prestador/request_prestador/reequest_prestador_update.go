@@ -3,19 +3,41 @@ package request_prestador
 import "meu-servico-agenda/internal/core/application/input"
 
 type PrestadorUpdateRequest struct {
-	Nome        string   `json:"nome" binding:"required,min=3,max=100" example:"joao" swagger:"desc('Nome do prestador')"`
-	Email       string   `json:"email" binding:"omitempty,email" example:"joao@email.com" swagger:"desc('Email do prestador')"`
-	Telefone    string   `json:"telefone" binding:"required,min=8,max=15" example:"62999677481" swagger:"desc('Telefone do prestador')"`
-	ImagemUrl   string   `json:"image_url" binding:"required,url" example:"https://tdfuderuzpylkctxbysu.supabase.co/storage/v1/object/public/imagens/bb515383d2f6ef76.jpg"`
-	CatalogoIDs []string `json:"catalogo_ids" binding:"omitempty,dive,required" swagger:"desc('IDs dos serviços no catálogo oferecidos pelo prestador')"`
+	Nome        string                     `json:"nome" binding:"required,min=3,max=100" example:"joao" swagger:"desc('Nome do prestador')"`
+	Email       string                     `json:"email" binding:"omitempty,email" example:"joao@email.com" swagger:"desc('Email do prestador')"`
+	Telefone    string                     `json:"telefone" binding:"required,min=8,max=15" example:"62999677481" swagger:"desc('Telefone do prestador')"`
+	ImagemUrl   string                     `json:"image_url" binding:"required,url" example:"https://tdfuderuzpylkctxbysu.supabase.co/storage/v1/object/public/imagens/bb515383d2f6ef76.jpg"`
+	CatalogoIDs []string                   `json:"catalogo_ids" binding:"omitempty,dive,required" swagger:"desc('IDs dos serviços no catálogo oferecidos pelo prestador')"`
+	FusoHorario string                     `json:"fuso_horario" binding:"omitempty,max=64" example:"America/Sao_Paulo" swagger:"desc('Fuso horário IANA da agenda do prestador; vazio mantém o atual')"`
+	Condicoes   []CondicaoDoServicoRequest `json:"condicoes_servico" binding:"omitempty,dive" swagger:"desc('Preço e duração praticados pelo prestador em serviços do seu catálogo')"`
 }
 
-func (r *PrestadorUpdateRequest) ToAlterarPrestadorInput() (*input.AlterarPrestadorInput) {
+type CondicaoDoServicoRequest struct {
+	CatalogoID    string `json:"catalogo_id" binding:"required" example:"d4hq3kbs3g3h7ra0k1b0" swagger:"desc('ID de um serviço presente em catalogo_ids')"`
+	Preco         *int   `json:"preco" binding:"omitempty,min=0" example:"15000" swagger:"desc('Preço em centavos; ausente mantém o do catálogo')"`
+	DuracaoPadrao *int   `json:"duracao_padrao" binding:"omitempty,min=2" example:"45" swagger:"desc('Duração em minutos; ausente mantém a do catálogo')"`
+}
+
+func (r *PrestadorUpdateRequest) ToAlterarPrestadorInput() *input.AlterarPrestadorInput {
 	return &input.AlterarPrestadorInput{
 		Nome:        r.Nome,
 		Email:       r.Email,
 		Telefone:    r.Telefone,
 		ImagemUrl:   r.ImagemUrl,
 		CatalogoIDs: r.CatalogoIDs,
+		FusoHorario: r.FusoHorario,
+		Condicoes:   r.toCondicoesDoServicoInput(),
+	}
+}
+
+func (r *PrestadorUpdateRequest) toCondicoesDoServicoInput() []input.CondicaoDoServicoInput {
+	condicoes := make([]input.CondicaoDoServicoInput, 0, len(r.Condicoes))
+	for _, c := range r.Condicoes {
+		condicoes = append(condicoes, input.CondicaoDoServicoInput{
+			CatalogoID:    c.CatalogoID,
+			Preco:         c.Preco,
+			DuracaoPadrao: c.DuracaoPadrao,
+		})
 	}
+	return condicoes
 }
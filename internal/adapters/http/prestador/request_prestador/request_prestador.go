@@ -14,6 +14,7 @@ type PrestadorRequest struct {
 	Telefone    string   `json:"telefone" binding:"required,min=8,max=15" example:"62999677481" swagger:"desc('Telefone do prestador')"`
 	ImagemUrl   string   `json:"image_url" binding:"required,url" example:"https://tdfuderuzpylkctxbysu.supabase.co/storage/v1/object/public/imagens/bb515383d2f6ef76.jpg"`
 	CatalogoIDs []string `json:"catalogo_ids" binding:"required,min=1" swagger:"desc('IDs dos serviços no catálogo oferecidos pelo prestador')"`
+	FusoHorario string   `json:"fuso_horario" binding:"omitempty,max=64" example:"America/Sao_Paulo" swagger:"desc('Fuso horário IANA da agenda do prestador, padrão UTC')"`
 }
 
 func (r *PrestadorRequest) ToCadastrarPrestadorInput() (*input.CadastrarPrestadorInput, error) {
@@ -28,6 +29,7 @@ func (r *PrestadorRequest) ToCadastrarPrestadorInput() (*input.CadastrarPrestado
 		Telefone:    r.Telefone,
 		ImagemUrl:   r.ImagemUrl,
 		CatalogoIDs: r.CatalogoIDs,
+		FusoHorario: r.FusoHorario,
 	}, nil
 }
 
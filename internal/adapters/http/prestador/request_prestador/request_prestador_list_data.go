@@ -15,16 +15,5 @@ func (r *BuscarPrestadoresDataRequest) ToInputPrestador() *input.PrestadorListDa
 		Data: *&r.Data, // ✅ Sempre vai ter valor
 	}
 
-	// Validações de paginação
-	if input.Page <= 0 {
-		input.Page = 1
-	}
-	if input.Limit <= 0 {
-		input.Limit = 10
-	}
-	if input.Limit > 100 {
-		input.Limit = 100
-	}
-
 	return input
 }
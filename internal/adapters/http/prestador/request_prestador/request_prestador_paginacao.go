@@ -15,13 +15,5 @@ func (r *PrestadorListRequest) ToInputPrestador() *input.PrestadorListInput {
 		Ativo: *r.Ativo, // ✅ Sempre vai ter valor
 	}
 
-	// Valores padrão
-	if input.Page <= 0 {
-		input.Page = 1
-	}
-	if input.Limit <= 0 {
-		input.Limit = 10
-	}
-
 	return input
 }
\ No newline at end of file
@@ -0,0 +1,18 @@
+package request_prestador
+
+import "meu-servico-agenda/internal/core/application/input"
+
+// TemposEntreAtendimentosRequest substitui o preparo e a limpeza dos serviços do prestador.
+// Campos nulos ou ausentes voltam a usar os tempos de cada serviço
+type TemposEntreAtendimentosRequest struct {
+	TempoPreparo *int `json:"tempo_preparo" binding:"omitempty,min=0,max=240" example:"10" swagger:"desc('Minutos de preparo antes do atendimento')"`
+	TempoLimpeza *int `json:"tempo_limpeza" binding:"omitempty,min=0,max=240" example:"15" swagger:"desc('Minutos de limpeza depois do atendimento')"`
+}
+
+func (r *TemposEntreAtendimentosRequest) ToTemposEntreAtendimentosInput(prestadorID string) *input.TemposEntreAtendimentosInput {
+	return &input.TemposEntreAtendimentosInput{
+		PrestadorID:  prestadorID,
+		TempoPreparo: r.TempoPreparo,
+		TempoLimpeza: r.TempoLimpeza,
+	}
+}
@@ -6,13 +6,14 @@ import (
 )
 
 type PrestadorPostResponse struct {
-	ID        string                               `json:"id"`
-	Nome      string                               `json:"nome"`
-	Email     string                               `json:"email"`
-	Telefone  string                               `json:"telefone"`
-	Ativo     bool                                 `json:"ativo"`
-	ImagemUrl string                               `json:"image_url"`
-	Catalogo  []response_catalogo.CatalogoResponse `json:"catalogo"`
+	ID          string                               `json:"id"`
+	Nome        string                               `json:"nome"`
+	Email       string                               `json:"email"`
+	Telefone    string                               `json:"telefone"`
+	Ativo       bool                                 `json:"ativo"`
+	ImagemUrl   string                               `json:"image_url"`
+	Catalogo    []response_catalogo.CatalogoResponse `json:"catalogo"`
+	FusoHorario string                               `json:"fuso_horario"`
 }
 
 func FromCriarPrestadorOutput(o output.CriarPrestadorOutput) PrestadorPostResponse {
@@ -23,12 +24,13 @@ func FromCriarPrestadorOutput(o output.CriarPrestadorOutput) PrestadorPostRespon
 	}
 
 	return PrestadorPostResponse{
-		ID:       o.ID,
-		Nome:     o.Nome,
-		Email:    o.Email,
-		Telefone: o.Telefone,
-		Ativo:    o.Ativo,
-		ImagemUrl: o.ImagemUrl,
-		Catalogo: catalogo,
+		ID:          o.ID,
+		Nome:        o.Nome,
+		Email:       o.Email,
+		Telefone:    o.Telefone,
+		Ativo:       o.Ativo,
+		ImagemUrl:   o.ImagemUrl,
+		Catalogo:    catalogo,
+		FusoHorario: o.FusoHorario,
 	}
 }
@@ -6,15 +6,27 @@ import (
 )
 
 type PrestadorResponse struct {
-	ID        string                               `json:"id"`
-	Nome      string                               `json:"nome"`
-	Email     string                               `json:"email"`
-	Telefone  string                               `json:"telefone"`
-	Cpf       string                               `json:"cpf"`
-	Ativo     bool                                 `json:"ativo"`
-	ImagemUrl string                               `json:"image_url"`
-	Catalogo  []response_catalogo.CatalogoResponse `json:"catalogo"`
-	Agenda    []AgendaDiariaResponse               `json:"agenda"`
+	ID          string                               `json:"id"`
+	Nome        string                               `json:"nome"`
+	Email       string                               `json:"email"`
+	Telefone    string                               `json:"telefone"`
+	Cpf         string                               `json:"cpf"`
+	Ativo       bool                                 `json:"ativo"`
+	ImagemUrl   string                               `json:"image_url"`
+	Catalogo    []response_catalogo.CatalogoResponse `json:"catalogo"`
+	Agenda      []AgendaDiariaResponse               `json:"agenda"`
+	FusoHorario string                               `json:"fuso_horario"`
+	// TempoPreparo e TempoLimpeza só aparecem quando o prestador substitui os do serviço
+	TempoPreparo *int `json:"tempo_preparo,omitempty"`
+	TempoLimpeza *int `json:"tempo_limpeza,omitempty"`
+	// Condicoes lista os serviços em que o prestador pratica preço ou duração próprios
+	Condicoes []CondicaoDoServicoResponse `json:"condicoes_servico"`
+}
+
+type CondicaoDoServicoResponse struct {
+	CatalogoID    string `json:"catalogo_id"`
+	Preco         *int   `json:"preco,omitempty"`
+	DuracaoPadrao *int   `json:"duracao_padrao,omitempty"`
 }
 
 func FromPrestadorOutput(o output.BuscarPrestadorOutput) PrestadorResponse {
@@ -29,15 +41,28 @@ func FromPrestadorOutput(o output.BuscarPrestadorOutput) PrestadorResponse {
 		catalogo[i] = response_catalogo.FromCatalogoResponse(c)
 	}
 
+	condicoes := make([]CondicaoDoServicoResponse, len(o.Condicoes))
+	for i, c := range o.Condicoes {
+		condicoes[i] = CondicaoDoServicoResponse{
+			CatalogoID:    c.CatalogoID,
+			Preco:         c.Preco,
+			DuracaoPadrao: c.DuracaoPadrao,
+		}
+	}
+
 	return PrestadorResponse{
-		ID:        o.ID,
-		Nome:      o.Nome,
-		Email:     o.Email,
-		Cpf:       o.Cpf,
-		Telefone:  o.Telefone,
-		Ativo:     o.Ativo,
-		ImagemUrl: o.ImagemUrl,
-		Catalogo:  catalogo,
-		Agenda:    agenda,
+		ID:           o.ID,
+		Nome:         o.Nome,
+		Email:        o.Email,
+		Cpf:          o.Cpf,
+		Telefone:     o.Telefone,
+		Ativo:        o.Ativo,
+		ImagemUrl:    o.ImagemUrl,
+		Catalogo:     catalogo,
+		FusoHorario:  o.FusoHorario,
+		Agenda:       agenda,
+		TempoPreparo: o.TempoPreparo,
+		TempoLimpeza: o.TempoLimpeza,
+		Condicoes:    condicoes,
 	}
 }
@@ -0,0 +1,165 @@
+package resposta
+
+import (
+	"errors"
+	"net/http"
+
+	"meu-servico-agenda/internal/core/application/service"
+	"meu-servico-agenda/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CodigoFalhaInterna acompanha os erros sem mapeamento, respondidos com 500
+const CodigoFalhaInterna = "falha_interna"
+
+type mapeamento struct {
+	err    error
+	status int
+	codigo string
+}
+
+// mapeamentos é a única tradução de erro para status HTTP da API. Todo erro
+// sentinela do domínio e dos serviços aparece aqui com um código estável; o
+// mesmo erro tem o mesmo status em qualquer rota
+var mapeamentos = []mapeamento{
+	// domínio — prestador
+	{domain.ErrAgendaDuplicada, http.StatusConflict, "agenda_duplicada"},
+	{domain.ErrPrestadorInativo, http.StatusConflict, "prestador_inativo"},
+	{domain.ErrPrestadorDeveTerCatalogo, http.StatusBadRequest, "prestador_deve_ter_catalogo"},
+	{domain.ErrAgendaNaoEncontrada, http.StatusNotFound, "agenda_nao_encontrada"},
+	{domain.ErrFusoHorarioInvalido, http.StatusBadRequest, "fuso_horario_invalido"},
+	{domain.ErrCondicaoForaDoCatalogo, http.StatusBadRequest, "condicao_fora_do_catalogo"},
+
+	// domínio — cliente
+	{domain.ErrEmailJaCadastrado, http.StatusConflict, "email_ja_cadastrado"},
+	{domain.ErrClienteAnonimizado, http.StatusConflict, "cliente_anonimizado"},
+
+	// domínio — usuário
+	{domain.ErrPapelInvalido, http.StatusBadRequest, "papel_invalido"},
+	{domain.ErrVinculoUsuarioInvalido, http.StatusBadRequest, "vinculo_usuario_invalido"},
+	{domain.ErrUsuarioJaCadastrado, http.StatusConflict, "usuario_ja_cadastrado"},
+
+	// domínio — LGPD
+	{domain.ErrSolicitanteObrigatorio, http.StatusBadRequest, "solicitante_obrigatorio"},
+
+	// domínio — catálogo
+	{domain.ErrDuracaoInvalida, http.StatusBadRequest, "duracao_invalida"},
+	{domain.ErrPrecoInvalido, http.StatusBadRequest, "preco_invalido"},
+	{domain.ErrNomeInvalido, http.StatusBadRequest, "nome_invalido"},
+	{domain.ErrCategoriaInvalida, http.StatusBadRequest, "categoria_invalida"},
+	{domain.ErrTempoEntreAtendimentosInvalido, http.StatusBadRequest, "tempo_entre_atendimentos_invalido"},
+
+	// domínio — agendamento
+	{domain.ErrHoraInicialMenorQueFinal, http.StatusBadRequest, "hora_inicial_menor_que_final"},
+	{domain.ErrTransicaoStatusInvalida, http.StatusConflict, "transicao_status_invalida"},
+	{domain.ErrAgendamentoNaoReagendavel, http.StatusConflict, "agendamento_nao_reagendavel"},
+	{domain.ErrHorarioJaReservado, http.StatusConflict, "horario_ja_reservado"},
+
+	// domínio — série e visita
+	{domain.ErrIntervaloSerieInvalido, http.StatusBadRequest, "intervalo_serie_invalido"},
+	{domain.ErrOcorrenciasSerieInvalidas, http.StatusBadRequest, "ocorrencias_serie_invalidas"},
+	{domain.ErrItensVisitaInvalidos, http.StatusBadRequest, "itens_visita_invalidos"},
+	{domain.ErrServicoRepetidoNaVisita, http.StatusBadRequest, "servico_repetido_na_visita"},
+
+	// domínio — agenda diária e modelo de agenda
+	{domain.ErrAgendaSemIntervalos, http.StatusBadRequest, "agenda_sem_intervalos"},
+	{domain.ErrIntervaloHorarioInvalido, http.StatusBadRequest, "intervalo_horario_invalido"},
+	{domain.ErrDataEstaNoPassado, http.StatusBadRequest, "data_no_passado"},
+	{domain.ErrIntervalosSesobrepoe, http.StatusBadRequest, "intervalos_sobrepostos"},
+	{domain.ErrModeloSemDiasDaSemana, http.StatusBadRequest, "modelo_sem_dias_da_semana"},
+	{domain.ErrDiaDaSemanaInvalido, http.StatusBadRequest, "dia_da_semana_invalido"},
+	{domain.ErrVigenciaInvalida, http.StatusBadRequest, "vigencia_invalida"},
+
+	// domínio — lista de espera
+	{domain.ErrJanelaEsperaInvalida, http.StatusBadRequest, "janela_espera_invalida"},
+	{domain.ErrSemOfertaPendente, http.StatusConflict, "sem_oferta_pendente"},
+	{domain.ErrOfertaExpirada, http.StatusConflict, "oferta_expirada"},
+	{domain.ErrTransicaoListaEsperaInvalida, http.StatusConflict, "transicao_lista_espera_invalida"},
+
+	// serviço — infraestrutura e prazo
+	{service.ErrFalhaInfraestrutura, http.StatusInternalServerError, "falha_infraestrutura"},
+	{service.ErrTempoEsgotado, http.StatusGatewayTimeout, "tempo_esgotado"},
+	{service.ErrRequisicaoCancelada, http.StatusServiceUnavailable, "requisicao_cancelada"},
+
+	// serviço — catálogo
+	{service.ErrCatalogoNaoExiste, http.StatusNotFound, "catalogo_nao_existe"},
+	{service.ErrCatalogoNaoEncontrado, http.StatusNotFound, "catalogo_nao_encontrado"},
+	{service.ErrCatalogoInvalido, http.StatusBadRequest, "catalogo_invalido"},
+
+	// serviço — prestador
+	{service.ErrCPFJaCadastrado, http.StatusConflict, "cpf_ja_cadastrado"},
+	{service.ErrPrestadorNaoEncontrado, http.StatusNotFound, "prestador_nao_encontrado"},
+	{service.ErrPrestadorInvalido, http.StatusBadRequest, "prestador_invalido"},
+	{service.ErrPrestadorNaoExiste, http.StatusNotFound, "prestador_nao_existe"},
+	{service.ErrPrestadorOcupado, http.StatusConflict, "prestador_ocupado"},
+	{service.ErrPrestadorInativo, http.StatusConflict, "prestador_inativo"},
+	{service.ErrFormatoDataInvalido, http.StatusBadRequest, "formato_data_invalido"},
+	{service.ErrAoBuscarPrestadoresDisponiveis, http.StatusInternalServerError, "erro_ao_buscar_prestadores_disponiveis"},
+	{service.ErrAoContarPrestadoresDisponiveis, http.StatusInternalServerError, "erro_ao_contar_prestadores_disponiveis"},
+
+	// serviço — cliente
+	{service.ErrClienteNaoEncontrado, http.StatusNotFound, "cliente_nao_encontrado"},
+	{service.ErrAoSalvarCliente, http.StatusInternalServerError, "erro_ao_salvar_cliente"},
+	{service.ErrClienteInvalido, http.StatusBadRequest, "cliente_invalido"},
+	{service.ErrClienteNaoExiste, http.StatusNotFound, "cliente_nao_existe"},
+	{service.ErrClienteOcupado, http.StatusConflict, "cliente_ocupado"},
+	{service.ErrClienteInativo, http.StatusConflict, "cliente_inativo"},
+
+	// serviço — agendamento
+	{service.ErrDataHoraInvalida, http.StatusBadRequest, "data_hora_invalida"},
+	{service.ErrHorarioIndisponivel, http.StatusConflict, "horario_indisponivel"},
+	{service.ErrDiaIndisponivel, http.StatusConflict, "dia_indisponivel"},
+	{service.ErrAgendaDuplicada, http.StatusConflict, "agenda_duplicada"},
+	{service.ErrAgendaNaoEncontrada, http.StatusNotFound, "agenda_nao_encontrada"},
+	{service.ErrAgendaJaExiste, http.StatusConflict, "agenda_ja_existe"},
+	{service.ErrAgendamentoDuplo, http.StatusConflict, "agendamento_duplo"},
+	{service.ErrAgendamentoNaoEncontrado, http.StatusNotFound, "agendamento_nao_encontrado"},
+	{service.ErrSerieNaoEncontrada, http.StatusNotFound, "serie_nao_encontrada"},
+	{service.ErrVisitaNaoEncontrada, http.StatusNotFound, "visita_nao_encontrada"},
+
+	// serviço — modelo de agenda
+	{service.ErrModeloAgendaNaoEncontrado, http.StatusNotFound, "modelo_agenda_nao_encontrado"},
+	{service.ErrPeriodoGeracaoInvalido, http.StatusBadRequest, "periodo_geracao_invalido"},
+
+	// serviço — usuário
+	{service.ErrCredenciaisInvalidas, http.StatusUnauthorized, "credenciais_invalidas"},
+	{service.ErrSenhaInvalida, http.StatusBadRequest, "senha_invalida"},
+	{service.ErrRefreshTokenInvalido, http.StatusUnauthorized, "refresh_token_invalido"},
+
+	// serviço — lista de espera
+	{service.ErrEntradaListaEsperaNaoEncontrada, http.StatusNotFound, "entrada_lista_espera_nao_encontrada"},
+	{service.ErrVagaReservada, http.StatusConflict, "vaga_reservada"},
+
+	// serviço — calendário
+	{service.ErrCalendarioNaoEncontrado, http.StatusNotFound, "calendario_nao_encontrado"},
+}
+
+// Classificar devolve o status e o código de um erro conhecido. ok é falso
+// para erros sem mapeamento, que a API trata como falha interna
+func Classificar(err error) (status int, codigo string, ok bool) {
+	m, ok := classificar(err)
+	return m.status, m.codigo, ok
+}
+
+func classificar(err error) (mapeamento, bool) {
+	for _, m := range mapeamentos {
+		if errors.Is(err, m.err) {
+			return m, true
+		}
+	}
+	return mapeamento{}, false
+}
+
+// Erro responde um erro devolvido pelos serviços. Erros conhecidos de 4xx usam
+// a mensagem traduzida como detail; os demais passam por FalhaInterna, que não
+// expõe a causa
+func Erro(c *gin.Context, err error) {
+	m, ok := classificar(err)
+	if !ok || m.status >= http.StatusInternalServerError {
+		FalhaInterna(c, err)
+		return
+	}
+
+	Escrever(c, NovoProblema(c, m.status, m.codigo, traduzirErro(c, m.err, m.codigo, err)))
+}
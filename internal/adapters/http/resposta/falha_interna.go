@@ -0,0 +1,42 @@
+package resposta
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FalhaInterna registra a causa real do erro e responde 500 sem expô-la. O
+// request_id da resposta é o mesmo do log, para o suporte encontrar a ocorrência.
+// O code é o do erro quando ele é um sentinela de 5xx e falha_interna nos demais
+// casos. Erros causados pelo fim do prazo ou pela desistência do cliente viram
+// 504 e 503
+func FalhaInterna(c *gin.Context, err error) {
+	ctx := c.Request.Context()
+	if motivo, ok := PrazoEsgotado(ctx, err); ok {
+		problema := Interrompida(c, motivo)
+		slog.WarnContext(ctx, "requisição interrompida",
+			slog.String("method", c.Request.Method),
+			slog.String("route", c.FullPath()),
+			slog.Int("status", problema.Status),
+			slog.Any("erro", err),
+		)
+		_ = c.Error(err)
+		Escrever(c, problema)
+		return
+	}
+
+	slog.ErrorContext(ctx, "falha interna",
+		slog.String("method", c.Request.Method),
+		slog.String("route", c.FullPath()),
+		slog.Any("erro", err),
+	)
+	_ = c.Error(err)
+
+	codigo := CodigoFalhaInterna
+	if status, conhecido, ok := Classificar(err); ok && status >= http.StatusInternalServerError {
+		codigo = conhecido
+	}
+	Escrever(c, NovoProblema(c, http.StatusInternalServerError, codigo, Mensagem(c, CodigoFalhaInterna)))
+}
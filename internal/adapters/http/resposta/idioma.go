@@ -0,0 +1,79 @@
+package resposta
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+// IdiomaPadrao responde quem não envia Accept-Language ou pede um idioma sem catálogo
+var IdiomaPadrao = language.BrazilianPortuguese
+
+// idiomas são os catálogos disponíveis; o primeiro é o padrão do matcher
+var idiomas = []language.Tag{language.BrazilianPortuguese, language.English}
+
+var escolhaDeIdioma = language.NewMatcher(idiomas)
+
+var catalogos = map[language.Tag]map[string]string{
+	language.BrazilianPortuguese: mensagensPtBR,
+	language.English:             mensagensEn,
+}
+
+// Idioma escolhe, pelo Accept-Language da requisição, o catálogo usado nas mensagens.
+// Os códigos de erro não mudam com o idioma
+func Idioma(c *gin.Context) language.Tag {
+	pedidos, _, err := language.ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+	if err != nil || len(pedidos) == 0 {
+		return IdiomaPadrao
+	}
+	_, indice, confianca := escolhaDeIdioma.Match(pedidos...)
+	if confianca == language.No {
+		return IdiomaPadrao
+	}
+	return idiomas[indice]
+}
+
+// Traduzir devolve a mensagem do código no idioma. ok é falso quando o
+// catálogo do idioma não tem o código
+func Traduzir(idioma language.Tag, codigo string) (mensagem string, ok bool) {
+	mensagem, ok = catalogos[idioma][codigo]
+	return mensagem, ok
+}
+
+// Chaves lista os códigos e regras de validação com mensagem no catálogo do idioma
+func Chaves(idioma language.Tag) []string {
+	chaves := make([]string, 0, len(catalogos[idioma]))
+	for chave := range catalogos[idioma] {
+		chaves = append(chaves, chave)
+	}
+	sort.Strings(chaves)
+	return chaves
+}
+
+// Mensagem traduz o código para o idioma da requisição, recorrendo ao
+// português e, por fim, ao próprio código
+func Mensagem(c *gin.Context, codigo string) string {
+	if mensagem, ok := Traduzir(Idioma(c), codigo); ok {
+		return mensagem
+	}
+	if mensagem, ok := Traduzir(IdiomaPadrao, codigo); ok {
+		return mensagem
+	}
+	return codigo
+}
+
+// traduzirErro troca a mensagem do sentinela pela do catálogo e mantém o
+// contexto acrescentado por quem embrulhou o erro, como "item 2: ..."
+func traduzirErro(c *gin.Context, sentinela error, codigo string, err error) string {
+	mensagem, ok := Traduzir(Idioma(c), codigo)
+	if !ok {
+		return err.Error()
+	}
+	if errors.Is(err, sentinela) && strings.Contains(err.Error(), sentinela.Error()) {
+		return strings.Replace(err.Error(), sentinela.Error(), mensagem, 1)
+	}
+	return mensagem
+}
@@ -0,0 +1,120 @@
+package resposta
+
+// mensagensEn é o catálogo em inglês. As chaves são os códigos de erro da API,
+// que não mudam com o idioma
+var mensagensEn = map[string]string{
+	// requisição e validação
+	"falha_interna":        "internal failure",
+	"dados_invalidos":      "one or more fields are invalid",
+	"corpo_invalido":       "the request body is not valid JSON",
+	"rota_nao_encontrada":  "route not found",
+	"tempo_esgotado":       "request timed out",
+	"requisicao_cancelada": "request canceled",
+	"falha_infraestrutura": "infrastructure failure",
+
+	// autenticação e idempotência
+	"nao_autenticado":                "send the access token in the Authorization header: Bearer <token>",
+	"token_invalido":                 "invalid or expired access token",
+	"acesso_negado":                  "user is not allowed to perform this operation",
+	"chave_idempotencia_invalida":    "Idempotency-Key must be between 1 and 255 characters",
+	"chave_idempotencia_reutilizada": "Idempotency-Key already used with a different request body",
+	"requisicao_em_andamento":        "a request with this Idempotency-Key is still in progress",
+	"credenciais_invalidas":          "invalid email or password",
+	"senha_invalida":                 "password must be 8 to 72 characters long",
+	"refresh_token_invalido":         "invalid, expired or revoked refresh token",
+	"papel_invalido":                 "invalid role, use admin, prestador or cliente",
+	"vinculo_usuario_invalido":       "cliente users must provide cliente_id, prestador users must provide prestador_id and admins have no link",
+	"usuario_ja_cadastrado":          "email already registered for another user",
+
+	// cliente e LGPD
+	"cliente_nao_encontrado":  "customer not found",
+	"cliente_nao_existe":      "customer not found",
+	"cliente_invalido":        "invalid customer",
+	"cliente_inativo":         "customer is inactive",
+	"cliente_ocupado":         "customer already has an appointment at this time",
+	"cliente_anonimizado":     "customer anonymized at the data subject's request cannot be changed",
+	"email_ja_cadastrado":     "email already registered for another customer",
+	"erro_ao_salvar_cliente":  "failed to save customer",
+	"solicitante_obrigatorio": "tell who is making the request",
+
+	// prestador e agenda
+	"prestador_nao_encontrado":               "provider not found",
+	"prestador_nao_existe":                   "provider not found",
+	"prestador_invalido":                     "invalid provider",
+	"prestador_inativo":                      "provider is inactive",
+	"prestador_ocupado":                      "provider already has an appointment at this time",
+	"prestador_deve_ter_catalogo":            "provider must offer at least one catalog service",
+	"cpf_ja_cadastrado":                      "CPF already registered",
+	"fuso_horario_invalido":                  "invalid time zone, use an IANA name (e.g. America/Sao_Paulo)",
+	"condicao_fora_do_catalogo":              "price and duration can only be set for services the provider offers",
+	"formato_data_invalido":                  "invalid date format, use YYYY-MM-DD",
+	"erro_ao_buscar_prestadores_disponiveis": "failed to fetch available providers",
+	"erro_ao_contar_prestadores_disponiveis": "failed to count available providers",
+	"agenda_duplicada":                       "duplicate daily schedule",
+	"agenda_nao_encontrada":                  "schedule not found",
+	"agenda_ja_existe":                       "schedule already exists",
+	"agenda_sem_intervalos":                  "schedule must contain at least one time range",
+	"intervalo_horario_invalido":             "start time must be before end time",
+	"intervalos_sobrepostos":                 "time ranges cannot overlap",
+	"data_no_passado":                        "the date is in the past",
+	"modelo_agenda_nao_encontrado":           "schedule template not found",
+	"modelo_sem_dias_da_semana":              "schedule template must contain at least one weekday",
+	"dia_da_semana_invalido":                 "invalid or repeated weekday",
+	"vigencia_invalida":                      "validity start must be before its end",
+	"periodo_geracao_invalido":               "invalid generation period: the end date must be after the start date and the period cannot exceed 366 days",
+
+	// catálogo
+	"catalogo_nao_existe":               "catalog service does not exist",
+	"catalogo_nao_encontrado":           "catalog service not found",
+	"catalogo_invalido":                 "invalid catalog service",
+	"nome_invalido":                     "invalid name",
+	"categoria_invalida":                "invalid category",
+	"duracao_invalida":                  "invalid default duration",
+	"preco_invalido":                    "invalid price",
+	"tempo_entre_atendimentos_invalido": "preparation and cleanup time must be between 0 and 240 minutes",
+
+	// agendamento, série e visita
+	"agendamento_nao_encontrado":   "appointment not found",
+	"agendamento_duplo":            "there is already an appointment for this category on this day",
+	"agendamento_nao_reagendavel":  "only pending or confirmed appointments can be rescheduled",
+	"transicao_status_invalida":    "invalid appointment status transition",
+	"hora_inicial_menor_que_final": "start time must be before the end",
+	"horario_ja_reservado":         "time slot already booked for the provider",
+	"horario_indisponivel":         "time slot unavailable",
+	"dia_indisponivel":             "day unavailable for appointments",
+	"data_hora_invalida":           "invalid appointment date/time",
+	"serie_nao_encontrada":         "appointment series not found",
+	"intervalo_serie_invalido":     "series interval must be 1 to 4 weeks",
+	"ocorrencias_serie_invalidas":  "series must have 2 to 52 occurrences",
+	"visita_nao_encontrada":        "visit not found",
+	"itens_visita_invalidos":       "visit must have 1 to 10 services",
+	"servico_repetido_na_visita":   "a visit cannot repeat the same service",
+
+	// lista de espera
+	"entrada_lista_espera_nao_encontrada": "waitlist entry not found",
+	"janela_espera_invalida":              "waiting window start must be before its end",
+	"sem_oferta_pendente":                 "there is no slot offered to this waitlist entry",
+	"oferta_expirada":                     "the deadline to accept the offered slot has expired",
+	"transicao_lista_espera_invalida":     "invalid waitlist status transition",
+	"vaga_reservada":                      "time slot reserved for a waitlist customer",
+
+	// calendário
+	"calendario_nao_encontrado": "calendar not found",
+
+	// regras de validação dos campos; %s recebe o parâmetro da regra
+	"validacao.required":   "field is required",
+	"validacao.email":      "must be a valid email",
+	"validacao.url":        "must be a valid URL",
+	"validacao.numeric":    "must contain only digits",
+	"validacao.len":        "must be exactly %s characters long",
+	"validacao.min.texto":  "must be at least %s characters long",
+	"validacao.min.lista":  "must have at least %s item(s)",
+	"validacao.min.numero": "must be greater than or equal to %s",
+	"validacao.max.texto":  "must be at most %s characters long",
+	"validacao.max.lista":  "must have at most %s item(s)",
+	"validacao.max.numero": "must be less than or equal to %s",
+	"validacao.oneof":      "must be one of: %s",
+	"validacao.datetime":   "must follow the format %s",
+	"validacao.tipo":       "must be of type %s",
+	"validacao.outra":      "does not satisfy the %s rule",
+}
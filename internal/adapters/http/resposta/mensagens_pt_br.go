@@ -0,0 +1,120 @@
+package resposta
+
+// mensagensPtBR é o catálogo em português do Brasil, o idioma padrão. As chaves são os códigos de erro da API,
+// que não mudam com o idioma
+var mensagensPtBR = map[string]string{
+	// requisição e validação
+	"falha_interna":        "falha na infraestrutura",
+	"dados_invalidos":      "um ou mais campos são inválidos",
+	"corpo_invalido":       "o corpo da requisição não é um JSON válido",
+	"rota_nao_encontrada":  "rota não encontrada",
+	"tempo_esgotado":       "tempo limite da requisição esgotado",
+	"requisicao_cancelada": "requisição cancelada",
+	"falha_infraestrutura": "falha na infraestrutura",
+
+	// autenticação e idempotência
+	"nao_autenticado":                "informe o token de acesso no cabeçalho Authorization: Bearer <token>",
+	"token_invalido":                 "token de acesso inválido ou expirado",
+	"acesso_negado":                  "usuário sem permissão para esta operação",
+	"chave_idempotencia_invalida":    "Idempotency-Key deve ter entre 1 e 255 caracteres",
+	"chave_idempotencia_reutilizada": "Idempotency-Key já utilizada com outro corpo de requisição",
+	"requisicao_em_andamento":        "requisição com esta Idempotency-Key ainda está em andamento",
+	"credenciais_invalidas":          "email ou senha inválidos",
+	"senha_invalida":                 "senha deve ter de 8 a 72 caracteres",
+	"refresh_token_invalido":         "refresh token inválido, expirado ou revogado",
+	"papel_invalido":                 "papel inválido, use admin, prestador ou cliente",
+	"vinculo_usuario_invalido":       "usuário cliente deve informar cliente_id, usuário prestador deve informar prestador_id e admin não tem vínculo",
+	"usuario_ja_cadastrado":          "email já cadastrado para outro usuário",
+
+	// cliente e LGPD
+	"cliente_nao_encontrado":  "cliente não encontrado",
+	"cliente_nao_existe":      "cliente não encontrado",
+	"cliente_invalido":        "cliente inválido",
+	"cliente_inativo":         "cliente está inativo",
+	"cliente_ocupado":         "cliente já possui agendamento neste horário",
+	"cliente_anonimizado":     "cliente anonimizado a pedido do titular não pode ser alterado",
+	"email_ja_cadastrado":     "email já cadastrado para outro cliente",
+	"erro_ao_salvar_cliente":  "falha ao salvar cliente",
+	"solicitante_obrigatorio": "informe quem está realizando a solicitação",
+
+	// prestador e agenda
+	"prestador_nao_encontrado":               "prestador não encontrado",
+	"prestador_nao_existe":                   "prestador não encontrado",
+	"prestador_invalido":                     "prestador inválido",
+	"prestador_inativo":                      "prestador está inativo",
+	"prestador_ocupado":                      "prestador já possui agendamento neste horário",
+	"prestador_deve_ter_catalogo":            "prestador deve ter ao menos um catálogo de serviços",
+	"cpf_ja_cadastrado":                      "cpf já possui um cadastro",
+	"fuso_horario_invalido":                  "fuso horário inválido, use um nome IANA (ex: America/Sao_Paulo)",
+	"condicao_fora_do_catalogo":              "preço e duração só podem ser definidos para serviços oferecidos pelo prestador",
+	"formato_data_invalido":                  "formato de data inválido, use AAAA-MM-DD",
+	"erro_ao_buscar_prestadores_disponiveis": "erro ao buscar prestadores disponíveis",
+	"erro_ao_contar_prestadores_disponiveis": "erro ao contar prestadores disponíveis",
+	"agenda_duplicada":                       "agenda diária duplicada",
+	"agenda_nao_encontrada":                  "agenda não encontrada",
+	"agenda_ja_existe":                       "agenda já existe",
+	"agenda_sem_intervalos":                  "agenda deve conter ao menos um intervalo",
+	"intervalo_horario_invalido":             "hora início deve ser menor que hora fim",
+	"intervalos_sobrepostos":                 "intervalos de horário não podem se sobrepor",
+	"data_no_passado":                        "a data está no passado",
+	"modelo_agenda_nao_encontrado":           "modelo de agenda não encontrado",
+	"modelo_sem_dias_da_semana":              "modelo de agenda deve conter ao menos um dia da semana",
+	"dia_da_semana_invalido":                 "dia da semana inválido ou repetido",
+	"vigencia_invalida":                      "início da vigência deve ser antes do fim",
+	"periodo_geracao_invalido":               "período de geração inválido: a data final deve ser posterior à inicial e o período não pode passar de 366 dias",
+
+	// catálogo
+	"catalogo_nao_existe":               "catálogo não existe",
+	"catalogo_nao_encontrado":           "catálogo não encontrado",
+	"catalogo_invalido":                 "catálogo inválido",
+	"nome_invalido":                     "nome inválido",
+	"categoria_invalida":                "categoria inválida",
+	"duracao_invalida":                  "duração padrão inválida",
+	"preco_invalido":                    "preço inválido",
+	"tempo_entre_atendimentos_invalido": "tempo de preparo e de limpeza deve ser de 0 a 240 minutos",
+
+	// agendamento, série e visita
+	"agendamento_nao_encontrado":   "agendamento não encontrado",
+	"agendamento_duplo":            "já existe um agendamento para essa categoria neste dia",
+	"agendamento_nao_reagendavel":  "somente agendamentos pendentes ou confirmados podem ser reagendados",
+	"transicao_status_invalida":    "transição de status do agendamento inválida",
+	"hora_inicial_menor_que_final": "horário início deve ser antes do fim",
+	"horario_ja_reservado":         "horário já reservado para o prestador",
+	"horario_indisponivel":         "horário indisponível",
+	"dia_indisponivel":             "dia indisponível para agendamentos",
+	"data_hora_invalida":           "data/hora de agendamento inválida",
+	"serie_nao_encontrada":         "série de agendamentos não encontrada",
+	"intervalo_serie_invalido":     "intervalo da série deve ser de 1 a 4 semanas",
+	"ocorrencias_serie_invalidas":  "série deve ter de 2 a 52 ocorrências",
+	"visita_nao_encontrada":        "visita não encontrada",
+	"itens_visita_invalidos":       "visita deve ter de 1 a 10 serviços",
+	"servico_repetido_na_visita":   "a visita não pode repetir o mesmo serviço",
+
+	// lista de espera
+	"entrada_lista_espera_nao_encontrada": "entrada da lista de espera não encontrada",
+	"janela_espera_invalida":              "início da janela de espera deve ser antes do fim",
+	"sem_oferta_pendente":                 "não há vaga oferecida para esta entrada da lista de espera",
+	"oferta_expirada":                     "prazo para aceitar a vaga oferecida expirou",
+	"transicao_lista_espera_invalida":     "transição de status da lista de espera inválida",
+	"vaga_reservada":                      "horário reservado para um cliente da lista de espera",
+
+	// calendário
+	"calendario_nao_encontrado": "calendário não encontrado",
+
+	// regras de validação dos campos; %s recebe o parâmetro da regra
+	"validacao.required":   "campo obrigatório",
+	"validacao.email":      "deve ser um email válido",
+	"validacao.url":        "deve ser uma URL válida",
+	"validacao.numeric":    "deve conter apenas números",
+	"validacao.len":        "deve ter exatamente %s caracteres",
+	"validacao.min.texto":  "deve ter ao menos %s caracteres",
+	"validacao.min.lista":  "deve ter ao menos %s item(ns)",
+	"validacao.min.numero": "deve ser maior ou igual a %s",
+	"validacao.max.texto":  "deve ter no máximo %s caracteres",
+	"validacao.max.lista":  "deve ter no máximo %s item(ns)",
+	"validacao.max.numero": "deve ser menor ou igual a %s",
+	"validacao.oneof":      "deve ser um de: %s",
+	"validacao.datetime":   "deve seguir o formato %s",
+	"validacao.tipo":       "deve ser do tipo %s",
+	"validacao.outra":      "não atende à regra %s",
+}
@@ -0,0 +1,36 @@
+package resposta
+
+import (
+	"context"
+	"errors"
+
+	"meu-servico-agenda/internal/core/application/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PrazoEsgotado diz se a requisição foi interrompida pelo contexto e por quê:
+// ErrTempoEsgotado quando o prazo acabou e ErrRequisicaoCancelada quando o
+// cliente desistiu antes do fim. ok é falso quando nem o erro nem o contexto
+// indicam interrupção
+func PrazoEsgotado(ctx context.Context, err error) (motivo error, ok bool) {
+	causa := ctx.Err()
+	if causa == nil && err != nil {
+		causa = err
+	}
+
+	switch {
+	case errors.Is(causa, context.DeadlineExceeded):
+		return service.ErrTempoEsgotado, true
+	case errors.Is(causa, context.Canceled):
+		return service.ErrRequisicaoCancelada, true
+	default:
+		return nil, false
+	}
+}
+
+// Interrompida monta o problema de uma requisição interrompida pelo contexto
+func Interrompida(c *gin.Context, motivo error) Problema {
+	status, codigo, _ := Classificar(motivo)
+	return NovoProblema(c, status, codigo, Mensagem(c, codigo))
+}
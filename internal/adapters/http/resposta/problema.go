@@ -0,0 +1,55 @@
+package resposta
+
+import (
+	"net/http"
+
+	"meu-servico-agenda/internal/infra/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContentTypeProblema é o tipo de mídia das respostas de erro (RFC 7807)
+const ContentTypeProblema = "application/problem+json"
+
+// Problema é o corpo de todas as respostas de erro da API. Code é estável e
+// é o que o consumidor deve usar para decidir o que fazer; Detail é texto para
+// pessoas e pode mudar
+type Problema struct {
+	Type     string `json:"type" example:"about:blank"`
+	Title    string `json:"title" example:"Not Found"`
+	Status   int    `json:"status" example:"404"`
+	Detail   string `json:"detail" example:"cliente não encontrado"`
+	Instance string `json:"instance,omitempty" example:"/clientes/8f14e45f"`
+	Code     string `json:"code" example:"cliente_nao_encontrado"`
+	// RequestID é o mesmo registrado no log, para o suporte encontrar a ocorrência
+	RequestID     string              `json:"request_id,omitempty" example:"9b2c6e1f4a7d3e58"`
+	InvalidParams []ParametroInvalido `json:"invalid_params,omitempty"`
+}
+
+// ParametroInvalido descreve um campo rejeitado na validação da requisição
+type ParametroInvalido struct {
+	Name   string `json:"name" example:"email"`
+	Code   string `json:"code" example:"email"`
+	Reason string `json:"reason" example:"deve ser um email válido"`
+}
+
+// NovoProblema monta o corpo de erro da requisição atual. detalhe já deve
+// estar no idioma da requisição; veja Mensagem
+func NovoProblema(c *gin.Context, status int, codigo, detalhe string) Problema {
+	return Problema{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detalhe,
+		Instance:  c.Request.URL.Path,
+		Code:      codigo,
+		RequestID: logging.RequestID(c.Request.Context()),
+	}
+}
+
+// Escrever interrompe a cadeia de handlers respondendo com o problema
+func Escrever(c *gin.Context, p Problema) {
+	c.Header("Content-Type", ContentTypeProblema)
+	c.Header("Content-Language", Idioma(c).String())
+	c.AbortWithStatusJSON(p.Status, p)
+}
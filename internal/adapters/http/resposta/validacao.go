@@ -0,0 +1,128 @@
+package resposta
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"meu-servico-agenda/internal/core/application/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// CodigoDadosInvalidos acompanha as requisições rejeitadas na validação
+const CodigoDadosInvalidos = "dados_invalidos"
+
+// Os erros do validator passam a usar o nome do campo como o cliente o envia
+// (json, form, header ou uri) em vez do nome do campo na struct Go
+func init() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(func(campo reflect.StructField) string {
+		for _, tag := range []string{"json", "form", "header", "uri"} {
+			nome, _, _ := strings.Cut(campo.Tag.Get(tag), ",")
+			if nome == "-" {
+				return ""
+			}
+			if nome != "" {
+				return nome
+			}
+		}
+		return campo.Name
+	})
+}
+
+// DadosInvalidos responde 400 para uma requisição que não passou no binding
+// ou na conversão para o input do serviço. Erros do validator viram
+// invalid_params, um por campo; erros conhecidos seguem o mapeamento central
+func DadosInvalidos(c *gin.Context, err error) {
+	if _, _, ok := Classificar(err); ok {
+		Erro(c, err)
+		return
+	}
+
+	var (
+		validacao validator.ValidationErrors
+		tipo      *json.UnmarshalTypeError
+		sintaxe   *json.SyntaxError
+		data      *time.ParseError
+	)
+	switch {
+	case errors.As(err, &validacao):
+		problema := NovoProblema(c, http.StatusBadRequest, CodigoDadosInvalidos, Mensagem(c, CodigoDadosInvalidos))
+		for _, fe := range validacao {
+			problema.InvalidParams = append(problema.InvalidParams, ParametroInvalido{
+				Name:   nomeDoCampo(fe),
+				Code:   fe.Tag(),
+				Reason: motivo(c, fe),
+			})
+		}
+		Escrever(c, problema)
+
+	case errors.As(err, &tipo):
+		problema := NovoProblema(c, http.StatusBadRequest, CodigoDadosInvalidos, Mensagem(c, CodigoDadosInvalidos))
+		problema.InvalidParams = []ParametroInvalido{{
+			Name:   tipo.Field,
+			Code:   "tipo",
+			Reason: fmt.Sprintf(Mensagem(c, "validacao.tipo"), tipo.Type),
+		}}
+		Escrever(c, problema)
+
+	case errors.As(err, &sintaxe), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		Escrever(c, NovoProblema(c, http.StatusBadRequest, "corpo_invalido", Mensagem(c, "corpo_invalido")))
+
+	case errors.As(err, &data):
+		Erro(c, fmt.Errorf("%w: %w", service.ErrFormatoDataInvalido, err))
+
+	default:
+		detalhe := Mensagem(c, CodigoDadosInvalidos) + ": " + err.Error()
+		Escrever(c, NovoProblema(c, http.StatusBadRequest, CodigoDadosInvalidos, detalhe))
+	}
+}
+
+// nomeDoCampo devolve o caminho do campo sem a struct raiz, como
+// "intervalos[0].hora_inicio"
+func nomeDoCampo(fe validator.FieldError) string {
+	if _, caminho, ok := strings.Cut(fe.Namespace(), "."); ok {
+		return caminho
+	}
+	return fe.Field()
+}
+
+// motivo traduz a regra que o campo não atendeu. Regras sem mensagem própria
+// usam a genérica, com o nome da regra
+func motivo(c *gin.Context, fe validator.FieldError) string {
+	chave := "validacao." + fe.Tag()
+	if fe.Tag() == "min" || fe.Tag() == "max" {
+		switch fe.Kind() {
+		case reflect.String:
+			chave += ".texto"
+		case reflect.Slice, reflect.Map, reflect.Array:
+			chave += ".lista"
+		default:
+			chave += ".numero"
+		}
+	}
+
+	mensagem, ok := Traduzir(Idioma(c), chave)
+	if !ok {
+		return fmt.Sprintf(Mensagem(c, "validacao.outra"), fe.Tag())
+	}
+	if !strings.Contains(mensagem, "%s") {
+		return mensagem
+	}
+	param := fe.Param()
+	if fe.Tag() == "oneof" {
+		param = strings.ReplaceAll(param, " ", ", ")
+	}
+	return fmt.Sprintf(mensagem, param)
+}
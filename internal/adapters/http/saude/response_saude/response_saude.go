@@ -0,0 +1,7 @@
+package response_saude
+
+type SaudeResponse struct {
+	Status string `json:"status" example:"ok"`
+	// Verificacoes traz o resultado de cada dependência conferida pelo /readyz
+	Verificacoes map[string]string `json:"verificacoes,omitempty"`
+}
@@ -0,0 +1,59 @@
+package saude
+
+import (
+	"context"
+	"meu-servico-agenda/internal/adapters/http/saude/response_saude"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	StatusOk           = "ok"
+	StatusIndisponivel = "indisponivel"
+
+	// TempoMaximoVerificacao evita que o /readyz fique preso num banco travado
+	TempoMaximoVerificacao = 2 * time.Second
+)
+
+// VerificadorBanco é atendido pelo *sql.DB
+type VerificadorBanco interface {
+	PingContext(ctx context.Context) error
+}
+
+type SaudeController struct {
+	banco VerificadorBanco
+}
+
+func NovoSaudeController(banco VerificadorBanco) *SaudeController {
+	return &SaudeController{
+		banco: banco,
+	}
+}
+
+// GetHealthz responde enquanto o processo estiver de pé, sem consultar
+// dependências. É a liveness probe; fica fora do /api/v1 e do Swagger como o /ping
+func (sc *SaudeController) GetHealthz(c *gin.Context) {
+	c.JSON(http.StatusOK, response_saude.SaudeResponse{Status: StatusOk})
+}
+
+// GetReadyz confere a conexão com o banco e responde 503 enquanto ela não
+// estiver disponível. É a readiness probe
+func (sc *SaudeController) GetReadyz(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), TempoMaximoVerificacao)
+	defer cancel()
+
+	if err := sc.banco.PingContext(ctx); err != nil {
+		c.JSON(http.StatusServiceUnavailable, response_saude.SaudeResponse{
+			Status:       StatusIndisponivel,
+			Verificacoes: map[string]string{"banco": StatusIndisponivel},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response_saude.SaudeResponse{
+		Status:       StatusOk,
+		Verificacoes: map[string]string{"banco": StatusOk},
+	})
+}
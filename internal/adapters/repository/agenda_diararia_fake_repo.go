@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"meu-servico-agenda/internal/core/application/port"
@@ -17,14 +18,14 @@ func NovoFakeAgendaDiariaRepositorio() port.AgendaDiariaRepositorio {
 	}
 }
 
-func (r *FakeAgendaDiariaRepositorio) Salvar(agenda *domain.AgendaDiaria, prestadorId string) error {
+func (r *FakeAgendaDiariaRepositorio) Salvar(ctx context.Context, agenda *domain.AgendaDiaria, prestadorId string) error {
 	// Cria chave única: prestadorID + data
 	chave := fmt.Sprintf("%s:%s", prestadorId, agenda.Data)
 	r.storage[chave] = agenda
 	return nil
 }
 
-func (r *FakeAgendaDiariaRepositorio) BuscarAgendaDoDia(prestadorID string, data string) (*domain.AgendaDiaria, error) {
+func (r *FakeAgendaDiariaRepositorio) BuscarAgendaDoDia(ctx context.Context, prestadorID string, data string) (*domain.AgendaDiaria, error) {
 	// Cria chave única: prestadorID + data
 	chave := fmt.Sprintf("%s:%s", prestadorID, data)
 	
@@ -37,7 +38,7 @@ func (r *FakeAgendaDiariaRepositorio) BuscarAgendaDoDia(prestadorID string, data
 	return agenda, nil
 }
 
-func (r *FakeAgendaDiariaRepositorio) AtualizarAgenda(agenda *domain.AgendaDiaria, prestadorID string) error {
+func (r *FakeAgendaDiariaRepositorio) AtualizarAgenda(ctx context.Context, agenda *domain.AgendaDiaria, prestadorID string) error {
 	// Cria chave única: prestadorID + data
 	chave := fmt.Sprintf("%s:%s", prestadorID, agenda.Data)
 
@@ -50,4 +51,21 @@ func (r *FakeAgendaDiariaRepositorio) AtualizarAgenda(agenda *domain.AgendaDiari
 	r.storage[chave] = agenda
 
 	return nil
-}
\ No newline at end of file
+}
+
+func (r *FakeAgendaDiariaRepositorio) DeletarAgenda(ctx context.Context, prestadorID string, data string) error {
+	chave := fmt.Sprintf("%s:%s", prestadorID, data)
+	
+	if _, exists := r.storage[chave]; !exists {
+		return sql.ErrNoRows
+	}
+
+	delete(r.storage, chave)
+	return nil
+}
+
+// salvarEstado permite que a FakeUnidadeDeTrabalho desfaça as alterações
+func (r *FakeAgendaDiariaRepositorio) salvarEstado() func() {
+	restaurar := salvarMapa(r.storage)
+	return func() { r.storage = restaurar() }
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"meu-servico-agenda/internal/core/application/port"
@@ -15,16 +16,16 @@ func NovoAgendaDiariaPostgresRepository(db *sql.DB) port.AgendaDiariaRepositorio
 	return &AgendaDiariaPostgresRepository{db: db}
 }
 
-func (r *AgendaDiariaPostgresRepository) Salvar(agenda *domain.AgendaDiaria, prestadorId string) error {
+func (r *AgendaDiariaPostgresRepository) Salvar(ctx context.Context, agenda *domain.AgendaDiaria, prestadorId string) error {
 	// Inicia transação
-	tx, err := r.db.Begin()
+	tx, err := iniciarTransacao(ctx, r.db)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback() // rollback automático em caso de erro
 
 	// 1️⃣ Insere agenda
-	_, err = tx.Exec(`
+	_, err = tx.ExecContext(ctx, `
 		INSERT INTO agendas_diarias (id, prestador_id, data, created_at)
 		VALUES ($1, $2, $3, NOW())
 	`,
@@ -38,7 +39,7 @@ func (r *AgendaDiariaPostgresRepository) Salvar(agenda *domain.AgendaDiaria, pre
 
 	// 2️⃣ Insere intervalos
 	if len(agenda.Intervalos) > 0 {
-		stmt, err := tx.Prepare(`
+		stmt, err := tx.PrepareContext(ctx, `
 			INSERT INTO intervalos_diarios (id, agenda_id, hora_inicio, hora_fim)
 			VALUES ($1, $2, $3, $4)
 		`)
@@ -48,7 +49,7 @@ func (r *AgendaDiariaPostgresRepository) Salvar(agenda *domain.AgendaDiaria, pre
 		defer stmt.Close()
 
 		for _, it := range agenda.Intervalos {
-			if _, err := stmt.Exec(it.Id, agenda.Id, it.HoraInicio.Format("15:04:05"), it.HoraFim.Format("15:04:05")); err != nil {
+			if _, err := stmt.ExecContext(ctx, it.Id, agenda.Id, it.HoraInicio.Format("15:04:05"), it.HoraFim.Format("15:04:05")); err != nil {
 				return fmt.Errorf("erro ao inserir intervalo: %w", err)
 			}
 		}
@@ -58,9 +59,9 @@ func (r *AgendaDiariaPostgresRepository) Salvar(agenda *domain.AgendaDiaria, pre
 	return tx.Commit()
 }
 
-func (r *AgendaDiariaPostgresRepository) AtualizarAgenda(agenda *domain.AgendaDiaria, prestadorID string) error {
+func (r *AgendaDiariaPostgresRepository) AtualizarAgenda(ctx context.Context, agenda *domain.AgendaDiaria, prestadorID string) error {
 	// Inicia transação
-	tx, err := r.db.Begin()
+	tx, err := iniciarTransacao(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("erro ao iniciar transação: %w", err)
 	}
@@ -68,7 +69,7 @@ func (r *AgendaDiariaPostgresRepository) AtualizarAgenda(agenda *domain.AgendaDi
 
 	// 0. ✅ VALIDAÇÃO: Verificar se a agenda pertence ao prestador
 	var count int
-	err = tx.QueryRow(`
+	err = tx.QueryRowContext(ctx, `
 		SELECT COUNT(*) 
 		FROM agendas_diarias 
 		WHERE id = $1 AND prestador_id = $2
@@ -81,7 +82,7 @@ func (r *AgendaDiariaPostgresRepository) AtualizarAgenda(agenda *domain.AgendaDi
 	}
 
 	// 1. Deletar todos os intervalos antigos
-	_, err = tx.Exec(`
+	_, err = tx.ExecContext(ctx, `
 		DELETE FROM intervalos_diarios 
 		WHERE agenda_id = $1
 	`, agenda.Id)
@@ -91,7 +92,7 @@ func (r *AgendaDiariaPostgresRepository) AtualizarAgenda(agenda *domain.AgendaDi
 
 	// 2. Inserir novos intervalos
 	for _, intervalo := range agenda.Intervalos {
-		_, err = tx.Exec(`
+		_, err = tx.ExecContext(ctx, `
 			INSERT INTO intervalos_diarios (id, agenda_id, hora_inicio, hora_fim)
 			VALUES ($1, $2, $3, $4)
 		`, intervalo.Id, agenda.Id, intervalo.HoraInicio, intervalo.HoraFim)
@@ -108,7 +109,7 @@ func (r *AgendaDiariaPostgresRepository) AtualizarAgenda(agenda *domain.AgendaDi
 	return nil
 }
 
-func (r *AgendaDiariaPostgresRepository) BuscarAgendaDoDia(prestadorID string, data string) (*domain.AgendaDiaria, error) {
+func (r *AgendaDiariaPostgresRepository) BuscarAgendaDoDia(ctx context.Context, prestadorID string, data string) (*domain.AgendaDiaria, error) {
 	query := `
 		SELECT 
 			ad.id AS agenda_id,
@@ -122,7 +123,7 @@ func (r *AgendaDiariaPostgresRepository) BuscarAgendaDoDia(prestadorID string, d
 		ORDER BY id.hora_inicio
 	`
 
-	rows, err := r.db.Query(query, prestadorID, data)
+	rows, err := conexao(ctx, r.db).QueryContext(ctx, query, prestadorID, data)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao buscar agenda: %w", err)
 	}
@@ -186,4 +187,46 @@ func (r *AgendaDiariaPostgresRepository) BuscarAgendaDoDia(prestadorID string, d
 	}
 
 	return agenda, nil
-}
\ No newline at end of file
+}
+
+func (r *AgendaDiariaPostgresRepository) DeletarAgenda(ctx context.Context, prestadorID string, data string) error {
+	tx, err := iniciarTransacao(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 1. Buscar ID da agenda
+	var agendaID string
+	err = tx.QueryRowContext(ctx, `
+		SELECT id FROM agendas_diarias 
+		WHERE prestador_id = $1 AND data = $2
+	`, prestadorID, data).Scan(&agendaID)
+
+	if err == sql.ErrNoRows {
+		return sql.ErrNoRows
+	}
+	if err != nil {
+		return fmt.Errorf("erro ao buscar agenda: %w", err)
+	}
+
+	// 2. Deletar intervalos
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM intervalos_diarios 
+		WHERE agenda_id = $1
+	`, agendaID)
+	if err != nil {
+		return fmt.Errorf("erro ao deletar intervalos: %w", err)
+	}
+
+	// 3. Deletar agenda
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM agendas_diarias 
+		WHERE id = $1
+	`, agendaID)
+	if err != nil {
+		return fmt.Errorf("erro ao deletar agenda: %w", err)
+	}
+
+	return tx.Commit()
+}
@@ -1,33 +1,133 @@
 package repository
 
 import (
+	"context"
+	"database/sql"
 	"meu-servico-agenda/internal/core/application/port"
 	"meu-servico-agenda/internal/core/domain"
 	"sort"
+	"sync"
 	"time"
 )
 
 type FakeAgendamentoRepositorio struct {
-	storage map[string]*domain.Agendamento
+	mu             sync.Mutex
+	storage        map[string]*domain.Agendamento
+	reagendamentos map[string][]*domain.Reagendamento
+	series         map[string]*domain.SerieAgendamento
+	visitas        map[string]*domain.Visita
 }
 
 func NovoFakeAgendamentoRepositorio() port.AgendamentoRepositorio {
-	return &FakeAgendamentoRepositorio{storage: make(map[string]*domain.Agendamento)}
+	return &FakeAgendamentoRepositorio{
+		storage:        make(map[string]*domain.Agendamento),
+		reagendamentos: make(map[string][]*domain.Reagendamento),
+		series:         make(map[string]*domain.SerieAgendamento),
+		visitas:        make(map[string]*domain.Visita),
+	}
 }
 
-func (r *FakeAgendamentoRepositorio) CriaAgendamento(agendamento *domain.Agendamento) error {
+// CriaAgendamento verifica e grava sob o mesmo lock, como a transação do repositório Postgres
+func (r *FakeAgendamentoRepositorio) CriaAgendamento(ctx context.Context, agendamento *domain.Agendamento) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.prestadorOcupado(agendamento) {
+		return domain.ErrHorarioJaReservado
+	}
+
 	r.storage[agendamento.ID] = agendamento
 	return nil
 }
 
-func (r *FakeAgendamentoRepositorio) BuscarPorPrestadorEPeriodo(prestadorID string, inicio, fim time.Time) ([]*domain.Agendamento, error) {
+// prestadorOcupado compara os períodos bloqueados, que incluem preparo e limpeza
+func (r *FakeAgendamentoRepositorio) prestadorOcupado(agendamento *domain.Agendamento) bool {
+	inicio, fim := agendamento.PeriodoOcupado()
+	for _, existente := range r.storage {
+		if existente.ID != agendamento.ID &&
+			existente.Prestador.ID == agendamento.Prestador.ID &&
+			existente.ConflitaCom(inicio, fim) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *FakeAgendamentoRepositorio) BuscarPorId(ctx context.Context, id string) (*domain.Agendamento, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	agendamento, ok := r.storage[id]
+	if !ok {
+		return nil, nil
+	}
+	return agendamento, nil
+}
+
+func (r *FakeAgendamentoRepositorio) BuscarPorIdParaAtualizar(ctx context.Context, id string) (*domain.Agendamento, error) {
+	return r.BuscarPorId(ctx, id)
+}
+
+// TravarCliente não faz nada: a FakeUnidadeDeTrabalho já executa uma transação por vez
+func (r *FakeAgendamentoRepositorio) TravarCliente(ctx context.Context, clienteID string) error {
+	return nil
+}
+
+// TravarPrestador também não faz nada, pelo mesmo motivo
+func (r *FakeAgendamentoRepositorio) TravarPrestador(ctx context.Context, prestadorID string) error {
+	return nil
+}
+
+func (r *FakeAgendamentoRepositorio) AtualizarStatus(ctx context.Context, id string, status domain.StatusDoAgendamento) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	agendamento, ok := r.storage[id]
+	if !ok {
+		return sql.ErrNoRows
+	}
+
+	agendamento.Status = status
+	return nil
+}
+
+func (r *FakeAgendamentoRepositorio) Reagendar(ctx context.Context, agendamento *domain.Agendamento, historico *domain.Reagendamento) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	atual, ok := r.storage[agendamento.ID]
+	if !ok {
+		return sql.ErrNoRows
+	}
+
+	if r.prestadorOcupado(agendamento) {
+		return domain.ErrHorarioJaReservado
+	}
+
+	atual.DataHoraInicio = agendamento.DataHoraInicio
+	atual.DataHoraFim = agendamento.DataHoraFim
+	atual.BloqueioInicio = agendamento.BloqueioInicio
+	atual.BloqueioFim = agendamento.BloqueioFim
+	r.reagendamentos[agendamento.ID] = append(r.reagendamentos[agendamento.ID], historico)
+	return nil
+}
+
+func (r *FakeAgendamentoRepositorio) ListarReagendamentos(ctx context.Context, agendamentoID string) ([]*domain.Reagendamento, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.reagendamentos[agendamentoID], nil
+}
+
+func (r *FakeAgendamentoRepositorio) BuscarPorPrestadorEPeriodo(ctx context.Context, prestadorID string, inicio, fim time.Time) ([]*domain.Agendamento, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
 	var resultados []*domain.Agendamento
 
 	for _, agendamento := range r.storage {
 		if agendamento.Prestador.ID == prestadorID &&
-			inicio.Before(agendamento.DataHoraFim) &&
-			fim.After(agendamento.DataHoraInicio) {
+			agendamento.ConflitaCom(inicio, fim) {
 
 			resultados = append(resultados, agendamento)
 		}
@@ -36,12 +136,15 @@ func (r *FakeAgendamentoRepositorio) BuscarPorPrestadorEPeriodo(prestadorID stri
 	return resultados, nil
 }
 
-func (r *FakeAgendamentoRepositorio) BuscarPorClienteEPeriodo(clienteID string, inicio, fim time.Time) ([]*domain.Agendamento, error) {
+func (r *FakeAgendamentoRepositorio) BuscarPorClienteEPeriodo(ctx context.Context, clienteID string, inicio, fim time.Time) ([]*domain.Agendamento, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
 	var resultados []*domain.Agendamento
 
 	for _, agendamento := range r.storage {
 		if agendamento.Cliente.ID == clienteID &&
+			agendamento.Status != domain.Cancelado &&
 			inicio.Before(agendamento.DataHoraFim) &&
 			fim.After(agendamento.DataHoraInicio) {
 
@@ -51,7 +154,10 @@ func (r *FakeAgendamentoRepositorio) BuscarPorClienteEPeriodo(clienteID string,
 
 	return resultados, nil
 }
-func (r *FakeAgendamentoRepositorio) BuscarAgendamentoClienteAPartirDaData(clienteID string, data time.Time) ([]*domain.Agendamento, error) {
+func (r *FakeAgendamentoRepositorio) BuscarAgendamentoClienteAPartirDaData(ctx context.Context, clienteID string, data time.Time) ([]*domain.Agendamento, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	var resultados []*domain.Agendamento
 
 	for _, agendamento := range r.storage {
@@ -72,6 +178,192 @@ func (r *FakeAgendamentoRepositorio) BuscarAgendamentoClienteAPartirDaData(clien
 	return resultados, nil
 }
 
-func (r *FakeAgendamentoRepositorio) BuscarAgendamentoPrestadorAPartirDaData(clienteID string, data time.Time) ([]*domain.Agendamento, error) {
-	return nil,nil
-}
\ No newline at end of file
+func (r *FakeAgendamentoRepositorio) BuscarAgendamentoPrestadorAPartirDaData(ctx context.Context, prestadorID string, data time.Time) ([]*domain.Agendamento, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var resultados []*domain.Agendamento
+
+	for _, agendamento := range r.storage {
+		if agendamento.Prestador != nil &&
+			agendamento.Prestador.ID == prestadorID &&
+			!agendamento.DataHoraInicio.Before(data) {
+			resultados = append(resultados, agendamento)
+		}
+	}
+
+	sort.Slice(resultados, func(i, j int) bool {
+		return resultados[i].DataHoraInicio.Before(resultados[j].DataHoraInicio)
+	})
+
+	return resultados, nil
+}
+
+func (r *FakeAgendamentoRepositorio) CriaSerie(ctx context.Context, serie *domain.SerieAgendamento) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.series[serie.ID] = serie
+	return nil
+}
+
+func (r *FakeAgendamentoRepositorio) BuscarSeriePorId(ctx context.Context, id string) (*domain.SerieAgendamento, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	serie, ok := r.series[id]
+	if !ok {
+		return nil, nil
+	}
+	return serie, nil
+}
+
+func (r *FakeAgendamentoRepositorio) BuscarSeriesDoCliente(ctx context.Context, clienteID string) ([]*domain.SerieAgendamento, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	resultados := []*domain.SerieAgendamento{}
+	for _, serie := range r.series {
+		if serie.Cliente != nil && serie.Cliente.ID == clienteID {
+			resultados = append(resultados, serie)
+		}
+	}
+
+	sort.Slice(resultados, func(i, j int) bool {
+		return resultados[i].DataHoraInicio.Before(resultados[j].DataHoraInicio)
+	})
+
+	return resultados, nil
+}
+
+func (r *FakeAgendamentoRepositorio) BuscarPorSerie(ctx context.Context, serieID string) ([]*domain.Agendamento, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var resultados []*domain.Agendamento
+	for _, agendamento := range r.storage {
+		if agendamento.SerieID == serieID {
+			resultados = append(resultados, agendamento)
+		}
+	}
+
+	sort.Slice(resultados, func(i, j int) bool {
+		return resultados[i].DataHoraInicio.Before(resultados[j].DataHoraInicio)
+	})
+
+	return resultados, nil
+}
+
+// CriaVisita grava todos os itens ou nenhum, verificando os conflitos sob o mesmo lock
+func (r *FakeAgendamentoRepositorio) CriaVisita(ctx context.Context, visita *domain.Visita) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, agendamento := range visita.Itens {
+		if r.prestadorOcupado(agendamento) {
+			return domain.ErrHorarioJaReservado
+		}
+	}
+
+	r.visitas[visita.ID] = &domain.Visita{ID: visita.ID, Cliente: visita.Cliente, Notas: visita.Notas}
+	for _, agendamento := range visita.Itens {
+		r.storage[agendamento.ID] = agendamento
+	}
+	return nil
+}
+
+func (r *FakeAgendamentoRepositorio) BuscarVisitaPorId(ctx context.Context, id string) (*domain.Visita, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	visita, ok := r.visitas[id]
+	if !ok {
+		return nil, nil
+	}
+	copia := *visita
+	return &copia, nil
+}
+
+// BuscarVisitasDoCliente ordena pelo ID: o xid cresce com a data de criação
+func (r *FakeAgendamentoRepositorio) BuscarVisitasDoCliente(ctx context.Context, clienteID string) ([]*domain.Visita, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	resultados := []*domain.Visita{}
+	for _, visita := range r.visitas {
+		if visita.Cliente != nil && visita.Cliente.ID == clienteID {
+			copia := *visita
+			resultados = append(resultados, &copia)
+		}
+	}
+
+	sort.Slice(resultados, func(i, j int) bool {
+		return resultados[i].ID < resultados[j].ID
+	})
+
+	return resultados, nil
+}
+
+func (r *FakeAgendamentoRepositorio) BuscarPorVisita(ctx context.Context, visitaID string) ([]*domain.Agendamento, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var resultados []*domain.Agendamento
+	for _, agendamento := range r.storage {
+		if agendamento.VisitaID == visitaID {
+			resultados = append(resultados, agendamento)
+		}
+	}
+
+	sort.Slice(resultados, func(i, j int) bool {
+		return resultados[i].DataHoraInicio.Before(resultados[j].DataHoraInicio)
+	})
+
+	return resultados, nil
+}
+
+func (r *FakeAgendamentoRepositorio) ApagarNotasDoCliente(ctx context.Context, clienteID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, agendamento := range r.storage {
+		if agendamento.Cliente != nil && agendamento.Cliente.ID == clienteID {
+			agendamento.Notas = ""
+		}
+	}
+	for _, serie := range r.series {
+		if serie.Cliente != nil && serie.Cliente.ID == clienteID {
+			serie.Notas = ""
+		}
+	}
+	for _, visita := range r.visitas {
+		if visita.Cliente != nil && visita.Cliente.ID == clienteID {
+			visita.Notas = ""
+		}
+	}
+	return nil
+}
+
+// salvarEstado permite que a FakeUnidadeDeTrabalho desfaça as alterações
+func (r *FakeAgendamentoRepositorio) salvarEstado() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	restaurarAgendamentos := salvarMapa(r.storage)
+	restaurarSeries := salvarMapa(r.series)
+	restaurarVisitas := salvarMapa(r.visitas)
+	reagendamentos := make(map[string][]*domain.Reagendamento, len(r.reagendamentos))
+	for id, historico := range r.reagendamentos {
+		reagendamentos[id] = historico[:len(historico):len(historico)]
+	}
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.storage = restaurarAgendamentos()
+		r.series = restaurarSeries()
+		r.visitas = restaurarVisitas()
+		r.reagendamentos = reagendamentos
+	}
+}
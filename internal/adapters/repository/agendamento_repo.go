@@ -1,10 +1,16 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"meu-servico-agenda/internal/core/application/port"
 	"meu-servico-agenda/internal/core/domain"
+	"sort"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type AgendamentoPostgresRepository struct {
@@ -15,14 +21,30 @@ func NovoAgendamentoPostgresRepository(db *sql.DB) port.AgendamentoRepositorio {
 	return &AgendamentoPostgresRepository{db: db}
 }
 
-func (r *AgendamentoPostgresRepository) CriaAgendamento(a *domain.Agendamento) error {
-	tx, err := r.db.Begin()
+// excecaoSobreposicao é o código do Postgres para violação de exclusion constraint
+const excecaoSobreposicao = "23P01"
+
+func (r *AgendamentoPostgresRepository) CriaAgendamento(ctx context.Context, a *domain.Agendamento) error {
+	tx, err := iniciarTransacao(ctx, r.db)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
-	_, err = tx.Exec(`
+	bloqueioInicio, bloqueioFim := a.PeriodoOcupado()
+	if err := reservarPeriodoPrestador(ctx, tx, a.Prestador.ID, bloqueioInicio, bloqueioFim, a.ID); err != nil {
+		return err
+	}
+
+	if err := inserirAgendamento(ctx, tx, a, bloqueioInicio, bloqueioFim); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func inserirAgendamento(ctx context.Context, tx *transacao, a *domain.Agendamento, bloqueioInicio, bloqueioFim time.Time) error {
+	_, err := tx.ExecContext(ctx, `
 		INSERT INTO agendamentos (
 			id,
 			cliente_id,
@@ -30,11 +52,19 @@ func (r *AgendamentoPostgresRepository) CriaAgendamento(a *domain.Agendamento) e
 			catalogo_id,
 			data_hora_inicio,
 			data_hora_fim,
+			bloqueio_inicio,
+			bloqueio_fim,
 			status,
 			notas,
+			serie_id,
+			visita_id,
+			servico_nome,
+			servico_preco,
+			servico_duracao,
+			servico_categoria,
 			created_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, ''), NULLIF($12, ''), $13, $14, $15, $16, NOW())
 	`,
 		a.ID,
 		a.Cliente.ID,
@@ -42,40 +72,333 @@ func (r *AgendamentoPostgresRepository) CriaAgendamento(a *domain.Agendamento) e
 		a.Catalogo.ID,
 		a.DataHoraInicio,
 		a.DataHoraFim,
+		bloqueioInicio,
+		bloqueioFim,
 		a.Status,
 		a.Notas,
+		a.SerieID,
+		a.VisitaID,
+		a.Servico.Nome,
+		a.Servico.Preco,
+		a.Servico.DuracaoPadrao,
+		a.Servico.Categoria,
 	)
 	if err != nil {
+		return traduzErroSobreposicao(err)
+	}
+
+	return nil
+}
+
+// reservarPeriodoPrestador serializa as gravações do mesmo prestador dentro da transação
+// e confirma que o período bloqueado, com preparo e limpeza, continua livre.
+// A exclusion constraint da tabela é a garantia final
+func reservarPeriodoPrestador(ctx context.Context, tx *transacao, prestadorID string, inicio, fim time.Time, ignorarID string) error {
+	if err := travarAgendaPrestador(ctx, tx, prestadorID); err != nil {
 		return err
 	}
 
-	return tx.Commit()
+	var ocupado bool
+	err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM agendamentos
+			WHERE prestador_id = $1
+			  AND bloqueio_inicio < $3
+			  AND bloqueio_fim    > $2
+			  AND status <> $4
+			  AND id <> $5
+		)
+	`, prestadorID, inicio, fim, domain.Cancelado, ignorarID).Scan(&ocupado)
+	if err != nil {
+		return err
+	}
+
+	if ocupado {
+		return domain.ErrHorarioJaReservado
+	}
+
+	return nil
+}
+
+// travarAgendaPrestador segura, até o fim da transação, a trava que serializa quem
+// reserva horários do prestador: agendamentos e ofertas da lista de espera
+func travarAgendaPrestador(ctx context.Context, db executor, prestadorID string) error {
+	if _, err := db.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, prestadorID); err != nil {
+		return fmt.Errorf("erro ao bloquear agenda do prestador: %w", err)
+	}
+	return nil
 }
 
-func (r *AgendamentoPostgresRepository) BuscarPorPrestadorEPeriodo(prestadorID string, inicio time.Time, fim time.Time) ([]*domain.Agendamento, error) {
+// travarAgendaCliente faz o mesmo para quem reserva ou move horários do cliente. A
+// chave dupla separa estas travas das do prestador
+func travarAgendaCliente(ctx context.Context, db executor, clienteID string) error {
+	if _, err := db.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('cliente'), hashtext($1))`, clienteID); err != nil {
+		return fmt.Errorf("erro ao bloquear agenda do cliente: %w", err)
+	}
+	return nil
+}
 
+func traduzErroSobreposicao(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == excecaoSobreposicao {
+		return domain.ErrHorarioJaReservado
+	}
+	return err
+}
+
+func (r *AgendamentoPostgresRepository) BuscarPorId(ctx context.Context, id string) (*domain.Agendamento, error) {
+	return r.buscarPorId(ctx, id, "")
+}
+
+func (r *AgendamentoPostgresRepository) BuscarPorIdParaAtualizar(ctx context.Context, id string) (*domain.Agendamento, error) {
+	return r.buscarPorId(ctx, id, "FOR UPDATE OF a")
+}
+
+func (r *AgendamentoPostgresRepository) TravarCliente(ctx context.Context, clienteID string) error {
+	return travarAgendaCliente(ctx, conexao(ctx, r.db), clienteID)
+}
+
+func (r *AgendamentoPostgresRepository) TravarPrestador(ctx context.Context, prestadorID string) error {
+	return travarAgendaPrestador(ctx, conexao(ctx, r.db), prestadorID)
+}
+
+func (r *AgendamentoPostgresRepository) buscarPorId(ctx context.Context, id string, trava string) (*domain.Agendamento, error) {
 	query := `
 	SELECT
 		a.id,
 		a.data_hora_inicio,
 		a.data_hora_fim,
+		a.bloqueio_inicio,
+		a.bloqueio_fim,
 		a.status,
 		a.notas,
+		COALESCE(a.serie_id, ''),
+		COALESCE(a.visita_id, ''),
 
 		c.id, c.nome, c.email, c.telefone,
-		p.id, p.nome, p.cpf, p.email, p.telefone,
-		cat.id, cat.nome, cat.duracao_padrao, cat.preco, cat.categoria
+		p.id, p.nome, p.cpf, p.email, p.telefone, p.ativo, p.fuso_horario,
+		cat.id, cat.nome, cat.duracao_padrao, cat.preco, cat.categoria,
+		a.servico_nome, a.servico_preco, a.servico_duracao, a.servico_categoria
+	FROM agendamentos a
+	JOIN clientes c   ON c.id = a.cliente_id
+	JOIN prestadores p ON p.id = a.prestador_id
+	JOIN catalogos cat ON cat.id = a.catalogo_id
+	WHERE a.id = $1
+	` + trava
+
+	var a domain.Agendamento
+	var cliente domain.Cliente
+	var prestador domain.Prestador
+	var catalogo domain.Catalogo
+	var notas sql.NullString
+
+	err := conexao(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
+		&a.ID,
+		&a.DataHoraInicio,
+		&a.DataHoraFim,
+		&a.BloqueioInicio,
+		&a.BloqueioFim,
+		&a.Status,
+		&notas,
+		&a.SerieID,
+		&a.VisitaID,
+
+		&cliente.ID,
+		&cliente.Nome,
+		&cliente.Email,
+		&cliente.Telefone,
+
+		&prestador.ID,
+		&prestador.Nome,
+		&prestador.Cpf,
+		&prestador.Email,
+		&prestador.Telefone,
+		&prestador.Ativo,
+		&prestador.FusoHorario,
+
+		&catalogo.ID,
+		&catalogo.Nome,
+		&catalogo.DuracaoPadrao,
+		&catalogo.Preco,
+		&catalogo.Categoria,
+
+		&a.Servico.Nome,
+		&a.Servico.Preco,
+		&a.Servico.DuracaoPadrao,
+		&a.Servico.Categoria,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	a.Notas = notas.String
+	a.Cliente = &cliente
+	a.Prestador = &prestador
+	a.Catalogo = &catalogo
+
+	return &a, nil
+}
+
+func (r *AgendamentoPostgresRepository) AtualizarStatus(ctx context.Context, id string, status domain.StatusDoAgendamento) error {
+	result, err := conexao(ctx, r.db).ExecContext(ctx, `
+		UPDATE agendamentos
+		SET status = $1
+		WHERE id = $2
+	`, status, id)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar status do agendamento: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *AgendamentoPostgresRepository) Reagendar(ctx context.Context, a *domain.Agendamento, historico *domain.Reagendamento) error {
+	tx, err := iniciarTransacao(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	bloqueioInicio, bloqueioFim := a.PeriodoOcupado()
+	if err := reservarPeriodoPrestador(ctx, tx, a.Prestador.ID, bloqueioInicio, bloqueioFim, a.ID); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE agendamentos
+		SET data_hora_inicio = $1,
+			data_hora_fim = $2,
+			bloqueio_inicio = $3,
+			bloqueio_fim = $4
+		WHERE id = $5
+	`, a.DataHoraInicio, a.DataHoraFim, bloqueioInicio, bloqueioFim, a.ID)
+	if err != nil {
+		if errors.Is(traduzErroSobreposicao(err), domain.ErrHorarioJaReservado) {
+			return domain.ErrHorarioJaReservado
+		}
+		return fmt.Errorf("erro ao reagendar agendamento: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO agendamento_reagendamentos (
+			id,
+			agendamento_id,
+			data_hora_inicio_anterior,
+			data_hora_fim_anterior,
+			data_hora_inicio_nova,
+			data_hora_fim_nova,
+			created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`,
+		historico.ID,
+		historico.AgendamentoID,
+		historico.DataHoraInicioAnterior,
+		historico.DataHoraFimAnterior,
+		historico.DataHoraInicioNova,
+		historico.DataHoraFimNova,
+		historico.CriadoEm,
+	)
+	if err != nil {
+		return fmt.Errorf("erro ao registrar histórico de reagendamento: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (r *AgendamentoPostgresRepository) ListarReagendamentos(ctx context.Context, agendamentoID string) ([]*domain.Reagendamento, error) {
+	rows, err := conexao(ctx, r.db).QueryContext(ctx, `
+		SELECT
+			id,
+			agendamento_id,
+			data_hora_inicio_anterior,
+			data_hora_fim_anterior,
+			data_hora_inicio_nova,
+			data_hora_fim_nova,
+			created_at
+		FROM agendamento_reagendamentos
+		WHERE agendamento_id = $1
+		ORDER BY created_at
+	`, agendamentoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var historico []*domain.Reagendamento
+
+	for rows.Next() {
+		var h domain.Reagendamento
+
+		err := rows.Scan(
+			&h.ID,
+			&h.AgendamentoID,
+			&h.DataHoraInicioAnterior,
+			&h.DataHoraFimAnterior,
+			&h.DataHoraInicioNova,
+			&h.DataHoraFimNova,
+			&h.CriadoEm,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		historico = append(historico, &h)
+	}
+
+	return historico, rows.Err()
+}
+
+func (r *AgendamentoPostgresRepository) BuscarPorPrestadorEPeriodo(ctx context.Context, prestadorID string, inicio time.Time, fim time.Time) ([]*domain.Agendamento, error) {
+
+	query := `
+	SELECT
+		a.id,
+		a.data_hora_inicio,
+		a.data_hora_fim,
+		a.bloqueio_inicio,
+		a.bloqueio_fim,
+		a.status,
+		COALESCE(a.notas, ''),
+
+		c.id, c.nome, c.email, c.telefone,
+		p.id, p.nome, p.cpf, p.email, p.telefone, p.fuso_horario,
+		cat.id, cat.nome, cat.duracao_padrao, cat.preco, cat.categoria,
+		a.servico_nome, a.servico_preco, a.servico_duracao, a.servico_categoria
 	FROM agendamentos a
 	JOIN clientes c   ON c.id = a.cliente_id
 	JOIN prestadores p ON p.id = a.prestador_id
 	JOIN catalogos cat ON cat.id = a.catalogo_id
 	WHERE a.prestador_id = $1
-	  AND a.data_hora_inicio < $3
-	  AND a.data_hora_fim    > $2
+	  AND a.bloqueio_inicio < $3
+	  AND a.bloqueio_fim    > $2
+	  AND a.status <> $4
 	ORDER BY a.data_hora_inicio
 	`
 
-	rows, err := r.db.Query(query, prestadorID, inicio, fim)
+	rows, err := conexao(ctx, r.db).QueryContext(ctx, query, prestadorID, inicio, fim, domain.Cancelado)
 	if err != nil {
 		return nil, err
 	}
@@ -93,6 +416,8 @@ func (r *AgendamentoPostgresRepository) BuscarPorPrestadorEPeriodo(prestadorID s
 			&a.ID,
 			&a.DataHoraInicio,
 			&a.DataHoraFim,
+			&a.BloqueioInicio,
+			&a.BloqueioFim,
 			&a.Status,
 			&a.Notas,
 
@@ -106,12 +431,18 @@ func (r *AgendamentoPostgresRepository) BuscarPorPrestadorEPeriodo(prestadorID s
 			&prestador.Cpf,
 			&prestador.Email,
 			&prestador.Telefone,
+			&prestador.FusoHorario,
 
 			&catalogo.ID,
 			&catalogo.Nome,
 			&catalogo.DuracaoPadrao,
 			&catalogo.Preco,
 			&catalogo.Categoria,
+
+			&a.Servico.Nome,
+			&a.Servico.Preco,
+			&a.Servico.DuracaoPadrao,
+			&a.Servico.Categoria,
 		)
 		if err != nil {
 			return nil, err
@@ -127,7 +458,7 @@ func (r *AgendamentoPostgresRepository) BuscarPorPrestadorEPeriodo(prestadorID s
 	return agendamentos, nil
 }
 
-func (r *AgendamentoPostgresRepository) BuscarPorClienteEPeriodo(clienteID string, inicio time.Time, fim time.Time) ([]*domain.Agendamento, error) {
+func (r *AgendamentoPostgresRepository) BuscarPorClienteEPeriodo(ctx context.Context, clienteID string, inicio time.Time, fim time.Time) ([]*domain.Agendamento, error) {
 
 	query := `
 	SELECT
@@ -135,20 +466,22 @@ func (r *AgendamentoPostgresRepository) BuscarPorClienteEPeriodo(clienteID strin
 		a.data_hora_inicio,
 		a.data_hora_fim,
 		a.status,
-		a.notas,
+		COALESCE(a.notas, ''),
 
-		p.id, p.nome, p.cpf, p.email, p.telefone,
-		cat.id, cat.nome, cat.duracao_padrao, cat.preco, cat.categoria
+		p.id, p.nome, p.cpf, p.email, p.telefone, p.fuso_horario,
+		cat.id, cat.nome, cat.duracao_padrao, cat.preco, cat.categoria,
+		a.servico_nome, a.servico_preco, a.servico_duracao, a.servico_categoria
 	FROM agendamentos a
 	JOIN prestadores p ON p.id = a.prestador_id
 	JOIN catalogos cat ON cat.id = a.catalogo_id
 	WHERE a.cliente_id = $1
 	  AND a.data_hora_inicio < $3
 	  AND a.data_hora_fim    > $2
+	  AND a.status <> $4
 	ORDER BY a.data_hora_inicio
 	`
 
-	rows, err := r.db.Query(query, clienteID, inicio, fim)
+	rows, err := conexao(ctx, r.db).QueryContext(ctx, query, clienteID, inicio, fim, domain.Cancelado)
 	if err != nil {
 		return nil, err
 	}
@@ -173,12 +506,18 @@ func (r *AgendamentoPostgresRepository) BuscarPorClienteEPeriodo(clienteID strin
 			&prestador.Cpf,
 			&prestador.Email,
 			&prestador.Telefone,
+			&prestador.FusoHorario,
 
 			&catalogo.ID,
 			&catalogo.Nome,
 			&catalogo.DuracaoPadrao,
 			&catalogo.Preco,
 			&catalogo.Categoria,
+
+			&a.Servico.Nome,
+			&a.Servico.Preco,
+			&a.Servico.DuracaoPadrao,
+			&a.Servico.Categoria,
 		)
 		if err != nil {
 			return nil, err
@@ -193,18 +532,21 @@ func (r *AgendamentoPostgresRepository) BuscarPorClienteEPeriodo(clienteID strin
 	return agendamentos, nil
 }
 
-func (r *AgendamentoPostgresRepository) BuscarAgendamentoClienteAPartirDaData(clienteID string, data time.Time) ([]*domain.Agendamento, error) {
+func (r *AgendamentoPostgresRepository) BuscarAgendamentoClienteAPartirDaData(ctx context.Context, clienteID string, data time.Time) ([]*domain.Agendamento, error) {
 	query := `
 	SELECT
 		a.id,
 		a.data_hora_inicio,
 		a.data_hora_fim,
 		a.status,
-		a.notas,
+		COALESCE(a.notas, ''),
+		COALESCE(a.serie_id, ''),
+		COALESCE(a.visita_id, ''),
 
 		c.id, c.nome, c.email, c.telefone,
-		p.id, p.nome, p.cpf, p.email, p.telefone,
-		cat.id, cat.nome, cat.duracao_padrao, cat.preco, cat.categoria
+		p.id, p.nome, p.cpf, p.email, p.telefone, p.fuso_horario,
+		cat.id, cat.nome, cat.duracao_padrao, cat.preco, cat.categoria,
+		a.servico_nome, a.servico_preco, a.servico_duracao, a.servico_categoria
 	FROM agendamentos a
 	JOIN clientes c   ON c.id = a.cliente_id
 	JOIN prestadores p ON p.id = a.prestador_id
@@ -214,7 +556,7 @@ func (r *AgendamentoPostgresRepository) BuscarAgendamentoClienteAPartirDaData(cl
 	ORDER BY a.data_hora_inicio
 	`
 
-	rows, err := r.db.Query(query, clienteID, data)
+	rows, err := conexao(ctx, r.db).QueryContext(ctx, query, clienteID, data)
 	if err != nil {
 		return nil, err
 	}
@@ -234,6 +576,8 @@ func (r *AgendamentoPostgresRepository) BuscarAgendamentoClienteAPartirDaData(cl
 			&a.DataHoraFim,
 			&a.Status,
 			&a.Notas,
+			&a.SerieID,
+			&a.VisitaID,
 
 			&cliente.ID,
 			&cliente.Nome,
@@ -245,12 +589,18 @@ func (r *AgendamentoPostgresRepository) BuscarAgendamentoClienteAPartirDaData(cl
 			&prestador.Cpf,
 			&prestador.Email,
 			&prestador.Telefone,
+			&prestador.FusoHorario,
 
 			&catalogo.ID,
 			&catalogo.Nome,
 			&catalogo.DuracaoPadrao,
 			&catalogo.Preco,
 			&catalogo.Categoria,
+
+			&a.Servico.Nome,
+			&a.Servico.Preco,
+			&a.Servico.DuracaoPadrao,
+			&a.Servico.Categoria,
 		)
 		if err != nil {
 			return nil, err
@@ -266,7 +616,7 @@ func (r *AgendamentoPostgresRepository) BuscarAgendamentoClienteAPartirDaData(cl
 	return agendamentos, rows.Err()
 }
 
-func (r *AgendamentoPostgresRepository) BuscarAgendamentoPrestadorAPartirDaData(prestadorID string, data time.Time) ([]*domain.Agendamento, error) {
+func (r *AgendamentoPostgresRepository) BuscarAgendamentoPrestadorAPartirDaData(ctx context.Context, prestadorID string, data time.Time) ([]*domain.Agendamento, error) {
 	query := `
 	SELECT
 		a.id,
@@ -274,10 +624,13 @@ func (r *AgendamentoPostgresRepository) BuscarAgendamentoPrestadorAPartirDaData(
 		a.data_hora_fim,
 		a.status,
 		a.notas,
+		COALESCE(a.serie_id, ''),
+		COALESCE(a.visita_id, ''),
 
 		c.id, c.nome, c.email, c.telefone,
-		p.id, p.nome, p.cpf, p.email, p.telefone, p.ativo, p.imagem_url,
-		cat.id, cat.nome, cat.duracao_padrao, cat.preco, cat.imagem_url, cat.categoria
+		p.id, p.nome, p.cpf, p.email, p.telefone, p.ativo, p.imagem_url, p.fuso_horario,
+		cat.id, cat.nome, cat.duracao_padrao, cat.preco, cat.imagem_url, cat.categoria,
+		a.servico_nome, a.servico_preco, a.servico_duracao, a.servico_categoria
 
 	FROM agendamentos a
 	JOIN clientes c ON c.id = a.cliente_id
@@ -288,7 +641,7 @@ func (r *AgendamentoPostgresRepository) BuscarAgendamentoPrestadorAPartirDaData(
 	ORDER BY a.data_hora_inicio
 	`
 
-	rows, err := r.db.Query(query, prestadorID, data)
+	rows, err := conexao(ctx, r.db).QueryContext(ctx, query, prestadorID, data)
 	if err != nil {
 		return nil, err
 	}
@@ -298,14 +651,16 @@ func (r *AgendamentoPostgresRepository) BuscarAgendamentoPrestadorAPartirDaData(
 
 	for rows.Next() {
 		var agendamentoID, clienteID, clienteNome, clienteEmail, clienteTelefone string
-		var prestadorID, prestadorNome, prestadorCpf, prestadorEmail, prestadorTelefone string
+		var prestadorID, prestadorNome, prestadorCpf, prestadorEmail, prestadorTelefone, prestadorFusoHorario string
 		var prestadorAtivo bool
 		var prestadorImagemUrl, catalogoImagemUrl sql.NullString
 		var catalogoID, catalogoNome, catalogoCategoria string
 		var catalogoDuracao, catalogoPreco int
+		var servico domain.ServicoAgendado
 		var dataHoraInicio, dataHoraFim time.Time
 		var status int
 		var notas sql.NullString
+		var serieID, visitaID string
 
 		err := rows.Scan(
 			&agendamentoID,
@@ -313,6 +668,8 @@ func (r *AgendamentoPostgresRepository) BuscarAgendamentoPrestadorAPartirDaData(
 			&dataHoraFim,
 			&status,
 			&notas,
+			&serieID,
+			&visitaID,
 
 			&clienteID,
 			&clienteNome,
@@ -326,6 +683,7 @@ func (r *AgendamentoPostgresRepository) BuscarAgendamentoPrestadorAPartirDaData(
 			&prestadorTelefone,
 			&prestadorAtivo,
 			&prestadorImagemUrl,
+			&prestadorFusoHorario,
 
 			&catalogoID,
 			&catalogoNome,
@@ -333,6 +691,11 @@ func (r *AgendamentoPostgresRepository) BuscarAgendamentoPrestadorAPartirDaData(
 			&catalogoPreco,
 			&catalogoImagemUrl,
 			&catalogoCategoria,
+
+			&servico.Nome,
+			&servico.Preco,
+			&servico.DuracaoPadrao,
+			&servico.Categoria,
 		)
 		if err != nil {
 			return nil, err
@@ -346,14 +709,15 @@ func (r *AgendamentoPostgresRepository) BuscarAgendamentoPrestadorAPartirDaData(
 		}
 
 		prestador := &domain.Prestador{
-			ID:        prestadorID,
-			Nome:      prestadorNome,
-			Cpf:       prestadorCpf,
-			Email:     prestadorEmail,
-			Telefone:  prestadorTelefone,
-			Ativo:     prestadorAtivo,
-			ImagemUrl: prestadorImagemUrl.String,
-			Agenda:    []domain.AgendaDiaria{},
+			ID:          prestadorID,
+			Nome:        prestadorNome,
+			Cpf:         prestadorCpf,
+			Email:       prestadorEmail,
+			Telefone:    prestadorTelefone,
+			Ativo:       prestadorAtivo,
+			ImagemUrl:   prestadorImagemUrl.String,
+			Agenda:      []domain.AgendaDiaria{},
+			FusoHorario: prestadorFusoHorario,
 		}
 
 		catalogo := &domain.Catalogo{
@@ -379,10 +743,323 @@ func (r *AgendamentoPostgresRepository) BuscarAgendamentoPrestadorAPartirDaData(
 			DataHoraFim:    dataHoraFim,
 			Status:         domain.StatusDoAgendamento(status),
 			Notas:          notasStr,
+			SerieID:        serieID,
+			VisitaID:       visitaID,
+			Servico:        servico,
 		}
 
 		agendamentos = append(agendamentos, agendamento)
 	}
 
 	return agendamentos, rows.Err()
-}
\ No newline at end of file
+}
+
+func (r *AgendamentoPostgresRepository) CriaSerie(ctx context.Context, serie *domain.SerieAgendamento) error {
+	_, err := conexao(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO agendamento_series (
+			id,
+			cliente_id,
+			prestador_id,
+			catalogo_id,
+			data_hora_inicio,
+			intervalo_semanas,
+			ocorrencias,
+			notas,
+			created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+	`,
+		serie.ID,
+		serie.Cliente.ID,
+		serie.Prestador.ID,
+		serie.Catalogo.ID,
+		serie.DataHoraInicio,
+		serie.IntervaloSemanas,
+		serie.Ocorrencias,
+		serie.Notas,
+	)
+	if err != nil {
+		return fmt.Errorf("erro ao inserir série de agendamentos: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AgendamentoPostgresRepository) BuscarSeriePorId(ctx context.Context, id string) (*domain.SerieAgendamento, error) {
+	row := conexao(ctx, r.db).QueryRowContext(ctx, `
+		SELECT id, cliente_id, prestador_id, catalogo_id, data_hora_inicio, intervalo_semanas, ocorrencias, notas
+		FROM agendamento_series
+		WHERE id = $1
+	`, id)
+
+	serie, err := scanSerie(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return serie, nil
+}
+
+func (r *AgendamentoPostgresRepository) BuscarSeriesDoCliente(ctx context.Context, clienteID string) ([]*domain.SerieAgendamento, error) {
+	rows, err := conexao(ctx, r.db).QueryContext(ctx, `
+		SELECT id, cliente_id, prestador_id, catalogo_id, data_hora_inicio, intervalo_semanas, ocorrencias, notas
+		FROM agendamento_series
+		WHERE cliente_id = $1
+		ORDER BY data_hora_inicio, id
+	`, clienteID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar séries do cliente: %w", err)
+	}
+	defer rows.Close()
+
+	series := []*domain.SerieAgendamento{}
+	for rows.Next() {
+		serie, err := scanSerie(rows)
+		if err != nil {
+			return nil, err
+		}
+		series = append(series, serie)
+	}
+
+	return series, rows.Err()
+}
+
+func scanSerie(s interface{ Scan(dest ...any) error }) (*domain.SerieAgendamento, error) {
+	var serie domain.SerieAgendamento
+	var clienteID, prestadorID, catalogoID string
+	var notas sql.NullString
+
+	err := s.Scan(
+		&serie.ID,
+		&clienteID,
+		&prestadorID,
+		&catalogoID,
+		&serie.DataHoraInicio,
+		&serie.IntervaloSemanas,
+		&serie.Ocorrencias,
+		&notas,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	serie.Cliente = &domain.Cliente{ID: clienteID}
+	serie.Prestador = &domain.Prestador{ID: prestadorID}
+	serie.Catalogo = &domain.Catalogo{ID: catalogoID}
+	serie.Notas = notas.String
+
+	return &serie, nil
+}
+
+// CriaVisita grava a visita e todos os seus itens na mesma transação; se algum
+// período já estiver ocupado nenhum item é criado
+func (r *AgendamentoPostgresRepository) CriaVisita(ctx context.Context, visita *domain.Visita) error {
+	tx, err := iniciarTransacao(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Os locks são tomados sempre na mesma ordem para que duas visitas com os
+	// mesmos prestadores não fiquem esperando uma pela outra
+	prestadores := make([]string, 0, len(visita.Itens))
+	for _, a := range visita.Itens {
+		prestadores = append(prestadores, a.Prestador.ID)
+	}
+	sort.Strings(prestadores)
+	for _, prestadorID := range prestadores {
+		if err := travarAgendaPrestador(ctx, tx, prestadorID); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO agendamento_visitas (id, cliente_id, notas, created_at)
+		VALUES ($1, $2, $3, NOW())
+	`, visita.ID, visita.Cliente.ID, visita.Notas)
+	if err != nil {
+		return fmt.Errorf("erro ao inserir visita: %w", err)
+	}
+
+	for _, a := range visita.Itens {
+		bloqueioInicio, bloqueioFim := a.PeriodoOcupado()
+		if err := reservarPeriodoPrestador(ctx, tx, a.Prestador.ID, bloqueioInicio, bloqueioFim, a.ID); err != nil {
+			return err
+		}
+
+		if err := inserirAgendamento(ctx, tx, a, bloqueioInicio, bloqueioFim); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *AgendamentoPostgresRepository) BuscarVisitaPorId(ctx context.Context, id string) (*domain.Visita, error) {
+	var visita domain.Visita
+	var clienteID string
+	var notas sql.NullString
+
+	err := conexao(ctx, r.db).QueryRowContext(ctx, `
+		SELECT id, cliente_id, notas
+		FROM agendamento_visitas
+		WHERE id = $1
+	`, id).Scan(&visita.ID, &clienteID, &notas)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	visita.Cliente = &domain.Cliente{ID: clienteID}
+	visita.Notas = notas.String
+
+	return &visita, nil
+}
+
+func (r *AgendamentoPostgresRepository) BuscarVisitasDoCliente(ctx context.Context, clienteID string) ([]*domain.Visita, error) {
+	rows, err := conexao(ctx, r.db).QueryContext(ctx, `
+		SELECT id, notas
+		FROM agendamento_visitas
+		WHERE cliente_id = $1
+		ORDER BY created_at, id
+	`, clienteID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar visitas do cliente: %w", err)
+	}
+	defer rows.Close()
+
+	visitas := []*domain.Visita{}
+	for rows.Next() {
+		var visita domain.Visita
+		var notas sql.NullString
+		if err := rows.Scan(&visita.ID, &notas); err != nil {
+			return nil, err
+		}
+		visita.Cliente = &domain.Cliente{ID: clienteID}
+		visita.Notas = notas.String
+		visitas = append(visitas, &visita)
+	}
+
+	return visitas, rows.Err()
+}
+
+func (r *AgendamentoPostgresRepository) BuscarPorSerie(ctx context.Context, serieID string) ([]*domain.Agendamento, error) {
+	return r.buscarPorGrupo(ctx, "a.serie_id", serieID)
+}
+
+func (r *AgendamentoPostgresRepository) BuscarPorVisita(ctx context.Context, visitaID string) ([]*domain.Agendamento, error) {
+	return r.buscarPorGrupo(ctx, "a.visita_id", visitaID)
+}
+
+// buscarPorGrupo lista, em ordem cronológica, os agendamentos de uma série ou de uma visita
+func (r *AgendamentoPostgresRepository) buscarPorGrupo(ctx context.Context, coluna, id string) ([]*domain.Agendamento, error) {
+	query := `
+	SELECT
+		a.id,
+		a.data_hora_inicio,
+		a.data_hora_fim,
+		a.bloqueio_inicio,
+		a.bloqueio_fim,
+		a.status,
+		a.notas,
+		COALESCE(a.serie_id, ''),
+		COALESCE(a.visita_id, ''),
+
+		c.id, c.nome, c.email, c.telefone,
+		p.id, p.nome, p.cpf, p.email, p.telefone, p.ativo, p.fuso_horario,
+		cat.id, cat.nome, cat.duracao_padrao, cat.preco, cat.categoria,
+		a.servico_nome, a.servico_preco, a.servico_duracao, a.servico_categoria
+	FROM agendamentos a
+	JOIN clientes c   ON c.id = a.cliente_id
+	JOIN prestadores p ON p.id = a.prestador_id
+	JOIN catalogos cat ON cat.id = a.catalogo_id
+	WHERE ` + coluna + ` = $1
+	ORDER BY a.data_hora_inicio
+	`
+
+	rows, err := conexao(ctx, r.db).QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var agendamentos []*domain.Agendamento
+
+	for rows.Next() {
+		var a domain.Agendamento
+		var cliente domain.Cliente
+		var prestador domain.Prestador
+		var catalogo domain.Catalogo
+		var notas sql.NullString
+
+		err := rows.Scan(
+			&a.ID,
+			&a.DataHoraInicio,
+			&a.DataHoraFim,
+			&a.BloqueioInicio,
+			&a.BloqueioFim,
+			&a.Status,
+			&notas,
+			&a.SerieID,
+			&a.VisitaID,
+
+			&cliente.ID,
+			&cliente.Nome,
+			&cliente.Email,
+			&cliente.Telefone,
+
+			&prestador.ID,
+			&prestador.Nome,
+			&prestador.Cpf,
+			&prestador.Email,
+			&prestador.Telefone,
+			&prestador.Ativo,
+			&prestador.FusoHorario,
+
+			&catalogo.ID,
+			&catalogo.Nome,
+			&catalogo.DuracaoPadrao,
+			&catalogo.Preco,
+			&catalogo.Categoria,
+
+			&a.Servico.Nome,
+			&a.Servico.Preco,
+			&a.Servico.DuracaoPadrao,
+			&a.Servico.Categoria,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		a.Notas = notas.String
+		a.Cliente = &cliente
+		a.Prestador = &prestador
+		a.Catalogo = &catalogo
+
+		agendamentos = append(agendamentos, &a)
+	}
+
+	return agendamentos, rows.Err()
+}
+
+// ApagarNotasDoCliente limpa as observações dos agendamentos, séries e visitas do cliente
+func (r *AgendamentoPostgresRepository) ApagarNotasDoCliente(ctx context.Context, clienteID string) error {
+	tx, err := iniciarTransacao(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, tabela := range []string{"agendamentos", "agendamento_series", "agendamento_visitas"} {
+		if _, err := tx.ExecContext(ctx, `UPDATE `+tabela+` SET notas = NULL WHERE cliente_id = $1`, clienteID); err != nil {
+			return fmt.Errorf("erro ao apagar notas de %s: %w", tabela, err)
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/domain"
+)
+
+type FakeTokenCalendarioRepositorio struct {
+	mu     sync.Mutex
+	tokens map[string]*domain.TokenCalendario
+}
+
+func NovoFakeTokenCalendarioRepositorio() port.TokenCalendarioRepositorio {
+	return &FakeTokenCalendarioRepositorio{tokens: make(map[string]*domain.TokenCalendario)}
+}
+
+func (r *FakeTokenCalendarioRepositorio) Substituir(ctx context.Context, t *domain.TokenCalendario) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for hash, atual := range r.tokens {
+		if (t.ClienteID != "" && atual.ClienteID == t.ClienteID) ||
+			(t.PrestadorID != "" && atual.PrestadorID == t.PrestadorID) {
+			delete(r.tokens, hash)
+		}
+	}
+
+	copia := *t
+	r.tokens[t.TokenHash] = &copia
+	return nil
+}
+
+func (r *FakeTokenCalendarioRepositorio) BuscarPorHash(ctx context.Context, tokenHash string) (*domain.TokenCalendario, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.tokens[tokenHash]
+	if !ok {
+		return nil, nil
+	}
+	copia := *t
+	return &copia, nil
+}
+
+func (r *FakeTokenCalendarioRepositorio) RemoverDoCliente(ctx context.Context, clienteID string) error {
+	r.remover(func(t *domain.TokenCalendario) bool { return t.ClienteID == clienteID })
+	return nil
+}
+
+func (r *FakeTokenCalendarioRepositorio) RemoverDoPrestador(ctx context.Context, prestadorID string) error {
+	r.remover(func(t *domain.TokenCalendario) bool { return t.PrestadorID == prestadorID })
+	return nil
+}
+
+func (r *FakeTokenCalendarioRepositorio) remover(doDono func(t *domain.TokenCalendario) bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for hash, t := range r.tokens {
+		if doDono(t) {
+			delete(r.tokens, hash)
+		}
+	}
+}
+
+// salvarEstado permite que a FakeUnidadeDeTrabalho desfaça as alterações
+func (r *FakeTokenCalendarioRepositorio) salvarEstado() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	restaurar := salvarMapa(r.tokens)
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.tokens = restaurar()
+	}
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/domain"
+)
+
+type TokenCalendarioPostgresRepository struct {
+	db *sql.DB
+}
+
+func NovoTokenCalendarioPostgresRepository(db *sql.DB) port.TokenCalendarioRepositorio {
+	return &TokenCalendarioPostgresRepository{db: db}
+}
+
+func (r *TokenCalendarioPostgresRepository) Substituir(ctx context.Context, t *domain.TokenCalendario) error {
+	// Só um dos donos é preenchido: o outro fica NULL
+	clienteID := sql.NullString{String: t.ClienteID, Valid: t.ClienteID != ""}
+	prestadorID := sql.NullString{String: t.PrestadorID, Valid: t.PrestadorID != ""}
+
+	tx, err := iniciarTransacao(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM calendario_tokens
+		WHERE cliente_id = $1 OR prestador_id = $2
+	`, clienteID, prestadorID)
+	if err != nil {
+		return fmt.Errorf("erro ao remover token de calendário anterior: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO calendario_tokens (token_hash, cliente_id, prestador_id, created_at)
+		VALUES ($1, $2, $3, $4)
+	`, t.TokenHash, clienteID, prestadorID, t.CriadoEm)
+	if err != nil {
+		return fmt.Errorf("erro ao salvar token de calendário: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (r *TokenCalendarioPostgresRepository) BuscarPorHash(ctx context.Context, tokenHash string) (*domain.TokenCalendario, error) {
+	var t domain.TokenCalendario
+	var clienteID, prestadorID sql.NullString
+
+	err := conexao(ctx, r.db).QueryRowContext(ctx, `
+		SELECT token_hash, cliente_id, prestador_id, created_at
+		FROM calendario_tokens
+		WHERE token_hash = $1
+	`, tokenHash).Scan(&t.TokenHash, &clienteID, &prestadorID, &t.CriadoEm)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	t.ClienteID = clienteID.String
+	t.PrestadorID = prestadorID.String
+	return &t, nil
+}
+
+func (r *TokenCalendarioPostgresRepository) RemoverDoCliente(ctx context.Context, clienteID string) error {
+	_, err := conexao(ctx, r.db).ExecContext(ctx, `DELETE FROM calendario_tokens WHERE cliente_id = $1`, clienteID)
+	if err != nil {
+		return fmt.Errorf("erro ao remover token de calendário do cliente: %w", err)
+	}
+	return nil
+}
+
+func (r *TokenCalendarioPostgresRepository) RemoverDoPrestador(ctx context.Context, prestadorID string) error {
+	_, err := conexao(ctx, r.db).ExecContext(ctx, `DELETE FROM calendario_tokens WHERE prestador_id = $1`, prestadorID)
+	if err != nil {
+		return fmt.Errorf("erro ao remover token de calendário do prestador: %w", err)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"meu-servico-agenda/internal/core/application/port"
 	"meu-servico-agenda/internal/core/domain"
@@ -15,12 +16,12 @@ func NovoCatalogoFakeRepo() port.CatalogoRepositorio {
 	return &CatalogoFakeRepo{Catalogo: make(map[string]*domain.Catalogo)}
 }
 
-func (r *CatalogoFakeRepo) Salvar(catalogo *domain.Catalogo) error {
+func (r *CatalogoFakeRepo) Salvar(ctx context.Context, catalogo *domain.Catalogo) error {
 	r.Catalogo[catalogo.ID] = catalogo
 	return nil
 }
 
-func (r *CatalogoFakeRepo) BuscarPorId(id string) (*domain.Catalogo, error) {
+func (r *CatalogoFakeRepo) BuscarPorId(ctx context.Context, id string) (*domain.Catalogo, error) {
 	catalogo := r.Catalogo[id]
 	if catalogo == nil {
 		return nil, errors.New("não encontrado")
@@ -28,7 +29,7 @@ func (r *CatalogoFakeRepo) BuscarPorId(id string) (*domain.Catalogo, error) {
 	return catalogo, nil
 }
 
-func (r *CatalogoFakeRepo) Listar(limit, offset int) ([]*domain.Catalogo, error) {
+func (r *CatalogoFakeRepo) Listar(ctx context.Context, limit, offset int) ([]*domain.Catalogo, error) {
 	catalogos := make([]*domain.Catalogo, 0, len(r.Catalogo))
 
 	// map → slice
@@ -53,11 +54,11 @@ func (r *CatalogoFakeRepo) Listar(limit, offset int) ([]*domain.Catalogo, error)
 	return catalogos[offset:end], nil
 }
 
-func (r *CatalogoFakeRepo) Contar() (int, error) {
+func (r *CatalogoFakeRepo) Contar(ctx context.Context) (int, error) {
 	return len(r.Catalogo), nil
 }
 
-func (r *CatalogoFakeRepo) Atualizar(catalogo *domain.Catalogo) error {
+func (r *CatalogoFakeRepo) Atualizar(ctx context.Context, catalogo *domain.Catalogo) error {
 	// Verifica se o catálogo existe antes de atualizar
 	if _, exists := r.Catalogo[catalogo.ID]; !exists {
 		return errors.New("catálogo não encontrado")
@@ -68,7 +69,7 @@ func (r *CatalogoFakeRepo) Atualizar(catalogo *domain.Catalogo) error {
 	return nil
 }
 
-func (r *CatalogoFakeRepo) Deletar(id string) error {
+func (r *CatalogoFakeRepo) Deletar(ctx context.Context, id string) error {
 	// Verifica se o catálogo existe
 	if _, exists := r.Catalogo[id]; !exists {
 		return errors.New("catálogo não encontrado")
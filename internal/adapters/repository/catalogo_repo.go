@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 
@@ -18,7 +19,7 @@ func NovoCatalogoPostgresRepositorio(db *sql.DB) port.CatalogoRepositorio {
 	}
 }
 
-func (r *CatalogoPostgresRepositorio) Salvar(c *domain.Catalogo) error {
+func (r *CatalogoPostgresRepositorio) Salvar(ctx context.Context, c *domain.Catalogo) error {
 	query := `
 		INSERT INTO catalogos (
 			id,
@@ -26,12 +27,14 @@ func (r *CatalogoPostgresRepositorio) Salvar(c *domain.Catalogo) error {
 			duracao_padrao,
 			preco,
 			categoria,
-			imagem_url
+			imagem_url,
+			tempo_preparo,
+			tempo_limpeza
 		)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 
-	_, err := r.db.Exec(
+	_, err := conexao(ctx, r.db).ExecContext(ctx,
 		query,
 		c.ID,
 		c.Nome,
@@ -39,6 +42,8 @@ func (r *CatalogoPostgresRepositorio) Salvar(c *domain.Catalogo) error {
 		c.Preco,
 		c.Categoria,
 		c.ImagemUrl,
+		c.TempoPreparo,
+		c.TempoLimpeza,
 	)
 
 	if err != nil {
@@ -47,14 +52,14 @@ func (r *CatalogoPostgresRepositorio) Salvar(c *domain.Catalogo) error {
 
 	return nil
 }
-func (r *CatalogoPostgresRepositorio) BuscarPorId(id string) (*domain.Catalogo, error) {
+func (r *CatalogoPostgresRepositorio) BuscarPorId(ctx context.Context, id string) (*domain.Catalogo, error) {
 	query := `
-		SELECT id, nome, duracao_padrao, preco, categoria, imagem_url
+		SELECT id, nome, duracao_padrao, preco, categoria, imagem_url, tempo_preparo, tempo_limpeza
 		FROM catalogos
 		WHERE id = $1
 	`
 
-	row := r.db.QueryRow(query, id)
+	row := conexao(ctx, r.db).QueryRowContext(ctx, query, id)
 
 	var c domain.Catalogo
 	err := row.Scan(
@@ -64,6 +69,8 @@ func (r *CatalogoPostgresRepositorio) BuscarPorId(id string) (*domain.Catalogo,
 		&c.Preco,
 		&c.Categoria,
 		&c.ImagemUrl,
+		&c.TempoPreparo,
+		&c.TempoLimpeza,
 	)
 
 	if err != nil {
@@ -76,7 +83,7 @@ func (r *CatalogoPostgresRepositorio) BuscarPorId(id string) (*domain.Catalogo,
 	return &c, nil
 }
 
-func (r *CatalogoPostgresRepositorio) Listar(limit, offset int) ([]*domain.Catalogo, error) {
+func (r *CatalogoPostgresRepositorio) Listar(ctx context.Context, limit, offset int) ([]*domain.Catalogo, error) {
 	query := `
 		SELECT
 			id,
@@ -84,13 +91,15 @@ func (r *CatalogoPostgresRepositorio) Listar(limit, offset int) ([]*domain.Catal
 			duracao_padrao,
 			preco,
 			categoria,
-			imagem_url
+			imagem_url,
+			tempo_preparo,
+			tempo_limpeza
 		FROM catalogos
 		ORDER BY created_at DESC
 		LIMIT $1 OFFSET $2
 	`
 
-	rows, err := r.db.Query(query, limit, offset)
+	rows, err := conexao(ctx, r.db).QueryContext(ctx, query, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -107,6 +116,8 @@ func (r *CatalogoPostgresRepositorio) Listar(limit, offset int) ([]*domain.Catal
 			&c.Preco,
 			&c.Categoria,
 			&c.ImagemUrl,
+			&c.TempoPreparo,
+			&c.TempoLimpeza,
 		); err != nil {
 			return nil, err
 		}
@@ -116,32 +127,36 @@ func (r *CatalogoPostgresRepositorio) Listar(limit, offset int) ([]*domain.Catal
 	return catalogos, nil
 }
 
-func (r *CatalogoPostgresRepositorio) Contar() (int, error) {
+func (r *CatalogoPostgresRepositorio) Contar(ctx context.Context) (int, error) {
 	query := `SELECT COUNT(*) FROM catalogos`
 
 	var total int
-	err := r.db.QueryRow(query).Scan(&total)
+	err := conexao(ctx, r.db).QueryRowContext(ctx, query).Scan(&total)
 	return total, err
 }
 
-func (r *CatalogoPostgresRepositorio) Atualizar(c *domain.Catalogo) error {
+func (r *CatalogoPostgresRepositorio) Atualizar(ctx context.Context, c *domain.Catalogo) error {
 	query := `
 		UPDATE catalogos
 		SET nome = $1,
 		    duracao_padrao = $2,
 		    preco = $3,
 		    categoria = $4,
-		    imagem_url = $5
-		WHERE id = $6
+		    imagem_url = $5,
+		    tempo_preparo = $6,
+		    tempo_limpeza = $7
+		WHERE id = $8
 	`
 
-	result, err := r.db.Exec(
+	result, err := conexao(ctx, r.db).ExecContext(ctx,
 		query,
 		c.Nome,
 		c.DuracaoPadrao,
 		c.Preco,
 		c.Categoria,
 		c.ImagemUrl,
+		c.TempoPreparo,
+		c.TempoLimpeza,
 		c.ID,
 	)
 
@@ -161,10 +176,10 @@ func (r *CatalogoPostgresRepositorio) Atualizar(c *domain.Catalogo) error {
 	return nil
 }
 
-func (r *CatalogoPostgresRepositorio) Deletar(id string) error {
+func (r *CatalogoPostgresRepositorio) Deletar(ctx context.Context, id string) error {
 	query := `DELETE FROM catalogos WHERE id = $1`
 
-	result, err := r.db.Exec(query, id)
+	result, err := conexao(ctx, r.db).ExecContext(ctx, query, id)
 	if err != nil {
 		return err
 	}
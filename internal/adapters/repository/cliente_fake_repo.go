@@ -1,8 +1,13 @@
 package repository
 
 import (
+	"context"
+	"database/sql"
+	"meu-servico-agenda/internal/core/application/input"
 	"meu-servico-agenda/internal/core/application/port"
 	"meu-servico-agenda/internal/core/domain"
+	"sort"
+	"strings"
 )
 
 // FakeClienteRepositorio é uma implementação de ClienteRepositorio que armazena dados em memória.
@@ -19,14 +24,14 @@ func NewFakeClienteRepositorio() port.ClienteRepositorio {
 }
 
 // Salvar simula a persistência, usando o ID do cliente como chave.
-func (r *FakeClienteRepositorio) Salvar(cliente *domain.Cliente) error {
+func (r *FakeClienteRepositorio) Salvar(ctx context.Context, cliente *domain.Cliente) error {
 	// CORREÇÃO: Usar o ID como chave (índice)
 	r.Clientes[cliente.ID] = cliente
 	return nil
 }
 
 // BuscarPorId simula a busca por um id.
-func (r *FakeClienteRepositorio) BuscarPorId(id string) (*domain.Cliente, error) {
+func (r *FakeClienteRepositorio) BuscarPorId(ctx context.Context, id string) (*domain.Cliente, error) {
 	// CORRETO: Agora a busca por 'id' funcionará, pois o mapa é indexado por ID.
 	cliente, ok := r.Clientes[id]
 	if !ok {
@@ -34,3 +39,99 @@ func (r *FakeClienteRepositorio) BuscarPorId(id string) (*domain.Cliente, error)
 	}
 	return cliente, nil
 }
+
+// BuscarPorEmail simula a busca pelo email único do cliente.
+func (r *FakeClienteRepositorio) BuscarPorEmail(ctx context.Context, email string) (*domain.Cliente, error) {
+	for _, cliente := range r.Clientes {
+		if cliente.Email == email {
+			return cliente, nil
+		}
+	}
+	return nil, nil
+}
+
+// Atualizar substitui os dados de contato, como o UPDATE do repositório Postgres.
+func (r *FakeClienteRepositorio) Atualizar(ctx context.Context, cliente *domain.Cliente) error {
+	atual, ok := r.Clientes[cliente.ID]
+	if !ok {
+		return sql.ErrNoRows
+	}
+
+	atual.Nome = cliente.Nome
+	atual.Email = cliente.Email
+	atual.Telefone = cliente.Telefone
+	return nil
+}
+
+// Listar aplica os filtros e a paginação em memória, ordenando por nome.
+func (r *FakeClienteRepositorio) Listar(ctx context.Context, in *input.ClienteListInput) ([]*domain.Cliente, error) {
+	filtrados := r.filtrar(in)
+
+	inicio := (in.Page - 1) * in.Limit
+	if inicio >= len(filtrados) {
+		return []*domain.Cliente{}, nil
+	}
+	fim := inicio + in.Limit
+	if fim > len(filtrados) {
+		fim = len(filtrados)
+	}
+
+	return filtrados[inicio:fim], nil
+}
+
+func (r *FakeClienteRepositorio) Contar(ctx context.Context, in *input.ClienteListInput) (int, error) {
+	return len(r.filtrar(in)), nil
+}
+
+func (r *FakeClienteRepositorio) filtrar(in *input.ClienteListInput) []*domain.Cliente {
+	busca := strings.ToLower(in.Busca)
+
+	var resultados []*domain.Cliente
+	for _, cliente := range r.Clientes {
+		if in.Ativo != nil && cliente.Ativo != *in.Ativo {
+			continue
+		}
+		if busca != "" &&
+			!strings.Contains(strings.ToLower(cliente.Nome), busca) &&
+			!strings.Contains(strings.ToLower(cliente.Email), busca) &&
+			!strings.Contains(cliente.Telefone, busca) {
+			continue
+		}
+		resultados = append(resultados, cliente)
+	}
+
+	sort.Slice(resultados, func(i, j int) bool {
+		if resultados[i].Nome != resultados[j].Nome {
+			return resultados[i].Nome < resultados[j].Nome
+		}
+		return resultados[i].ID < resultados[j].ID
+	})
+
+	return resultados
+}
+
+func (r *FakeClienteRepositorio) AtualizarStatus(ctx context.Context, id string, ativo bool) error {
+	cliente, ok := r.Clientes[id]
+	if !ok {
+		return sql.ErrNoRows
+	}
+
+	cliente.Ativo = ativo
+	return nil
+}
+
+func (r *FakeClienteRepositorio) Anonimizar(ctx context.Context, cliente *domain.Cliente) error {
+	atual, ok := r.Clientes[cliente.ID]
+	if !ok {
+		return sql.ErrNoRows
+	}
+
+	*atual = *cliente
+	return nil
+}
+
+// salvarEstado permite que a FakeUnidadeDeTrabalho desfaça as alterações
+func (r *FakeClienteRepositorio) salvarEstado() func() {
+	restaurar := salvarMapa(r.Clientes)
+	return func() { r.Clientes = restaurar() }
+}
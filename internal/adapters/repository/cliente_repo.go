@@ -1,9 +1,12 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 
+	"meu-servico-agenda/internal/core/application/input"
 	"meu-servico-agenda/internal/core/application/port"
 	"meu-servico-agenda/internal/core/domain"
 
@@ -20,39 +23,62 @@ func NovoClientePostgresRepositorio(db *sql.DB) port.ClienteRepositorio {
 	}
 }
 
-func (r *ClientePostgresRepositorio) Salvar(cliente *domain.Cliente) error {
+// violacaoUnicidade é o código do Postgres para violação de unique constraint
+const violacaoUnicidade = "23505"
+
+func (r *ClientePostgresRepositorio) Salvar(ctx context.Context, cliente *domain.Cliente) error {
 	query := `
-		INSERT INTO clientes (id, nome, email, telefone)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO clientes (id, nome, email, telefone, ativo)
+		VALUES ($1, $2, $3, $4, $5)
 	`
 
-	_, err := r.db.Exec(
+	_, err := conexao(ctx, r.db).ExecContext(ctx,
 		query,
 		cliente.ID,
 		cliente.Nome,
 		cliente.Email,
 		cliente.Telefone,
+		cliente.Ativo,
 	)
 
 	if err != nil {
-		// trata erro de email duplicado
-		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
-			return errors.New("email ja cadastrado")
-		}
-		return err
+		return traduzErroEmailDuplicado(err)
 	}
 
 	return nil
 }
 
-func (r *ClientePostgresRepositorio) BuscarPorId(id string) (*domain.Cliente, error) {
+// traduzErroEmailDuplicado converte a violação de uq_clientes_email no erro de domínio
+func traduzErroEmailDuplicado(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == violacaoUnicidade {
+		return domain.ErrEmailJaCadastrado
+	}
+	return err
+}
+
+func (r *ClientePostgresRepositorio) BuscarPorId(ctx context.Context, id string) (*domain.Cliente, error) {
 	query := `
-		SELECT id, nome, email, telefone
+		SELECT id, nome, email, telefone, ativo, anonimizado_em
 		FROM clientes
 		WHERE id = $1
 	`
 
-	row := r.db.QueryRow(query, id)
+	return r.buscarUm(ctx, query, id)
+}
+
+func (r *ClientePostgresRepositorio) BuscarPorEmail(ctx context.Context, email string) (*domain.Cliente, error) {
+	query := `
+		SELECT id, nome, email, telefone, ativo, anonimizado_em
+		FROM clientes
+		WHERE email = $1
+	`
+
+	return r.buscarUm(ctx, query, email)
+}
+
+func (r *ClientePostgresRepositorio) buscarUm(ctx context.Context, query string, arg string) (*domain.Cliente, error) {
+	row := conexao(ctx, r.db).QueryRowContext(ctx, query, arg)
 
 	var cliente domain.Cliente
 	err := row.Scan(
@@ -60,6 +86,8 @@ func (r *ClientePostgresRepositorio) BuscarPorId(id string) (*domain.Cliente, er
 		&cliente.Nome,
 		&cliente.Email,
 		&cliente.Telefone,
+		&cliente.Ativo,
+		&cliente.AnonimizadoEm,
 	)
 
 	if err != nil {
@@ -71,3 +99,140 @@ func (r *ClientePostgresRepositorio) BuscarPorId(id string) (*domain.Cliente, er
 
 	return &cliente, nil
 }
+
+func (r *ClientePostgresRepositorio) Atualizar(ctx context.Context, cliente *domain.Cliente) error {
+	result, err := conexao(ctx, r.db).ExecContext(ctx, `
+		UPDATE clientes
+		SET nome = $1, email = $2, telefone = $3
+		WHERE id = $4
+	`, cliente.Nome, cliente.Email, cliente.Telefone, cliente.ID)
+
+	if err != nil {
+		return traduzErroEmailDuplicado(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// filtroClientes monta o WHERE da listagem; os parâmetros começam em $1
+func filtroClientes(in *input.ClienteListInput) (string, []any) {
+	where := "WHERE 1 = 1"
+	var args []any
+
+	if in.Busca != "" {
+		args = append(args, "%"+in.Busca+"%")
+		where += fmt.Sprintf(" AND (nome ILIKE $%d OR email ILIKE $%d OR telefone ILIKE $%d)", len(args), len(args), len(args))
+	}
+
+	if in.Ativo != nil {
+		args = append(args, *in.Ativo)
+		where += fmt.Sprintf(" AND ativo = $%d", len(args))
+	}
+
+	return where, args
+}
+
+func (r *ClientePostgresRepositorio) Listar(ctx context.Context, in *input.ClienteListInput) ([]*domain.Cliente, error) {
+	where, args := filtroClientes(in)
+	offset := (in.Page - 1) * in.Limit
+	args = append(args, in.Limit, offset)
+
+	query := fmt.Sprintf(`
+		SELECT id, nome, email, telefone, ativo, anonimizado_em
+		FROM clientes
+		%s
+		ORDER BY nome, id
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)-1, len(args))
+
+	rows, err := conexao(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar clientes: %w", err)
+	}
+	defer rows.Close()
+
+	clientes := []*domain.Cliente{}
+	for rows.Next() {
+		var cliente domain.Cliente
+		if err := rows.Scan(
+			&cliente.ID,
+			&cliente.Nome,
+			&cliente.Email,
+			&cliente.Telefone,
+			&cliente.Ativo,
+			&cliente.AnonimizadoEm,
+		); err != nil {
+			return nil, err
+		}
+		clientes = append(clientes, &cliente)
+	}
+
+	return clientes, rows.Err()
+}
+
+func (r *ClientePostgresRepositorio) Contar(ctx context.Context, in *input.ClienteListInput) (int, error) {
+	where, args := filtroClientes(in)
+
+	var total int
+	err := conexao(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM clientes `+where, args...).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao contar clientes: %w", err)
+	}
+
+	return total, nil
+}
+
+func (r *ClientePostgresRepositorio) AtualizarStatus(ctx context.Context, id string, ativo bool) error {
+	result, err := conexao(ctx, r.db).ExecContext(ctx, `
+		UPDATE clientes
+		SET ativo = $1
+		WHERE id = $2
+	`, ativo, id)
+
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *ClientePostgresRepositorio) Anonimizar(ctx context.Context, cliente *domain.Cliente) error {
+	result, err := conexao(ctx, r.db).ExecContext(ctx, `
+		UPDATE clientes
+		SET nome = $1, email = $2, telefone = $3, ativo = $4, anonimizado_em = $5
+		WHERE id = $6
+	`, cliente.Nome, cliente.Email, cliente.Telefone, cliente.Ativo, cliente.AnonimizadoEm, cliente.ID)
+
+	if err != nil {
+		return fmt.Errorf("erro ao anonimizar cliente: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/domain"
+)
+
+type FakeIdempotenciaRepositorio struct {
+	mu        sync.Mutex
+	registros map[string]domain.RegistroIdempotencia
+}
+
+func NovoFakeIdempotenciaRepositorio() port.IdempotenciaRepositorio {
+	return &FakeIdempotenciaRepositorio{
+		registros: make(map[string]domain.RegistroIdempotencia),
+	}
+}
+
+func (r *FakeIdempotenciaRepositorio) Reservar(ctx context.Context, registro *domain.RegistroIdempotencia) (*domain.RegistroIdempotencia, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existente, ok := r.registros[registro.Chave]; ok && !existente.Expirado(registro.CriadoEm) {
+		return &existente, nil
+	}
+
+	r.registros[registro.Chave] = *registro
+	return nil, nil
+}
+
+func (r *FakeIdempotenciaRepositorio) Concluir(ctx context.Context, chave string, statusCode int, corpo []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	registro, ok := r.registros[chave]
+	if !ok {
+		return sql.ErrNoRows
+	}
+
+	registro.StatusCode = statusCode
+	registro.Corpo = append([]byte(nil), corpo...)
+	r.registros[chave] = registro
+	return nil
+}
+
+func (r *FakeIdempotenciaRepositorio) Liberar(ctx context.Context, chave string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.registros, chave)
+	return nil
+}
+
+func (r *FakeIdempotenciaRepositorio) RemoverExpirados(ctx context.Context, agora time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var removidos int64
+	for chave, registro := range r.registros {
+		if registro.Expirado(agora) {
+			delete(r.registros, chave)
+			removidos++
+		}
+	}
+	return removidos, nil
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/domain"
+)
+
+type IdempotenciaPostgresRepository struct {
+	db *sql.DB
+}
+
+func NovoIdempotenciaPostgresRepository(db *sql.DB) port.IdempotenciaRepositorio {
+	return &IdempotenciaPostgresRepository{db: db}
+}
+
+// tentativasReservar limita quantas vezes Reservar tenta de novo quando a chave some
+// entre o INSERT e a leitura do registro existente
+const tentativasReservar = 3
+
+func (r *IdempotenciaPostgresRepository) Reservar(ctx context.Context, registro *domain.RegistroIdempotencia) (*domain.RegistroIdempotencia, error) {
+	for tentativa := 1; ; tentativa++ {
+		reservada, err := r.inserir(ctx, registro)
+		if err != nil {
+			return nil, err
+		}
+		if reservada {
+			return nil, nil
+		}
+
+		// Um Liberar ou RemoverExpirados concorrente pode apagar o registro que
+		// impediu o INSERT; nesse caso a chave está livre e vale reservar de novo
+		existente, err := r.buscar(ctx, registro.Chave)
+		if errors.Is(err, sql.ErrNoRows) && tentativa < tentativasReservar {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("erro ao buscar chave de idempotência: %w", err)
+		}
+		return existente, nil
+	}
+}
+
+// inserir grava o registro se a chave estiver livre ou expirada e informa se gravou
+func (r *IdempotenciaPostgresRepository) inserir(ctx context.Context, registro *domain.RegistroIdempotencia) (bool, error) {
+	// Um único INSERT decide quem fica com a chave: só sobrescreve registros expirados
+	var chave string
+	err := conexao(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO idempotencia_chaves (chave, hash_requisicao, criado_em, expira_em)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (chave) DO UPDATE SET
+			hash_requisicao = EXCLUDED.hash_requisicao,
+			status_code = NULL,
+			corpo = NULL,
+			criado_em = EXCLUDED.criado_em,
+			expira_em = EXCLUDED.expira_em
+		WHERE idempotencia_chaves.expira_em <= EXCLUDED.criado_em
+		RETURNING chave
+	`,
+		registro.Chave,
+		registro.HashRequisicao,
+		registro.CriadoEm,
+		registro.ExpiraEm,
+	).Scan(&chave)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return false, fmt.Errorf("erro ao reservar chave de idempotência: %w", err)
+}
+
+func (r *IdempotenciaPostgresRepository) buscar(ctx context.Context, chave string) (*domain.RegistroIdempotencia, error) {
+	var (
+		existente  domain.RegistroIdempotencia
+		statusCode sql.NullInt64
+	)
+	err := conexao(ctx, r.db).QueryRowContext(ctx, `
+		SELECT chave, hash_requisicao, status_code, corpo, criado_em, expira_em
+		FROM idempotencia_chaves
+		WHERE chave = $1
+	`, chave).Scan(
+		&existente.Chave,
+		&existente.HashRequisicao,
+		&statusCode,
+		&existente.Corpo,
+		&existente.CriadoEm,
+		&existente.ExpiraEm,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	existente.StatusCode = int(statusCode.Int64)
+	return &existente, nil
+}
+
+func (r *IdempotenciaPostgresRepository) Concluir(ctx context.Context, chave string, statusCode int, corpo []byte) error {
+	result, err := conexao(ctx, r.db).ExecContext(ctx, `
+		UPDATE idempotencia_chaves
+		SET status_code = $1, corpo = $2
+		WHERE chave = $3
+	`, statusCode, corpo, chave)
+	if err != nil {
+		return fmt.Errorf("erro ao concluir chave de idempotência: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *IdempotenciaPostgresRepository) Liberar(ctx context.Context, chave string) error {
+	_, err := conexao(ctx, r.db).ExecContext(ctx, `DELETE FROM idempotencia_chaves WHERE chave = $1`, chave)
+	if err != nil {
+		return fmt.Errorf("erro ao liberar chave de idempotência: %w", err)
+	}
+	return nil
+}
+
+func (r *IdempotenciaPostgresRepository) RemoverExpirados(ctx context.Context, agora time.Time) (int64, error) {
+	result, err := conexao(ctx, r.db).ExecContext(ctx, `DELETE FROM idempotencia_chaves WHERE expira_em <= $1`, agora)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao remover chaves de idempotência expiradas: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/domain"
+)
+
+type FakeListaEsperaRepositorio struct {
+	mu       sync.Mutex
+	entradas map[string]domain.EntradaListaEspera
+}
+
+func NovoFakeListaEsperaRepositorio() port.ListaEsperaRepositorio {
+	return &FakeListaEsperaRepositorio{
+		entradas: make(map[string]domain.EntradaListaEspera),
+	}
+}
+
+func (r *FakeListaEsperaRepositorio) Salvar(ctx context.Context, entrada *domain.EntradaListaEspera) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entradas[entrada.ID] = copiaEntrada(entrada)
+	return nil
+}
+
+func (r *FakeListaEsperaRepositorio) BuscarPorId(ctx context.Context, id string) (*domain.EntradaListaEspera, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entrada, ok := r.entradas[id]
+	if !ok {
+		return nil, nil
+	}
+	copia := copiaEntrada(&entrada)
+	return &copia, nil
+}
+
+func (r *FakeListaEsperaRepositorio) BuscarPorIdParaAtualizar(ctx context.Context, id string) (*domain.EntradaListaEspera, error) {
+	return r.BuscarPorId(ctx, id)
+}
+
+// TravarPrestador não faz nada: a FakeUnidadeDeTrabalho já executa uma transação por vez
+func (r *FakeListaEsperaRepositorio) TravarPrestador(ctx context.Context, prestadorID string) error {
+	return nil
+}
+
+func (r *FakeListaEsperaRepositorio) Atualizar(ctx context.Context, entrada *domain.EntradaListaEspera) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.entradas[entrada.ID]; !ok {
+		return sql.ErrNoRows
+	}
+	r.entradas[entrada.ID] = copiaEntrada(entrada)
+	return nil
+}
+
+func (r *FakeListaEsperaRepositorio) BuscarAguardando(ctx context.Context, prestadorID string, inicio, fim time.Time) ([]*domain.EntradaListaEspera, error) {
+	return r.filtrar(func(e *domain.EntradaListaEspera) bool {
+		return e.Status == domain.AguardandoVaga &&
+			e.AceitaPrestador(prestadorID) &&
+			e.JanelaInicio.Before(fim) && e.JanelaFim.After(inicio)
+	}), nil
+}
+
+func (r *FakeListaEsperaRepositorio) BuscarOfertasAtivas(ctx context.Context, prestadorID string, inicio, fim, agora time.Time) ([]*domain.EntradaListaEspera, error) {
+	return r.filtrar(func(e *domain.EntradaListaEspera) bool {
+		return e.Status == domain.VagaOferecida &&
+			!e.OfertaVencida(agora) &&
+			e.Oferta.PrestadorID == prestadorID &&
+			e.Oferta.DataHoraInicio.Before(fim) && e.Oferta.DataHoraFim.After(inicio)
+	}), nil
+}
+
+func (r *FakeListaEsperaRepositorio) BuscarPorCliente(ctx context.Context, clienteID string) ([]*domain.EntradaListaEspera, error) {
+	return r.filtrar(func(e *domain.EntradaListaEspera) bool {
+		return e.ClienteID == clienteID
+	}), nil
+}
+
+func (r *FakeListaEsperaRepositorio) BuscarOfertasVencidas(ctx context.Context, agora time.Time) ([]*domain.EntradaListaEspera, error) {
+	return r.filtrar(func(e *domain.EntradaListaEspera) bool {
+		return e.OfertaVencida(agora)
+	}), nil
+}
+
+// salvarEstado permite que a FakeUnidadeDeTrabalho desfaça as alterações
+func (r *FakeListaEsperaRepositorio) salvarEstado() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entradas := make(map[string]domain.EntradaListaEspera, len(r.entradas))
+	for id, e := range r.entradas {
+		entradas[id] = e
+	}
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.entradas = entradas
+	}
+}
+
+// filtrar devolve cópias ordenadas por ordem de chegada na lista
+func (r *FakeListaEsperaRepositorio) filtrar(filtro func(*domain.EntradaListaEspera) bool) []*domain.EntradaListaEspera {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	resultado := []*domain.EntradaListaEspera{}
+	for _, e := range r.entradas {
+		copia := copiaEntrada(&e)
+		if filtro(&copia) {
+			resultado = append(resultado, &copia)
+		}
+	}
+
+	sort.Slice(resultado, func(i, j int) bool {
+		if resultado[i].CriadoEm.Equal(resultado[j].CriadoEm) {
+			return resultado[i].ID < resultado[j].ID
+		}
+		return resultado[i].CriadoEm.Before(resultado[j].CriadoEm)
+	})
+	return resultado
+}
+
+func copiaEntrada(e *domain.EntradaListaEspera) domain.EntradaListaEspera {
+	copia := *e
+	if e.Oferta != nil {
+		oferta := *e.Oferta
+		copia.Oferta = &oferta
+	}
+	return copia
+}
@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/domain"
+)
+
+type ListaEsperaPostgresRepository struct {
+	db *sql.DB
+}
+
+func NovoListaEsperaPostgresRepository(db *sql.DB) port.ListaEsperaRepositorio {
+	return &ListaEsperaPostgresRepository{db: db}
+}
+
+const colunasListaEspera = `
+	id, cliente_id, catalogo_id, COALESCE(prestador_id, ''),
+	janela_inicio, janela_fim, status, created_at,
+	oferta_prestador_id, oferta_inicio, oferta_fim, oferta_expira_em,
+	COALESCE(agendamento_id, '')
+`
+
+func (r *ListaEsperaPostgresRepository) Salvar(ctx context.Context, e *domain.EntradaListaEspera) error {
+	_, err := conexao(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO lista_espera (id, cliente_id, catalogo_id, prestador_id, janela_inicio, janela_fim, status, created_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8)
+	`,
+		e.ID,
+		e.ClienteID,
+		e.CatalogoID,
+		e.PrestadorID,
+		e.JanelaInicio,
+		e.JanelaFim,
+		e.Status,
+		e.CriadoEm,
+	)
+	if err != nil {
+		return fmt.Errorf("erro ao inserir entrada na lista de espera: %w", err)
+	}
+	return nil
+}
+
+func (r *ListaEsperaPostgresRepository) BuscarPorId(ctx context.Context, id string) (*domain.EntradaListaEspera, error) {
+	return r.buscar(ctx, `SELECT `+colunasListaEspera+` FROM lista_espera WHERE id = $1`, id)
+}
+
+func (r *ListaEsperaPostgresRepository) BuscarPorIdParaAtualizar(ctx context.Context, id string) (*domain.EntradaListaEspera, error) {
+	return r.buscar(ctx, `SELECT `+colunasListaEspera+` FROM lista_espera WHERE id = $1 FOR UPDATE`, id)
+}
+
+func (r *ListaEsperaPostgresRepository) TravarPrestador(ctx context.Context, prestadorID string) error {
+	return travarAgendaPrestador(ctx, conexao(ctx, r.db), prestadorID)
+}
+
+func (r *ListaEsperaPostgresRepository) buscar(ctx context.Context, query string, args ...any) (*domain.EntradaListaEspera, error) {
+	row := conexao(ctx, r.db).QueryRowContext(ctx, query, args...)
+
+	entrada, err := scanEntradaListaEspera(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return entrada, nil
+}
+
+func (r *ListaEsperaPostgresRepository) Atualizar(ctx context.Context, e *domain.EntradaListaEspera) error {
+	var (
+		ofertaPrestadorID sql.NullString
+		ofertaInicio      sql.NullTime
+		ofertaFim         sql.NullTime
+		ofertaExpiraEm    sql.NullTime
+	)
+	if e.Oferta != nil {
+		ofertaPrestadorID = sql.NullString{String: e.Oferta.PrestadorID, Valid: true}
+		ofertaInicio = sql.NullTime{Time: e.Oferta.DataHoraInicio, Valid: true}
+		ofertaFim = sql.NullTime{Time: e.Oferta.DataHoraFim, Valid: true}
+		ofertaExpiraEm = sql.NullTime{Time: e.Oferta.ExpiraEm, Valid: true}
+	}
+
+	result, err := conexao(ctx, r.db).ExecContext(ctx, `
+		UPDATE lista_espera
+		SET status = $1,
+			oferta_prestador_id = $2,
+			oferta_inicio = $3,
+			oferta_fim = $4,
+			oferta_expira_em = $5,
+			agendamento_id = NULLIF($6, '')
+		WHERE id = $7
+	`,
+		e.Status,
+		ofertaPrestadorID,
+		ofertaInicio,
+		ofertaFim,
+		ofertaExpiraEm,
+		e.AgendamentoID,
+		e.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("erro ao atualizar lista de espera: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *ListaEsperaPostgresRepository) BuscarAguardando(ctx context.Context, prestadorID string, inicio, fim time.Time) ([]*domain.EntradaListaEspera, error) {
+	return r.listar(ctx, `
+		SELECT `+colunasListaEspera+`
+		FROM lista_espera
+		WHERE status = $1
+		  AND (prestador_id IS NULL OR prestador_id = $2)
+		  AND janela_inicio < $4
+		  AND janela_fim > $3
+		ORDER BY created_at, id
+		FOR UPDATE
+	`, domain.AguardandoVaga, prestadorID, inicio, fim)
+}
+
+func (r *ListaEsperaPostgresRepository) BuscarOfertasAtivas(ctx context.Context, prestadorID string, inicio, fim, agora time.Time) ([]*domain.EntradaListaEspera, error) {
+	return r.listar(ctx, `
+		SELECT `+colunasListaEspera+`
+		FROM lista_espera
+		WHERE status = $1
+		  AND oferta_prestador_id = $2
+		  AND oferta_inicio < $4
+		  AND oferta_fim > $3
+		  AND oferta_expira_em > $5
+		ORDER BY created_at, id
+	`, domain.VagaOferecida, prestadorID, inicio, fim, agora)
+}
+
+func (r *ListaEsperaPostgresRepository) BuscarPorCliente(ctx context.Context, clienteID string) ([]*domain.EntradaListaEspera, error) {
+	return r.listar(ctx, `
+		SELECT `+colunasListaEspera+`
+		FROM lista_espera
+		WHERE cliente_id = $1
+		ORDER BY created_at, id
+	`, clienteID)
+}
+
+func (r *ListaEsperaPostgresRepository) BuscarOfertasVencidas(ctx context.Context, agora time.Time) ([]*domain.EntradaListaEspera, error) {
+	return r.listar(ctx, `
+		SELECT `+colunasListaEspera+`
+		FROM lista_espera
+		WHERE status = $1
+		  AND oferta_expira_em <= $2
+		ORDER BY created_at, id
+	`, domain.VagaOferecida, agora)
+}
+
+func (r *ListaEsperaPostgresRepository) listar(ctx context.Context, query string, args ...any) ([]*domain.EntradaListaEspera, error) {
+	rows, err := conexao(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar lista de espera: %w", err)
+	}
+	defer rows.Close()
+
+	entradas := []*domain.EntradaListaEspera{}
+	for rows.Next() {
+		entrada, err := scanEntradaListaEspera(rows)
+		if err != nil {
+			return nil, err
+		}
+		entradas = append(entradas, entrada)
+	}
+
+	return entradas, rows.Err()
+}
+
+type scannerListaEspera interface {
+	Scan(dest ...any) error
+}
+
+func scanEntradaListaEspera(s scannerListaEspera) (*domain.EntradaListaEspera, error) {
+	var (
+		e                 domain.EntradaListaEspera
+		ofertaPrestadorID sql.NullString
+		ofertaInicio      sql.NullTime
+		ofertaFim         sql.NullTime
+		ofertaExpiraEm    sql.NullTime
+	)
+
+	err := s.Scan(
+		&e.ID,
+		&e.ClienteID,
+		&e.CatalogoID,
+		&e.PrestadorID,
+		&e.JanelaInicio,
+		&e.JanelaFim,
+		&e.Status,
+		&e.CriadoEm,
+		&ofertaPrestadorID,
+		&ofertaInicio,
+		&ofertaFim,
+		&ofertaExpiraEm,
+		&e.AgendamentoID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if ofertaPrestadorID.Valid {
+		e.Oferta = &domain.OfertaVaga{
+			PrestadorID:    ofertaPrestadorID.String,
+			DataHoraInicio: ofertaInicio.Time,
+			DataHoraFim:    ofertaFim.Time,
+			ExpiraEm:       ofertaExpiraEm.Time,
+		}
+	}
+
+	return &e, nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/domain"
+	"sort"
+)
+
+type FakeModeloAgendaRepositorio struct {
+	storage map[string]*domain.ModeloAgenda
+}
+
+func NovoFakeModeloAgendaRepositorio() port.ModeloAgendaRepositorio {
+	return &FakeModeloAgendaRepositorio{
+		storage: make(map[string]*domain.ModeloAgenda),
+	}
+}
+
+func (r *FakeModeloAgendaRepositorio) Salvar(ctx context.Context, modelo *domain.ModeloAgenda) error {
+	r.storage[modelo.ID] = modelo
+	return nil
+}
+
+func (r *FakeModeloAgendaRepositorio) BuscarPorId(ctx context.Context, id string) (*domain.ModeloAgenda, error) {
+	modelo, ok := r.storage[id]
+	if !ok {
+		return nil, nil
+	}
+	return modelo, nil
+}
+
+func (r *FakeModeloAgendaRepositorio) ListarPorPrestador(ctx context.Context, prestadorID string) ([]*domain.ModeloAgenda, error) {
+	var modelos []*domain.ModeloAgenda
+	for _, m := range r.storage {
+		if m.PrestadorID == prestadorID {
+			modelos = append(modelos, m)
+		}
+	}
+
+	sort.Slice(modelos, func(i, j int) bool {
+		return modelos[i].VigenciaInicio.Before(modelos[j].VigenciaInicio)
+	})
+
+	return modelos, nil
+}
+
+func (r *FakeModeloAgendaRepositorio) Deletar(ctx context.Context, id string) error {
+	if _, ok := r.storage[id]; !ok {
+		return sql.ErrNoRows
+	}
+	delete(r.storage, id)
+	return nil
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/domain"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type ModeloAgendaPostgresRepository struct {
+	db *sql.DB
+}
+
+func NovoModeloAgendaPostgresRepository(db *sql.DB) port.ModeloAgendaRepositorio {
+	return &ModeloAgendaPostgresRepository{db: db}
+}
+
+func (r *ModeloAgendaPostgresRepository) Salvar(ctx context.Context, modelo *domain.ModeloAgenda) error {
+	tx, err := iniciarTransacao(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	dias := make([]int64, len(modelo.DiasDaSemana))
+	for i, d := range modelo.DiasDaSemana {
+		dias[i] = int64(d)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO modelos_agenda (id, prestador_id, dias_semana, vigencia_inicio, vigencia_fim, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+	`,
+		modelo.ID,
+		modelo.PrestadorID,
+		pq.Array(dias),
+		modelo.VigenciaInicio.Format("2006-01-02"),
+		modelo.VigenciaFim.Format("2006-01-02"),
+	)
+	if err != nil {
+		return fmt.Errorf("erro ao inserir modelo de agenda: %w", err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO modelos_agenda_intervalos (id, modelo_id, hora_inicio, hora_fim)
+		VALUES ($1, $2, $3, $4)
+	`)
+	if err != nil {
+		return fmt.Errorf("erro ao preparar insert de intervalos: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, it := range modelo.Intervalos {
+		if _, err := stmt.ExecContext(ctx, it.Id, modelo.ID, it.HoraInicio.Format("15:04:05"), it.HoraFim.Format("15:04:05")); err != nil {
+			return fmt.Errorf("erro ao inserir intervalo do modelo: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *ModeloAgendaPostgresRepository) BuscarPorId(ctx context.Context, id string) (*domain.ModeloAgenda, error) {
+	modelos, err := r.buscar(ctx, `WHERE m.id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(modelos) == 0 {
+		return nil, nil
+	}
+	return modelos[0], nil
+}
+
+func (r *ModeloAgendaPostgresRepository) ListarPorPrestador(ctx context.Context, prestadorID string) ([]*domain.ModeloAgenda, error) {
+	return r.buscar(ctx, `WHERE m.prestador_id = $1`, prestadorID)
+}
+
+func (r *ModeloAgendaPostgresRepository) Deletar(ctx context.Context, id string) error {
+	result, err := conexao(ctx, r.db).ExecContext(ctx, `DELETE FROM modelos_agenda WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("erro ao deletar modelo de agenda: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *ModeloAgendaPostgresRepository) buscar(ctx context.Context, filtro string, arg string) ([]*domain.ModeloAgenda, error) {
+	query := `
+		SELECT
+			m.id,
+			m.prestador_id,
+			m.dias_semana,
+			m.vigencia_inicio,
+			m.vigencia_fim,
+			i.id,
+			i.hora_inicio,
+			i.hora_fim
+		FROM modelos_agenda m
+		LEFT JOIN modelos_agenda_intervalos i ON i.modelo_id = m.id
+		` + filtro + `
+		ORDER BY m.vigencia_inicio, m.id, i.hora_inicio
+	`
+
+	rows, err := conexao(ctx, r.db).QueryContext(ctx, query, arg)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar modelos de agenda: %w", err)
+	}
+	defer rows.Close()
+
+	var modelos []*domain.ModeloAgenda
+	porID := make(map[string]*domain.ModeloAgenda)
+
+	for rows.Next() {
+		var (
+			modeloID       string
+			prestadorID    string
+			dias           pq.Int64Array
+			vigenciaInicio time.Time
+			vigenciaFim    time.Time
+
+			intervaloID         sql.NullString
+			intervaloHoraInicio sql.NullTime
+			intervaloHoraFim    sql.NullTime
+		)
+
+		err := rows.Scan(
+			&modeloID,
+			&prestadorID,
+			&dias,
+			&vigenciaInicio,
+			&vigenciaFim,
+			&intervaloID,
+			&intervaloHoraInicio,
+			&intervaloHoraFim,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao fazer scan: %w", err)
+		}
+
+		modelo, ok := porID[modeloID]
+		if !ok {
+			modelo = &domain.ModeloAgenda{
+				ID:             modeloID,
+				PrestadorID:    prestadorID,
+				DiasDaSemana:   make([]time.Weekday, len(dias)),
+				Intervalos:     []domain.IntervaloDiario{},
+				VigenciaInicio: vigenciaInicio,
+				VigenciaFim:    vigenciaFim,
+			}
+			for i, d := range dias {
+				modelo.DiasDaSemana[i] = time.Weekday(d)
+			}
+			porID[modeloID] = modelo
+			modelos = append(modelos, modelo)
+		}
+
+		if intervaloID.Valid {
+			modelo.Intervalos = append(modelo.Intervalos, domain.IntervaloDiario{
+				Id:         intervaloID.String,
+				HoraInicio: intervaloHoraInicio.Time,
+				HoraFim:    intervaloHoraFim.Time,
+			})
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao iterar rows: %w", err)
+	}
+
+	return modelos, nil
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -24,12 +25,12 @@ func NovoFakePrestadorRepositorio(catalogoRepo port.CatalogoRepositorio) port.Pr
 	}
 }
 
-func (r *FakePrestadorRepositorio) Salvar(prestador *domain.Prestador) error {
+func (r *FakePrestadorRepositorio) Salvar(ctx context.Context, prestador *domain.Prestador) error {
 	r.storage[prestador.ID] = prestador
 	return nil
 }
 
-func (r *FakePrestadorRepositorio) BuscarPorId(id string) (*domain.Prestador, error) {
+func (r *FakePrestadorRepositorio) BuscarPorId(ctx context.Context, id string) (*domain.Prestador, error) {
 	prestador := r.storage[id]
 	if prestador == nil {
 		return nil, errors.New("não encontrado")
@@ -37,7 +38,7 @@ func (r *FakePrestadorRepositorio) BuscarPorId(id string) (*domain.Prestador, er
 	return prestador, nil
 }
 
-func (r *FakePrestadorRepositorio) BuscarPorCPF(cpf string) (*domain.Prestador, error) {
+func (r *FakePrestadorRepositorio) BuscarPorCPF(ctx context.Context, cpf string) (*domain.Prestador, error) {
 	cpf = cpfcnpj.Clean(cpf)
 	for _, p := range r.storage {
 		if cpfcnpj.Clean(p.Cpf) == cpf {
@@ -47,7 +48,7 @@ func (r *FakePrestadorRepositorio) BuscarPorCPF(cpf string) (*domain.Prestador,
 	return nil, nil
 }
 
-func (r *FakePrestadorRepositorio) BuscarAgendaDoDia(prestadorID string, data string) (*domain.AgendaDiaria, error) {
+func (r *FakePrestadorRepositorio) BuscarAgendaDoDia(ctx context.Context, prestadorID string, data string) (*domain.AgendaDiaria, error) {
 	prestador, ok := r.storage[prestadorID]
 	if !ok {
 		return nil, nil
@@ -62,7 +63,7 @@ func (r *FakePrestadorRepositorio) BuscarAgendaDoDia(prestadorID string, data st
 	return nil, nil
 }
 
-func (r *FakePrestadorRepositorio) Atualizar(input *input.AlterarPrestadorInput) error {
+func (r *FakePrestadorRepositorio) Atualizar(ctx context.Context, input *input.AlterarPrestadorInput) error {
 	// 1️⃣ Verifica se o prestador existe
 	prestador, exists := r.storage[input.Id]
 	if !exists {
@@ -71,7 +72,7 @@ func (r *FakePrestadorRepositorio) Atualizar(input *input.AlterarPrestadorInput)
 
 	// 2️⃣ Valida se os catálogos existem
 	for _, catalogoID := range input.CatalogoIDs {
-		_, err := r.catalogoRepo.BuscarPorId(catalogoID)
+		_, err := r.catalogoRepo.BuscarPorId(ctx, catalogoID)
 		if err != nil {
 			return fmt.Errorf("catálogo %s não existe", catalogoID)
 		}
@@ -82,22 +83,35 @@ func (r *FakePrestadorRepositorio) Atualizar(input *input.AlterarPrestadorInput)
 	prestador.Email = input.Email
 	prestador.Telefone = input.Telefone
 	prestador.ImagemUrl = input.ImagemUrl
+	if input.FusoHorario != "" {
+		prestador.FusoHorario = input.FusoHorario
+	}
 
 	// 4️⃣ Atualiza os catálogos
 	novos := make([]domain.Catalogo, len(input.CatalogoIDs))
 	for i, catalogoID := range input.CatalogoIDs {
-		catalogo, _ := r.catalogoRepo.BuscarPorId(catalogoID)
+		catalogo, _ := r.catalogoRepo.BuscarPorId(ctx, catalogoID)
 		novos[i] = *catalogo
 	}
 	prestador.Catalogo = novos
 
+	condicoes := make([]domain.CondicaoDoServico, 0, len(input.Condicoes))
+	for _, c := range input.Condicoes {
+		condicoes = append(condicoes, domain.CondicaoDoServico{
+			CatalogoID:    c.CatalogoID,
+			Preco:         c.Preco,
+			DuracaoPadrao: c.DuracaoPadrao,
+		})
+	}
+	prestador.Condicoes = condicoes
+
 	// 5️⃣ Salva de volta
 	r.storage[input.Id] = prestador
 
 	return nil
 }
 
-func (r *FakePrestadorRepositorio) Listar(input *input.PrestadorListInput) ([]*domain.Prestador, error) {
+func (r *FakePrestadorRepositorio) Listar(ctx context.Context, input *input.PrestadorListInput) ([]*domain.Prestador, error) {
 	// ✅ Sempre filtra por status (obrigatório)
 	todos := make([]*domain.Prestador, 0, len(r.storage))
 	for _, p := range r.storage {
@@ -126,7 +140,7 @@ func (r *FakePrestadorRepositorio) Listar(input *input.PrestadorListInput) ([]*d
 	return todos[offset:fim], nil
 }
 
-func (r *FakePrestadorRepositorio) Contar(ativo bool) (int, error) {
+func (r *FakePrestadorRepositorio) Contar(ctx context.Context, ativo bool) (int, error) {
 	// ✅ Conta apenas os que correspondem ao filtro
 	count := 0
 	for _, p := range r.storage {
@@ -138,7 +152,7 @@ func (r *FakePrestadorRepositorio) Contar(ativo bool) (int, error) {
 	return count, nil
 }
 
-func (r *FakePrestadorRepositorio) AtualizarStatus(id string, ativo bool) error {
+func (r *FakePrestadorRepositorio) AtualizarStatus(ctx context.Context, id string, ativo bool) error {
 	prestador, exists := r.storage[id]
 	if !exists {
 		return sql.ErrNoRows
@@ -150,18 +164,19 @@ func (r *FakePrestadorRepositorio) AtualizarStatus(id string, ativo bool) error
 	return nil
 }
 
-func (r *FakeAgendaDiariaRepositorio) DeletarAgenda(prestadorID string, data string) error {
-	chave := fmt.Sprintf("%s:%s", prestadorID, data)
-	
-	if _, exists := r.storage[chave]; !exists {
+func (r *FakePrestadorRepositorio) AtualizarTemposEntreAtendimentos(ctx context.Context, id string, preparo, limpeza *int) error {
+	prestador, exists := r.storage[id]
+	if !exists {
 		return sql.ErrNoRows
 	}
 
-	delete(r.storage, chave)
+	prestador.TempoPreparo = preparo
+	prestador.TempoLimpeza = limpeza
+
 	return nil
 }
 
-func (r *FakePrestadorRepositorio) BuscarPrestadoresDisponiveisPorData(data string, page, limit int) ([]*domain.Prestador, error) {
+func (r *FakePrestadorRepositorio) BuscarPrestadoresDisponiveisPorData(ctx context.Context, data string, page, limit int) ([]*domain.Prestador, error) {
 	// Filtra prestadores ativos que têm agenda na data
 	disponiveis := make([]*domain.Prestador, 0)
 	
@@ -206,7 +221,7 @@ func (r *FakePrestadorRepositorio) BuscarPrestadoresDisponiveisPorData(data stri
 }
 
 // ContarPrestadoresDisponiveisPorData conta quantos prestadores ativos têm agenda na data informada
-func (r *FakePrestadorRepositorio) ContarPrestadoresDisponiveisPorData(data string) (int, error) {
+func (r *FakePrestadorRepositorio) ContarPrestadoresDisponiveisPorData(ctx context.Context, data string) (int, error) {
 	count := 0
 	
 	for _, p := range r.storage {
@@ -225,4 +240,10 @@ func (r *FakePrestadorRepositorio) ContarPrestadoresDisponiveisPorData(data stri
 	}
 	
 	return count, nil
-}
\ No newline at end of file
+}
+
+// salvarEstado permite que a FakeUnidadeDeTrabalho desfaça as alterações
+func (r *FakePrestadorRepositorio) salvarEstado() func() {
+	restaurar := salvarMapa(r.storage)
+	return func() { r.storage = restaurar() }
+}
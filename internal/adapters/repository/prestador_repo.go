@@ -1,9 +1,10 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
 	"strings"
 	"time"
 
@@ -22,18 +23,18 @@ func NewPrestadorPostgresRepository(db *sql.DB) port.PrestadorRepositorio {
 	return &PrestadorPostgresRepository{db: db}
 }
 
-func (r *PrestadorPostgresRepository) Salvar(prestador *domain.Prestador) error {
+func (r *PrestadorPostgresRepository) Salvar(ctx context.Context, prestador *domain.Prestador) error {
 	// Inicia uma transação
-	tx, err := r.db.Begin()
+	tx, err := iniciarTransacao(ctx, r.db)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback() // rollback automático se ocorrer erro
 
 	// 1️⃣ Insere prestador
-	_, err = tx.Exec(`
-		INSERT INTO prestadores (id, nome, cpf, email, telefone, ativo, imagem_url, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO prestadores (id, nome, cpf, email, telefone, ativo, imagem_url, fuso_horario, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
 	`,
 		prestador.ID,
 		prestador.Nome,
@@ -42,16 +43,17 @@ func (r *PrestadorPostgresRepository) Salvar(prestador *domain.Prestador) error
 		prestador.Telefone,
 		prestador.Ativo,
 		prestador.ImagemUrl,
+		prestador.FusoHorario,
 	)
 	if err != nil {
-		log.Printf("erro ao inserir prestador: %+v", err)
+		slog.ErrorContext(ctx, "erro ao inserir prestador", slog.Any("erro", err))
 		return err
 	}
 
 	// 2️⃣ Vincula catálogos
 	if len(prestador.Catalogo) > 0 {
 		// ON CONFLICT precisa do nome da constraint ou coluna
-		stmt, err := tx.Prepare(`
+		stmt, err := tx.PrepareContext(ctx, `
 			INSERT INTO prestador_catalogos (prestador_id, catalogo_id)
 			VALUES ($1, $2)
 			ON CONFLICT (prestador_id, catalogo_id) DO NOTHING
@@ -62,9 +64,9 @@ func (r *PrestadorPostgresRepository) Salvar(prestador *domain.Prestador) error
 		defer stmt.Close()
 
 		for _, catalogo := range prestador.Catalogo {
-			if _, err := stmt.Exec(prestador.ID, catalogo.ID); err != nil {
-				log.Printf("erro ao inserir prestador_catalogos: prestador_id=%s, catalogo_id=%s, erro=%v",
-					prestador.ID, catalogo.ID, err)
+			if _, err := stmt.ExecContext(ctx, prestador.ID, catalogo.ID); err != nil {
+				slog.ErrorContext(ctx, "erro ao inserir prestador_catalogos",
+					slog.String("prestador_id", prestador.ID), slog.String("catalogo_id", catalogo.ID), slog.Any("erro", err))
 				return err
 			}
 		}
@@ -72,13 +74,13 @@ func (r *PrestadorPostgresRepository) Salvar(prestador *domain.Prestador) error
 
 	// 3️⃣ Commit da transação
 	if err := tx.Commit(); err != nil {
-		log.Printf("erro ao fazer commit: %v", err)
+		slog.ErrorContext(ctx, "erro ao fazer commit", slog.Any("erro", err))
 		return err
 	}
 	return nil
 }
 
-func (r *PrestadorPostgresRepository) BuscarPorId(id string) (*domain.Prestador, error) {
+func (r *PrestadorPostgresRepository) BuscarPorId(ctx context.Context, id string) (*domain.Prestador, error) {
 	query := `
 	SELECT 
 		p.id,
@@ -88,6 +90,9 @@ func (r *PrestadorPostgresRepository) BuscarPorId(id string) (*domain.Prestador,
 		p.telefone,
 		p.ativo,
 		p.imagem_url AS prestador_imagem_url,
+		p.fuso_horario,
+		p.tempo_preparo,
+		p.tempo_limpeza,
 		-- Dados do Catálogo
 		c.id AS catalogo_id,
 		c.nome AS catalogo_nome,
@@ -95,6 +100,10 @@ func (r *PrestadorPostgresRepository) BuscarPorId(id string) (*domain.Prestador,
 		c.preco AS catalogo_preco,
 		c.imagem_url AS catalogo_imagem_url,
 		c.categoria AS catalogo_categoria,
+		c.tempo_preparo AS catalogo_tempo_preparo,
+		c.tempo_limpeza AS catalogo_tempo_limpeza,
+		pc.preco AS condicao_preco,
+		pc.duracao_padrao AS condicao_duracao_padrao,
 		-- Dados da Agenda Diária
 		ad.id AS agenda_id,
 		ad.data AS agenda_data,
@@ -114,7 +123,7 @@ func (r *PrestadorPostgresRepository) BuscarPorId(id string) (*domain.Prestador,
 		id.hora_inicio
 	`
 
-	rows, err := r.db.Query(query, id)
+	rows, err := conexao(ctx, r.db).QueryContext(ctx, query, id)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao executar query: %w", err)
 	}
@@ -128,8 +137,9 @@ func (r *PrestadorPostgresRepository) BuscarPorId(id string) (*domain.Prestador,
 	for rows.Next() {
 		var (
 			// Prestador
-			pID, pNome, pCpf, pEmail, pTelefone, pImagemUrl string
-			pAtivo                                          bool
+			pID, pNome, pCpf, pEmail, pTelefone, pImagemUrl, pFusoHorario string
+			pAtivo                                                        bool
+			pTempoPreparo, pTempoLimpeza                                  sql.NullInt64
 
 			// Catálogo (nullable)
 			catalogoID            sql.NullString
@@ -138,6 +148,12 @@ func (r *PrestadorPostgresRepository) BuscarPorId(id string) (*domain.Prestador,
 			catalogoPreco         sql.NullInt64
 			catalogoImagemUrl     sql.NullString
 			catalogoCategoria     sql.NullString
+			catalogoTempoPreparo  sql.NullInt64
+			catalogoTempoLimpeza  sql.NullInt64
+
+			// Preço e duração próprios do prestador (nullable)
+			condicaoPreco         sql.NullInt64
+			condicaoDuracaoPadrao sql.NullInt64
 
 			// Agenda (nullable)
 			agendaID   sql.NullString
@@ -150,9 +166,11 @@ func (r *PrestadorPostgresRepository) BuscarPorId(id string) (*domain.Prestador,
 		)
 
 		err := rows.Scan(
-			&pID, &pNome, &pCpf, &pEmail, &pTelefone, &pAtivo, &pImagemUrl,
+			&pID, &pNome, &pCpf, &pEmail, &pTelefone, &pAtivo, &pImagemUrl, &pFusoHorario,
+			&pTempoPreparo, &pTempoLimpeza,
 			&catalogoID, &catalogoNome, &catalogoDuracaoPadrao, &catalogoPreco,
-			&catalogoImagemUrl, &catalogoCategoria,
+			&catalogoImagemUrl, &catalogoCategoria, &catalogoTempoPreparo, &catalogoTempoLimpeza,
+			&condicaoPreco, &condicaoDuracaoPadrao,
 			&agendaID, &agendaData,
 			&intervaloID, &intervaloHoraInicio, &intervaloHoraFim,
 		)
@@ -163,13 +181,16 @@ func (r *PrestadorPostgresRepository) BuscarPorId(id string) (*domain.Prestador,
 		// Inicializa prestador apenas uma vez
 		if prestador == nil {
 			prestador = &domain.Prestador{
-				ID:        pID,
-				Nome:      pNome,
-				Cpf:       pCpf,
-				Email:     pEmail,
-				Telefone:  pTelefone,
-				Ativo:     pAtivo,
-				ImagemUrl: pImagemUrl,
+				ID:           pID,
+				Nome:         pNome,
+				Cpf:          pCpf,
+				Email:        pEmail,
+				Telefone:     pTelefone,
+				Ativo:        pAtivo,
+				ImagemUrl:    pImagemUrl,
+				FusoHorario:  pFusoHorario,
+				TempoPreparo: inteiroOpcional(pTempoPreparo),
+				TempoLimpeza: inteiroOpcional(pTempoLimpeza),
 			}
 		}
 
@@ -182,11 +203,21 @@ func (r *PrestadorPostgresRepository) BuscarPorId(id string) (*domain.Prestador,
 					DuracaoPadrao: int(catalogoDuracaoPadrao.Int64),
 					Preco:         int(catalogoPreco.Int64),
 					Categoria:     catalogoCategoria.String,
+					TempoPreparo:  int(catalogoTempoPreparo.Int64),
+					TempoLimpeza:  int(catalogoTempoLimpeza.Int64),
 				}
 				if catalogoImagemUrl.Valid {
 					catalogo.ImagemUrl = catalogoImagemUrl.String
 				}
 				catalogosMap[catalogoID.String] = catalogo
+
+				if condicaoPreco.Valid || condicaoDuracaoPadrao.Valid {
+					prestador.Condicoes = append(prestador.Condicoes, domain.CondicaoDoServico{
+						CatalogoID:    catalogoID.String,
+						Preco:         inteiroOpcional(condicaoPreco),
+						DuracaoPadrao: inteiroOpcional(condicaoDuracaoPadrao),
+					})
+				}
 			}
 		}
 
@@ -205,7 +236,7 @@ func (r *PrestadorPostgresRepository) BuscarPorId(id string) (*domain.Prestador,
 			if intervaloID.Valid {
 				// Cria chave única: agendaID + intervaloID
 				chaveIntervalo := fmt.Sprintf("%s:%s", agendaID.String, intervaloID.String)
-				
+
 				// ✅ Verifica se já foi adicionado
 				if !intervalosMap[chaveIntervalo] {
 					intervalo := domain.IntervaloDiario{
@@ -217,7 +248,7 @@ func (r *PrestadorPostgresRepository) BuscarPorId(id string) (*domain.Prestador,
 						agendasMap[agendaID.String].Intervalos,
 						intervalo,
 					)
-					
+
 					// ✅ Marca como adicionado
 					intervalosMap[chaveIntervalo] = true
 				}
@@ -248,10 +279,19 @@ func (r *PrestadorPostgresRepository) BuscarPorId(id string) (*domain.Prestador,
 	return prestador, nil
 }
 
-func (r *PrestadorPostgresRepository) BuscarPorCPF(cpf string) (*domain.Prestador, error) {
+// inteiroOpcional converte uma coluna INTEGER que aceita nulo
+func inteiroOpcional(n sql.NullInt64) *int {
+	if !n.Valid {
+		return nil
+	}
+	v := int(n.Int64)
+	return &v
+}
+
+func (r *PrestadorPostgresRepository) BuscarPorCPF(ctx context.Context, cpf string) (*domain.Prestador, error) {
 	var p domain.Prestador
-	err := r.db.QueryRow(`
-        SELECT id, nome, cpf, email, telefone, ativo, imagem_url
+	err := conexao(ctx, r.db).QueryRowContext(ctx, `
+        SELECT id, nome, cpf, email, telefone, ativo, imagem_url, fuso_horario
         FROM prestadores
         WHERE cpf = $1
     `, cpf).Scan(
@@ -262,6 +302,7 @@ func (r *PrestadorPostgresRepository) BuscarPorCPF(cpf string) (*domain.Prestado
 		&p.Telefone,
 		&p.Ativo,
 		&p.ImagemUrl,
+		&p.FusoHorario,
 	)
 
 	if err == sql.ErrNoRows {
@@ -275,15 +316,15 @@ func (r *PrestadorPostgresRepository) BuscarPorCPF(cpf string) (*domain.Prestado
 	return &p, nil
 }
 
-func (r *PrestadorPostgresRepository) BuscarAgendaDoDia(prestadorID string, data string) (*domain.AgendaDiaria, error) {
+func (r *PrestadorPostgresRepository) BuscarAgendaDoDia(ctx context.Context, prestadorID string, data string) (*domain.AgendaDiaria, error) {
 
-	rows, err := r.db.Query(`
+	rows, err := conexao(ctx, r.db).QueryContext(ctx, `
 		SELECT
 			a.id,
 			a.data,
 			i.id,
-			(a.data + i.hora_inicio) AT TIME ZONE 'UTC',
-			(a.data + i.hora_fim)    AT TIME ZONE 'UTC'
+			i.hora_inicio,
+			i.hora_fim
 		FROM agendas_diarias a
 		LEFT JOIN intervalos_diarios i ON i.agenda_id = a.id
 		WHERE a.prestador_id = $1
@@ -339,22 +380,23 @@ func (r *PrestadorPostgresRepository) BuscarAgendaDoDia(prestadorID string, data
 	return agenda, nil
 }
 
-func (r *PrestadorPostgresRepository) Atualizar(input *input.AlterarPrestadorInput) error {
+func (r *PrestadorPostgresRepository) Atualizar(ctx context.Context, input *input.AlterarPrestadorInput) error {
 	// Inicia uma transação
-	tx, err := r.db.Begin()
+	tx, err := iniciarTransacao(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("erro ao iniciar transação: %w", err)
 	}
 	defer tx.Rollback()
 
 	// 1️⃣ Atualiza dados do prestador (APENAS campos editáveis - sem ID e CPF)
-	result, err := tx.Exec(`
+	result, err := tx.ExecContext(ctx, `
 		UPDATE prestadores 
 		SET 
 			nome = $1,
 			email = $2,
 			telefone = $3,
-			imagem_url = $4
+			imagem_url = $4,
+			fuso_horario = COALESCE(NULLIF($6, ''), fuso_horario)
 		WHERE id = $5
 	`,
 		input.Nome,
@@ -362,6 +404,7 @@ func (r *PrestadorPostgresRepository) Atualizar(input *input.AlterarPrestadorInp
 		input.Telefone,
 		input.ImagemUrl,
 		input.Id,
+		input.FusoHorario,
 	)
 	if err != nil {
 		return fmt.Errorf("erro ao atualizar prestador: %w", err)
@@ -377,18 +420,18 @@ func (r *PrestadorPostgresRepository) Atualizar(input *input.AlterarPrestadorInp
 	}
 
 	// 2️⃣ Remove todos os catálogos antigos
-	_, err = tx.Exec(`
+	_, err = tx.ExecContext(ctx, `
 		DELETE FROM prestador_catalogos WHERE prestador_id = $1
 	`, input.Id)
 	if err != nil {
 		return fmt.Errorf("erro ao remover catálogos antigos: %w", err)
 	}
 
-	// 3️⃣ Insere novos catálogos
+	// 3️⃣ Insere novos catálogos com o preço e a duração próprios do prestador
 	if len(input.CatalogoIDs) > 0 {
-		stmt, err := tx.Prepare(`
-			INSERT INTO prestador_catalogos (prestador_id, catalogo_id)
-			VALUES ($1, $2)
+		stmt, err := tx.PrepareContext(ctx, `
+			INSERT INTO prestador_catalogos (prestador_id, catalogo_id, preco, duracao_padrao)
+			VALUES ($1, $2, $3, $4)
 		`)
 		if err != nil {
 			return fmt.Errorf("erro ao preparar inserção de catálogos: %w", err)
@@ -396,7 +439,14 @@ func (r *PrestadorPostgresRepository) Atualizar(input *input.AlterarPrestadorInp
 		defer stmt.Close()
 
 		for _, catalogoID := range input.CatalogoIDs {
-			_, err := stmt.Exec(input.Id, catalogoID)
+			var preco, duracao *int
+			for _, c := range input.Condicoes {
+				if c.CatalogoID == catalogoID {
+					preco, duracao = c.Preco, c.DuracaoPadrao
+				}
+			}
+
+			_, err := stmt.ExecContext(ctx, input.Id, catalogoID, preco, duracao)
 			if err != nil {
 				// Detecta erro de FK (catálogo não existe)
 				if pqErr, ok := err.(*pq.Error); ok {
@@ -422,13 +472,13 @@ func (r *PrestadorPostgresRepository) Atualizar(input *input.AlterarPrestadorInp
 	return nil
 }
 
-func (r *PrestadorPostgresRepository) Listar(input *input.PrestadorListInput) ([]*domain.Prestador, error) {
+func (r *PrestadorPostgresRepository) Listar(ctx context.Context, input *input.PrestadorListInput) ([]*domain.Prestador, error) {
 	offset := (input.Page - 1) * input.Limit
 
 	query := `
 	WITH prestadores_paginados AS (
 		SELECT 
-			id, nome, cpf, email, telefone, ativo, imagem_url, created_at
+			id, nome, cpf, email, telefone, ativo, imagem_url, fuso_horario, created_at
 		FROM prestadores
 		WHERE ativo = $3
 		ORDER BY created_at DESC
@@ -442,6 +492,7 @@ func (r *PrestadorPostgresRepository) Listar(input *input.PrestadorListInput) ([
 		p.telefone,
 		p.ativo,
 		p.imagem_url,
+		p.fuso_horario,
 		-- Dados do Catálogo
 		c.id AS catalogo_id,
 		c.nome AS catalogo_nome,
@@ -468,7 +519,7 @@ func (r *PrestadorPostgresRepository) Listar(input *input.PrestadorListInput) ([
 		id.hora_inicio NULLS LAST
 	`
 
-	rows, err := r.db.Query(query, input.Limit, offset, input.Ativo)
+	rows, err := conexao(ctx, r.db).QueryContext(ctx, query, input.Limit, offset, input.Ativo)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao executar query: %w", err)
 	}
@@ -484,8 +535,8 @@ func (r *PrestadorPostgresRepository) Listar(input *input.PrestadorListInput) ([
 	for rows.Next() {
 		var (
 			// Prestador
-			pID, pNome, pCpf, pEmail, pTelefone, pImagemUrl string
-			pAtivo                                          bool
+			pID, pNome, pCpf, pEmail, pTelefone, pImagemUrl, pFusoHorario string
+			pAtivo                                                        bool
 
 			// Catálogo (nullable devido ao LEFT JOIN)
 			catalogoID            sql.NullString
@@ -506,7 +557,7 @@ func (r *PrestadorPostgresRepository) Listar(input *input.PrestadorListInput) ([
 		)
 
 		err := rows.Scan(
-			&pID, &pNome, &pCpf, &pEmail, &pTelefone, &pAtivo, &pImagemUrl,
+			&pID, &pNome, &pCpf, &pEmail, &pTelefone, &pAtivo, &pImagemUrl, &pFusoHorario,
 			&catalogoID, &catalogoNome, &catalogoDuracaoPadrao, &catalogoPreco,
 			&catalogoImagemUrl, &catalogoCategoria,
 			&agendaID, &agendaData,
@@ -520,15 +571,16 @@ func (r *PrestadorPostgresRepository) Listar(input *input.PrestadorListInput) ([
 		if _, exists := prestadoresMap[pID]; !exists {
 			prestadoresOrdenados = append(prestadoresOrdenados, pID)
 			prestadoresMap[pID] = &domain.Prestador{
-				ID:        pID,
-				Nome:      pNome,
-				Cpf:       pCpf,
-				Email:     pEmail,
-				Telefone:  pTelefone,
-				Ativo:     pAtivo,
-				ImagemUrl: pImagemUrl,
-				Catalogo:  []domain.Catalogo{},
-				Agenda:    []domain.AgendaDiaria{},
+				ID:          pID,
+				Nome:        pNome,
+				Cpf:         pCpf,
+				Email:       pEmail,
+				Telefone:    pTelefone,
+				Ativo:       pAtivo,
+				ImagemUrl:   pImagemUrl,
+				FusoHorario: pFusoHorario,
+				Catalogo:    []domain.Catalogo{},
+				Agenda:      []domain.AgendaDiaria{},
 			}
 			catalogosMap[pID] = make(map[string]*domain.Catalogo)
 			agendasMap[pID] = make(map[string]*domain.AgendaDiaria)
@@ -568,7 +620,7 @@ func (r *PrestadorPostgresRepository) Listar(input *input.PrestadorListInput) ([
 			if intervaloID.Valid {
 				// Cria chave única: agendaID + intervaloID
 				chaveIntervalo := fmt.Sprintf("%s:%s", agendaID.String, intervaloID.String)
-				
+
 				// ✅ Verifica se já foi adicionado
 				if !intervalosMap[pID][chaveIntervalo] {
 					intervalo := domain.IntervaloDiario{
@@ -580,7 +632,7 @@ func (r *PrestadorPostgresRepository) Listar(input *input.PrestadorListInput) ([
 						agendasMap[pID][agendaID.String].Intervalos,
 						intervalo,
 					)
-					
+
 					// ✅ Marca como adicionado
 					intervalosMap[pID][chaveIntervalo] = true
 				}
@@ -614,28 +666,28 @@ func (r *PrestadorPostgresRepository) Listar(input *input.PrestadorListInput) ([
 }
 
 // ✅ Contar com filtro obrigatório
-func (r *PrestadorPostgresRepository) Contar(ativo bool) (int, error) {
+func (r *PrestadorPostgresRepository) Contar(ctx context.Context, ativo bool) (int, error) {
 	var total int
-	err := r.db.QueryRow(`
+	err := conexao(ctx, r.db).QueryRowContext(ctx, `
 		SELECT COUNT(*) 
 		FROM prestadores 
 		WHERE ativo = $1
 	`, ativo).Scan(&total)
-	
+
 	if err != nil {
 		return 0, fmt.Errorf("erro ao contar prestadores: %w", err)
 	}
-	
+
 	return total, nil
 }
 
-func (r *PrestadorPostgresRepository) AtualizarStatus(id string, ativo bool) error {
-	result, err := r.db.Exec(`
+func (r *PrestadorPostgresRepository) AtualizarStatus(ctx context.Context, id string, ativo bool) error {
+	result, err := conexao(ctx, r.db).ExecContext(ctx, `
 		UPDATE prestadores 
 		SET ativo = $1
 		WHERE id = $2
 	`, ativo, id)
-	
+
 	if err != nil {
 		return fmt.Errorf("erro ao atualizar status: %w", err)
 	}
@@ -652,55 +704,37 @@ func (r *PrestadorPostgresRepository) AtualizarStatus(id string, ativo bool) err
 	return nil
 }
 
-func (r *AgendaDiariaPostgresRepository) DeletarAgenda(prestadorID string, data string) error {
-	tx, err := r.db.Begin()
-	if err != nil {
-		return fmt.Errorf("erro ao iniciar transação: %w", err)
-	}
-	defer tx.Rollback()
+func (r *PrestadorPostgresRepository) AtualizarTemposEntreAtendimentos(ctx context.Context, id string, preparo, limpeza *int) error {
+	result, err := conexao(ctx, r.db).ExecContext(ctx, `
+		UPDATE prestadores
+		SET tempo_preparo = $1,
+			tempo_limpeza = $2
+		WHERE id = $3
+	`, preparo, limpeza, id)
 
-	// 1. Buscar ID da agenda
-	var agendaID string
-	err = tx.QueryRow(`
-		SELECT id FROM agendas_diarias 
-		WHERE prestador_id = $1 AND data = $2
-	`, prestadorID, data).Scan(&agendaID)
-	
-	if err == sql.ErrNoRows {
-		return sql.ErrNoRows
-	}
 	if err != nil {
-		return fmt.Errorf("erro ao buscar agenda: %w", err)
+		return fmt.Errorf("erro ao atualizar tempos entre atendimentos: %w", err)
 	}
 
-	// 2. Deletar intervalos
-	_, err = tx.Exec(`
-		DELETE FROM intervalos_diarios 
-		WHERE agenda_id = $1
-	`, agendaID)
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("erro ao deletar intervalos: %w", err)
+		return fmt.Errorf("erro ao verificar linhas afetadas: %w", err)
 	}
 
-	// 3. Deletar agenda
-	_, err = tx.Exec(`
-		DELETE FROM agendas_diarias 
-		WHERE id = $1
-	`, agendaID)
-	if err != nil {
-		return fmt.Errorf("erro ao deletar agenda: %w", err)
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
 	}
 
-	return tx.Commit()
+	return nil
 }
 
-func (r *PrestadorPostgresRepository) BuscarPrestadoresDisponiveisPorData(data string, page, limit int) ([]*domain.Prestador, error) {
+func (r *PrestadorPostgresRepository) BuscarPrestadoresDisponiveisPorData(ctx context.Context, data string, page, limit int) ([]*domain.Prestador, error) {
 	offset := (page - 1) * limit
 
 	query := `
 	WITH prestadores_paginados AS (
 		SELECT DISTINCT
-			p.id, p.nome, p.cpf, p.email, p.telefone, p.ativo, p.imagem_url, p.created_at
+			p.id, p.nome, p.cpf, p.email, p.telefone, p.ativo, p.imagem_url, p.fuso_horario, p.created_at
 		FROM prestadores p
 		INNER JOIN agendas_diarias ad ON p.id = ad.prestador_id AND ad.data = $1
 		WHERE p.ativo = TRUE
@@ -715,6 +749,7 @@ func (r *PrestadorPostgresRepository) BuscarPrestadoresDisponiveisPorData(data s
 		p.telefone,
 		p.ativo,
 		p.imagem_url,
+		p.fuso_horario,
 		-- Dados do Catálogo
 		c.id AS catalogo_id,
 		c.nome AS catalogo_nome,
@@ -740,7 +775,7 @@ func (r *PrestadorPostgresRepository) BuscarPrestadoresDisponiveisPorData(data s
 		id.hora_inicio NULLS LAST
 	`
 
-	rows, err := r.db.Query(query, data, limit, offset)
+	rows, err := conexao(ctx, r.db).QueryContext(ctx, query, data, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao executar query: %w", err)
 	}
@@ -756,8 +791,8 @@ func (r *PrestadorPostgresRepository) BuscarPrestadoresDisponiveisPorData(data s
 	for rows.Next() {
 		var (
 			// Prestador
-			pID, pNome, pCpf, pEmail, pTelefone, pImagemUrl string
-			pAtivo                                          bool
+			pID, pNome, pCpf, pEmail, pTelefone, pImagemUrl, pFusoHorario string
+			pAtivo                                                        bool
 
 			// Catálogo (nullable devido ao LEFT JOIN)
 			catalogoID            sql.NullString
@@ -778,7 +813,7 @@ func (r *PrestadorPostgresRepository) BuscarPrestadoresDisponiveisPorData(data s
 		)
 
 		err := rows.Scan(
-			&pID, &pNome, &pCpf, &pEmail, &pTelefone, &pAtivo, &pImagemUrl,
+			&pID, &pNome, &pCpf, &pEmail, &pTelefone, &pAtivo, &pImagemUrl, &pFusoHorario,
 			&catalogoID, &catalogoNome, &catalogoDuracaoPadrao, &catalogoPreco,
 			&catalogoImagemUrl, &catalogoCategoria,
 			&agendaID, &agendaData,
@@ -792,15 +827,16 @@ func (r *PrestadorPostgresRepository) BuscarPrestadoresDisponiveisPorData(data s
 		if _, exists := prestadoresMap[pID]; !exists {
 			prestadoresOrdem = append(prestadoresOrdem, pID)
 			prestadoresMap[pID] = &domain.Prestador{
-				ID:        pID,
-				Nome:      pNome,
-				Cpf:       pCpf,
-				Email:     pEmail,
-				Telefone:  pTelefone,
-				Ativo:     pAtivo,
-				ImagemUrl: pImagemUrl,
-				Catalogo:  []domain.Catalogo{},
-				Agenda:    []domain.AgendaDiaria{},
+				ID:          pID,
+				Nome:        pNome,
+				Cpf:         pCpf,
+				Email:       pEmail,
+				Telefone:    pTelefone,
+				Ativo:       pAtivo,
+				ImagemUrl:   pImagemUrl,
+				FusoHorario: pFusoHorario,
+				Catalogo:    []domain.Catalogo{},
+				Agenda:      []domain.AgendaDiaria{},
 			}
 			catalogosMap[pID] = make(map[string]*domain.Catalogo)
 		}
@@ -881,18 +917,18 @@ func (r *PrestadorPostgresRepository) BuscarPrestadoresDisponiveisPorData(data s
 	return prestadores, nil
 }
 
-func (r *PrestadorPostgresRepository) ContarPrestadoresDisponiveisPorData(data string) (int, error) {
+func (r *PrestadorPostgresRepository) ContarPrestadoresDisponiveisPorData(ctx context.Context, data string) (int, error) {
 	var total int
-	err := r.db.QueryRow(`
+	err := conexao(ctx, r.db).QueryRowContext(ctx, `
 		SELECT COUNT(DISTINCT p.id)
 		FROM prestadores p
 		INNER JOIN agendas_diarias ad ON p.id = ad.prestador_id AND ad.data = $1
 		WHERE p.ativo = TRUE
 	`, data).Scan(&total)
-	
+
 	if err != nil {
 		return 0, fmt.Errorf("erro ao contar prestadores disponíveis: %w", err)
 	}
-	
+
 	return total, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/domain"
+)
+
+type FakeRefreshTokenRepositorio struct {
+	mu     sync.Mutex
+	tokens map[string]*domain.RefreshToken
+}
+
+func NovoFakeRefreshTokenRepositorio() port.RefreshTokenRepositorio {
+	return &FakeRefreshTokenRepositorio{tokens: make(map[string]*domain.RefreshToken)}
+}
+
+func (r *FakeRefreshTokenRepositorio) Salvar(ctx context.Context, t *domain.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copia := *t
+	r.tokens[t.ID] = &copia
+	return nil
+}
+
+func (r *FakeRefreshTokenRepositorio) BuscarPorHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, t := range r.tokens {
+		if t.TokenHash == tokenHash {
+			copia := *t
+			return &copia, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *FakeRefreshTokenRepositorio) Revogar(ctx context.Context, id string, agora time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if t, ok := r.tokens[id]; ok && t.RevogadoEm == nil {
+		t.RevogadoEm = &agora
+	}
+	return nil
+}
+
+func (r *FakeRefreshTokenRepositorio) RevogarDoUsuario(ctx context.Context, usuarioID string, agora time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, t := range r.tokens {
+		if t.UsuarioID == usuarioID && t.RevogadoEm == nil {
+			t.RevogadoEm = &agora
+		}
+	}
+	return nil
+}
+
+// salvarEstado permite que a FakeUnidadeDeTrabalho desfaça as alterações
+func (r *FakeRefreshTokenRepositorio) salvarEstado() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	restaurar := salvarMapa(r.tokens)
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.tokens = restaurar()
+	}
+}
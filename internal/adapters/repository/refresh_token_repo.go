@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/domain"
+)
+
+type RefreshTokenPostgresRepository struct {
+	db *sql.DB
+}
+
+func NovoRefreshTokenPostgresRepository(db *sql.DB) port.RefreshTokenRepositorio {
+	return &RefreshTokenPostgresRepository{db: db}
+}
+
+func (r *RefreshTokenPostgresRepository) Salvar(ctx context.Context, t *domain.RefreshToken) error {
+	_, err := conexao(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO refresh_tokens (id, usuario_id, token_hash, created_at, expira_em)
+		VALUES ($1, $2, $3, $4, $5)
+	`, t.ID, t.UsuarioID, t.TokenHash, t.CriadoEm, t.ExpiraEm)
+	if err != nil {
+		return fmt.Errorf("erro ao salvar refresh token: %w", err)
+	}
+	return nil
+}
+
+func (r *RefreshTokenPostgresRepository) BuscarPorHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	var t domain.RefreshToken
+	err := conexao(ctx, r.db).QueryRowContext(ctx, `
+		SELECT id, usuario_id, token_hash, created_at, expira_em, revogado_em
+		FROM refresh_tokens
+		WHERE token_hash = $1
+	`, tokenHash).Scan(&t.ID, &t.UsuarioID, &t.TokenHash, &t.CriadoEm, &t.ExpiraEm, &t.RevogadoEm)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *RefreshTokenPostgresRepository) Revogar(ctx context.Context, id string, agora time.Time) error {
+	_, err := conexao(ctx, r.db).ExecContext(ctx, `
+		UPDATE refresh_tokens
+		SET revogado_em = $1
+		WHERE id = $2 AND revogado_em IS NULL
+	`, agora, id)
+	if err != nil {
+		return fmt.Errorf("erro ao revogar refresh token: %w", err)
+	}
+	return nil
+}
+
+func (r *RefreshTokenPostgresRepository) RevogarDoUsuario(ctx context.Context, usuarioID string, agora time.Time) error {
+	_, err := conexao(ctx, r.db).ExecContext(ctx, `
+		UPDATE refresh_tokens
+		SET revogado_em = $1
+		WHERE usuario_id = $2 AND revogado_em IS NULL
+	`, agora, usuarioID)
+	if err != nil {
+		return fmt.Errorf("erro ao revogar sessões do usuário: %w", err)
+	}
+	return nil
+}
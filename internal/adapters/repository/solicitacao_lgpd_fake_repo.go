@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/domain"
+	"sync"
+)
+
+type FakeSolicitacaoLGPDRepositorio struct {
+	mu           sync.Mutex
+	solicitacoes []*domain.SolicitacaoLGPD
+}
+
+func NovoFakeSolicitacaoLGPDRepositorio() port.SolicitacaoLGPDRepositorio {
+	return &FakeSolicitacaoLGPDRepositorio{}
+}
+
+func (r *FakeSolicitacaoLGPDRepositorio) Registrar(ctx context.Context, s *domain.SolicitacaoLGPD) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.solicitacoes = append(r.solicitacoes, s)
+	return nil
+}
+
+// salvarEstado permite que a FakeUnidadeDeTrabalho desfaça as alterações
+func (r *FakeSolicitacaoLGPDRepositorio) salvarEstado() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	registradas := len(r.solicitacoes)
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.solicitacoes = r.solicitacoes[:registradas]
+	}
+}
+
+func (r *FakeSolicitacaoLGPDRepositorio) ListarPorCliente(ctx context.Context, clienteID string) ([]*domain.SolicitacaoLGPD, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Percorre de trás para frente: a mais recente primeiro
+	resultados := []*domain.SolicitacaoLGPD{}
+	for i := len(r.solicitacoes) - 1; i >= 0; i-- {
+		if r.solicitacoes[i].ClienteID == clienteID {
+			resultados = append(resultados, r.solicitacoes[i])
+		}
+	}
+	return resultados, nil
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/domain"
+)
+
+type SolicitacaoLGPDPostgresRepository struct {
+	db *sql.DB
+}
+
+func NovoSolicitacaoLGPDPostgresRepository(db *sql.DB) port.SolicitacaoLGPDRepositorio {
+	return &SolicitacaoLGPDPostgresRepository{db: db}
+}
+
+func (r *SolicitacaoLGPDPostgresRepository) Registrar(ctx context.Context, s *domain.SolicitacaoLGPD) error {
+	_, err := conexao(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO lgpd_solicitacoes (id, cliente_id, tipo, usuario_id, observacao, resultado, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, s.ID, s.ClienteID, s.Tipo, s.UsuarioID, s.Observacao, s.Resultado, s.CriadoEm)
+	if err != nil {
+		return fmt.Errorf("erro ao registrar solicitação LGPD: %w", err)
+	}
+	return nil
+}
+
+func (r *SolicitacaoLGPDPostgresRepository) ListarPorCliente(ctx context.Context, clienteID string) ([]*domain.SolicitacaoLGPD, error) {
+	rows, err := conexao(ctx, r.db).QueryContext(ctx, `
+		SELECT id, cliente_id, tipo, usuario_id, observacao, resultado, created_at
+		FROM lgpd_solicitacoes
+		WHERE cliente_id = $1
+		ORDER BY created_at DESC
+	`, clienteID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	solicitacoes := []*domain.SolicitacaoLGPD{}
+	for rows.Next() {
+		var s domain.SolicitacaoLGPD
+		if err := rows.Scan(&s.ID, &s.ClienteID, &s.Tipo, &s.UsuarioID, &s.Observacao, &s.Resultado, &s.CriadoEm); err != nil {
+			return nil, err
+		}
+		solicitacoes = append(solicitacoes, &s)
+	}
+
+	return solicitacoes, rows.Err()
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"meu-servico-agenda/internal/core/application/port"
+)
+
+// participanteTransacao é um repositório fake capaz de desfazer as próprias
+// alterações. salvarEstado devolve a função que volta ao estado salvo
+type participanteTransacao interface {
+	salvarEstado() (restaurar func())
+}
+
+// FakeUnidadeDeTrabalho dá aos repositórios fake a semântica da transação do
+// Postgres: uma unidade de trabalho por vez e, em caso de erro ou pânico, os
+// repositórios participantes voltam ao estado de antes
+type FakeUnidadeDeTrabalho struct {
+	mu            sync.Mutex
+	participantes []participanteTransacao
+}
+
+// NovaFakeUnidadeDeTrabalho recebe os repositórios fake que participam das
+// transações; os que não sabem desfazer alterações são ignorados
+func NovaFakeUnidadeDeTrabalho(repos ...any) port.UnidadeDeTrabalho {
+	u := &FakeUnidadeDeTrabalho{}
+	for _, repo := range repos {
+		if p, ok := repo.(participanteTransacao); ok {
+			u.participantes = append(u.participantes, p)
+		}
+	}
+	return u
+}
+
+func (u *FakeUnidadeDeTrabalho) Executar(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(chaveTransacao{}) != nil {
+		return fn(ctx)
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	restauracoes := make([]func(), 0, len(u.participantes))
+	for _, p := range u.participantes {
+		restauracoes = append(restauracoes, p.salvarEstado())
+	}
+	rollback := func() {
+		for _, restaurar := range restauracoes {
+			restaurar()
+		}
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			rollback()
+			panic(r)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, chaveTransacao{}, u)); err != nil {
+		rollback()
+		return err
+	}
+	return nil
+}
+
+// salvarMapa guarda os ponteiros de um mapa e o valor de cada um. A restauração
+// devolve os mesmos objetos ao estado anterior, para que quem ainda tem o
+// ponteiro enxergue o rollback
+func salvarMapa[T any](m map[string]*T) (restaurar func() map[string]*T) {
+	ponteiros := make(map[string]*T, len(m))
+	valores := make(map[string]T, len(m))
+	for chave, p := range m {
+		ponteiros[chave] = p
+		valores[chave] = *p
+	}
+
+	return func() map[string]*T {
+		for chave, p := range ponteiros {
+			*p = valores[chave]
+		}
+		return ponteiros
+	}
+}
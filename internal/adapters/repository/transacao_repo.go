@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"meu-servico-agenda/internal/core/application/port"
+)
+
+// chaveTransacao guarda no contexto a transação da unidade de trabalho em andamento
+type chaveTransacao struct{}
+
+// executor é o que os repositórios usam para falar com o banco: a transação da
+// unidade de trabalho em andamento ou, fora dela, o pool de conexões
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func conexao(ctx context.Context, db *sql.DB) executor {
+	if tx, ok := ctx.Value(chaveTransacao{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// transacao é a transação usada por um método de repositório. Dentro de uma
+// unidade de trabalho ela é a transação externa, e o commit e o rollback ficam
+// com quem a abriu
+type transacao struct {
+	*sql.Tx
+	externa bool
+}
+
+func iniciarTransacao(ctx context.Context, db *sql.DB) (*transacao, error) {
+	if tx, ok := ctx.Value(chaveTransacao{}).(*sql.Tx); ok {
+		return &transacao{Tx: tx, externa: true}, nil
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &transacao{Tx: tx}, nil
+}
+
+func (t *transacao) Commit() error {
+	if t.externa {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+func (t *transacao) Rollback() error {
+	if t.externa {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
+
+type UnidadeDeTrabalhoPostgres struct {
+	db *sql.DB
+}
+
+func NovaUnidadeDeTrabalhoPostgres(db *sql.DB) port.UnidadeDeTrabalho {
+	return &UnidadeDeTrabalhoPostgres{db: db}
+}
+
+func (u *UnidadeDeTrabalhoPostgres) Executar(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(chaveTransacao{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := u.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			panic(r)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, chaveTransacao{}, tx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
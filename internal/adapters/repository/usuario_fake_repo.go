@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/domain"
+)
+
+type FakeUsuarioRepositorio struct {
+	mu       sync.Mutex
+	usuarios map[string]*domain.Usuario
+}
+
+func NovoFakeUsuarioRepositorio() port.UsuarioRepositorio {
+	return &FakeUsuarioRepositorio{usuarios: make(map[string]*domain.Usuario)}
+}
+
+func (r *FakeUsuarioRepositorio) Salvar(ctx context.Context, u *domain.Usuario) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existente := range r.usuarios {
+		if existente.Email == u.Email {
+			return domain.ErrUsuarioJaCadastrado
+		}
+	}
+
+	copia := *u
+	r.usuarios[u.ID] = &copia
+	return nil
+}
+
+func (r *FakeUsuarioRepositorio) BuscarPorId(ctx context.Context, id string) (*domain.Usuario, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u, ok := r.usuarios[id]
+	if !ok {
+		return nil, nil
+	}
+	copia := *u
+	return &copia, nil
+}
+
+func (r *FakeUsuarioRepositorio) BuscarPorEmail(ctx context.Context, email string) (*domain.Usuario, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, u := range r.usuarios {
+		if u.Email == email {
+			copia := *u
+			return &copia, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *FakeUsuarioRepositorio) ExisteAdmin(ctx context.Context) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, u := range r.usuarios {
+		if u.Papel == domain.PapelAdmin {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *FakeUsuarioRepositorio) BuscarPorCliente(ctx context.Context, clienteID string) ([]*domain.Usuario, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var usuarios []*domain.Usuario
+	for _, u := range r.usuarios {
+		if u.ClienteID == clienteID {
+			copia := *u
+			usuarios = append(usuarios, &copia)
+		}
+	}
+	sort.Slice(usuarios, func(i, j int) bool { return usuarios[i].CriadoEm.Before(usuarios[j].CriadoEm) })
+	return usuarios, nil
+}
+
+func (r *FakeUsuarioRepositorio) Anonimizar(ctx context.Context, u *domain.Usuario) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if atual, ok := r.usuarios[u.ID]; ok {
+		atual.Email = u.Email
+		atual.Ativo = u.Ativo
+	}
+	return nil
+}
+
+// salvarEstado permite que a FakeUnidadeDeTrabalho desfaça as alterações
+func (r *FakeUsuarioRepositorio) salvarEstado() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	restaurar := salvarMapa(r.usuarios)
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.usuarios = restaurar()
+	}
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/domain"
+
+	"github.com/lib/pq"
+)
+
+type UsuarioPostgresRepository struct {
+	db *sql.DB
+}
+
+func NovoUsuarioPostgresRepository(db *sql.DB) port.UsuarioRepositorio {
+	return &UsuarioPostgresRepository{db: db}
+}
+
+func (r *UsuarioPostgresRepository) Salvar(ctx context.Context, u *domain.Usuario) error {
+	// Admin não tem vínculo: as chaves estrangeiras ficam NULL
+	clienteID := sql.NullString{String: u.ClienteID, Valid: u.ClienteID != ""}
+	prestadorID := sql.NullString{String: u.PrestadorID, Valid: u.PrestadorID != ""}
+
+	_, err := conexao(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO usuarios (id, email, senha_hash, papel, cliente_id, prestador_id, ativo, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, u.ID, u.Email, u.SenhaHash, u.Papel, clienteID, prestadorID, u.Ativo, u.CriadoEm)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == violacaoUnicidade {
+			return domain.ErrUsuarioJaCadastrado
+		}
+		return fmt.Errorf("erro ao salvar usuário: %w", err)
+	}
+	return nil
+}
+
+func (r *UsuarioPostgresRepository) BuscarPorId(ctx context.Context, id string) (*domain.Usuario, error) {
+	return r.buscarUm(ctx, `
+		SELECT id, email, senha_hash, papel, cliente_id, prestador_id, ativo, created_at
+		FROM usuarios
+		WHERE id = $1
+	`, id)
+}
+
+func (r *UsuarioPostgresRepository) BuscarPorEmail(ctx context.Context, email string) (*domain.Usuario, error) {
+	return r.buscarUm(ctx, `
+		SELECT id, email, senha_hash, papel, cliente_id, prestador_id, ativo, created_at
+		FROM usuarios
+		WHERE email = $1
+	`, email)
+}
+
+func (r *UsuarioPostgresRepository) buscarUm(ctx context.Context, query string, arg string) (*domain.Usuario, error) {
+	u, err := scanUsuario(conexao(ctx, r.db).QueryRowContext(ctx, query, arg))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return u, nil
+}
+
+func scanUsuario(row interface{ Scan(dest ...any) error }) (*domain.Usuario, error) {
+	var u domain.Usuario
+	var clienteID, prestadorID sql.NullString
+
+	err := row.Scan(
+		&u.ID,
+		&u.Email,
+		&u.SenhaHash,
+		&u.Papel,
+		&clienteID,
+		&prestadorID,
+		&u.Ativo,
+		&u.CriadoEm,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	u.ClienteID = clienteID.String
+	u.PrestadorID = prestadorID.String
+	return &u, nil
+}
+
+func (r *UsuarioPostgresRepository) ExisteAdmin(ctx context.Context) (bool, error) {
+	var existe bool
+	err := conexao(ctx, r.db).QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM usuarios WHERE papel = $1)`, domain.PapelAdmin).Scan(&existe)
+	if err != nil {
+		return false, fmt.Errorf("erro ao verificar admin: %w", err)
+	}
+	return existe, nil
+}
+
+func (r *UsuarioPostgresRepository) BuscarPorCliente(ctx context.Context, clienteID string) ([]*domain.Usuario, error) {
+	rows, err := conexao(ctx, r.db).QueryContext(ctx, `
+		SELECT id, email, senha_hash, papel, cliente_id, prestador_id, ativo, created_at
+		FROM usuarios
+		WHERE cliente_id = $1
+		ORDER BY created_at
+	`, clienteID)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar usuários do cliente: %w", err)
+	}
+	defer rows.Close()
+
+	var usuarios []*domain.Usuario
+	for rows.Next() {
+		u, err := scanUsuario(rows)
+		if err != nil {
+			return nil, err
+		}
+		usuarios = append(usuarios, u)
+	}
+	return usuarios, rows.Err()
+}
+
+func (r *UsuarioPostgresRepository) Anonimizar(ctx context.Context, u *domain.Usuario) error {
+	_, err := conexao(ctx, r.db).ExecContext(ctx, `
+		UPDATE usuarios SET email = $1, ativo = $2 WHERE id = $3
+	`, u.Email, u.Ativo, u.ID)
+	if err != nil {
+		return fmt.Errorf("erro ao anonimizar usuário: %w", err)
+	}
+	return nil
+}
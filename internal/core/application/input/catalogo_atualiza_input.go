@@ -7,4 +7,7 @@ type CatalogoUpdateInput struct {
 	Preco         int
 	Categoria     string
 	ImagemUrl     string
+	// TempoPreparo e TempoLimpeza nulos mantêm os valores atuais
+	TempoPreparo *int
+	TempoLimpeza *int
 }
\ No newline at end of file
@@ -6,4 +6,6 @@ type CatalogoInput struct {
 	Preco         int
 	ImagemUrl     string
 	Categoria     string
+	TempoPreparo  int
+	TempoLimpeza  int
 }
@@ -0,0 +1,8 @@
+package input
+
+type AlterarClienteInput struct {
+	Id       string
+	Nome     string
+	Email    string
+	Telefone string
+}
@@ -0,0 +1,10 @@
+package input
+
+type ClienteListInput struct {
+	Page  int
+	Limit int
+	// Busca filtra por parte do nome, do email ou do telefone
+	Busca string
+	// Ativo nil lista clientes ativos e inativos
+	Ativo *bool
+}
@@ -0,0 +1,10 @@
+package input
+
+import "time"
+
+type HorariosDisponiveisInput struct {
+	PrestadorID      string
+	CatalogoID       string
+	Data             time.Time
+	IntervaloMinutos int
+}
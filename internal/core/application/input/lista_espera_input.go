@@ -0,0 +1,11 @@
+package input
+
+import "time"
+
+type CadastrarListaEsperaInput struct {
+	ClienteID    string
+	CatalogoID   string
+	PrestadorID  string // opcional
+	JanelaInicio time.Time
+	JanelaFim    time.Time
+}
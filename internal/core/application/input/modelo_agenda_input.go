@@ -0,0 +1,18 @@
+package input
+
+import "time"
+
+type CadastrarModeloAgendaInput struct {
+	PrestadorID    string
+	DiasDaSemana   []time.Weekday
+	Intervalos     []IntervaloInput
+	VigenciaInicio time.Time
+	VigenciaFim    time.Time
+}
+
+type GerarAgendasInput struct {
+	PrestadorID string
+	ModeloID    string
+	DataInicio  time.Time
+	DataFim     time.Time
+}
@@ -7,4 +7,14 @@ type AlterarPrestadorInput struct {
 	Telefone    string
 	ImagemUrl   string
 	CatalogoIDs []string
+	FusoHorario string // vazio mantém o fuso atual
+	// Condicoes substitui o preço e a duração de serviços do prestador.
+	// Serviços fora da lista voltam a usar os valores do catálogo
+	Condicoes []CondicaoDoServicoInput
+}
+
+type CondicaoDoServicoInput struct {
+	CatalogoID    string
+	Preco         *int
+	DuracaoPadrao *int
 }
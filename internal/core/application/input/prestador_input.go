@@ -7,4 +7,5 @@ type CadastrarPrestadorInput struct {
 	Telefone    string
 	ImagemUrl   string
 	CatalogoIDs []string
+	FusoHorario string
 }
@@ -0,0 +1,8 @@
+package input
+
+import "time"
+
+type ReagendarAgendamentoInput struct {
+	AgendamentoID  string
+	DataHoraInicio time.Time
+}
@@ -0,0 +1,27 @@
+package input
+
+import "time"
+
+// EscopoSerie define quais ocorrências de uma série são afetadas por uma operação
+type EscopoSerie string
+
+const (
+	EscopoSerieTodas     EscopoSerie = "todas"
+	EscopoSerieRestantes EscopoSerie = "restantes" // apenas as que ainda não começaram
+)
+
+type CadastrarSerieInput struct {
+	ClienteID        string
+	PrestadorID      string
+	CatalogoID       string
+	DataHoraInicio   time.Time
+	IntervaloSemanas int
+	Ocorrencias      int
+	Notas            string
+}
+
+type ReagendarSerieInput struct {
+	SerieID        string
+	DataHoraInicio time.Time
+	Escopo         EscopoSerie
+}
@@ -0,0 +1,7 @@
+package input
+
+type TemposEntreAtendimentosInput struct {
+	PrestadorID  string
+	TempoPreparo *int
+	TempoLimpeza *int
+}
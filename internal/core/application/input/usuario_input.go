@@ -0,0 +1,9 @@
+package input
+
+type CadastrarUsuarioInput struct {
+	Email       string
+	Senha       string
+	Papel       string
+	ClienteID   string // obrigatório para o papel cliente
+	PrestadorID string // obrigatório para o papel prestador
+}
@@ -0,0 +1,15 @@
+package input
+
+import "time"
+
+type CadastrarVisitaInput struct {
+	ClienteID      string
+	DataHoraInicio time.Time
+	Itens          []ItemVisitaInput
+	Notas          string
+}
+
+type ItemVisitaInput struct {
+	PrestadorID string
+	CatalogoID  string
+}
@@ -27,14 +27,30 @@ func FromPrestador(p *domain.Prestador) *output.BuscarPrestadorOutput {
 	}
 
 	return &output.BuscarPrestadorOutput{
-		ID:        p.ID,
-		Nome:      p.Nome,
-		Email:     p.Email,
-		Telefone:  p.Telefone,
-		Cpf:       p.Cpf,
-		Ativo:     p.Ativo,
-		ImagemUrl: p.ImagemUrl,
-		Catalogo:  CatalogosFromDomain(p.Catalogo),
-		Agenda:    agenda,
+		ID:           p.ID,
+		Nome:         p.Nome,
+		Email:        p.Email,
+		Telefone:     p.Telefone,
+		Cpf:          p.Cpf,
+		Ativo:        p.Ativo,
+		ImagemUrl:    p.ImagemUrl,
+		Catalogo:     CatalogosFromDomain(p.Catalogo),
+		Agenda:       agenda,
+		FusoHorario:  p.FusoHorario,
+		TempoPreparo: p.TempoPreparo,
+		TempoLimpeza: p.TempoLimpeza,
+		Condicoes:    condicoesDoServicoOutput(p.Condicoes),
 	}
 }
+
+func condicoesDoServicoOutput(condicoes []domain.CondicaoDoServico) []output.CondicaoDoServicoOutput {
+	result := make([]output.CondicaoDoServicoOutput, len(condicoes))
+	for i, c := range condicoes {
+		result[i] = output.CondicaoDoServicoOutput{
+			CatalogoID:    c.CatalogoID,
+			Preco:         c.Preco,
+			DuracaoPadrao: c.DuracaoPadrao,
+		}
+	}
+	return result
+}
@@ -17,6 +17,8 @@ func CatalogoFromDomainOutput(c *domain.Catalogo) *output.CatalogoOutput {
 		Preco:         c.Preco,
 		Categoria:     c.Categoria,
 		ImagemUrl:     c.ImagemUrl, 
+		TempoPreparo:  c.TempoPreparo,
+		TempoLimpeza:  c.TempoLimpeza,
 	}
 }
 
@@ -13,5 +13,7 @@ func FromCatalogoOutput(c *domain.Catalogo) *output.CatalogoOutput {
 		Preco:         c.Preco,
 		Categoria:     c.Categoria,
 		ImagemUrl:     c.ImagemUrl,
+		TempoPreparo:  c.TempoPreparo,
+		TempoLimpeza:  c.TempoLimpeza,
 	}
 }
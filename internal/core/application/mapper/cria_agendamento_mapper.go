@@ -5,16 +5,22 @@ import (
 	"meu-servico-agenda/internal/core/domain"
 )
 
+// NovoAgendamentoOutput devolve os horários no fuso do prestador, para que o
+// deslocamento exibido corresponda ao horário de parede da agenda
 func NovoAgendamentoOutput(a *domain.Agendamento) *output.AgendamentoOutput {
+	loc := a.Prestador.Localizacao()
 	return &output.AgendamentoOutput{
 		ID:             a.ID,
 		Cliente:        a.Cliente,
 		Prestador:      a.Prestador,
 		Catalogo:       a.Catalogo,
-		DataHoraInicio: a.DataHoraInicio,
-		DataHoraFim:    a.DataHoraFim,
+		Servico:        a.Servico,
+		DataHoraInicio: a.DataHoraInicio.In(loc),
+		DataHoraFim:    a.DataHoraFim.In(loc),
 		Status:         a.Status,
 		Notas:          a.Notas,
+		SerieID:        a.SerieID,
+		VisitaID:       a.VisitaID,
 	}
 }
 
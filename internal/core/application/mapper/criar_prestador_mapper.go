@@ -7,13 +7,14 @@ import (
 
 func FromDomainToCriarOutput(p *domain.Prestador) *output.CriarPrestadorOutput {
 	return &output.CriarPrestadorOutput{
-		ID:       p.ID,
-		Nome:     p.Nome,
-		Email:    p.Email,
-		Telefone: p.Telefone,
-		Ativo:    p.Ativo,
-		ImagemUrl: p.ImagemUrl,
-		Catalogo: CatalogosFromDomain(p.Catalogo),
+		ID:          p.ID,
+		Nome:        p.Nome,
+		Email:       p.Email,
+		Telefone:    p.Telefone,
+		Ativo:       p.Ativo,
+		ImagemUrl:   p.ImagemUrl,
+		Catalogo:    CatalogosFromDomain(p.Catalogo),
+		FusoHorario: p.FusoHorario,
 	}
 }
 
@@ -27,6 +28,8 @@ func CatalogosFromDomain(catalogos []domain.Catalogo) []output.CatalogoOutput {
 			Preco:         c.Preco,
 			Categoria:     c.Categoria,
 			ImagemUrl:     c.ImagemUrl,
+			TempoPreparo:  c.TempoPreparo,
+			TempoLimpeza:  c.TempoLimpeza,
 		}
 	}
 	return result
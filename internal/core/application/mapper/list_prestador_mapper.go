@@ -13,15 +13,16 @@ func PrestadorFromDomainOutput(p *domain.Prestador) *output.BuscarPrestadorOutpu
 	}
 
 	return &output.BuscarPrestadorOutput{
-		ID:        p.ID,
-		Nome:      p.Nome,
-		Cpf:       p.Cpf,
-		Email:     p.Email,
-		Telefone:  p.Telefone,
-		Ativo:     p.Ativo,
-		ImagemUrl: p.ImagemUrl,
-		Catalogo:  CatalogosFromDomain(p.Catalogo),
-		Agenda:    AgendasFromDomain(p.Agenda),
+		ID:          p.ID,
+		Nome:        p.Nome,
+		Cpf:         p.Cpf,
+		Email:       p.Email,
+		Telefone:    p.Telefone,
+		Ativo:       p.Ativo,
+		ImagemUrl:   p.ImagemUrl,
+		Catalogo:    CatalogosFromDomain(p.Catalogo),
+		Agenda:      AgendasFromDomain(p.Agenda),
+		FusoHorario: p.FusoHorario,
 	}
 }
 
@@ -87,7 +88,7 @@ func IntervalosFromDomain(intervalos []domain.IntervaloDiario) []output.Interval
 	outputs := make([]output.IntervaloDiarioOutput, 0, len(intervalos))
 	for _, i := range intervalos {
 		outputs = append(outputs, output.IntervaloDiarioOutput{
-			ID:         i.Id,
+			ID: i.Id,
 			// ✅ Converte time.Time para string no formato HH:MM:SS
 			HoraInicio: i.HoraInicio.Format("15:04:05"),
 			HoraFim:    i.HoraFim.Format("15:04:05"),
@@ -95,4 +96,4 @@ func IntervalosFromDomain(intervalos []domain.IntervaloDiario) []output.Interval
 	}
 
 	return outputs
-}
\ No newline at end of file
+}
@@ -0,0 +1,31 @@
+package mapper
+
+import (
+	"meu-servico-agenda/internal/core/application/output"
+	"meu-servico-agenda/internal/core/domain"
+)
+
+func ListaEsperaOutput(e *domain.EntradaListaEspera) *output.ListaEsperaOutput {
+	out := &output.ListaEsperaOutput{
+		ID:            e.ID,
+		ClienteID:     e.ClienteID,
+		CatalogoID:    e.CatalogoID,
+		PrestadorID:   e.PrestadorID,
+		JanelaInicio:  e.JanelaInicio,
+		JanelaFim:     e.JanelaFim,
+		Status:        e.Status,
+		CriadoEm:      e.CriadoEm,
+		AgendamentoID: e.AgendamentoID,
+	}
+
+	if e.Oferta != nil {
+		out.Oferta = &output.OfertaVagaOutput{
+			PrestadorID:    e.Oferta.PrestadorID,
+			DataHoraInicio: e.Oferta.DataHoraInicio,
+			DataHoraFim:    e.Oferta.DataHoraFim,
+			ExpiraEm:       e.Oferta.ExpiraEm,
+		}
+	}
+
+	return out
+}
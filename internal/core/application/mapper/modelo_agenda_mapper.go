@@ -0,0 +1,30 @@
+package mapper
+
+import (
+	"meu-servico-agenda/internal/core/application/output"
+	"meu-servico-agenda/internal/core/domain"
+)
+
+func ModeloAgendaOutput(m *domain.ModeloAgenda) *output.ModeloAgendaOutput {
+	dias := make([]int, len(m.DiasDaSemana))
+	for i, d := range m.DiasDaSemana {
+		dias[i] = int(d)
+	}
+
+	return &output.ModeloAgendaOutput{
+		ID:             m.ID,
+		PrestadorID:    m.PrestadorID,
+		DiasDaSemana:   dias,
+		Intervalos:     IntervalosFromDomain(m.Intervalos),
+		VigenciaInicio: m.VigenciaInicio.Format("2006-01-02"),
+		VigenciaFim:    m.VigenciaFim.Format("2006-01-02"),
+	}
+}
+
+func ModelosAgendaOutput(modelos []*domain.ModeloAgenda) []*output.ModeloAgendaOutput {
+	outputs := make([]*output.ModeloAgendaOutput, len(modelos))
+	for i, m := range modelos {
+		outputs[i] = ModeloAgendaOutput(m)
+	}
+	return outputs
+}
@@ -0,0 +1,21 @@
+package mapper
+
+import (
+	"meu-servico-agenda/internal/core/application/output"
+	"meu-servico-agenda/internal/core/domain"
+)
+
+func ReagendamentosOutput(historico []*domain.Reagendamento) []*output.ReagendamentoOutput {
+	outputs := make([]*output.ReagendamentoOutput, len(historico))
+	for i, r := range historico {
+		outputs[i] = &output.ReagendamentoOutput{
+			ID:                     r.ID,
+			DataHoraInicioAnterior: r.DataHoraInicioAnterior,
+			DataHoraFimAnterior:    r.DataHoraFimAnterior,
+			DataHoraInicioNova:     r.DataHoraInicioNova,
+			DataHoraFimNova:        r.DataHoraFimNova,
+			CriadoEm:               r.CriadoEm,
+		}
+	}
+	return outputs
+}
@@ -0,0 +1,24 @@
+package mapper
+
+import (
+	"meu-servico-agenda/internal/core/application/output"
+	"meu-servico-agenda/internal/core/domain"
+)
+
+func NovaVisitaOutput(v *domain.Visita) *output.VisitaOutput {
+	out := &output.VisitaOutput{
+		ID:           v.ID,
+		ClienteID:    v.Cliente.ID,
+		PrecoTotal:   v.PrecoTotal(),
+		DuracaoTotal: v.DuracaoTotal(),
+		Itens:        BuscaAgendamentoData(v.Itens),
+	}
+
+	// Início e fim seguem o fuso dos itens, que é o do prestador de cada serviço
+	if len(out.Itens) > 0 {
+		out.DataHoraInicio = out.Itens[0].DataHoraInicio
+		out.DataHoraFim = out.Itens[len(out.Itens)-1].DataHoraFim
+	}
+
+	return out
+}
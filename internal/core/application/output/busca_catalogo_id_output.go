@@ -7,4 +7,6 @@ type CatalogoOutput struct {
 	Preco         int
 	Categoria     string
 	ImagemUrl     string
+	TempoPreparo  int
+	TempoLimpeza  int
 }
@@ -1,15 +1,26 @@
 package output
 
 type BuscarPrestadorOutput struct {
-	ID        string
-	Nome      string
-	Email     string
-	Telefone  string
-	Cpf       string
-	Ativo     bool
-	ImagemUrl string
-	Catalogo  []CatalogoOutput
-	Agenda    []AgendaDiariaOutput
+	ID          string
+	Nome        string
+	Email       string
+	Telefone    string
+	Cpf         string
+	Ativo       bool
+	ImagemUrl   string
+	Catalogo    []CatalogoOutput
+	Agenda      []AgendaDiariaOutput
+	FusoHorario string
+	// TempoPreparo e TempoLimpeza nulos indicam que valem os tempos de cada serviço
+	TempoPreparo *int
+	TempoLimpeza *int
+	Condicoes    []CondicaoDoServicoOutput
+}
+
+type CondicaoDoServicoOutput struct {
+	CatalogoID    string
+	Preco         *int
+	DuracaoPadrao *int
 }
 type AgendaDiariaOutput struct {
 	ID         string
@@ -0,0 +1,17 @@
+package output
+
+import "time"
+
+// CalendarioOutput é o conteúdo do feed iCalendar. Exatamente um de ClienteID e
+// PrestadorID é preenchido, conforme o dono do token
+type CalendarioOutput struct {
+	ClienteID    string
+	PrestadorID  string
+	Agendamentos []*AgendamentoOutput
+}
+
+// TokenCalendarioOutput é o token da URL secreta, entregue só uma vez
+type TokenCalendarioOutput struct {
+	Token    string
+	CriadoEm time.Time
+}
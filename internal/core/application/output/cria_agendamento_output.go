@@ -10,8 +10,11 @@ type AgendamentoOutput struct {
 	Cliente        *domain.Cliente
 	Prestador      *domain.Prestador
 	Catalogo       *domain.Catalogo
+	Servico        domain.ServicoAgendado
 	DataHoraInicio time.Time
 	DataHoraFim    time.Time
 	Status         domain.StatusDoAgendamento
 	Notas          string
+	SerieID        string
+	VisitaID       string
 }
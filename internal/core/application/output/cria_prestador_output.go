@@ -1,11 +1,12 @@
 package output
 
 type CriarPrestadorOutput struct {
-	ID        string
-	Nome      string
-	Email     string
-	Telefone  string
-	ImagemUrl string
-	Ativo     bool
-	Catalogo  []CatalogoOutput
+	ID          string
+	Nome        string
+	Email       string
+	Telefone    string
+	ImagemUrl   string
+	Ativo       bool
+	Catalogo    []CatalogoOutput
+	FusoHorario string
 }
@@ -0,0 +1,16 @@
+package output
+
+import (
+	"meu-servico-agenda/internal/core/domain"
+	"time"
+)
+
+// ExportacaoClienteOutput reúne tudo o que o sistema guarda sobre o cliente
+type ExportacaoClienteOutput struct {
+	Cliente      *domain.Cliente
+	Agendamentos []*AgendamentoOutput
+	Series       []*domain.SerieAgendamento
+	Visitas      []*domain.Visita
+	ListaEspera  []*ListaEsperaOutput
+	ExportadoEm  time.Time
+}
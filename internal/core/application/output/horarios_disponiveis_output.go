@@ -0,0 +1,12 @@
+package output
+
+import "time"
+
+type HorariosDisponiveisOutput struct {
+	PrestadorID    string
+	CatalogoID     string
+	Data           string
+	FusoHorario    string
+	DuracaoMinutos int
+	Horarios       []time.Time
+}
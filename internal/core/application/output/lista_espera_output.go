@@ -0,0 +1,26 @@
+package output
+
+import (
+	"meu-servico-agenda/internal/core/domain"
+	"time"
+)
+
+type ListaEsperaOutput struct {
+	ID            string
+	ClienteID     string
+	CatalogoID    string
+	PrestadorID   string
+	JanelaInicio  time.Time
+	JanelaFim     time.Time
+	Status        domain.StatusListaEspera
+	CriadoEm      time.Time
+	Oferta        *OfertaVagaOutput
+	AgendamentoID string
+}
+
+type OfertaVagaOutput struct {
+	PrestadorID    string
+	DataHoraInicio time.Time
+	DataHoraFim    time.Time
+	ExpiraEm       time.Time
+}
@@ -0,0 +1,15 @@
+package output
+
+type ModeloAgendaOutput struct {
+	ID             string
+	PrestadorID    string
+	DiasDaSemana   []int
+	Intervalos     []IntervaloDiarioOutput
+	VigenciaInicio string
+	VigenciaFim    string
+}
+
+type GeracaoAgendaOutput struct {
+	Criadas   []string
+	Ignoradas []string
+}
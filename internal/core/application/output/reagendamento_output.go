@@ -0,0 +1,12 @@
+package output
+
+import "time"
+
+type ReagendamentoOutput struct {
+	ID                     string
+	DataHoraInicioAnterior time.Time
+	DataHoraFimAnterior    time.Time
+	DataHoraInicioNova     time.Time
+	DataHoraFimNova        time.Time
+	CriadoEm               time.Time
+}
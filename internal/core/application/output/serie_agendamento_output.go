@@ -0,0 +1,15 @@
+package output
+
+import "time"
+
+type SerieAgendamentoOutput struct {
+	SerieID   string
+	Agendados []*AgendamentoOutput
+	Falhas    []FalhaOcorrenciaOutput
+}
+
+type FalhaOcorrenciaOutput struct {
+	AgendamentoID  string
+	DataHoraInicio time.Time
+	Motivo         string
+}
@@ -0,0 +1,10 @@
+package output
+
+import "time"
+
+// TokensOutput é o par de tokens entregue no login e em cada renovação
+type TokensOutput struct {
+	AccessToken  string
+	ExpiraEm     time.Time
+	RefreshToken string
+}
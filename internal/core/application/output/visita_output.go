@@ -0,0 +1,13 @@
+package output
+
+import "time"
+
+type VisitaOutput struct {
+	ID             string
+	ClienteID      string
+	DataHoraInicio time.Time
+	DataHoraFim    time.Time
+	PrecoTotal     int
+	DuracaoTotal   int
+	Itens          []*AgendamentoOutput
+}
@@ -1,12 +1,13 @@
 package port
 
 import (
+	"context"
 	"meu-servico-agenda/internal/core/domain"
 )
 
 type AgendaDiariaRepositorio interface {
-	Salvar(agenda *domain.AgendaDiaria, prestadorId string) error
-	AtualizarAgenda(agenda *domain.AgendaDiaria, prestadorID string) error 
-	BuscarAgendaDoDia(prestadorID string, data string) (*domain.AgendaDiaria, error)
-	DeletarAgenda(prestadorID string, data string) error
+	Salvar(ctx context.Context, agenda *domain.AgendaDiaria, prestadorId string) error
+	AtualizarAgenda(ctx context.Context, agenda *domain.AgendaDiaria, prestadorID string) error 
+	BuscarAgendaDoDia(ctx context.Context, prestadorID string, data string) (*domain.AgendaDiaria, error)
+	DeletarAgenda(ctx context.Context, prestadorID string, data string) error
 }
\ No newline at end of file
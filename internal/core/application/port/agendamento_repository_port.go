@@ -1,14 +1,39 @@
 package port
 
 import (
+	"context"
 	"meu-servico-agenda/internal/core/domain"
 	"time"
 )
 
 type AgendamentoRepositorio interface {
-	CriaAgendamento(*domain.Agendamento) error
-	BuscarPorPrestadorEPeriodo(prestadorID string, inicio time.Time, fim time.Time) ([]*domain.Agendamento, error)
-	BuscarPorClienteEPeriodo(clienteID string, inicio time.Time, fim time.Time) ([]*domain.Agendamento, error)
-	BuscarAgendamentoClienteAPartirDaData(clienteID string, data time.Time) ([]*domain.Agendamento, error)
-	BuscarAgendamentoPrestadorAPartirDaData(clienteID string, data time.Time) ([]*domain.Agendamento, error)
+	CriaAgendamento(ctx context.Context, agendamento *domain.Agendamento) error
+	BuscarPorId(ctx context.Context, id string) (*domain.Agendamento, error)
+	// BuscarPorIdParaAtualizar é o BuscarPorId que trava o agendamento até o fim da transação do ctx
+	BuscarPorIdParaAtualizar(ctx context.Context, id string) (*domain.Agendamento, error)
+	// TravarCliente serializa, até o fim da transação do ctx, quem reserva ou move
+	// horários do cliente
+	TravarCliente(ctx context.Context, clienteID string) error
+	// TravarPrestador segura, até o fim da transação do ctx, a mesma trava que a
+	// gravação de agendamentos usa para o prestador
+	TravarPrestador(ctx context.Context, prestadorID string) error
+	AtualizarStatus(ctx context.Context, id string, status domain.StatusDoAgendamento) error
+	Reagendar(ctx context.Context, agendamento *domain.Agendamento, historico *domain.Reagendamento) error
+	ListarReagendamentos(ctx context.Context, agendamentoID string) ([]*domain.Reagendamento, error)
+	BuscarPorPrestadorEPeriodo(ctx context.Context, prestadorID string, inicio time.Time, fim time.Time) ([]*domain.Agendamento, error)
+	BuscarPorClienteEPeriodo(ctx context.Context, clienteID string, inicio time.Time, fim time.Time) ([]*domain.Agendamento, error)
+	BuscarAgendamentoClienteAPartirDaData(ctx context.Context, clienteID string, data time.Time) ([]*domain.Agendamento, error)
+	BuscarAgendamentoPrestadorAPartirDaData(ctx context.Context, prestadorID string, data time.Time) ([]*domain.Agendamento, error)
+	CriaSerie(ctx context.Context, serie *domain.SerieAgendamento) error
+	BuscarSeriePorId(ctx context.Context, id string) (*domain.SerieAgendamento, error)
+	BuscarPorSerie(ctx context.Context, serieID string) ([]*domain.Agendamento, error)
+	// BuscarSeriesDoCliente lista as séries do cliente pela data da primeira ocorrência
+	BuscarSeriesDoCliente(ctx context.Context, clienteID string) ([]*domain.SerieAgendamento, error)
+	CriaVisita(ctx context.Context, visita *domain.Visita) error
+	BuscarVisitaPorId(ctx context.Context, id string) (*domain.Visita, error)
+	BuscarPorVisita(ctx context.Context, visitaID string) ([]*domain.Agendamento, error)
+	// BuscarVisitasDoCliente lista as visitas do cliente sem os itens, da mais antiga para a mais nova
+	BuscarVisitasDoCliente(ctx context.Context, clienteID string) ([]*domain.Visita, error)
+	// ApagarNotasDoCliente remove as observações livres, que podem conter dados pessoais
+	ApagarNotasDoCliente(ctx context.Context, clienteID string) error
 }
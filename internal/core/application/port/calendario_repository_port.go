@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+
+	"meu-servico-agenda/internal/core/domain"
+)
+
+type TokenCalendarioRepositorio interface {
+	// Substituir guarda o token no lugar do que o cliente ou o prestador já tinha,
+	// que deixa de abrir o feed
+	Substituir(ctx context.Context, token *domain.TokenCalendario) error
+	// BuscarPorHash devolve nil, nil quando o token não existe
+	BuscarPorHash(ctx context.Context, tokenHash string) (*domain.TokenCalendario, error)
+	// RemoverDoCliente e RemoverDoPrestador apagam o token do dono, se existir:
+	// a URL deixa de abrir o feed e nenhuma outra é criada
+	RemoverDoCliente(ctx context.Context, clienteID string) error
+	RemoverDoPrestador(ctx context.Context, prestadorID string) error
+}
@@ -1,17 +1,21 @@
 package port
 
-import "meu-servico-agenda/internal/core/domain"
+import (
+	"context"
+
+	"meu-servico-agenda/internal/core/domain"
+)
 
 type CatalogoRepositorio interface {
 	//Post
-	Salvar(catalogo *domain.Catalogo) error
+	Salvar(ctx context.Context, catalogo *domain.Catalogo) error
 	//GetById
-	BuscarPorId(id string) (*domain.Catalogo, error)
+	BuscarPorId(ctx context.Context, id string) (*domain.Catalogo, error)
 	//GetAll Paginação
-	Listar(limit, offset int) ([]*domain.Catalogo, error)
-	Contar() (int, error)
+	Listar(ctx context.Context, limit, offset int) ([]*domain.Catalogo, error)
+	Contar(ctx context.Context) (int, error)
 	//Update
-	Atualizar(catalogo *domain.Catalogo) error
+	Atualizar(ctx context.Context, catalogo *domain.Catalogo) error
 	//Delete
-	Deletar(id string) error
+	Deletar(ctx context.Context, id string) error
 }
\ No newline at end of file
@@ -1,8 +1,19 @@
 package port
 
-import "meu-servico-agenda/internal/core/domain"
+import (
+	"context"
+	"meu-servico-agenda/internal/core/application/input"
+	"meu-servico-agenda/internal/core/domain"
+)
 
 type ClienteRepositorio interface {
-    Salvar(cliente *domain.Cliente) error
-    BuscarPorId(id string) (*domain.Cliente, error)
-}
\ No newline at end of file
+	Salvar(ctx context.Context, cliente *domain.Cliente) error
+	BuscarPorId(ctx context.Context, id string) (*domain.Cliente, error)
+	BuscarPorEmail(ctx context.Context, email string) (*domain.Cliente, error)
+	Atualizar(ctx context.Context, cliente *domain.Cliente) error
+	Listar(ctx context.Context, input *input.ClienteListInput) ([]*domain.Cliente, error)
+	Contar(ctx context.Context, input *input.ClienteListInput) (int, error)
+	AtualizarStatus(ctx context.Context, id string, ativo bool) error
+	// Anonimizar grava os dados já anonimizados e a data da anonimização
+	Anonimizar(ctx context.Context, cliente *domain.Cliente) error
+}
@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"meu-servico-agenda/internal/core/domain"
+)
+
+type IdempotenciaRepositorio interface {
+	// Reservar grava o registro como em andamento. Quando a chave já existe e não
+	// expirou, nada é gravado e o registro existente é devolvido
+	Reservar(ctx context.Context, registro *domain.RegistroIdempotencia) (*domain.RegistroIdempotencia, error)
+	Concluir(ctx context.Context, chave string, statusCode int, corpo []byte) error
+	// Liberar remove a reserva para que a chave possa ser usada de novo
+	Liberar(ctx context.Context, chave string) error
+	RemoverExpirados(ctx context.Context, agora time.Time) (int64, error)
+}
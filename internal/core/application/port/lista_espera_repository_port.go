@@ -0,0 +1,29 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"meu-servico-agenda/internal/core/domain"
+)
+
+type ListaEsperaRepositorio interface {
+	Salvar(ctx context.Context, entrada *domain.EntradaListaEspera) error
+	// BuscarPorId devolve nil, nil quando a entrada não existe
+	BuscarPorId(ctx context.Context, id string) (*domain.EntradaListaEspera, error)
+	// BuscarPorIdParaAtualizar é o BuscarPorId que trava a entrada até o fim da transação do ctx
+	BuscarPorIdParaAtualizar(ctx context.Context, id string) (*domain.EntradaListaEspera, error)
+	// TravarPrestador serializa, até o fim da transação do ctx, quem oferece ou reserva
+	// horários do prestador. É a mesma trava usada na gravação de agendamentos
+	TravarPrestador(ctx context.Context, prestadorID string) error
+	// Atualizar grava status, oferta e agendamento; devolve sql.ErrNoRows quando a entrada não existe
+	Atualizar(ctx context.Context, entrada *domain.EntradaListaEspera) error
+	// BuscarAguardando lista, da mais antiga para a mais nova, as entradas sem oferta que
+	// aceitam o prestador e cuja janela se sobrepõe ao período, travando-as até o fim da transação
+	BuscarAguardando(ctx context.Context, prestadorID string, inicio, fim time.Time) ([]*domain.EntradaListaEspera, error)
+	// BuscarOfertasAtivas lista as vagas oferecidas e ainda não vencidas do prestador no período
+	BuscarOfertasAtivas(ctx context.Context, prestadorID string, inicio, fim, agora time.Time) ([]*domain.EntradaListaEspera, error)
+	// BuscarPorCliente lista todas as entradas do cliente, da mais antiga para a mais nova
+	BuscarPorCliente(ctx context.Context, clienteID string) ([]*domain.EntradaListaEspera, error)
+	BuscarOfertasVencidas(ctx context.Context, agora time.Time) ([]*domain.EntradaListaEspera, error)
+}
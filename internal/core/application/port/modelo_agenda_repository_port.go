@@ -0,0 +1,14 @@
+package port
+
+import (
+	"context"
+
+	"meu-servico-agenda/internal/core/domain"
+)
+
+type ModeloAgendaRepositorio interface {
+	Salvar(ctx context.Context, modelo *domain.ModeloAgenda) error
+	BuscarPorId(ctx context.Context, id string) (*domain.ModeloAgenda, error)
+	ListarPorPrestador(ctx context.Context, prestadorID string) ([]*domain.ModeloAgenda, error)
+	Deletar(ctx context.Context, id string) error
+}
@@ -1,19 +1,21 @@
 package port
 
 import (
+	"context"
 	"meu-servico-agenda/internal/core/application/input"
 	"meu-servico-agenda/internal/core/domain"
 )
 
 type PrestadorRepositorio interface {
-	Salvar(prestador *domain.Prestador) error
-	BuscarPorId(id string) (*domain.Prestador, error)
-	BuscarPorCPF(cpf string) (*domain.Prestador, error)
-	BuscarAgendaDoDia(prestadorID string, data string) (*domain.AgendaDiaria, error)
-	Atualizar(prestador *input.AlterarPrestadorInput) error
-	Listar(input *input.PrestadorListInput) ([]*domain.Prestador, error)
-	Contar(ativo bool) (int, error)
-	AtualizarStatus(id string, ativo bool) error 
-	BuscarPrestadoresDisponiveisPorData(data string, page, limit int) ([]*domain.Prestador, error)
-	ContarPrestadoresDisponiveisPorData(data string) (int, error)
+	Salvar(ctx context.Context, prestador *domain.Prestador) error
+	BuscarPorId(ctx context.Context, id string) (*domain.Prestador, error)
+	BuscarPorCPF(ctx context.Context, cpf string) (*domain.Prestador, error)
+	BuscarAgendaDoDia(ctx context.Context, prestadorID string, data string) (*domain.AgendaDiaria, error)
+	Atualizar(ctx context.Context, prestador *input.AlterarPrestadorInput) error
+	Listar(ctx context.Context, input *input.PrestadorListInput) ([]*domain.Prestador, error)
+	Contar(ctx context.Context, ativo bool) (int, error)
+	AtualizarStatus(ctx context.Context, id string, ativo bool) error 
+	BuscarPrestadoresDisponiveisPorData(ctx context.Context, data string, page, limit int) ([]*domain.Prestador, error)
+	ContarPrestadoresDisponiveisPorData(ctx context.Context, data string) (int, error)
+	AtualizarTemposEntreAtendimentos(ctx context.Context, id string, preparo, limpeza *int) error
 }
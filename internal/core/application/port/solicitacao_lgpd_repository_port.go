@@ -0,0 +1,13 @@
+package port
+
+import (
+	"context"
+
+	"meu-servico-agenda/internal/core/domain"
+)
+
+type SolicitacaoLGPDRepositorio interface {
+	Registrar(ctx context.Context, solicitacao *domain.SolicitacaoLGPD) error
+	// ListarPorCliente devolve as solicitações da mais recente para a mais antiga
+	ListarPorCliente(ctx context.Context, clienteID string) ([]*domain.SolicitacaoLGPD, error)
+}
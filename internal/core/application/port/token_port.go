@@ -0,0 +1,15 @@
+package port
+
+import (
+	"time"
+
+	"meu-servico-agenda/internal/core/domain"
+)
+
+// EmissorToken assina e valida os tokens de acesso entregues no login
+type EmissorToken interface {
+	Emitir(identidade *domain.Identidade) (token string, expiraEm time.Time, err error)
+	// Validar devolve a identidade contida no token ou erro quando a assinatura não
+	// confere ou o token venceu
+	Validar(token string) (*domain.Identidade, error)
+}
@@ -0,0 +1,11 @@
+package port
+
+import "context"
+
+// UnidadeDeTrabalho executa várias operações de repositório numa só transação.
+// Os repositórios chamados com o ctx recebido por fn participam dela; se fn
+// devolve erro ou entra em pânico, tudo é desfeito. Chamadas aninhadas
+// reaproveitam a transação já aberta
+type UnidadeDeTrabalho interface {
+	Executar(ctx context.Context, fn func(ctx context.Context) error) error
+}
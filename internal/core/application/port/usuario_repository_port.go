@@ -0,0 +1,30 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"meu-servico-agenda/internal/core/domain"
+)
+
+type UsuarioRepositorio interface {
+	// Salvar devolve domain.ErrUsuarioJaCadastrado quando o email já está em uso
+	Salvar(ctx context.Context, usuario *domain.Usuario) error
+	// BuscarPorId e BuscarPorEmail devolvem nil, nil quando o usuário não existe
+	BuscarPorId(ctx context.Context, id string) (*domain.Usuario, error)
+	BuscarPorEmail(ctx context.Context, email string) (*domain.Usuario, error)
+	ExisteAdmin(ctx context.Context) (bool, error)
+	// BuscarPorCliente devolve as contas vinculadas ao cadastro do cliente
+	BuscarPorCliente(ctx context.Context, clienteID string) ([]*domain.Usuario, error)
+	// Anonimizar grava o email e o status de uma conta anonimizada
+	Anonimizar(ctx context.Context, usuario *domain.Usuario) error
+}
+
+type RefreshTokenRepositorio interface {
+	Salvar(ctx context.Context, token *domain.RefreshToken) error
+	// BuscarPorHash devolve nil, nil quando o token não existe
+	BuscarPorHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error)
+	Revogar(ctx context.Context, id string, agora time.Time) error
+	// RevogarDoUsuario encerra todas as sessões abertas do usuário
+	RevogarDoUsuario(ctx context.Context, usuarioID string, agora time.Time) error
+}
@@ -1,6 +1,9 @@
 package service
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"meu-servico-agenda/internal/adapters/http/agendamento/request_agendamento"
 	"meu-servico-agenda/internal/core/application/input"
 	"meu-servico-agenda/internal/core/application/mapper"
@@ -15,6 +18,9 @@ type AgendamentoService struct {
 	agendamentoRepo port.AgendamentoRepositorio
 	catalogoRepo    port.CatalogoRepositorio
 	clienteRepo     port.ClienteRepositorio
+	observador      ObservadorDeVagas
+	metricas        MetricasAgendamento
+	transacao       port.UnidadeDeTrabalho
 }
 
 func NovaAgendamentoService(pr port.PrestadorRepositorio, ar port.AgendamentoRepositorio, cr port.CatalogoRepositorio, cl port.ClienteRepositorio) *AgendamentoService {
@@ -23,121 +29,199 @@ func NovaAgendamentoService(pr port.PrestadorRepositorio, ar port.AgendamentoRep
 		agendamentoRepo: ar,
 		catalogoRepo:    cr,
 		clienteRepo:     cl,
+		metricas:        semMetricas{},
+		transacao:       semTransacao{},
 	}
 }
 
-func (s *AgendamentoService) CadastraAgendamento(request request_agendamento.AgendamentoRequest) (*output.AgendamentoOutput, error) {
+func (s *AgendamentoService) CadastraAgendamento(ctx context.Context, request request_agendamento.AgendamentoRequest) (*output.AgendamentoOutput, error) {
 	input, err := request.ToAgendamento()
 	if err != nil {
 		return nil, err
 	}
 
-	validaDataErr := domain.ValidarDataNoPassado(input.DataHoraInicio)
-	if validaDataErr != nil {
-		return nil, domain.ErrDataEstaNoPassado
+	return s.Agendar(ctx, *input)
+}
+
+// DefinirObservadorDeVagas liga a lista de espera aos cancelamentos e reagendamentos
+func (s *AgendamentoService) DefinirObservadorDeVagas(o ObservadorDeVagas) {
+	s.observador = o
+}
+
+// DefinirUnidadeDeTrabalho faz as consultas de validação e a gravação do
+// agendamento acontecerem na mesma transação
+func (s *AgendamentoService) DefinirUnidadeDeTrabalho(u port.UnidadeDeTrabalho) {
+	s.transacao = u
+}
+
+// Agendar aplica as regras de CadastraAgendamento a partir do input já convertido
+func (s *AgendamentoService) Agendar(ctx context.Context, input input.CadastrarAgendamentoInput) (*output.AgendamentoOutput, error) {
+	var out *output.AgendamentoOutput
+	err := s.transacao.Executar(ctx, func(ctx context.Context) error {
+		var err error
+		out, err = s.agendar(ctx, input)
+		return err
+	})
+	if err != nil {
+		s.registrarRejeicao(err)
+		return nil, err
 	}
 
-	cliente, err := s.clienteRepo.BuscarPorId(input.ClienteID)
+	s.metricas.AgendamentoCriado()
+	return out, nil
+}
+
+func (s *AgendamentoService) agendar(ctx context.Context, input input.CadastrarAgendamentoInput) (*output.AgendamentoOutput, error) {
+	cliente, err := s.clienteRepo.BuscarPorId(ctx, input.ClienteID)
 	if err != nil || cliente == nil {
 		return nil, ErrClienteNaoExiste
 	}
+	if !cliente.Ativo {
+		return nil, ErrClienteInativo
+	}
+
+	if err := s.agendamentoRepo.TravarCliente(ctx, cliente.ID); err != nil {
+		return nil, err
+	}
 
-	prestador, err := s.prestadorRepo.BuscarPorId(input.PrestadorID)
+	prestador, err := s.prestadorRepo.BuscarPorId(ctx, input.PrestadorID)
 	if err != nil || prestador == nil {
 		return nil, ErrPrestadorNaoExiste
 	}
 
-	catalogo, err := s.catalogoRepo.BuscarPorId(input.CatalogoID)
+	// O "hoje" é o do fuso do prestador, não o de UTC
+	validaDataErr := domain.ValidarDataNoPassadoEm(input.DataHoraInicio, prestador.Localizacao())
+	if validaDataErr != nil {
+		return nil, domain.ErrDataEstaNoPassado
+	}
+
+	catalogo, err := s.catalogoRepo.BuscarPorId(ctx, input.CatalogoID)
 	if err != nil || catalogo == nil {
 		return nil, ErrCatalogoNaoExiste
 	}
 
-	dataHorarioFim := input.DataHoraInicio.Add(time.Duration(catalogo.DuracaoPadrao) * time.Minute)
+	// A duração pode ter sido ajustada pelo prestador para este serviço
+	dataHorarioFim := input.DataHoraInicio.Add(time.Duration(prestador.DuracaoDoServico(catalogo)) * time.Minute)
 
-	// Extrai apenas a data (sem hora) para validações por dia
-	inicioDoDia := time.Date(
-		input.DataHoraInicio.Year(),
-		input.DataHoraInicio.Month(),
-		input.DataHoraInicio.Day(),
-		0, 0, 0, 0,
-		input.DataHoraInicio.Location(),
+	if err := s.validarHorario(ctx, cliente, prestador, catalogo, input.DataHoraInicio, dataHorarioFim, ""); err != nil {
+		return nil, err
+	}
+
+	agendamento, err := domain.NovoAgendamento(
+		cliente,
+		prestador,
+		catalogo,
+		input.DataHoraInicio,
+		dataHorarioFim,
+		input.Notas,
 	)
-	fimDoDia := inicioDoDia.Add(24 * time.Hour)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.agendamentoRepo.CriaAgendamento(ctx, agendamento); err != nil {
+		// Outra requisição reservou o mesmo período entre a validação e a gravação
+		if errors.Is(err, domain.ErrHorarioJaReservado) {
+			return nil, ErrPrestadorOcupado
+		}
+		return nil, err
+	}
+
+	out := mapper.NovoAgendamentoOutput(agendamento)
+	return out, nil
+}
+
+// validarHorario aplica as regras de agenda e de conflito para o período informado.
+// ignorarID desconsidera o próprio agendamento quando ele está sendo remarcado.
+// O dia é sempre o do calendário local do prestador
+func (s *AgendamentoService) validarHorario(ctx context.Context, cliente *domain.Cliente, prestador *domain.Prestador, catalogo *domain.Catalogo, inicio, fim time.Time, ignorarID string) error {
+	loc := prestador.Localizacao()
+	inicioLocal := inicio.In(loc)
+
+	// Extrai apenas a data (sem hora) para validações por dia. AddDate respeita
+	// dias de 23h/25h nas mudanças de horário de verão
+	inicioDoDia := domain.InicioDoDiaEm(inicioLocal, loc)
+	fimDoDia := inicioDoDia.AddDate(0, 0, 1)
 
 	// ✅ Valida se já existe agendamento da mesma categoria no mesmo dia
-	agendamentosDoDia, err := s.agendamentoRepo.BuscarPorClienteEPeriodo(
-		input.ClienteID,
+	agendamentosDoDia, err := s.agendamentoRepo.BuscarPorClienteEPeriodo(ctx,
+		cliente.ID,
 		inicioDoDia,
 		fimDoDia,
 	)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Verifica se algum agendamento do dia é da mesma categoria
 	for _, agend := range agendamentosDoDia {
-		if agend.Catalogo.ID == catalogo.ID {
-			return nil, ErrAgendamentoDuplo
+		if agend.ID != ignorarID && agend.Catalogo.ID == catalogo.ID {
+			return ErrAgendamentoDuplo
 		}
 	}
 
 	// Busca a agenda do prestador para o dia solicitado
-	dia := input.DataHoraInicio.Format("2006-01-02")
-	agendaDoDia, err := s.prestadorRepo.BuscarAgendaDoDia(prestador.ID, dia)
+	dia := inicioLocal.Format("2006-01-02")
+	agendaDoDia, err := s.prestadorRepo.BuscarAgendaDoDia(ctx, prestador.ID, dia)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Valida se o dia é atendido pelo prestador
 	if agendaDoDia == nil {
-		return nil, ErrDiaIndisponivel
+		return ErrDiaIndisponivel
 	}
 
 	// Valida se o horário solicitado está dentro dos horários disponíveis do dia
-	if !agendaDoDia.PermiteAgendamento(input.DataHoraInicio, dataHorarioFim) {
-		return nil, ErrHorarioIndisponivel
+	if !agendaDoDia.PermiteAgendamento(inicio, fim, loc) {
+		return ErrHorarioIndisponivel
+	}
+
+	// Vagas oferecidas pela lista de espera ficam reservadas até o prazo de aceite
+	if s.observador != nil {
+		reservada, err := s.observador.VagaReservadaParaOutro(ctx, prestador.ID, cliente.ID, inicio, fim)
+		if err != nil {
+			return err
+		}
+		if reservada {
+			return ErrVagaReservada
+		}
 	}
 
-	// Um prestador não pode ter dois atendimentos no mesmo período
-	conflitosPrestador, err := s.agendamentoRepo.BuscarPorPrestadorEPeriodo(prestador.ID, input.DataHoraInicio, dataHorarioFim)
+	// Um prestador não pode ter dois atendimentos no mesmo período, contando preparo e limpeza
+	bloqueioInicio, bloqueioFim := domain.PeriodoBloqueado(prestador, catalogo, inicio, fim)
+	conflitosPrestador, err := s.agendamentoRepo.BuscarPorPrestadorEPeriodo(ctx, prestador.ID, bloqueioInicio, bloqueioFim)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if len(conflitosPrestador) > 0 {
-		return nil, ErrPrestadorOcupado
+	if len(semAgendamento(conflitosPrestador, ignorarID)) > 0 {
+		return ErrPrestadorOcupado
 	}
 
 	// Um cliente não pode ter dois agendamentos simultâneos
-	conflitosCliente, err := s.agendamentoRepo.BuscarPorClienteEPeriodo(cliente.ID, input.DataHoraInicio, dataHorarioFim)
+	conflitosCliente, err := s.agendamentoRepo.BuscarPorClienteEPeriodo(ctx, cliente.ID, inicio, fim)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if len(conflitosCliente) > 0 {
-		return nil, ErrClienteOcupado
+	if len(semAgendamento(conflitosCliente, ignorarID)) > 0 {
+		return ErrClienteOcupado
 	}
 
-	agendamento, err := domain.NovoAgendamento(
-		cliente,
-		prestador,
-		catalogo,
-		input.DataHoraInicio,
-		dataHorarioFim,
-		input.Notas,
-	)
-	if err != nil {
-		return nil, err
-	}
+	return nil
+}
 
-	if err := s.agendamentoRepo.CriaAgendamento(agendamento); err != nil {
-		return nil, err
+func semAgendamento(agendamentos []*domain.Agendamento, id string) []*domain.Agendamento {
+	resultado := make([]*domain.Agendamento, 0, len(agendamentos))
+	for _, a := range agendamentos {
+		if a.ID != id {
+			resultado = append(resultado, a)
+		}
 	}
-
-	out := mapper.NovoAgendamentoOutput(agendamento)
-	return out, nil
+	return resultado
 }
 
-func (s *AgendamentoService) ConsultaAgendamentoClienteData(request input.AgendamentoDataInput, id string) ([]*output.AgendamentoOutput, error) {
-	cliente, err := s.clienteRepo.BuscarPorId(id)
+func (s *AgendamentoService) ConsultaAgendamentoClienteData(ctx context.Context, request input.AgendamentoDataInput, id string) ([]*output.AgendamentoOutput, error) {
+	cliente, err := s.clienteRepo.BuscarPorId(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -145,7 +229,7 @@ func (s *AgendamentoService) ConsultaAgendamentoClienteData(request input.Agenda
 		return nil, ErrClienteNaoEncontrado
 	}
 
-	agendamentos, err := s.agendamentoRepo.BuscarAgendamentoClienteAPartirDaData(id, request.Data)
+	agendamentos, err := s.agendamentoRepo.BuscarAgendamentoClienteAPartirDaData(ctx, id, request.Data)
 	if err != nil {
 		return nil, err
 	}
@@ -155,8 +239,8 @@ func (s *AgendamentoService) ConsultaAgendamentoClienteData(request input.Agenda
 	return out, nil
 }
 
-func (s *AgendamentoService) ConsultaAgendamentoPrestadorData(request input.AgendamentoDataInput, id string) ([]*output.AgendamentoOutput, error) {
-	prestador, err := s.prestadorRepo.BuscarPorId(id)
+func (s *AgendamentoService) ConsultaAgendamentoPrestadorData(ctx context.Context, request input.AgendamentoDataInput, id string) ([]*output.AgendamentoOutput, error) {
+	prestador, err := s.prestadorRepo.BuscarPorId(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -164,7 +248,7 @@ func (s *AgendamentoService) ConsultaAgendamentoPrestadorData(request input.Agen
 		return nil, ErrPrestadorNaoEncontrado
 	}
 
-	agendamentos, err := s.agendamentoRepo.BuscarAgendamentoPrestadorAPartirDaData(id, request.Data)
+	agendamentos, err := s.agendamentoRepo.BuscarAgendamentoPrestadorAPartirDaData(ctx, id, request.Data)
 	if err != nil {
 		return nil, err
 	}
@@ -172,4 +256,241 @@ func (s *AgendamentoService) ConsultaAgendamentoPrestadorData(request input.Agen
 	out := mapper.BuscaAgendamentoData(agendamentos)
 
 	return out, nil
-}
\ No newline at end of file
+}
+
+func (s *AgendamentoService) ConfirmarAgendamento(ctx context.Context, id string) error {
+	return s.alterarStatus(ctx, id, (*domain.Agendamento).Confirmar)
+}
+
+func (s *AgendamentoService) CancelarAgendamento(ctx context.Context, id string) error {
+	return s.alterarStatus(ctx, id, (*domain.Agendamento).Cancelar)
+}
+
+func (s *AgendamentoService) ConcluirAgendamento(ctx context.Context, id string) error {
+	return s.alterarStatus(ctx, id, (*domain.Agendamento).Concluir)
+}
+
+// alterarStatus aplica a transição do domínio e persiste o novo status. O agendamento
+// fica travado da leitura à gravação, para que uma mudança concorrente não se perca
+func (s *AgendamentoService) alterarStatus(ctx context.Context, id string, transicao func(*domain.Agendamento) error) error {
+	var agendamento *domain.Agendamento
+	err := s.transacao.Executar(ctx, func(ctx context.Context) error {
+		var err error
+		agendamento, err = s.agendamentoRepo.BuscarPorIdParaAtualizar(ctx, id)
+		if err != nil {
+			return err
+		}
+		if agendamento == nil {
+			return ErrAgendamentoNaoEncontrado
+		}
+
+		if err := transicao(agendamento); err != nil {
+			return err
+		}
+
+		if err := s.agendamentoRepo.AtualizarStatus(ctx, agendamento.ID, agendamento.Status); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrAgendamentoNaoEncontrado
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if agendamento.Status == domain.Cancelado {
+		s.metricas.AgendamentoCancelado()
+
+		liberadoInicio, liberadoFim := agendamento.PeriodoOcupado()
+		avisarVagaLiberada(ctx, s.observador, agendamento.Prestador.ID, liberadoInicio, liberadoFim)
+	}
+
+	return nil
+}
+
+// ReagendarAgendamento move o agendamento mantendo a duração original e registra os
+// horários anteriores. A leitura, as validações e a gravação vão na mesma transação
+func (s *AgendamentoService) ReagendarAgendamento(ctx context.Context, in input.ReagendarAgendamentoInput) (*output.AgendamentoOutput, error) {
+	var out *output.AgendamentoOutput
+	var anterior *domain.Agendamento
+	err := s.transacao.Executar(ctx, func(ctx context.Context) error {
+		var err error
+		out, anterior, err = s.reagendar(ctx, in)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	liberadoInicio, liberadoFim := anterior.PeriodoOcupado()
+	avisarVagaLiberada(ctx, s.observador, anterior.Prestador.ID, liberadoInicio, liberadoFim)
+
+	return out, nil
+}
+
+// reagendar devolve também o agendamento como estava, para avisar a vaga liberada
+func (s *AgendamentoService) reagendar(ctx context.Context, in input.ReagendarAgendamentoInput) (*output.AgendamentoOutput, *domain.Agendamento, error) {
+	agendamento, err := s.agendamentoRepo.BuscarPorIdParaAtualizar(ctx, in.AgendamentoID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if agendamento == nil {
+		return nil, nil, ErrAgendamentoNaoEncontrado
+	}
+
+	cliente, err := s.clienteRepo.BuscarPorId(ctx, agendamento.Cliente.ID)
+	if err != nil || cliente == nil {
+		return nil, nil, ErrClienteNaoExiste
+	}
+	if !cliente.Ativo {
+		return nil, nil, ErrClienteInativo
+	}
+
+	if err := s.agendamentoRepo.TravarCliente(ctx, cliente.ID); err != nil {
+		return nil, nil, err
+	}
+
+	if err := domain.ValidarDataNoPassadoEm(in.DataHoraInicio, agendamento.Prestador.Localizacao()); err != nil {
+		return nil, nil, domain.ErrDataEstaNoPassado
+	}
+
+	// Preparo e limpeza seguem a configuração atual do serviço e do prestador
+	prestador, err := s.prestadorRepo.BuscarPorId(ctx, agendamento.Prestador.ID)
+	if err != nil || prestador == nil {
+		return nil, nil, ErrPrestadorNaoExiste
+	}
+
+	catalogo, err := s.catalogoRepo.BuscarPorId(ctx, agendamento.Catalogo.ID)
+	if err != nil || catalogo == nil {
+		return nil, nil, ErrCatalogoNaoExiste
+	}
+
+	// Trabalha sobre uma cópia para não alterar o agendamento antes das validações
+	reagendado := *agendamento
+	duracao := agendamento.DataHoraFim.Sub(agendamento.DataHoraInicio)
+
+	historico, err := reagendado.Reagendar(in.DataHoraInicio, in.DataHoraInicio.Add(duracao))
+	if err != nil {
+		return nil, nil, err
+	}
+	reagendado.BloqueioInicio, reagendado.BloqueioFim = domain.PeriodoBloqueado(prestador, catalogo, reagendado.DataHoraInicio, reagendado.DataHoraFim)
+
+	if err := s.validarHorario(ctx,
+		reagendado.Cliente,
+		prestador,
+		catalogo,
+		reagendado.DataHoraInicio,
+		reagendado.DataHoraFim,
+		reagendado.ID,
+	); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.agendamentoRepo.Reagendar(ctx, &reagendado, historico); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, ErrAgendamentoNaoEncontrado
+		}
+		if errors.Is(err, domain.ErrHorarioJaReservado) {
+			return nil, nil, ErrPrestadorOcupado
+		}
+		return nil, nil, err
+	}
+
+	return mapper.NovoAgendamentoOutput(&reagendado), agendamento, nil
+}
+
+func (s *AgendamentoService) ListarReagendamentos(ctx context.Context, id string) ([]*output.ReagendamentoOutput, error) {
+	agendamento, err := s.agendamentoRepo.BuscarPorId(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if agendamento == nil {
+		return nil, ErrAgendamentoNaoEncontrado
+	}
+
+	historico, err := s.agendamentoRepo.ListarReagendamentos(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ReagendamentosOutput(historico), nil
+}
+
+// IntervaloPadraoHorariosMinutos é o passo da grade de horários quando o cliente não informa um
+const IntervaloPadraoHorariosMinutos = 15
+
+func (s *AgendamentoService) BuscarHorariosDisponiveis(ctx context.Context, in input.HorariosDisponiveisInput) (*output.HorariosDisponiveisOutput, error) {
+	prestador, err := s.prestadorRepo.BuscarPorId(ctx, in.PrestadorID)
+	if err != nil || prestador == nil {
+		return nil, ErrPrestadorNaoExiste
+	}
+
+	// A data consultada é um dia de calendário no fuso do prestador
+	loc := prestador.Localizacao()
+	inicioDoDia := domain.InicioDoDiaEm(in.Data, loc)
+	if err := domain.ValidarDataNoPassadoEm(inicioDoDia, loc); err != nil {
+		return nil, err
+	}
+
+	if !prestador.Ativo {
+		return nil, ErrPrestadorInativo
+	}
+
+	catalogo, err := s.catalogoRepo.BuscarPorId(ctx, in.CatalogoID)
+	if err != nil || catalogo == nil {
+		return nil, ErrCatalogoNaoExiste
+	}
+
+	intervalo := in.IntervaloMinutos
+	if intervalo <= 0 {
+		intervalo = IntervaloPadraoHorariosMinutos
+	}
+
+	dia := inicioDoDia.Format("2006-01-02")
+	out := &output.HorariosDisponiveisOutput{
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		Data:           dia,
+		FusoHorario:    loc.String(),
+		DuracaoMinutos: prestador.DuracaoDoServico(catalogo),
+		Horarios:       []time.Time{},
+	}
+
+	agendaDoDia, err := s.prestadorRepo.BuscarAgendaDoDia(ctx, prestador.ID, dia)
+	if err != nil {
+		return nil, err
+	}
+
+	// Dia sem agenda não é erro: apenas não há horários
+	if agendaDoDia == nil {
+		return out, nil
+	}
+
+	// O preparo do primeiro horário e a limpeza do último podem invadir os dias vizinhos
+	preparo, limpeza := prestador.TemposEntreAtendimentos(catalogo)
+	ocupados, err := s.agendamentoRepo.BuscarPorPrestadorEPeriodo(ctx, prestador.ID, inicioDoDia.Add(-preparo), inicioDoDia.AddDate(0, 0, 1).Add(limpeza))
+	if err != nil {
+		return nil, err
+	}
+
+	livres := agendaDoDia.HorariosLivres(
+		time.Duration(prestador.DuracaoDoServico(catalogo))*time.Minute,
+		preparo,
+		limpeza,
+		time.Duration(intervalo)*time.Minute,
+		ocupados,
+		loc,
+	)
+
+	// Descarta horários que já passaram (relevante quando a data é hoje)
+	agora := time.Now()
+	for _, h := range livres {
+		if h.After(agora) {
+			out.Horarios = append(out.Horarios, h)
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"meu-servico-agenda/internal/core/application/input"
+	"meu-servico-agenda/internal/core/application/output"
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/domain"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	TamanhoMinimoSenha = 8
+	// bcrypt ignora o que passa de 72 bytes
+	TamanhoMaximoSenha = 72
+	// TTLPadraoRefreshToken é por quanto tempo o usuário fica logado sem informar a senha
+	TTLPadraoRefreshToken = 30 * 24 * time.Hour
+)
+
+// hashFicticio é comparado quando o email não existe, para que a resposta demore o
+// mesmo que uma senha errada e não revele quais emails têm conta
+var hashFicticio = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("senha-ficticia"), bcrypt.DefaultCost)
+	return hash
+})
+
+type AuthService struct {
+	usuarioRepo      port.UsuarioRepositorio
+	refreshTokenRepo port.RefreshTokenRepositorio
+	clienteRepo      port.ClienteRepositorio
+	prestadorRepo    port.PrestadorRepositorio
+	emissor          port.EmissorToken
+	ttlRefreshToken  time.Duration
+}
+
+func NovaAuthService(
+	ur port.UsuarioRepositorio,
+	rr port.RefreshTokenRepositorio,
+	cl port.ClienteRepositorio,
+	pr port.PrestadorRepositorio,
+	emissor port.EmissorToken,
+	ttlRefreshToken time.Duration,
+) *AuthService {
+	return &AuthService{
+		usuarioRepo:      ur,
+		refreshTokenRepo: rr,
+		clienteRepo:      cl,
+		prestadorRepo:    pr,
+		emissor:          emissor,
+		ttlRefreshToken:  ttlRefreshToken,
+	}
+}
+
+// CadastrarUsuario cria a conta de acesso ligada a um cliente ou prestador já cadastrado
+func (s *AuthService) CadastrarUsuario(ctx context.Context, in input.CadastrarUsuarioInput) (*domain.Usuario, error) {
+	papel := domain.Papel(in.Papel)
+
+	switch {
+	case papel == domain.PapelCliente && in.ClienteID != "":
+		cliente, err := s.clienteRepo.BuscarPorId(ctx, in.ClienteID)
+		if err != nil || cliente == nil {
+			return nil, ErrClienteNaoExiste
+		}
+	case papel == domain.PapelPrestador && in.PrestadorID != "":
+		prestador, err := s.prestadorRepo.BuscarPorId(ctx, in.PrestadorID)
+		if err != nil || prestador == nil {
+			return nil, ErrPrestadorNaoExiste
+		}
+	}
+
+	hash, err := gerarHashSenha(in.Senha)
+	if err != nil {
+		return nil, err
+	}
+
+	usuario, err := domain.NovoUsuario(in.Email, hash, papel, in.ClienteID, in.PrestadorID)
+	if err != nil {
+		return nil, err
+	}
+
+	existente, err := s.usuarioRepo.BuscarPorEmail(ctx, usuario.Email)
+	if err != nil {
+		return nil, ErrFalhaInfraestrutura
+	}
+	if existente != nil {
+		return nil, domain.ErrUsuarioJaCadastrado
+	}
+
+	if err := s.usuarioRepo.Salvar(ctx, usuario); err != nil {
+		if errors.Is(err, domain.ErrUsuarioJaCadastrado) {
+			return nil, err
+		}
+		return nil, ErrFalhaInfraestrutura
+	}
+
+	return usuario, nil
+}
+
+// GarantirAdmin cria o primeiro admin quando ainda não existe nenhum. Sem ele ninguém
+// conseguiria cadastrar os demais usuários
+func (s *AuthService) GarantirAdmin(ctx context.Context, email, senha string) error {
+	existe, err := s.usuarioRepo.ExisteAdmin(ctx)
+	if err != nil {
+		return err
+	}
+	if existe {
+		return nil
+	}
+
+	if _, err := s.CadastrarUsuario(ctx, input.CadastrarUsuarioInput{
+		Email: email,
+		Senha: senha,
+		Papel: string(domain.PapelAdmin),
+	}); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "usuário admin criado", slog.String("email", email))
+	return nil
+}
+
+func (s *AuthService) Login(ctx context.Context, email, senha string) (*output.TokensOutput, error) {
+	usuario, err := s.usuarioRepo.BuscarPorEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		return nil, ErrFalhaInfraestrutura
+	}
+
+	if usuario == nil {
+		_ = bcrypt.CompareHashAndPassword(hashFicticio(), []byte(senha))
+		return nil, ErrCredenciaisInvalidas
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(usuario.SenhaHash), []byte(senha)); err != nil {
+		return nil, ErrCredenciaisInvalidas
+	}
+
+	if !usuario.Ativo {
+		return nil, ErrCredenciaisInvalidas
+	}
+
+	return s.emitirTokens(ctx, usuario)
+}
+
+// Renovar troca um refresh token válido por um novo par de tokens. O token usado é
+// revogado; se um token já revogado for apresentado de novo, ele pode ter vazado e
+// todas as sessões do usuário são encerradas
+func (s *AuthService) Renovar(ctx context.Context, refreshToken string) (*output.TokensOutput, error) {
+	agora := time.Now().UTC()
+
+	token, err := s.refreshTokenRepo.BuscarPorHash(ctx, hashTokenSecreto(refreshToken))
+	if err != nil {
+		return nil, ErrFalhaInfraestrutura
+	}
+	if token == nil {
+		return nil, ErrRefreshTokenInvalido
+	}
+
+	if token.RevogadoEm != nil {
+		if err := s.refreshTokenRepo.RevogarDoUsuario(ctx, token.UsuarioID, agora); err != nil {
+			return nil, ErrFalhaInfraestrutura
+		}
+		return nil, ErrRefreshTokenInvalido
+	}
+
+	if !token.Valido(agora) {
+		return nil, ErrRefreshTokenInvalido
+	}
+
+	usuario, err := s.usuarioRepo.BuscarPorId(ctx, token.UsuarioID)
+	if err != nil {
+		return nil, ErrFalhaInfraestrutura
+	}
+	if usuario == nil || !usuario.Ativo {
+		return nil, ErrRefreshTokenInvalido
+	}
+
+	if err := s.refreshTokenRepo.Revogar(ctx, token.ID, agora); err != nil {
+		return nil, ErrFalhaInfraestrutura
+	}
+
+	return s.emitirTokens(ctx, usuario)
+}
+
+// Sair revoga o refresh token. Um token desconhecido não é erro: a sessão já não existe
+func (s *AuthService) Sair(ctx context.Context, refreshToken string) error {
+	token, err := s.refreshTokenRepo.BuscarPorHash(ctx, hashTokenSecreto(refreshToken))
+	if err != nil {
+		return ErrFalhaInfraestrutura
+	}
+	if token == nil || token.RevogadoEm != nil {
+		return nil
+	}
+
+	if err := s.refreshTokenRepo.Revogar(ctx, token.ID, time.Now().UTC()); err != nil {
+		return ErrFalhaInfraestrutura
+	}
+	return nil
+}
+
+func (s *AuthService) emitirTokens(ctx context.Context, usuario *domain.Usuario) (*output.TokensOutput, error) {
+	accessToken, expiraEm, err := s.emissor.Emitir(usuario.Identidade())
+	if err != nil {
+		return nil, ErrFalhaInfraestrutura
+	}
+
+	refreshToken, err := gerarTokenSecreto()
+	if err != nil {
+		return nil, ErrFalhaInfraestrutura
+	}
+
+	registro := domain.NovoRefreshToken(usuario.ID, hashTokenSecreto(refreshToken), s.ttlRefreshToken)
+	if err := s.refreshTokenRepo.Salvar(ctx, registro); err != nil {
+		return nil, ErrFalhaInfraestrutura
+	}
+
+	return &output.TokensOutput{
+		AccessToken:  accessToken,
+		ExpiraEm:     expiraEm,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func gerarHashSenha(senha string) (string, error) {
+	if len(senha) < TamanhoMinimoSenha || len(senha) > TamanhoMaximoSenha {
+		return "", ErrSenhaInvalida
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(senha), bcrypt.DefaultCost)
+	if err != nil {
+		return "", ErrFalhaInfraestrutura
+	}
+	return string(hash), nil
+}
+
+// gerarTokenSecreto sorteia os refresh tokens e os tokens das URLs de calendário
+func gerarTokenSecreto() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashTokenSecreto é o que fica no banco: quem lê a tabela não consegue usar os tokens
+func hashTokenSecreto(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"meu-servico-agenda/internal/core/application/mapper"
+	"meu-servico-agenda/internal/core/application/output"
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/domain"
+)
+
+// JanelaPassadaCalendario é quanto do passado o feed mantém. Sem ela, um
+// agendamento cancelado logo antes do horário sairia do feed antes de o
+// calendário assinante ver o STATUS:CANCELLED
+const JanelaPassadaCalendario = 30 * 24 * time.Hour
+
+// CalendarioService emite os tokens das URLs secretas e monta os feeds
+// iCalendar de clientes e prestadores
+type CalendarioService struct {
+	clienteRepo     port.ClienteRepositorio
+	prestadorRepo   port.PrestadorRepositorio
+	agendamentoRepo port.AgendamentoRepositorio
+	tokenRepo       port.TokenCalendarioRepositorio
+}
+
+func NovaCalendarioService(cl port.ClienteRepositorio, pr port.PrestadorRepositorio, ar port.AgendamentoRepositorio, tr port.TokenCalendarioRepositorio) *CalendarioService {
+	return &CalendarioService{
+		clienteRepo:     cl,
+		prestadorRepo:   pr,
+		agendamentoRepo: ar,
+		tokenRepo:       tr,
+	}
+}
+
+// GerarTokenCliente cria a URL secreta do feed do cliente. A URL anterior,
+// se existir, deixa de funcionar
+func (s *CalendarioService) GerarTokenCliente(ctx context.Context, clienteID string) (*output.TokenCalendarioOutput, error) {
+	cliente, err := s.clienteRepo.BuscarPorId(ctx, clienteID)
+	if err != nil {
+		return nil, err
+	}
+	if cliente == nil {
+		return nil, ErrClienteNaoEncontrado
+	}
+
+	return s.gerarToken(ctx, func(hash string) *domain.TokenCalendario {
+		return domain.NovoTokenCalendarioCliente(cliente.ID, hash)
+	})
+}
+
+// GerarTokenPrestador cria a URL secreta do feed do prestador. A URL anterior,
+// se existir, deixa de funcionar
+func (s *CalendarioService) GerarTokenPrestador(ctx context.Context, prestadorID string) (*output.TokenCalendarioOutput, error) {
+	prestador, err := s.prestadorRepo.BuscarPorId(ctx, prestadorID)
+	if err != nil {
+		return nil, err
+	}
+	if prestador == nil {
+		return nil, ErrPrestadorNaoEncontrado
+	}
+
+	return s.gerarToken(ctx, func(hash string) *domain.TokenCalendario {
+		return domain.NovoTokenCalendarioPrestador(prestador.ID, hash)
+	})
+}
+
+// RevogarTokenCliente apaga a URL do feed do cliente sem criar outra
+func (s *CalendarioService) RevogarTokenCliente(ctx context.Context, clienteID string) error {
+	cliente, err := s.clienteRepo.BuscarPorId(ctx, clienteID)
+	if err != nil {
+		return err
+	}
+	if cliente == nil {
+		return ErrClienteNaoEncontrado
+	}
+
+	return s.tokenRepo.RemoverDoCliente(ctx, cliente.ID)
+}
+
+// RevogarTokenPrestador apaga a URL do feed do prestador sem criar outra
+func (s *CalendarioService) RevogarTokenPrestador(ctx context.Context, prestadorID string) error {
+	prestador, err := s.prestadorRepo.BuscarPorId(ctx, prestadorID)
+	if err != nil {
+		return err
+	}
+	if prestador == nil {
+		return ErrPrestadorNaoEncontrado
+	}
+
+	return s.tokenRepo.RemoverDoPrestador(ctx, prestador.ID)
+}
+
+func (s *CalendarioService) gerarToken(ctx context.Context, novo func(hash string) *domain.TokenCalendario) (*output.TokenCalendarioOutput, error) {
+	token, err := gerarTokenSecreto()
+	if err != nil {
+		return nil, ErrFalhaInfraestrutura
+	}
+
+	registro := novo(hashTokenSecreto(token))
+	if err := s.tokenRepo.Substituir(ctx, registro); err != nil {
+		return nil, err
+	}
+
+	return &output.TokenCalendarioOutput{Token: token, CriadoEm: registro.CriadoEm}, nil
+}
+
+// Feed devolve os agendamentos do dono do token a partir de JanelaPassadaCalendario
+// atrás, incluindo os cancelados. O token de um dono inativo ou anonimizado não abre
+// o feed, mesmo que ainda não tenha sido apagado
+func (s *CalendarioService) Feed(ctx context.Context, token string) (*output.CalendarioOutput, error) {
+	registro, err := s.tokenRepo.BuscarPorHash(ctx, hashTokenSecreto(token))
+	if err != nil {
+		return nil, err
+	}
+	if registro == nil {
+		return nil, ErrCalendarioNaoEncontrado
+	}
+
+	ativo, err := s.donoAtivo(ctx, registro)
+	if err != nil {
+		return nil, err
+	}
+	if !ativo {
+		return nil, ErrCalendarioNaoEncontrado
+	}
+
+	desde := time.Now().Add(-JanelaPassadaCalendario)
+
+	var agendamentos []*domain.Agendamento
+	if registro.PrestadorID != "" {
+		agendamentos, err = s.agendamentoRepo.BuscarAgendamentoPrestadorAPartirDaData(ctx, registro.PrestadorID, desde)
+	} else {
+		agendamentos, err = s.agendamentoRepo.BuscarAgendamentoClienteAPartirDaData(ctx, registro.ClienteID, desde)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &output.CalendarioOutput{
+		ClienteID:    registro.ClienteID,
+		PrestadorID:  registro.PrestadorID,
+		Agendamentos: mapper.BuscaAgendamentoData(agendamentos),
+	}, nil
+}
+
+func (s *CalendarioService) donoAtivo(ctx context.Context, registro *domain.TokenCalendario) (bool, error) {
+	if registro.PrestadorID != "" {
+		prestador, err := s.prestadorRepo.BuscarPorId(ctx, registro.PrestadorID)
+		if err != nil {
+			return false, err
+		}
+		return prestador != nil && prestador.Ativo, nil
+	}
+
+	cliente, err := s.clienteRepo.BuscarPorId(ctx, registro.ClienteID)
+	if err != nil {
+		return false, err
+	}
+	return cliente != nil && cliente.Ativo && cliente.AnonimizadoEm == nil, nil
+}
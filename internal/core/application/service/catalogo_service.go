@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"meu-servico-agenda/internal/core/application/input"
 	"meu-servico-agenda/internal/core/application/mapper"
 	"meu-servico-agenda/internal/core/application/output"
@@ -9,14 +10,19 @@ import (
 )
 
 type CatalogoService struct {
-	repo port.CatalogoRepositorio
+	repo      port.CatalogoRepositorio
+	paginacao Paginacao
 }
 
 func NovoCatalogoService(r port.CatalogoRepositorio) *CatalogoService {
-	return &CatalogoService{repo: r}
+	return &CatalogoService{repo: r, paginacao: PaginacaoPadrao}
 }
 
-func (s *CatalogoService) Cadastra(input *input.CatalogoInput) (*output.CatalogoOutput, error) {
+func (s *CatalogoService) DefinirPaginacao(p Paginacao) {
+	s.paginacao = p
+}
+
+func (s *CatalogoService) Cadastra(ctx context.Context, input *input.CatalogoInput) (*output.CatalogoOutput, error) {
 
 	catalogo, err := domain.NovoCatalogo(
 		input.Nome,
@@ -30,42 +36,35 @@ func (s *CatalogoService) Cadastra(input *input.CatalogoInput) (*output.Catalogo
 		return nil, err
 	}
 
-	if err := s.repo.Salvar(catalogo); err != nil {
+	if err := catalogo.DefinirTemposEntreAtendimentos(input.TempoPreparo, input.TempoLimpeza); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Salvar(ctx, catalogo); err != nil {
 		return nil, err
 	}
 
 	return mapper.FromCatalogoOutput(catalogo), nil
 }
 
-func (s *CatalogoService) BuscarPorId(id string) (*output.CatalogoOutput, error) {
-	catalogo, err := s.repo.BuscarPorId(id)
+func (s *CatalogoService) BuscarPorId(ctx context.Context, id string) (*output.CatalogoOutput, error) {
+	catalogo, err := s.repo.BuscarPorId(ctx, id)
 	if err != nil {
 		return nil, ErrCatalogoNaoEncontrado
 	}
 	return mapper.FromCatalogoOutput(catalogo), nil
 }
 
-func (s *CatalogoService) Listar(in *input.ListCatalogoInput) ([]*output.CatalogoOutput, int, error) {
-
-	page := in.Page
-	limit := in.Limit
-
-	if page <= 0 {
-		page = 1
-	}
-
-	if limit <= 0 || limit > 100 {
-		limit = 10
-	}
-
-	offset := (page - 1) * limit
+func (s *CatalogoService) Listar(ctx context.Context, in *input.ListCatalogoInput) ([]*output.CatalogoOutput, int, error) {
+	s.paginacao.Normalizar(&in.Page, &in.Limit)
+	offset := (in.Page - 1) * in.Limit
 
-	catalogos, err := s.repo.Listar(limit, offset)
+	catalogos, err := s.repo.Listar(ctx, in.Limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	total, err := s.repo.Contar()
+	total, err := s.repo.Contar(ctx)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -73,9 +72,9 @@ func (s *CatalogoService) Listar(in *input.ListCatalogoInput) ([]*output.Catalog
 	return mapper.CatalogosFromDomainOutput(catalogos), total, nil
 }
 
-func (s *CatalogoService) Atualizar(input *input.CatalogoUpdateInput) error {
+func (s *CatalogoService) Atualizar(ctx context.Context, input *input.CatalogoUpdateInput) error {
 	// Verifica se existe
-	catalogo, err := s.repo.BuscarPorId(input.ID)
+	catalogo, err := s.repo.BuscarPorId(ctx, input.ID)
 	if err != nil {
 		return ErrCatalogoNaoEncontrado
 	}
@@ -100,22 +99,33 @@ func (s *CatalogoService) Atualizar(input *input.CatalogoUpdateInput) error {
 
 	catalogo.ImagemUrl = input.ImagemUrl
 
-	if err := s.repo.Atualizar(catalogo); err != nil {
+	preparo, limpeza := catalogo.TempoPreparo, catalogo.TempoLimpeza
+	if input.TempoPreparo != nil {
+		preparo = *input.TempoPreparo
+	}
+	if input.TempoLimpeza != nil {
+		limpeza = *input.TempoLimpeza
+	}
+	if err := catalogo.DefinirTemposEntreAtendimentos(preparo, limpeza); err != nil {
+		return err
+	}
+
+	if err := s.repo.Atualizar(ctx, catalogo); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (s *CatalogoService) Deletar(id string) error {
+func (s *CatalogoService) Deletar(ctx context.Context, id string) error {
 	// Verifica se o catálogo existe
-	_, err := s.repo.BuscarPorId(id)
+	_, err := s.repo.BuscarPorId(ctx, id)
 	if err != nil {
 		return ErrCatalogoNaoEncontrado
 	}
 
 	// Deleta o catálogo
-	if err := s.repo.Deletar(id); err != nil {
+	if err := s.repo.Deletar(ctx, id); err != nil {
 		return ErrFalhaInfraestrutura
 	}
 
@@ -1,34 +1,68 @@
 package service
 
 import (
+	"context"
+	"database/sql"
 	"errors"
 
+	"meu-servico-agenda/internal/core/application/input"
 	"meu-servico-agenda/internal/core/application/port"
 	"meu-servico-agenda/internal/core/domain"
 )
 
 type ServiceCliente struct {
-	repo port.ClienteRepositorio
+	repo      port.ClienteRepositorio
+	tokenRepo port.TokenCalendarioRepositorio
+	paginacao Paginacao
 }
 
 func NovoServiceCliente(r port.ClienteRepositorio) *ServiceCliente {
-	return &ServiceCliente{repo: r}
+	return &ServiceCliente{repo: r, paginacao: PaginacaoPadrao}
 }
 
-func (s *ServiceCliente) Cadastra(cliente *domain.Cliente) (*domain.Cliente, error) {
+func (s *ServiceCliente) DefinirPaginacao(p Paginacao) {
+	s.paginacao = p
+}
+
+// DefinirTokensCalendario faz a inativação apagar a URL do calendário do cliente
+func (s *ServiceCliente) DefinirTokensCalendario(tr port.TokenCalendarioRepositorio) {
+	s.tokenRepo = tr
+}
+
+func (s *ServiceCliente) Cadastra(ctx context.Context, cliente *domain.Cliente) (*domain.Cliente, error) {
 	if cliente == nil {
 		return nil, ErrClienteNaoEncontrado
 	}
 
-	if err := s.repo.Salvar(cliente); err != nil {
+	if err := s.validarEmailDisponivel(ctx, cliente.Email, cliente.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Salvar(ctx, cliente); err != nil {
+		// Outro cadastro com o mesmo email pode ter sido gravado depois da verificação
+		if errors.Is(err, domain.ErrEmailJaCadastrado) {
+			return nil, err
+		}
 		return nil, errors.New(ErrAoSalvarCliente.Error() + err.Error())
 	}
 
 	return cliente, nil
 }
 
-func (s *ServiceCliente) BuscarPorId(id string) (*domain.Cliente, error) {
-	cliente, err := s.repo.BuscarPorId(id)
+// validarEmailDisponivel garante que o email não pertence a outro cliente
+func (s *ServiceCliente) validarEmailDisponivel(ctx context.Context, email, clienteID string) error {
+	existente, err := s.repo.BuscarPorEmail(ctx, email)
+	if err != nil {
+		return ErrFalhaInfraestrutura
+	}
+	if existente != nil && existente.ID != clienteID {
+		return domain.ErrEmailJaCadastrado
+	}
+	return nil
+}
+
+func (s *ServiceCliente) BuscarPorId(ctx context.Context, id string) (*domain.Cliente, error) {
+	cliente, err := s.repo.BuscarPorId(ctx, id)
 
 	if err != nil {
 		return nil, ErrFalhaInfraestrutura
@@ -40,3 +74,86 @@ func (s *ServiceCliente) BuscarPorId(id string) (*domain.Cliente, error) {
 
 	return cliente, nil
 }
+
+// Atualizar substitui os dados de contato do cliente
+func (s *ServiceCliente) Atualizar(ctx context.Context, in *input.AlterarClienteInput) (*domain.Cliente, error) {
+	cliente, err := s.BuscarPorId(ctx, in.Id)
+	if err != nil {
+		return nil, err
+	}
+	if cliente.AnonimizadoEm != nil {
+		return nil, domain.ErrClienteAnonimizado
+	}
+
+	if err := s.validarEmailDisponivel(ctx, in.Email, cliente.ID); err != nil {
+		return nil, err
+	}
+
+	atualizado := *cliente
+	atualizado.Nome = in.Nome
+	atualizado.Email = in.Email
+	atualizado.Telefone = in.Telefone
+
+	if err := s.repo.Atualizar(ctx, &atualizado); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrClienteNaoEncontrado
+		case errors.Is(err, domain.ErrEmailJaCadastrado):
+			return nil, err
+		default:
+			return nil, ErrFalhaInfraestrutura
+		}
+	}
+
+	return &atualizado, nil
+}
+
+func (s *ServiceCliente) Listar(ctx context.Context, in *input.ClienteListInput) ([]*domain.Cliente, int, error) {
+	s.paginacao.Normalizar(&in.Page, &in.Limit)
+
+	clientes, err := s.repo.Listar(ctx, in)
+	if err != nil {
+		return nil, 0, ErrFalhaInfraestrutura
+	}
+
+	total, err := s.repo.Contar(ctx, in)
+	if err != nil {
+		return nil, 0, ErrFalhaInfraestrutura
+	}
+
+	return clientes, total, nil
+}
+
+func (s *ServiceCliente) Inativar(ctx context.Context, id string) error {
+	return s.alterarStatus(ctx, id, false)
+}
+
+func (s *ServiceCliente) Ativar(ctx context.Context, id string) error {
+	return s.alterarStatus(ctx, id, true)
+}
+
+func (s *ServiceCliente) alterarStatus(ctx context.Context, id string, ativo bool) error {
+	cliente, err := s.BuscarPorId(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Um cliente anonimizado não volta a receber agendamentos
+	if ativo && cliente.AnonimizadoEm != nil {
+		return domain.ErrClienteAnonimizado
+	}
+
+	if err := s.repo.AtualizarStatus(ctx, id, ativo); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrClienteNaoEncontrado
+		}
+		return ErrFalhaInfraestrutura
+	}
+
+	if !ativo && s.tokenRepo != nil {
+		if err := s.tokenRepo.RemoverDoCliente(ctx, id); err != nil {
+			return ErrFalhaInfraestrutura
+		}
+	}
+	return nil
+}
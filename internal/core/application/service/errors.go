@@ -13,29 +13,53 @@ var (
 
 	ErrFalhaInfraestrutura = errors.New("falha na infraestrutura")
 
+	//validação de prazo da requisição
+	ErrTempoEsgotado       = errors.New("tempo limite da requisição esgotado")
+	ErrRequisicaoCancelada = errors.New("requisição cancelada")
+
 	//validação de prestador
 	ErrPrestadorNaoEncontrado         = errors.New("prestador não encontrado")
 	ErrPrestadorInvalido              = errors.New("prestador inválido")
 	ErrPrestadorNaoExiste             = errors.New("prestador não encontrado")
 	ErrPrestadorOcupado               = errors.New("prestador já possui agendamento neste horário")
 	ErrPrestadorInativo               = errors.New("prestador está inativo")
-	ErrFormatoDataInvalido            = errors.New("formato de data inválido. Use YYYY-MM-DD")
+	ErrFormatoDataInvalido            = errors.New("formato de data inválido, use AAAA-MM-DD")
 	ErrAoBuscarPrestadoresDisponiveis = errors.New("erro ao buscar prestadores disponíveis")
 	ErrAoContarPrestadoresDisponiveis = errors.New("erro ao contar prestadores disponíveis")
 
 	//validação de cliente
 	ErrClienteNaoEncontrado = errors.New("cliente não encontrado")
-	ErrAoSalvarCliente      = errors.New("falha ao salvar cliente: ")
+	ErrAoSalvarCliente      = errors.New("falha ao salvar cliente")
 	ErrClienteInvalido      = errors.New("cliente inválido")
 	ErrClienteNaoExiste     = errors.New("cliente não encontrado")
 	ErrClienteOcupado       = errors.New("cliente já possui agendamento neste horário")
+	ErrClienteInativo       = errors.New("cliente está inativo")
 
 	//validação de agendamento
 	ErrDataHoraInvalida    = errors.New("data/hora de agendamento inválida")
 	ErrHorarioIndisponivel = errors.New("horário indisponível")
 	ErrDiaIndisponivel     = errors.New("dia indisponível para agendamentos")
-	ErrAgendaDuplicada     = errors.New("Agenda diaria duplicada")
+	ErrAgendaDuplicada     = errors.New("agenda diária duplicada")
 	ErrAgendaNaoEncontrada = errors.New("agenda não encontrada")
-	ErrAgendaJaExiste      = errors.New("agenda ja existe")
-	ErrAgendamentoDuplo  = errors.New("Ja existe um agendamento para essa categoria neste dia")
+	ErrAgendaJaExiste      = errors.New("agenda já existe")
+	ErrAgendamentoDuplo  = errors.New("já existe um agendamento para essa categoria neste dia")
+	ErrAgendamentoNaoEncontrado = errors.New("agendamento não encontrado")
+	ErrSerieNaoEncontrada       = errors.New("série de agendamentos não encontrada")
+	ErrVisitaNaoEncontrada      = errors.New("visita não encontrada")
+
+	//validação de modelo de agenda
+	ErrModeloAgendaNaoEncontrado = errors.New("modelo de agenda não encontrado")
+	ErrPeriodoGeracaoInvalido    = errors.New("período de geração inválido: a data final deve ser posterior à inicial e o período não pode passar de 366 dias")
+
+	//validação de usuário
+	ErrCredenciaisInvalidas = errors.New("email ou senha inválidos")
+	ErrSenhaInvalida        = errors.New("senha deve ter de 8 a 72 caracteres")
+	ErrRefreshTokenInvalido = errors.New("refresh token inválido, expirado ou revogado")
+
+	//validação de lista de espera
+	ErrEntradaListaEsperaNaoEncontrada = errors.New("entrada da lista de espera não encontrada")
+	ErrVagaReservada                   = errors.New("horário reservado para um cliente da lista de espera")
+
+	//validação de calendário
+	ErrCalendarioNaoEncontrado = errors.New("calendário não encontrado")
 )
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"meu-servico-agenda/internal/core/application/mapper"
+	"meu-servico-agenda/internal/core/application/output"
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/domain"
+	"time"
+)
+
+// LGPDService atende os pedidos de acesso e de eliminação de dados do titular.
+// Todo pedido fica registrado com o usuário que o fez, a data e o resultado
+type LGPDService struct {
+	clienteRepo     port.ClienteRepositorio
+	agendamentoRepo port.AgendamentoRepositorio
+	listaEsperaRepo port.ListaEsperaRepositorio
+	solicitacaoRepo port.SolicitacaoLGPDRepositorio
+	tokenRepo       port.TokenCalendarioRepositorio
+	usuarioRepo     port.UsuarioRepositorio
+	refreshRepo     port.RefreshTokenRepositorio
+	transacao       port.UnidadeDeTrabalho
+}
+
+func NovaLGPDService(cl port.ClienteRepositorio, ar port.AgendamentoRepositorio, lr port.ListaEsperaRepositorio, sr port.SolicitacaoLGPDRepositorio, tr port.TokenCalendarioRepositorio, ur port.UsuarioRepositorio, rr port.RefreshTokenRepositorio) *LGPDService {
+	return &LGPDService{
+		clienteRepo:     cl,
+		agendamentoRepo: ar,
+		listaEsperaRepo: lr,
+		solicitacaoRepo: sr,
+		tokenRepo:       tr,
+		usuarioRepo:     ur,
+		refreshRepo:     rr,
+		transacao:       semTransacao{},
+	}
+}
+
+// DefinirUnidadeDeTrabalho faz a anonimização e o seu registro serem gravados numa
+// só transação
+func (s *LGPDService) DefinirUnidadeDeTrabalho(u port.UnidadeDeTrabalho) {
+	s.transacao = u
+}
+
+// ExportarDados devolve o cadastro do cliente, todos os agendamentos com as notas,
+// as séries, as visitas e as entradas na lista de espera
+func (s *LGPDService) ExportarDados(ctx context.Context, clienteID, usuarioID, observacao string) (*output.ExportacaoClienteOutput, error) {
+	solicitacao, err := domain.NovaSolicitacaoLGPD(clienteID, domain.SolicitacaoExportacao, usuarioID, observacao)
+	if err != nil {
+		return nil, err
+	}
+
+	exportacao, err := s.exportar(ctx, clienteID)
+	if err != nil {
+		s.registrarFalha(ctx, solicitacao)
+		return nil, err
+	}
+	exportacao.ExportadoEm = solicitacao.CriadoEm
+
+	if err := s.solicitacaoRepo.Registrar(ctx, solicitacao); err != nil {
+		return nil, err
+	}
+
+	return exportacao, nil
+}
+
+func (s *LGPDService) exportar(ctx context.Context, clienteID string) (*output.ExportacaoClienteOutput, error) {
+	cliente, err := s.buscarCliente(ctx, clienteID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A data zero inclui todo o histórico
+	agendamentos, err := s.agendamentoRepo.BuscarAgendamentoClienteAPartirDaData(ctx, cliente.ID, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	series, err := s.agendamentoRepo.BuscarSeriesDoCliente(ctx, cliente.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	visitas, err := s.agendamentoRepo.BuscarVisitasDoCliente(ctx, cliente.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	entradas, err := s.listaEsperaRepo.BuscarPorCliente(ctx, cliente.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	listaEspera := make([]*output.ListaEsperaOutput, len(entradas))
+	for i, entrada := range entradas {
+		listaEspera[i] = mapper.ListaEsperaOutput(entrada)
+	}
+
+	return &output.ExportacaoClienteOutput{
+		Cliente:      cliente,
+		Agendamentos: mapper.BuscaAgendamentoData(agendamentos),
+		Series:       series,
+		Visitas:      visitas,
+		ListaEspera:  listaEspera,
+	}, nil
+}
+
+// Anonimizar apaga os dados pessoais do cliente, as notas dos seus agendamentos e a
+// URL do seu calendário, e desativa as contas de acesso do cliente, com o email
+// anonimizado e as sessões encerradas. Os agendamentos continuam existindo para o histórico financeiro. A anonimização e
+// o seu registro vão na mesma transação: se qualquer gravação falhar nada muda e o
+// pedido fica registrado como falho
+func (s *LGPDService) Anonimizar(ctx context.Context, clienteID, usuarioID, observacao string) error {
+	solicitacao, err := domain.NovaSolicitacaoLGPD(clienteID, domain.SolicitacaoAnonimizacao, usuarioID, observacao)
+	if err != nil {
+		return err
+	}
+
+	err = s.transacao.Executar(ctx, func(ctx context.Context) error {
+		return s.anonimizar(ctx, solicitacao)
+	})
+	if err != nil {
+		s.registrarFalha(ctx, solicitacao)
+		return err
+	}
+
+	return nil
+}
+
+func (s *LGPDService) anonimizar(ctx context.Context, solicitacao *domain.SolicitacaoLGPD) error {
+	cliente, err := s.buscarCliente(ctx, solicitacao.ClienteID)
+	if err != nil {
+		return err
+	}
+
+	if err := cliente.Anonimizar(solicitacao.CriadoEm); err != nil {
+		return err
+	}
+
+	if err := s.agendamentoRepo.ApagarNotasDoCliente(ctx, cliente.ID); err != nil {
+		return err
+	}
+
+	if err := s.clienteRepo.Anonimizar(ctx, cliente); err != nil {
+		return err
+	}
+
+	if err := s.tokenRepo.RemoverDoCliente(ctx, cliente.ID); err != nil {
+		return err
+	}
+
+	if err := s.anonimizarUsuarios(ctx, cliente.ID, solicitacao.CriadoEm); err != nil {
+		return err
+	}
+
+	return s.solicitacaoRepo.Registrar(ctx, solicitacao)
+}
+
+func (s *LGPDService) anonimizarUsuarios(ctx context.Context, clienteID string, agora time.Time) error {
+	usuarios, err := s.usuarioRepo.BuscarPorCliente(ctx, clienteID)
+	if err != nil {
+		return err
+	}
+
+	for _, usuario := range usuarios {
+		usuario.Anonimizar()
+		if err := s.usuarioRepo.Anonimizar(ctx, usuario); err != nil {
+			return err
+		}
+		if err := s.refreshRepo.RevogarDoUsuario(ctx, usuario.ID, agora); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegistrarNegacao grava o pedido de um usuário sem permissão para fazê-lo
+func (s *LGPDService) RegistrarNegacao(ctx context.Context, clienteID string, tipo domain.TipoSolicitacaoLGPD, usuarioID, observacao string) error {
+	solicitacao, err := domain.NovaSolicitacaoLGPD(clienteID, tipo, usuarioID, observacao)
+	if err != nil {
+		return err
+	}
+	solicitacao.Resultado = domain.SolicitacaoNegada
+
+	return s.solicitacaoRepo.Registrar(ctx, solicitacao)
+}
+
+func (s *LGPDService) ListarSolicitacoes(ctx context.Context, clienteID string) ([]*domain.SolicitacaoLGPD, error) {
+	if _, err := s.buscarCliente(ctx, clienteID); err != nil {
+		return nil, err
+	}
+
+	return s.solicitacaoRepo.ListarPorCliente(ctx, clienteID)
+}
+
+// registrarFalha grava o pedido que não foi atendido. Quem chamou já tem um erro
+// para devolver, então uma falha aqui só vai para o log
+func (s *LGPDService) registrarFalha(ctx context.Context, solicitacao *domain.SolicitacaoLGPD) {
+	solicitacao.Resultado = domain.SolicitacaoFalhou
+	if err := s.solicitacaoRepo.Registrar(ctx, solicitacao); err != nil {
+		slog.ErrorContext(ctx, "erro ao registrar solicitação LGPD não atendida",
+			slog.String("cliente_id", solicitacao.ClienteID),
+			slog.String("tipo", string(solicitacao.Tipo)),
+			slog.Any("erro", err),
+		)
+	}
+}
+
+func (s *LGPDService) buscarCliente(ctx context.Context, id string) (*domain.Cliente, error) {
+	cliente, err := s.clienteRepo.BuscarPorId(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if cliente == nil {
+		return nil, ErrClienteNaoEncontrado
+	}
+	return cliente, nil
+}
@@ -0,0 +1,446 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"meu-servico-agenda/internal/core/application/input"
+	"meu-servico-agenda/internal/core/application/mapper"
+	"meu-servico-agenda/internal/core/application/output"
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/domain"
+)
+
+// PrazoOfertaListaEspera é o tempo que o cliente tem para aceitar a vaga oferecida
+const PrazoOfertaListaEspera = 30 * time.Minute
+
+// ListaEsperaService mantém a fila de clientes aguardando horário e oferece as vagas
+// liberadas por cancelamentos, reagendamentos e novas agendas ao primeiro da fila
+type ListaEsperaService struct {
+	listaEsperaRepo port.ListaEsperaRepositorio
+	prestadorRepo   port.PrestadorRepositorio
+	catalogoRepo    port.CatalogoRepositorio
+	clienteRepo     port.ClienteRepositorio
+	agendamentos    *AgendamentoService
+	transacao       port.UnidadeDeTrabalho
+}
+
+// NovaListaEsperaService também registra o serviço como observador do AgendamentoService,
+// sem o qual as vagas oferecidas não ficariam reservadas
+func NovaListaEsperaService(lr port.ListaEsperaRepositorio, pr port.PrestadorRepositorio, cr port.CatalogoRepositorio, cl port.ClienteRepositorio, as *AgendamentoService) *ListaEsperaService {
+	s := &ListaEsperaService{
+		listaEsperaRepo: lr,
+		prestadorRepo:   pr,
+		catalogoRepo:    cr,
+		clienteRepo:     cl,
+		agendamentos:    as,
+		transacao:       semTransacao{},
+	}
+	as.DefinirObservadorDeVagas(s)
+	return s
+}
+
+// DefinirUnidadeDeTrabalho faz cada operação da fila rodar numa transação. É nela que
+// vale a trava por prestador que impede a mesma vaga de ser oferecida duas vezes,
+// inclusive entre réplicas da API
+func (s *ListaEsperaService) DefinirUnidadeDeTrabalho(u port.UnidadeDeTrabalho) {
+	s.transacao = u
+}
+
+func (s *ListaEsperaService) Cadastrar(ctx context.Context, in input.CadastrarListaEsperaInput) (*output.ListaEsperaOutput, error) {
+	cliente, err := s.clienteRepo.BuscarPorId(ctx, in.ClienteID)
+	if err != nil || cliente == nil {
+		return nil, ErrClienteNaoExiste
+	}
+	if !cliente.Ativo {
+		return nil, ErrClienteInativo
+	}
+
+	catalogo, err := s.catalogoRepo.BuscarPorId(ctx, in.CatalogoID)
+	if err != nil || catalogo == nil {
+		return nil, ErrCatalogoNaoExiste
+	}
+
+	if in.PrestadorID != "" {
+		prestador, err := s.prestadorRepo.BuscarPorId(ctx, in.PrestadorID)
+		if err != nil || prestador == nil {
+			return nil, ErrPrestadorNaoExiste
+		}
+		if !prestador.Ativo {
+			return nil, ErrPrestadorInativo
+		}
+		if !ofereceCatalogo(prestador, catalogo.ID) {
+			return nil, ErrCatalogoInvalido
+		}
+	}
+
+	entrada, err := domain.NovaEntradaListaEspera(cliente.ID, catalogo.ID, in.PrestadorID, in.JanelaInicio, in.JanelaFim)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.listaEsperaRepo.Salvar(ctx, entrada); err != nil {
+		return nil, err
+	}
+
+	return mapper.ListaEsperaOutput(entrada), nil
+}
+
+func (s *ListaEsperaService) BuscarPorId(ctx context.Context, id string) (*output.ListaEsperaOutput, error) {
+	entrada, err := s.buscarEntrada(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ListaEsperaOutput(entrada), nil
+}
+
+// Cancelar retira o cliente da fila; uma vaga que estivesse oferecida a ele passa ao próximo
+func (s *ListaEsperaService) Cancelar(ctx context.Context, id string) error {
+	return s.transacao.Executar(ctx, func(ctx context.Context) error {
+		return s.cancelar(ctx, id)
+	})
+}
+
+func (s *ListaEsperaService) cancelar(ctx context.Context, id string) error {
+	entrada, err := s.buscarEntradaParaAtualizar(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	oferta := entrada.Oferta
+	if entrada.Status != domain.VagaOferecida {
+		oferta = nil
+	}
+
+	if err := entrada.Cancelar(); err != nil {
+		return err
+	}
+
+	if err := s.atualizar(ctx, entrada); err != nil {
+		return err
+	}
+
+	if oferta != nil {
+		return s.distribuirVaga(ctx, oferta.PrestadorID, oferta.DataHoraInicio, oferta.DataHoraFim)
+	}
+	return nil
+}
+
+// AceitarOferta agenda a vaga oferecida com as regras de CadastraAgendamento.
+// Uma oferta vencida é encerrada e a vaga segue para o próximo da fila
+func (s *ListaEsperaService) AceitarOferta(ctx context.Context, id string) (*output.ListaEsperaOutput, error) {
+	var (
+		saida      *output.ListaEsperaOutput
+		errExpirou error
+	)
+	err := s.transacao.Executar(ctx, func(ctx context.Context) error {
+		entrada, err := s.buscarEntradaParaAtualizar(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := entrada.ValidarAceite(time.Now()); err != nil {
+			if !errors.Is(err, domain.ErrOfertaExpirada) {
+				return err
+			}
+			// a expiração precisa ser gravada mesmo com o aceite recusado
+			errExpirou = err
+			return s.expirar(ctx, entrada)
+		}
+
+		saida, err = s.aceitar(ctx, entrada)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if errExpirou != nil {
+		return nil, errExpirou
+	}
+
+	return saida, nil
+}
+
+func (s *ListaEsperaService) aceitar(ctx context.Context, entrada *domain.EntradaListaEspera) (*output.ListaEsperaOutput, error) {
+	agendamento, err := s.agendamentos.Agendar(ctx, input.CadastrarAgendamentoInput{
+		ClienteID:      entrada.ClienteID,
+		PrestadorID:    entrada.Oferta.PrestadorID,
+		CatalogoID:     entrada.CatalogoID,
+		DataHoraInicio: entrada.Oferta.DataHoraInicio,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entrada.ConfirmarAceite(agendamento.ID)
+	if err := s.atualizar(ctx, entrada); err != nil {
+		return nil, err
+	}
+
+	return mapper.ListaEsperaOutput(entrada), nil
+}
+
+// ExpirarOfertas encerra as ofertas com prazo vencido e repassa as vagas ao próximo da fila.
+// Devolve quantas ofertas foram encerradas
+func (s *ListaEsperaService) ExpirarOfertas(ctx context.Context) (int, error) {
+	vencidas, err := s.listaEsperaRepo.BuscarOfertasVencidas(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	expiradas := 0
+	for _, vencida := range vencidas {
+		expirou := false
+		err := s.transacao.Executar(ctx, func(ctx context.Context) error {
+			// outra réplica pode ter expirado ou o cliente aceitado a oferta desde a busca
+			entrada, err := s.listaEsperaRepo.BuscarPorIdParaAtualizar(ctx, vencida.ID)
+			if err != nil || entrada == nil || !entrada.OfertaVencida(time.Now()) {
+				return err
+			}
+
+			if err := s.expirar(ctx, entrada); err != nil {
+				return err
+			}
+			expirou = true
+			return nil
+		})
+		if err != nil {
+			return expiradas, err
+		}
+		if expirou {
+			expiradas++
+		}
+	}
+
+	return expiradas, nil
+}
+
+// IniciarExpiracaoOfertas roda ExpirarOfertas periodicamente.
+// A função devolvida interrompe a verificação. Cada rodada tem no máximo
+// o próprio intervalo para terminar
+func (s *ListaEsperaService) IniciarExpiracaoOfertas(intervalo time.Duration) func() {
+	ticker := time.NewTicker(intervalo)
+	parar := make(chan struct{})
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				ctx, cancelar := context.WithTimeout(context.Background(), intervalo)
+				expiradas, err := s.ExpirarOfertas(ctx)
+				cancelar()
+				if err != nil {
+					slog.Error("erro ao expirar ofertas da lista de espera", slog.Any("erro", err))
+					continue
+				}
+				if expiradas > 0 {
+					slog.Info("ofertas da lista de espera expiradas", slog.Int("quantidade", expiradas))
+				}
+			case <-parar:
+				return
+			}
+		}
+	}()
+
+	return func() { close(parar) }
+}
+
+// VagaLiberada implementa ObservadorDeVagas
+func (s *ListaEsperaService) VagaLiberada(ctx context.Context, prestadorID string, inicio, fim time.Time) error {
+	return s.transacao.Executar(ctx, func(ctx context.Context) error {
+		return s.distribuirVaga(ctx, prestadorID, inicio, fim)
+	})
+}
+
+// VagaReservadaParaOutro implementa ObservadorDeVagas. Na transação do agendamento, a
+// trava do prestador faz a resposta valer até a gravação
+func (s *ListaEsperaService) VagaReservadaParaOutro(ctx context.Context, prestadorID, clienteID string, inicio, fim time.Time) (bool, error) {
+	if err := s.listaEsperaRepo.TravarPrestador(ctx, prestadorID); err != nil {
+		return false, err
+	}
+
+	ofertas, err := s.listaEsperaRepo.BuscarOfertasAtivas(ctx, prestadorID, inicio, fim, time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	for _, e := range ofertas {
+		if e.ClienteID != clienteID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// distribuirVaga percorre a fila por ordem de chegada e oferece à primeira entrada
+// compatível o primeiro horário livre que toque o período liberado; as demais esperam a
+// próxima vaga. Deve rodar dentro da transação, que segura a trava do prestador até a
+// oferta ser gravada
+func (s *ListaEsperaService) distribuirVaga(ctx context.Context, prestadorID string, inicio, fim time.Time) error {
+	if err := s.listaEsperaRepo.TravarPrestador(ctx, prestadorID); err != nil {
+		return err
+	}
+
+	prestador, err := s.prestadorRepo.BuscarPorId(ctx, prestadorID)
+	if err != nil || prestador == nil || !prestador.Ativo {
+		return nil
+	}
+
+	entradas, err := s.listaEsperaRepo.BuscarAguardando(ctx, prestadorID, inicio, fim)
+	if err != nil {
+		return err
+	}
+
+	for _, entrada := range entradas {
+		if !ofereceCatalogo(prestador, entrada.CatalogoID) {
+			continue
+		}
+
+		vagaInicio, vagaFim, encontrada, err := s.procurarVaga(ctx, prestador, entrada, inicio, fim)
+		if err != nil {
+			return err
+		}
+		if !encontrada {
+			continue
+		}
+
+		if err := entrada.Oferecer(prestador.ID, vagaInicio, vagaFim, time.Now().Add(PrazoOfertaListaEspera)); err != nil {
+			return err
+		}
+		return s.atualizar(ctx, entrada)
+	}
+
+	return nil
+}
+
+// procurarVaga busca, dia a dia no calendário do prestador, um horário que caiba na janela
+// da entrada, toque o período liberado e passe pelas regras de agendamento para o cliente.
+// Cliente ou serviço que não existem mais só fazem a entrada ser pulada
+func (s *ListaEsperaService) procurarVaga(ctx context.Context, prestador *domain.Prestador, entrada *domain.EntradaListaEspera, inicio, fim time.Time) (time.Time, time.Time, bool, error) {
+	cliente, err := s.clienteRepo.BuscarPorId(ctx, entrada.ClienteID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, time.Time{}, false, err
+	}
+	if cliente == nil {
+		return time.Time{}, time.Time{}, false, nil
+	}
+
+	catalogo, err := s.catalogoRepo.BuscarPorId(ctx, entrada.CatalogoID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, time.Time{}, false, err
+	}
+	if catalogo == nil {
+		return time.Time{}, time.Time{}, false, nil
+	}
+
+	periodoInicio := maisTarde(inicio, entrada.JanelaInicio)
+	periodoFim := maisCedo(fim, entrada.JanelaFim)
+	duracao := time.Duration(prestador.DuracaoDoServico(catalogo)) * time.Minute
+	loc := prestador.Localizacao()
+
+	for dia := domain.InicioDoDiaEm(periodoInicio.In(loc), loc); dia.Before(periodoFim); dia = dia.AddDate(0, 0, 1) {
+		horarios, err := s.agendamentos.BuscarHorariosDisponiveis(ctx, input.HorariosDisponiveisInput{
+			PrestadorID: prestador.ID,
+			CatalogoID:  catalogo.ID,
+			Data:        dia,
+		})
+		if err != nil {
+			if errors.Is(err, domain.ErrDataEstaNoPassado) {
+				continue
+			}
+			return time.Time{}, time.Time{}, false, err
+		}
+
+		for _, h := range horarios.Horarios {
+			hFim := h.Add(duracao)
+			if !entrada.Comporta(h, hFim) || !h.Before(fim) || !hFim.After(inicio) {
+				continue
+			}
+
+			err := s.agendamentos.validarHorario(ctx, cliente, prestador, catalogo, h, hFim, "")
+			if err == nil {
+				return h, hFim, true, nil
+			}
+			if !falhaDeOcorrencia(err) {
+				return time.Time{}, time.Time{}, false, err
+			}
+		}
+	}
+
+	return time.Time{}, time.Time{}, false, nil
+}
+
+// expirar encerra a oferta vencida e repassa a vaga. Deve rodar dentro da transação
+func (s *ListaEsperaService) expirar(ctx context.Context, entrada *domain.EntradaListaEspera) error {
+	oferta := *entrada.Oferta
+
+	if err := entrada.ExpirarOferta(); err != nil {
+		return err
+	}
+	if err := s.atualizar(ctx, entrada); err != nil {
+		return err
+	}
+
+	return s.distribuirVaga(ctx, oferta.PrestadorID, oferta.DataHoraInicio, oferta.DataHoraFim)
+}
+
+func (s *ListaEsperaService) buscarEntrada(ctx context.Context, id string) (*domain.EntradaListaEspera, error) {
+	entrada, err := s.listaEsperaRepo.BuscarPorId(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entrada == nil {
+		return nil, ErrEntradaListaEsperaNaoEncontrada
+	}
+	return entrada, nil
+}
+
+// buscarEntradaParaAtualizar trava a entrada até o fim da transação
+func (s *ListaEsperaService) buscarEntradaParaAtualizar(ctx context.Context, id string) (*domain.EntradaListaEspera, error) {
+	entrada, err := s.listaEsperaRepo.BuscarPorIdParaAtualizar(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if entrada == nil {
+		return nil, ErrEntradaListaEsperaNaoEncontrada
+	}
+	return entrada, nil
+}
+
+func (s *ListaEsperaService) atualizar(ctx context.Context, entrada *domain.EntradaListaEspera) error {
+	if err := s.listaEsperaRepo.Atualizar(ctx, entrada); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrEntradaListaEsperaNaoEncontrada
+		}
+		return err
+	}
+	return nil
+}
+
+func ofereceCatalogo(prestador *domain.Prestador, catalogoID string) bool {
+	for _, c := range prestador.Catalogo {
+		if c.ID == catalogoID {
+			return true
+		}
+	}
+	return false
+}
+
+func maisTarde(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func maisCedo(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
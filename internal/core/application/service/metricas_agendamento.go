@@ -0,0 +1,56 @@
+package service
+
+import (
+	"errors"
+
+	"meu-servico-agenda/internal/core/domain"
+)
+
+// MetricasAgendamento recebe os eventos de negócio dos agendamentos. O serviço
+// não conhece a ferramenta de monitoramento por trás dela
+type MetricasAgendamento interface {
+	AgendamentoCriado()
+	AgendamentoRejeitado(motivo string)
+	AgendamentoCancelado()
+}
+
+// semMetricas é o padrão quando nenhuma implementação foi definida
+type semMetricas struct{}
+
+func (semMetricas) AgendamentoCriado()          {}
+func (semMetricas) AgendamentoRejeitado(string) {}
+func (semMetricas) AgendamentoCancelado()       {}
+
+// motivosRejeicao dá um nome estável às regras de negócio que recusam um
+// agendamento; falhas de infraestrutura não entram na contagem
+var motivosRejeicao = []struct {
+	err    error
+	motivo string
+}{
+	{ErrPrestadorOcupado, "prestador_ocupado"},
+	{ErrHorarioIndisponivel, "horario_indisponivel"},
+	{ErrDiaIndisponivel, "dia_indisponivel"},
+	{ErrClienteOcupado, "cliente_ocupado"},
+	{ErrAgendamentoDuplo, "agendamento_duplo"},
+	{ErrVagaReservada, "vaga_reservada"},
+	{ErrClienteInativo, "cliente_inativo"},
+	{ErrClienteNaoExiste, "cliente_nao_existe"},
+	{ErrPrestadorNaoExiste, "prestador_nao_existe"},
+	{ErrCatalogoNaoExiste, "catalogo_nao_existe"},
+	{domain.ErrDataEstaNoPassado, "data_no_passado"},
+}
+
+// DefinirMetricas liga os agendamentos criados, rejeitados e cancelados ao monitoramento
+func (s *AgendamentoService) DefinirMetricas(m MetricasAgendamento) {
+	s.metricas = m
+}
+
+// registrarRejeicao conta err quando ele é uma das regras de negócio conhecidas
+func (s *AgendamentoService) registrarRejeicao(err error) {
+	for _, r := range motivosRejeicao {
+		if errors.Is(err, r.err) {
+			s.metricas.AgendamentoRejeitado(r.motivo)
+			return
+		}
+	}
+}
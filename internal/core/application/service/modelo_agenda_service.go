@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"meu-servico-agenda/internal/core/application/input"
+	"meu-servico-agenda/internal/core/application/mapper"
+	"meu-servico-agenda/internal/core/application/output"
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/domain"
+	"time"
+)
+
+// MaxDiasGeracaoAgenda limita o período materializado em uma única chamada
+const MaxDiasGeracaoAgenda = 366
+
+type ModeloAgendaService struct {
+	prestadorRepo    port.PrestadorRepositorio
+	modeloRepo       port.ModeloAgendaRepositorio
+	agendaDiariaRepo port.AgendaDiariaRepositorio
+	observador       ObservadorDeVagas
+	transacao        port.UnidadeDeTrabalho
+}
+
+func NovoModeloAgendaService(pr port.PrestadorRepositorio, mr port.ModeloAgendaRepositorio, ad port.AgendaDiariaRepositorio) *ModeloAgendaService {
+	return &ModeloAgendaService{
+		prestadorRepo:    pr,
+		modeloRepo:       mr,
+		agendaDiariaRepo: ad,
+		transacao:        semTransacao{},
+	}
+}
+
+func (s *ModeloAgendaService) Cadastrar(ctx context.Context, cmd *input.CadastrarModeloAgendaInput) (*output.ModeloAgendaOutput, error) {
+	if _, err := s.buscarPrestadorAtivo(ctx, cmd.PrestadorID); err != nil {
+		return nil, err
+	}
+
+	intervalos := make([]domain.IntervaloDiario, 0, len(cmd.Intervalos))
+	for _, i := range cmd.Intervalos {
+		intervalo, err := domain.NovoIntervaloDiario(i.Inicio, i.Fim)
+		if err != nil {
+			return nil, err
+		}
+		intervalos = append(intervalos, *intervalo)
+	}
+
+	modelo, err := domain.NovoModeloAgenda(cmd.PrestadorID, cmd.DiasDaSemana, intervalos, cmd.VigenciaInicio, cmd.VigenciaFim)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.modeloRepo.Salvar(ctx, modelo); err != nil {
+		return nil, err
+	}
+
+	return mapper.ModeloAgendaOutput(modelo), nil
+}
+
+func (s *ModeloAgendaService) Listar(ctx context.Context, prestadorID string) ([]*output.ModeloAgendaOutput, error) {
+	prestador, err := s.prestadorRepo.BuscarPorId(ctx, prestadorID)
+	if err != nil || prestador == nil {
+		return nil, ErrPrestadorNaoEncontrado
+	}
+
+	modelos, err := s.modeloRepo.ListarPorPrestador(ctx, prestadorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ModelosAgendaOutput(modelos), nil
+}
+
+func (s *ModeloAgendaService) Deletar(ctx context.Context, prestadorID, modeloID string) error {
+	if _, err := s.buscarModelo(ctx, prestadorID, modeloID); err != nil {
+		return err
+	}
+
+	if err := s.modeloRepo.Deletar(ctx, modeloID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrModeloAgendaNaoEncontrado
+		}
+		return err
+	}
+
+	return nil
+}
+
+// DefinirObservadorDeVagas liga a lista de espera às agendas geradas
+func (s *ModeloAgendaService) DefinirObservadorDeVagas(o ObservadorDeVagas) {
+	s.observador = o
+}
+
+// DefinirUnidadeDeTrabalho faz as agendas de uma geração serem gravadas numa
+// só transação
+func (s *ModeloAgendaService) DefinirUnidadeDeTrabalho(u port.UnidadeDeTrabalho) {
+	s.transacao = u
+}
+
+// GerarAgendas materializa o modelo em agendas diárias no período informado.
+// Dias que já possuem agenda (criada manualmente ou por outra geração) são mantidos.
+// Ou todos os dias do período são gravados, ou nenhum
+func (s *ModeloAgendaService) GerarAgendas(ctx context.Context, cmd *input.GerarAgendasInput) (*output.GeracaoAgendaOutput, error) {
+	if cmd.DataFim.Before(cmd.DataInicio) || cmd.DataFim.Sub(cmd.DataInicio) >= MaxDiasGeracaoAgenda*24*time.Hour {
+		return nil, ErrPeriodoGeracaoInvalido
+	}
+
+	prestador, err := s.buscarPrestadorAtivo(ctx, cmd.PrestadorID)
+	if err != nil {
+		return nil, err
+	}
+
+	// As datas do período são dias de calendário no fuso do prestador
+	loc := prestador.Localizacao()
+	if err := domain.ValidarDataNoPassadoEm(domain.InicioDoDiaEm(cmd.DataInicio, loc), loc); err != nil {
+		return nil, err
+	}
+
+	modelo, err := s.buscarModelo(ctx, cmd.PrestadorID, cmd.ModeloID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &output.GeracaoAgendaOutput{
+		Criadas:   []string{},
+		Ignoradas: []string{},
+	}
+	var criadas []time.Time
+
+	err = s.transacao.Executar(ctx, func(ctx context.Context) error {
+		for data := cmd.DataInicio; !data.After(cmd.DataFim); data = data.AddDate(0, 0, 1) {
+			if !modelo.AplicaEm(data) {
+				continue
+			}
+
+			dia := data.Format("2006-01-02")
+
+			existente, err := s.agendaDiariaRepo.BuscarAgendaDoDia(ctx, cmd.PrestadorID, dia)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+			if existente != nil {
+				out.Ignoradas = append(out.Ignoradas, dia)
+				continue
+			}
+
+			agenda, err := modelo.NovaAgendaPara(data, loc)
+			if err != nil {
+				return err
+			}
+
+			if err := prestador.AdicionarAgenda(agenda); err != nil {
+				if errors.Is(err, domain.ErrAgendaDuplicada) {
+					out.Ignoradas = append(out.Ignoradas, dia)
+					continue
+				}
+				return err
+			}
+
+			if err := s.agendaDiariaRepo.Salvar(ctx, agenda, cmd.PrestadorID); err != nil {
+				return err
+			}
+			out.Criadas = append(out.Criadas, dia)
+			criadas = append(criadas, data)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// A lista de espera só é avisada depois que as agendas foram gravadas
+	for _, data := range criadas {
+		inicioDoDia := domain.InicioDoDiaEm(data, loc)
+		avisarVagaLiberada(ctx, s.observador, prestador.ID, inicioDoDia, inicioDoDia.AddDate(0, 0, 1))
+	}
+
+	return out, nil
+}
+
+func (s *ModeloAgendaService) buscarPrestadorAtivo(ctx context.Context, prestadorID string) (*domain.Prestador, error) {
+	prestador, err := s.prestadorRepo.BuscarPorId(ctx, prestadorID)
+	if err != nil || prestador == nil {
+		return nil, ErrPrestadorNaoEncontrado
+	}
+
+	if !prestador.Ativo {
+		return nil, ErrPrestadorInativo
+	}
+
+	return prestador, nil
+}
+
+func (s *ModeloAgendaService) buscarModelo(ctx context.Context, prestadorID, modeloID string) (*domain.ModeloAgenda, error) {
+	modelo, err := s.modeloRepo.BuscarPorId(ctx, modeloID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Um modelo de outro prestador é tratado como inexistente
+	if modelo == nil || modelo.PrestadorID != prestadorID {
+		return nil, ErrModeloAgendaNaoEncontrado
+	}
+
+	return modelo, nil
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// ObservadorDeVagas é avisado pelos serviços de agenda quando um período de um prestador
+// pode ter ficado livre (cancelamento, reagendamento ou ampliação da agenda) e é consultado
+// antes de cada agendamento para respeitar vagas reservadas a outro cliente
+type ObservadorDeVagas interface {
+	VagaLiberada(ctx context.Context, prestadorID string, inicio, fim time.Time) error
+	VagaReservadaParaOutro(ctx context.Context, prestadorID, clienteID string, inicio, fim time.Time) (bool, error)
+}
+
+// avisarVagaLiberada não interrompe a operação que liberou a vaga: uma falha ao
+// oferecer a vaga é apenas registrada
+func avisarVagaLiberada(ctx context.Context, o ObservadorDeVagas, prestadorID string, inicio, fim time.Time) {
+	if o == nil {
+		return
+	}
+	if err := o.VagaLiberada(ctx, prestadorID, inicio, fim); err != nil {
+		slog.ErrorContext(ctx, "erro ao oferecer vaga liberada", slog.String("prestador_id", prestadorID), slog.Any("erro", err))
+	}
+}
@@ -0,0 +1,23 @@
+package service
+
+// Paginacao define o tamanho das páginas das listagens
+type Paginacao struct {
+	LimitePadrao int // usado quando o limite não é informado
+	LimiteMaximo int // limites maiores são reduzidos a este valor
+}
+
+var PaginacaoPadrao = Paginacao{LimitePadrao: 10, LimiteMaximo: 100}
+
+// Normalizar aplica a página 1 e o limite padrão quando não informados e corta o
+// limite no máximo permitido
+func (p Paginacao) Normalizar(page, limit *int) {
+	if *page <= 0 {
+		*page = 1
+	}
+	if *limit <= 0 {
+		*limit = p.LimitePadrao
+	}
+	if *limit > p.LimiteMaximo {
+		*limit = p.LimiteMaximo
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -20,6 +21,10 @@ type PrestadorService struct {
 	prestadorRepo    port.PrestadorRepositorio
 	catalogoRepo     port.CatalogoRepositorio
 	agendaDiariaRepo port.AgendaDiariaRepositorio
+	observador       ObservadorDeVagas
+	tokenRepo        port.TokenCalendarioRepositorio
+	paginacao        Paginacao
+	transacao        port.UnidadeDeTrabalho
 }
 
 func NovaPrestadorService(pr port.PrestadorRepositorio, cr port.CatalogoRepositorio, ad port.AgendaDiariaRepositorio) *PrestadorService {
@@ -27,14 +32,36 @@ func NovaPrestadorService(pr port.PrestadorRepositorio, cr port.CatalogoReposito
 		prestadorRepo:    pr,
 		catalogoRepo:     cr,
 		agendaDiariaRepo: ad,
+		paginacao:        PaginacaoPadrao,
+		transacao:        semTransacao{},
 	}
 }
 
-func (s *PrestadorService) Cadastra(cmd *input.CadastrarPrestadorInput) (*output.CriarPrestadorOutput, error) {
+// DefinirObservadorDeVagas liga a lista de espera às alterações de agenda
+func (s *PrestadorService) DefinirObservadorDeVagas(o ObservadorDeVagas) {
+	s.observador = o
+}
+
+// DefinirTokensCalendario faz a inativação apagar a URL do calendário do prestador
+func (s *PrestadorService) DefinirTokensCalendario(tr port.TokenCalendarioRepositorio) {
+	s.tokenRepo = tr
+}
+
+func (s *PrestadorService) DefinirPaginacao(p Paginacao) {
+	s.paginacao = p
+}
+
+// DefinirUnidadeDeTrabalho faz a consulta e a gravação da agenda acontecerem
+// na mesma transação
+func (s *PrestadorService) DefinirUnidadeDeTrabalho(u port.UnidadeDeTrabalho) {
+	s.transacao = u
+}
+
+func (s *PrestadorService) Cadastra(ctx context.Context, cmd *input.CadastrarPrestadorInput) (*output.CriarPrestadorOutput, error) {
 
 	cpf := cpfcnpj.Clean(cmd.CPF)
 
-	prestadorExistente, err := s.prestadorRepo.BuscarPorCPF(cpf)
+	prestadorExistente, err := s.prestadorRepo.BuscarPorCPF(ctx, cpf)
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +72,7 @@ func (s *PrestadorService) Cadastra(cmd *input.CadastrarPrestadorInput) (*output
 
 	catalogos := []domain.Catalogo{}
 	for _, id := range cmd.CatalogoIDs {
-		c, err := s.catalogoRepo.BuscarPorId(id)
+		c, err := s.catalogoRepo.BuscarPorId(ctx, id)
 		if err != nil {
 			return nil, fmt.Errorf("%w: %s", ErrCatalogoNaoExiste, id)
 		}
@@ -64,7 +91,13 @@ func (s *PrestadorService) Cadastra(cmd *input.CadastrarPrestadorInput) (*output
 		return nil, err
 	}
 
-	if err := s.prestadorRepo.Salvar(prestador); err != nil {
+	if cmd.FusoHorario != "" {
+		if err := prestador.DefinirFusoHorario(cmd.FusoHorario); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.prestadorRepo.Salvar(ctx, prestador); err != nil {
 		return nil, err
 	}
 
@@ -73,8 +106,8 @@ func (s *PrestadorService) Cadastra(cmd *input.CadastrarPrestadorInput) (*output
 	return out, nil
 }
 
-func (s *PrestadorService) BuscarPorId(id string) (*output.BuscarPrestadorOutput, error) {
-	prestador, err := s.prestadorRepo.BuscarPorId(id)
+func (s *PrestadorService) BuscarPorId(ctx context.Context, id string) (*output.BuscarPrestadorOutput, error) {
+	prestador, err := s.prestadorRepo.BuscarPorId(ctx, id)
 	if err != nil {
 		return nil, ErrPrestadorNaoEncontrado
 	}
@@ -84,12 +117,22 @@ func (s *PrestadorService) BuscarPorId(id string) (*output.BuscarPrestadorOutput
 	return out, nil
 }
 
-func (s *PrestadorService) Atualizar(input *input.AlterarPrestadorInput) error {
+func (s *PrestadorService) Atualizar(ctx context.Context, input *input.AlterarPrestadorInput) error {
 	if len(input.CatalogoIDs) == 0 {
 		return domain.ErrPrestadorDeveTerCatalogo
 	}
 
-	if err := s.prestadorRepo.Atualizar(input); err != nil {
+	if input.FusoHorario != "" {
+		if err := domain.ValidarFusoHorario(input.FusoHorario); err != nil {
+			return err
+		}
+	}
+
+	if err := validarCondicoesDoServico(input); err != nil {
+		return err
+	}
+
+	if err := s.prestadorRepo.Atualizar(ctx, input); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return ErrPrestadorNaoEncontrado
 		}
@@ -103,26 +146,40 @@ func (s *PrestadorService) Atualizar(input *input.AlterarPrestadorInput) error {
 	return nil
 }
 
-func (s *PrestadorService) ListarPrestadores(input *input.PrestadorListInput) ([]*output.BuscarPrestadorOutput, int, error) {
-	// Validações
-	if input.Page <= 0 {
-		input.Page = 1
-	}
-	if input.Limit <= 0 {
-		input.Limit = 10
+// validarCondicoesDoServico exige que cada condição aponte para um serviço
+// da nova lista de catálogos, uma única vez
+func validarCondicoesDoServico(input *input.AlterarPrestadorInput) error {
+	oferecidos := make(map[string]bool, len(input.CatalogoIDs))
+	for _, id := range input.CatalogoIDs {
+		oferecidos[id] = true
 	}
-	if input.Limit > 100 {
-		input.Limit = 100
+
+	for _, c := range input.Condicoes {
+		if !oferecidos[c.CatalogoID] {
+			return domain.ErrCondicaoForaDoCatalogo
+		}
+		// Cada serviço aceita uma única condição
+		delete(oferecidos, c.CatalogoID)
+
+		if _, err := domain.NovaCondicaoDoServico(c.CatalogoID, c.Preco, c.DuracaoPadrao); err != nil {
+			return err
+		}
 	}
 
+	return nil
+}
+
+func (s *PrestadorService) ListarPrestadores(ctx context.Context, input *input.PrestadorListInput) ([]*output.BuscarPrestadorOutput, int, error) {
+	s.paginacao.Normalizar(&input.Page, &input.Limit)
+
 	// Buscar prestadores (sempre com filtro)
-	prestadores, err := s.prestadorRepo.Listar(input)
+	prestadores, err := s.prestadorRepo.Listar(ctx, input)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// Contar total (sempre com filtro)
-	total, err := s.prestadorRepo.Contar(input.Ativo)
+	total, err := s.prestadorRepo.Contar(ctx, input.Ativo)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -133,8 +190,8 @@ func (s *PrestadorService) ListarPrestadores(input *input.PrestadorListInput) ([
 }
 
 // Service
-func (s *PrestadorService) Inativar(id string) error {
-	prestador, err := s.prestadorRepo.BuscarPorId(id)
+func (s *PrestadorService) Inativar(ctx context.Context, id string) error {
+	prestador, err := s.prestadorRepo.BuscarPorId(ctx, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return ErrPrestadorNaoEncontrado
@@ -145,11 +202,18 @@ func (s *PrestadorService) Inativar(id string) error {
 	prestador.Ativo = false
 
 	// Usar método específico no repo ou o Salvar existente
-	return s.prestadorRepo.AtualizarStatus(id, false)
+	if err := s.prestadorRepo.AtualizarStatus(ctx, id, false); err != nil {
+		return err
+	}
+
+	if s.tokenRepo != nil {
+		return s.tokenRepo.RemoverDoPrestador(ctx, id)
+	}
+	return nil
 }
 
-func (s *PrestadorService) Ativar(id string) error {
-	prestador, err := s.prestadorRepo.BuscarPorId(id)
+func (s *PrestadorService) Ativar(ctx context.Context, id string) error {
+	prestador, err := s.prestadorRepo.BuscarPorId(ctx, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return ErrPrestadorNaoEncontrado
@@ -159,16 +223,38 @@ func (s *PrestadorService) Ativar(id string) error {
 
 	prestador.Ativo = true
 
-	err = s.prestadorRepo.AtualizarStatus(id, true)
+	err = s.prestadorRepo.AtualizarStatus(ctx, id, true)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (s *PrestadorService) SalvarAgenda(cmd *input.AdicionarAgendaInput) error {
+// DefinirTemposEntreAtendimentos substitui, para todos os serviços do prestador,
+// o preparo e a limpeza do catálogo. Valores nulos voltam a usar os do catálogo
+func (s *PrestadorService) DefinirTemposEntreAtendimentos(ctx context.Context, in *input.TemposEntreAtendimentosInput) error {
+	prestador, err := s.prestadorRepo.BuscarPorId(ctx, in.PrestadorID)
+	if err != nil {
+		return ErrPrestadorNaoEncontrado
+	}
+
+	if err := prestador.DefinirTemposEntreAtendimentos(in.TempoPreparo, in.TempoLimpeza); err != nil {
+		return err
+	}
+
+	if err := s.prestadorRepo.AtualizarTemposEntreAtendimentos(ctx, prestador.ID, prestador.TempoPreparo, prestador.TempoLimpeza); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPrestadorNaoEncontrado
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *PrestadorService) SalvarAgenda(ctx context.Context, cmd *input.AdicionarAgendaInput) error {
 	// 1. Buscar prestador
-	prestador, err := s.prestadorRepo.BuscarPorId(cmd.PrestadorID)
+	prestador, err := s.prestadorRepo.BuscarPorId(ctx, cmd.PrestadorID)
 	if err != nil {
 		return ErrPrestadorNaoEncontrado
 	}
@@ -189,35 +275,47 @@ func (s *PrestadorService) SalvarAgenda(cmd *input.AdicionarAgendaInput) error {
 	}
 
 	// 4. Criar agenda usando construtor do domínio
-	novaAgenda, err := domain.NovaAgendaDiaria(cmd.Data, intervalos)
+	novaAgenda, err := domain.NovaAgendaDiariaNoFuso(cmd.Data, intervalos, prestador.Localizacao())
 	if err != nil {
 		return err
 	}
 
-	// 5. Verificar se já existe agenda para essa data
+	// 5. Verificar se já existe agenda para essa data e, na mesma transação,
+	// ATUALIZAR quando existe ou CRIAR quando não existe
 	dataFormatada := cmd.Data.Format("2006-01-02")
-	agendaExistente, err := s.agendaDiariaRepo.BuscarAgendaDoDia(cmd.PrestadorID, dataFormatada)
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		return err
-	}
+	err = s.transacao.Executar(ctx, func(ctx context.Context) error {
+		agendaExistente, err := s.agendaDiariaRepo.BuscarAgendaDoDia(ctx, cmd.PrestadorID, dataFormatada)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		if agendaExistente != nil {
+			// ATUALIZAÇÃO
+			novaAgenda.Id = agendaExistente.Id
+			return s.agendaDiariaRepo.AtualizarAgenda(ctx, novaAgenda, cmd.PrestadorID)
+		}
 
-	// 6. Se existe, ATUALIZAR. Se não existe, CRIAR
-	if agendaExistente != nil {
-		// ATUALIZAÇÃO
-		novaAgenda.Id = agendaExistente.Id
-		return s.agendaDiariaRepo.AtualizarAgenda(novaAgenda, cmd.PrestadorID)
-	} else {
 		// CRIAÇÃO
 		if err := prestador.AdicionarAgenda(novaAgenda); err != nil {
 			return err
 		}
-		return s.agendaDiariaRepo.Salvar(novaAgenda, cmd.PrestadorID)
+		return s.agendaDiariaRepo.Salvar(ctx, novaAgenda, cmd.PrestadorID)
+	})
+	if err != nil {
+		return err
 	}
+
+	// 6. O dia pode ter ganhado horários: oferece à lista de espera
+	loc := prestador.Localizacao()
+	inicioDoDia := domain.InicioDoDiaEm(cmd.Data, loc)
+	avisarVagaLiberada(ctx, s.observador, prestador.ID, inicioDoDia, inicioDoDia.AddDate(0, 0, 1))
+
+	return nil
 }
 
-func (s *PrestadorService) DeletarAgenda(prestadorID string, data string) error {
+func (s *PrestadorService) DeletarAgenda(ctx context.Context, prestadorID string, data string) error {
 	// 1. Buscar prestador
-	prestador, err := s.prestadorRepo.BuscarPorId(prestadorID)
+	prestador, err := s.prestadorRepo.BuscarPorId(ctx, prestadorID)
 	if err != nil {
 		return ErrPrestadorNaoEncontrado
 	}
@@ -228,7 +326,7 @@ func (s *PrestadorService) DeletarAgenda(prestadorID string, data string) error
 	}
 
 	// 3. Verificar se agenda existe
-	_, err = s.agendaDiariaRepo.BuscarAgendaDoDia(prestadorID, data)
+	_, err = s.agendaDiariaRepo.BuscarAgendaDoDia(ctx, prestadorID, data)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return ErrAgendaNaoEncontrada
@@ -245,10 +343,11 @@ func (s *PrestadorService) DeletarAgenda(prestadorID string, data string) error
 	}
 
 	// 6. Deletar do repositório
-	return s.agendaDiariaRepo.DeletarAgenda(prestadorID, data)
+	return s.agendaDiariaRepo.DeletarAgenda(ctx, prestadorID, data)
 }
 
-func (s *PrestadorService) BuscarPrestadoresDisponiveisPorData(input *input.PrestadorListDataInput) ([]*output.BuscarPrestadorOutput, int, error) {
+func (s *PrestadorService) BuscarPrestadoresDisponiveisPorData(ctx context.Context, input *input.PrestadorListDataInput) ([]*output.BuscarPrestadorOutput, int, error) {
+	s.paginacao.Normalizar(&input.Page, &input.Limit)
 
 	// Validar formato da data
 	dataTime, err := time.Parse("2006-01-02", input.Data)
@@ -262,13 +361,13 @@ func (s *PrestadorService) BuscarPrestadoresDisponiveisPorData(input *input.Pres
 	}
 
 	// Buscar prestadores disponíveis
-	prestadores, err := s.prestadorRepo.BuscarPrestadoresDisponiveisPorData(input.Data, input.Page, input.Limit)
+	prestadores, err := s.prestadorRepo.BuscarPrestadoresDisponiveisPorData(ctx, input.Data, input.Page, input.Limit)
 	if err != nil {
 		return nil, 0, ErrAoBuscarPrestadoresDisponiveis
 	}
 
 	// Contar total
-	total, err := s.prestadorRepo.ContarPrestadoresDisponiveisPorData(input.Data)
+	total, err := s.prestadorRepo.ContarPrestadoresDisponiveisPorData(ctx, input.Data)
 	if err != nil {
 		return nil, 0, ErrAoContarPrestadoresDisponiveis
 	}
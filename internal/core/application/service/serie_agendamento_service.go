@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"meu-servico-agenda/internal/core/application/input"
+	"meu-servico-agenda/internal/core/application/mapper"
+	"meu-servico-agenda/internal/core/application/output"
+	"meu-servico-agenda/internal/core/domain"
+	"sort"
+	"time"
+)
+
+// CadastraSerie agenda todas as ocorrências possíveis da série. Ocorrências que violam
+// as regras de CadastraAgendamento são devolvidas em Falhas com o motivo; a série só é
+// gravada quando ao menos uma ocorrência pôde ser agendada. A série e as ocorrências
+// são gravadas na mesma transação: um erro de infraestrutura não deixa a série pela metade
+func (s *AgendamentoService) CadastraSerie(ctx context.Context, in input.CadastrarSerieInput) (*output.SerieAgendamentoOutput, error) {
+	var out *output.SerieAgendamentoOutput
+	err := s.transacao.Executar(ctx, func(ctx context.Context) error {
+		var err error
+		out, err = s.cadastrarSerie(ctx, in)
+		return err
+	})
+	if err != nil {
+		s.registrarRejeicao(err)
+		return nil, err
+	}
+
+	for range out.Agendados {
+		s.metricas.AgendamentoCriado()
+	}
+	return out, nil
+}
+
+func (s *AgendamentoService) cadastrarSerie(ctx context.Context, in input.CadastrarSerieInput) (*output.SerieAgendamentoOutput, error) {
+	cliente, err := s.clienteRepo.BuscarPorId(ctx, in.ClienteID)
+	if err != nil || cliente == nil {
+		return nil, ErrClienteNaoExiste
+	}
+	if !cliente.Ativo {
+		return nil, ErrClienteInativo
+	}
+
+	prestador, err := s.prestadorRepo.BuscarPorId(ctx, in.PrestadorID)
+	if err != nil || prestador == nil {
+		return nil, ErrPrestadorNaoExiste
+	}
+
+	if err := domain.ValidarDataNoPassadoEm(in.DataHoraInicio, prestador.Localizacao()); err != nil {
+		return nil, domain.ErrDataEstaNoPassado
+	}
+
+	catalogo, err := s.catalogoRepo.BuscarPorId(ctx, in.CatalogoID)
+	if err != nil || catalogo == nil {
+		return nil, ErrCatalogoNaoExiste
+	}
+
+	serie, err := domain.NovaSerieAgendamento(cliente, prestador, catalogo, in.DataHoraInicio, in.IntervaloSemanas, in.Ocorrencias, in.Notas)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &output.SerieAgendamentoOutput{
+		Agendados: []*output.AgendamentoOutput{},
+		Falhas:    []output.FalhaOcorrenciaOutput{},
+	}
+
+	duracao := time.Duration(prestador.DuracaoDoServico(catalogo)) * time.Minute
+	var ocorrencias []*domain.Agendamento
+
+	for _, inicio := range serie.InicioDasOcorrencias() {
+		fim := inicio.Add(duracao)
+
+		if err := s.validarHorario(ctx, cliente, prestador, catalogo, inicio, fim, ""); err != nil {
+			if !falhaDeOcorrencia(err) {
+				return nil, err
+			}
+			s.registrarRejeicao(err)
+			out.Falhas = append(out.Falhas, output.FalhaOcorrenciaOutput{DataHoraInicio: inicio, Motivo: err.Error()})
+			continue
+		}
+
+		agendamento, err := domain.NovoAgendamento(cliente, prestador, catalogo, inicio, fim, in.Notas)
+		if err != nil {
+			return nil, err
+		}
+		agendamento.SerieID = serie.ID
+		ocorrencias = append(ocorrencias, agendamento)
+	}
+
+	if len(ocorrencias) == 0 {
+		return out, nil
+	}
+
+	if err := s.agendamentoRepo.CriaSerie(ctx, serie); err != nil {
+		return nil, err
+	}
+	out.SerieID = serie.ID
+
+	for _, agendamento := range ocorrencias {
+		if err := s.agendamentoRepo.CriaAgendamento(ctx, agendamento); err != nil {
+			if !errors.Is(err, domain.ErrHorarioJaReservado) {
+				return nil, err
+			}
+			s.registrarRejeicao(ErrPrestadorOcupado)
+			out.Falhas = append(out.Falhas, output.FalhaOcorrenciaOutput{
+				DataHoraInicio: agendamento.DataHoraInicio,
+				Motivo:         ErrPrestadorOcupado.Error(),
+			})
+			continue
+		}
+		out.Agendados = append(out.Agendados, mapper.NovoAgendamentoOutput(agendamento))
+	}
+
+	return out, nil
+}
+
+func (s *AgendamentoService) ListarSerie(ctx context.Context, serieID string) ([]*output.AgendamentoOutput, error) {
+	ocorrencias, err := s.buscarOcorrencias(ctx, serieID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.BuscaAgendamentoData(ocorrencias), nil
+}
+
+// CancelarSerie cancela as ocorrências pendentes ou confirmadas dentro do escopo
+func (s *AgendamentoService) CancelarSerie(ctx context.Context, serieID string, escopo input.EscopoSerie) (*output.SerieAgendamentoOutput, error) {
+	ocorrencias, err := s.ocorrenciasAtivas(ctx, serieID, escopo)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &output.SerieAgendamentoOutput{
+		SerieID:   serieID,
+		Agendados: []*output.AgendamentoOutput{},
+		Falhas:    []output.FalhaOcorrenciaOutput{},
+	}
+
+	for _, agendamento := range ocorrencias {
+		if err := s.CancelarAgendamento(ctx, agendamento.ID); err != nil {
+			if !falhaDeOcorrencia(err) {
+				return nil, err
+			}
+			out.Falhas = append(out.Falhas, output.FalhaOcorrenciaOutput{
+				AgendamentoID:  agendamento.ID,
+				DataHoraInicio: agendamento.DataHoraInicio,
+				Motivo:         err.Error(),
+			})
+			continue
+		}
+
+		cancelado := *agendamento
+		cancelado.Status = domain.Cancelado
+		out.Agendados = append(out.Agendados, mapper.NovoAgendamentoOutput(&cancelado))
+	}
+
+	return out, nil
+}
+
+// ReagendarSerie desloca as ocorrências do escopo pela diferença entre o novo início
+// e o início da primeira ocorrência afetada, aplicando as regras de ReagendarAgendamento.
+// O deslocamento é medido em horário de parede do prestador, então uma série das 10:00
+// continua às 10:00 locais mesmo quando atravessa uma mudança de horário de verão
+func (s *AgendamentoService) ReagendarSerie(ctx context.Context, in input.ReagendarSerieInput) (*output.SerieAgendamentoOutput, error) {
+	ocorrencias, err := s.ocorrenciasAtivas(ctx, in.SerieID, in.Escopo)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &output.SerieAgendamentoOutput{
+		SerieID:   in.SerieID,
+		Agendados: []*output.AgendamentoOutput{},
+		Falhas:    []output.FalhaOcorrenciaOutput{},
+	}
+
+	if len(ocorrencias) == 0 {
+		return out, nil
+	}
+
+	loc := ocorrencias[0].Prestador.Localizacao()
+	deslocamento := horarioDeParede(in.DataHoraInicio, loc).Sub(horarioDeParede(ocorrencias[0].DataHoraInicio, loc))
+
+	// Ao adiar, começa pela última ocorrência para que uma não ocupe o horário da seguinte
+	if deslocamento > 0 {
+		sort.Slice(ocorrencias, func(i, j int) bool {
+			return ocorrencias[i].DataHoraInicio.After(ocorrencias[j].DataHoraInicio)
+		})
+	}
+
+	for _, agendamento := range ocorrencias {
+		parede := horarioDeParede(agendamento.DataHoraInicio, loc).Add(deslocamento)
+		novoInicio := time.Date(parede.Year(), parede.Month(), parede.Day(), parede.Hour(), parede.Minute(), parede.Second(), 0, loc)
+
+		reagendado, err := s.ReagendarAgendamento(ctx, input.ReagendarAgendamentoInput{
+			AgendamentoID:  agendamento.ID,
+			DataHoraInicio: novoInicio,
+		})
+		if err != nil {
+			if !falhaDeOcorrencia(err) {
+				return nil, err
+			}
+			out.Falhas = append(out.Falhas, output.FalhaOcorrenciaOutput{
+				AgendamentoID:  agendamento.ID,
+				DataHoraInicio: novoInicio,
+				Motivo:         err.Error(),
+			})
+			continue
+		}
+		out.Agendados = append(out.Agendados, reagendado)
+	}
+
+	sort.Slice(out.Agendados, func(i, j int) bool {
+		return out.Agendados[i].DataHoraInicio.Before(out.Agendados[j].DataHoraInicio)
+	})
+
+	return out, nil
+}
+
+func (s *AgendamentoService) buscarOcorrencias(ctx context.Context, serieID string) ([]*domain.Agendamento, error) {
+	serie, err := s.agendamentoRepo.BuscarSeriePorId(ctx, serieID)
+	if err != nil {
+		return nil, err
+	}
+	if serie == nil {
+		return nil, ErrSerieNaoEncontrada
+	}
+
+	return s.agendamentoRepo.BuscarPorSerie(ctx, serieID)
+}
+
+// ocorrenciasAtivas devolve, em ordem cronológica, as ocorrências pendentes ou confirmadas do escopo
+func (s *AgendamentoService) ocorrenciasAtivas(ctx context.Context, serieID string, escopo input.EscopoSerie) ([]*domain.Agendamento, error) {
+	ocorrencias, err := s.buscarOcorrencias(ctx, serieID)
+	if err != nil {
+		return nil, err
+	}
+
+	agora := time.Now()
+	ativas := make([]*domain.Agendamento, 0, len(ocorrencias))
+	for _, a := range ocorrencias {
+		if a.Status != domain.Pendente && a.Status != domain.Confirmado {
+			continue
+		}
+		if escopo == input.EscopoSerieRestantes && !a.DataHoraInicio.After(agora) {
+			continue
+		}
+		ativas = append(ativas, a)
+	}
+
+	sort.Slice(ativas, func(i, j int) bool {
+		return ativas[i].DataHoraInicio.Before(ativas[j].DataHoraInicio)
+	})
+
+	return ativas, nil
+}
+
+// falhaDeOcorrencia separa as regras de negócio que apenas impedem uma ocorrência
+// dos erros de infraestrutura, que interrompem a operação inteira
+func falhaDeOcorrencia(err error) bool {
+	return errors.Is(err, ErrDiaIndisponivel) ||
+		errors.Is(err, ErrHorarioIndisponivel) ||
+		errors.Is(err, ErrPrestadorOcupado) ||
+		errors.Is(err, ErrVagaReservada) ||
+		errors.Is(err, ErrClienteOcupado) ||
+		errors.Is(err, ErrAgendamentoDuplo) ||
+		errors.Is(err, domain.ErrDataEstaNoPassado) ||
+		errors.Is(err, domain.ErrAgendamentoNaoReagendavel) ||
+		errors.Is(err, domain.ErrTransicaoStatusInvalida)
+}
+
+// horarioDeParede representa o horário local de t no fuso como se fosse UTC, permitindo
+// somar durações sem que a mudança de deslocamento do fuso altere a hora exibida
+func horarioDeParede(t time.Time, loc *time.Location) time.Time {
+	l := t.In(loc)
+	return time.Date(l.Year(), l.Month(), l.Day(), l.Hour(), l.Minute(), l.Second(), 0, time.UTC)
+}
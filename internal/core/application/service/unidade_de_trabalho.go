@@ -0,0 +1,11 @@
+package service
+
+import "context"
+
+// semTransacao é o padrão quando nenhuma unidade de trabalho foi definida: as
+// operações rodam uma a uma, cada repositório com a própria transação
+type semTransacao struct{}
+
+func (semTransacao) Executar(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"meu-servico-agenda/internal/core/application/input"
+	"meu-servico-agenda/internal/core/application/mapper"
+	"meu-servico-agenda/internal/core/application/output"
+	"meu-servico-agenda/internal/core/domain"
+	"sort"
+)
+
+// CadastraVisita agenda vários serviços em sequência para o mesmo cliente. Todos os itens
+// passam pelas regras de CadastraAgendamento e a visita só é gravada se nenhum falhar.
+// As validações e a gravação vão na mesma transação, como em Agendar
+func (s *AgendamentoService) CadastraVisita(ctx context.Context, in input.CadastrarVisitaInput) (*output.VisitaOutput, error) {
+	var out *output.VisitaOutput
+	err := s.transacao.Executar(ctx, func(ctx context.Context) error {
+		var err error
+		out, err = s.cadastrarVisita(ctx, in)
+		return err
+	})
+	if err != nil {
+		s.registrarRejeicao(err)
+		return nil, err
+	}
+
+	for range out.Itens {
+		s.metricas.AgendamentoCriado()
+	}
+	return out, nil
+}
+
+func (s *AgendamentoService) cadastrarVisita(ctx context.Context, in input.CadastrarVisitaInput) (*output.VisitaOutput, error) {
+	cliente, err := s.clienteRepo.BuscarPorId(ctx, in.ClienteID)
+	if err != nil || cliente == nil {
+		return nil, ErrClienteNaoExiste
+	}
+	if !cliente.Ativo {
+		return nil, ErrClienteInativo
+	}
+
+	if err := s.agendamentoRepo.TravarCliente(ctx, cliente.ID); err != nil {
+		return nil, err
+	}
+
+	itens := make([]domain.ItemVisita, len(in.Itens))
+	for i, item := range in.Itens {
+		prestador, err := s.prestadorRepo.BuscarPorId(ctx, item.PrestadorID)
+		if err != nil || prestador == nil {
+			return nil, ErrPrestadorNaoExiste
+		}
+
+		catalogo, err := s.catalogoRepo.BuscarPorId(ctx, item.CatalogoID)
+		if err != nil || catalogo == nil {
+			return nil, ErrCatalogoNaoExiste
+		}
+
+		itens[i] = domain.ItemVisita{Prestador: prestador, Catalogo: catalogo}
+	}
+
+	if len(itens) > 0 {
+		if err := domain.ValidarDataNoPassadoEm(in.DataHoraInicio, itens[0].Prestador.Localizacao()); err != nil {
+			return nil, domain.ErrDataEstaNoPassado
+		}
+	}
+
+	visita, err := domain.NovaVisita(cliente, in.DataHoraInicio, itens, in.Notas)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.travarPrestadores(ctx, visita); err != nil {
+		return nil, err
+	}
+
+	// O número do item acompanha o erro para o cliente saber qual serviço não coube
+	for i, agendamento := range visita.Itens {
+		if err := s.validarHorario(ctx, cliente, agendamento.Prestador, agendamento.Catalogo, agendamento.DataHoraInicio, agendamento.DataHoraFim, ""); err != nil {
+			return nil, fmt.Errorf("item %d: %w", i+1, err)
+		}
+		if err := conflitoEntreItens(visita.Itens[:i], agendamento); err != nil {
+			return nil, fmt.Errorf("item %d: %w", i+1, err)
+		}
+	}
+
+	if err := s.agendamentoRepo.CriaVisita(ctx, visita); err != nil {
+		if errors.Is(err, domain.ErrHorarioJaReservado) {
+			return nil, ErrPrestadorOcupado
+		}
+		return nil, err
+	}
+
+	return mapper.NovaVisitaOutput(visita), nil
+}
+
+// conflitoEntreItens aplica as regras de validarHorario entre os itens da própria visita,
+// que o banco ainda não enxerga
+func conflitoEntreItens(anteriores []*domain.Agendamento, item *domain.Agendamento) error {
+	ocupadoInicio, ocupadoFim := item.PeriodoOcupado()
+	for _, anterior := range anteriores {
+		if anterior.Catalogo.ID == item.Catalogo.ID {
+			return ErrAgendamentoDuplo
+		}
+		if anterior.Prestador.ID == item.Prestador.ID && anterior.ConflitaCom(ocupadoInicio, ocupadoFim) {
+			return ErrPrestadorOcupado
+		}
+		if item.DataHoraInicio.Before(anterior.DataHoraFim) && item.DataHoraFim.After(anterior.DataHoraInicio) {
+			return ErrClienteOcupado
+		}
+	}
+	return nil
+}
+
+func (s *AgendamentoService) BuscarVisita(ctx context.Context, visitaID string) (*output.VisitaOutput, error) {
+	visita, err := s.agendamentoRepo.BuscarVisitaPorId(ctx, visitaID)
+	if err != nil {
+		return nil, err
+	}
+	if visita == nil {
+		return nil, ErrVisitaNaoEncontrada
+	}
+
+	visita.Itens, err = s.agendamentoRepo.BuscarPorVisita(ctx, visitaID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.NovaVisitaOutput(visita), nil
+}
+
+// travarPrestadores trava a agenda de todos os prestadores da visita antes das
+// validações, em ordem, para que duas visitas com os mesmos prestadores não fiquem
+// esperando uma pela outra
+func (s *AgendamentoService) travarPrestadores(ctx context.Context, visita *domain.Visita) error {
+	prestadores := make([]string, 0, len(visita.Itens))
+	for _, a := range visita.Itens {
+		prestadores = append(prestadores, a.Prestador.ID)
+	}
+	sort.Strings(prestadores)
+	for _, prestadorID := range prestadores {
+		if err := s.agendamentoRepo.TravarPrestador(ctx, prestadorID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -21,6 +21,12 @@ type IntervaloDiario struct {
 }
 
 func NovaAgendaDiaria(data time.Time, intervalos []IntervaloDiario) (*AgendaDiaria, error) {
+	return NovaAgendaDiariaNoFuso(data, intervalos, time.UTC)
+}
+
+// NovaAgendaDiariaNoFuso cria a agenda validando o "hoje" no fuso do prestador.
+// A data é tratada como dia de calendário: apenas ano, mês e dia são usados
+func NovaAgendaDiariaNoFuso(data time.Time, intervalos []IntervaloDiario, loc *time.Location) (*AgendaDiaria, error) {
 	if len(intervalos) == 0 {
 		return nil, ErrAgendaSemIntervalos
 	}
@@ -37,14 +43,15 @@ func NovaAgendaDiaria(data time.Time, intervalos []IntervaloDiario) (*AgendaDiar
 		return nil, err
 	}
 
-	err := ValidarDataNoPassado(data)
+	dia := time.Date(data.Year(), data.Month(), data.Day(), 0, 0, 0, 0, loc)
+	err := ValidarDataNoPassadoEm(dia, loc)
 	if err != nil {
 		return nil, err
 	}
 
 	return &AgendaDiaria{
 		Id:         xid.New().String(),
-		Data:       data.Format("2006-01-02"),
+		Data:       dia.Format("2006-01-02"),
 		Intervalos: intervalos,
 	}, nil
 }
@@ -93,47 +100,97 @@ func validarSobreposicao(intervalos []IntervaloDiario) error {
 	return nil
 }
 
-func (a *AgendaDiaria) PermiteAgendamento(inicio, fim time.Time) bool {
+// PermiteAgendamento verifica se o período cabe em algum intervalo do dia.
+// Os intervalos são horários de parede no fuso do prestador (loc)
+func (a *AgendaDiaria) PermiteAgendamento(inicio, fim time.Time, loc *time.Location) bool {
 
-	// Converte entrada para UTC
-	inicioUTC := inicio.UTC()
-	fimUTC := fim.UTC()
-
-	// Parse da data da agenda EM UTC
-	dataAgenda, err := time.ParseInLocation("2006-01-02", a.Data, time.UTC)
+	// Parse da data da agenda no fuso do prestador
+	dataAgenda, err := time.ParseInLocation("2006-01-02", a.Data, loc)
 	if err != nil {
 		return false
 	}
 
 	for _, it := range a.Intervalos {
+		inicioIntervalo, fimIntervalo := limitesDoIntervalo(dataAgenda, it, loc)
+
+		if !inicio.Before(inicioIntervalo) && !fim.After(fimIntervalo) {
+			return true
+		}
+	}
+	return false
+}
+
+// HorariosLivres retorna todos os inícios possíveis para um serviço com a duração
+// informada, percorrendo os intervalos do dia em passos fixos e descartando os
+// horários que se sobrepõem aos agendamentos já existentes. O preparo e a limpeza
+// contam no conflito, mas não precisam caber no intervalo de trabalho. Os horários
+// são devolvidos no fuso do prestador (loc)
+func (a *AgendaDiaria) HorariosLivres(duracao, preparo, limpeza, passo time.Duration, ocupados []*Agendamento, loc *time.Location) []time.Time {
+	horarios := []time.Time{}
+	if duracao <= 0 || passo <= 0 {
+		return horarios
+	}
+
+	dataAgenda, err := time.ParseInLocation("2006-01-02", a.Data, loc)
+	if err != nil {
+		return horarios
+	}
+
+	intervalos := make([]IntervaloDiario, len(a.Intervalos))
+	copy(intervalos, a.Intervalos)
+	sort.Slice(intervalos, func(i, j int) bool {
+		hi := intervalos[i].HoraInicio
+		hj := intervalos[j].HoraInicio
+		return hi.Hour() < hj.Hour() || (hi.Hour() == hj.Hour() && hi.Minute() < hj.Minute())
+	})
+
+	for _, it := range intervalos {
+		inicioIntervalo, fimIntervalo := limitesDoIntervalo(dataAgenda, it, loc)
+
+		for inicio := inicioIntervalo; !inicio.Add(duracao).After(fimIntervalo); inicio = inicio.Add(passo) {
+			if !conflitaComAgendamentos(inicio.Add(-preparo), inicio.Add(duracao+limpeza), ocupados) {
+				horarios = append(horarios, inicio)
+			}
+		}
+	}
+
+	return horarios
+}
+
+// limitesDoIntervalo posiciona o intervalo (somente hora) na data da agenda,
+// no fuso informado. Em dias com mudança de horário o time.Date normaliza
+// horários inexistentes (ex: 02:30 no início do horário de verão)
+func limitesDoIntervalo(dataAgenda time.Time, it IntervaloDiario, loc *time.Location) (time.Time, time.Time) {
+	inicio := time.Date(
+		dataAgenda.Year(),
+		dataAgenda.Month(),
+		dataAgenda.Day(),
+		it.HoraInicio.Hour(),
+		it.HoraInicio.Minute(),
+		0,
+		0,
+		loc,
+	)
+
+	fim := time.Date(
+		dataAgenda.Year(),
+		dataAgenda.Month(),
+		dataAgenda.Day(),
+		it.HoraFim.Hour(),
+		it.HoraFim.Minute(),
+		0,
+		0,
+		loc,
+	)
+
+	return inicio, fim
+}
 
-		inicioIntervalo := time.Date(
-			dataAgenda.Year(),
-			dataAgenda.Month(),
-			dataAgenda.Day(),
-			it.HoraInicio.Hour(),
-			it.HoraInicio.Minute(),
-			0,
-			0,
-			time.UTC,
-		)
-
-		fimIntervalo := time.Date(
-			dataAgenda.Year(),
-			dataAgenda.Month(),
-			dataAgenda.Day(),
-			it.HoraFim.Hour(),
-			it.HoraFim.Minute(),
-			0,
-			0,
-			time.UTC,
-		)
-
-		if !inicioUTC.Before(inicioIntervalo) && !fimUTC.After(fimIntervalo) {
-			//log.Printf("✅ retornei true, %s data inicio e %s data final", inicioIntervalo, fimIntervalo)
+func conflitaComAgendamentos(inicio, fim time.Time, agendamentos []*Agendamento) bool {
+	for _, ag := range agendamentos {
+		if ag.ConflitaCom(inicio, fim) {
 			return true
 		}
 	}
-	//log.Printf("✅ retornei false")
 	return false
 }
@@ -24,6 +24,25 @@ type Agendamento struct {
 	DataHoraFim    time.Time
 	Status         StatusDoAgendamento
 	Notas          string
+	SerieID        string // vazio quando o agendamento não pertence a uma série
+	VisitaID       string // vazio quando o agendamento não faz parte de uma visita
+	// BloqueioInicio e BloqueioFim delimitam o período em que o prestador fica ocupado,
+	// incluindo preparo e limpeza. O cliente vê apenas DataHoraInicio e DataHoraFim
+	BloqueioInicio time.Time
+	BloqueioFim    time.Time
+	// Servico guarda o serviço como foi contratado; mudanças posteriores no
+	// catálogo ou no prestador não alteram o agendamento
+	Servico ServicoAgendado
+}
+
+// ServicoAgendado é a cópia dos dados do serviço no momento da reserva
+type ServicoAgendado struct {
+	Nome string
+	// Preco em centavos, já considerando o preço próprio do prestador
+	Preco int
+	// DuracaoPadrao em minutos
+	DuracaoPadrao int
+	Categoria     string
 }
 
 func NovoAgendamento(
@@ -39,6 +58,8 @@ func NovoAgendamento(
 		return nil, ErrHoraInicialMenorQueFinal
 	}
 
+	bloqueioInicio, bloqueioFim := PeriodoBloqueado(prestador, catalogo, dataHoraInicio, dataHoraFim)
+
 	return &Agendamento{
 		ID:             xid.New().String(),
 		Cliente:        cliente,
@@ -48,5 +69,67 @@ func NovoAgendamento(
 		DataHoraFim:    dataHoraFim,
 		Status:         Pendente,
 		Notas:          nota,
+		BloqueioInicio: bloqueioInicio,
+		BloqueioFim:    bloqueioFim,
+		Servico: ServicoAgendado{
+			Nome:          catalogo.Nome,
+			Preco:         prestador.PrecoDoServico(catalogo),
+			DuracaoPadrao: int(dataHoraFim.Sub(dataHoraInicio) / time.Minute),
+			Categoria:     catalogo.Categoria,
+		},
 	}, nil
 }
+
+// PeriodoBloqueado estende o atendimento com o preparo antes e a limpeza depois
+func PeriodoBloqueado(prestador *Prestador, catalogo *Catalogo, inicio, fim time.Time) (time.Time, time.Time) {
+	preparo, limpeza := prestador.TemposEntreAtendimentos(catalogo)
+	return inicio.Add(-preparo), fim.Add(limpeza)
+}
+
+// PeriodoOcupado devolve o período bloqueado do prestador. Agendamentos sem bloqueio
+// registrado ocupam apenas o horário do atendimento
+func (a *Agendamento) PeriodoOcupado() (time.Time, time.Time) {
+	if a.BloqueioInicio.IsZero() || a.BloqueioFim.IsZero() {
+		return a.DataHoraInicio, a.DataHoraFim
+	}
+	return a.BloqueioInicio, a.BloqueioFim
+}
+
+// ConflitaCom indica se o prestador estaria ocupado com este agendamento no período informado
+func (a *Agendamento) ConflitaCom(inicio, fim time.Time) bool {
+	if a.Status == Cancelado {
+		return false
+	}
+	ocupadoInicio, ocupadoFim := a.PeriodoOcupado()
+	return inicio.Before(ocupadoFim) && fim.After(ocupadoInicio)
+}
+
+// Confirmar move o agendamento de Pendente para Confirmado
+func (a *Agendamento) Confirmar() error {
+	if a.Status != Pendente {
+		return ErrTransicaoStatusInvalida
+	}
+
+	a.Status = Confirmado
+	return nil
+}
+
+// Cancelar permite cancelar agendamentos que ainda não foram concluídos
+func (a *Agendamento) Cancelar() error {
+	if a.Status != Pendente && a.Status != Confirmado {
+		return ErrTransicaoStatusInvalida
+	}
+
+	a.Status = Cancelado
+	return nil
+}
+
+// Concluir só é permitido para agendamentos confirmados
+func (a *Agendamento) Concluir() error {
+	if a.Status != Confirmado {
+		return ErrTransicaoStatusInvalida
+	}
+
+	a.Status = Concluido
+	return nil
+}
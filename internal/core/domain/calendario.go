@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// TokenCalendario libera, sem login, o feed iCalendar de um cliente ou de um
+// prestador. Só o hash do valor entregue na URL é guardado, e exatamente um
+// dos dois IDs é preenchido
+type TokenCalendario struct {
+	TokenHash   string
+	ClienteID   string
+	PrestadorID string
+	CriadoEm    time.Time
+}
+
+func NovoTokenCalendarioCliente(clienteID, tokenHash string) *TokenCalendario {
+	return &TokenCalendario{
+		TokenHash: tokenHash,
+		ClienteID: clienteID,
+		CriadoEm:  time.Now().UTC(),
+	}
+}
+
+func NovoTokenCalendarioPrestador(prestadorID, tokenHash string) *TokenCalendario {
+	return &TokenCalendario{
+		TokenHash:   tokenHash,
+		PrestadorID: prestadorID,
+		CriadoEm:    time.Now().UTC(),
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"github.com/rs/xid"
 )
 
+// MaxTempoEntreAtendimentos limita, em minutos, o preparo e a limpeza de um serviço
+const MaxTempoEntreAtendimentos = 240
+
 type Catalogo struct {
 	ID   string
 	Nome string
@@ -12,6 +15,10 @@ type Catalogo struct {
 	Preco         int
 	ImagemUrl     string
 	Categoria     string
+	// TempoPreparo e TempoLimpeza, em minutos, bloqueiam o prestador antes e depois
+	// do atendimento sem alterar o horário combinado com o cliente
+	TempoPreparo int
+	TempoLimpeza int
 }
 
 func NovoCatalogo(nome string, duracao int, preco int, categoria string, image_url string) (*Catalogo, error) {
@@ -33,3 +40,18 @@ func NovoCatalogo(nome string, duracao int, preco int, categoria string, image_u
 		ImagemUrl:     image_url,
 	}, nil
 }
+
+// DefinirTemposEntreAtendimentos altera o preparo e a limpeza do serviço
+func (c *Catalogo) DefinirTemposEntreAtendimentos(preparo, limpeza int) error {
+	if !tempoEntreAtendimentosValido(preparo) || !tempoEntreAtendimentosValido(limpeza) {
+		return ErrTempoEntreAtendimentosInvalido
+	}
+
+	c.TempoPreparo = preparo
+	c.TempoLimpeza = limpeza
+	return nil
+}
+
+func tempoEntreAtendimentosValido(minutos int) bool {
+	return minutos >= 0 && minutos <= MaxTempoEntreAtendimentos
+}
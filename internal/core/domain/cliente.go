@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/rs/xid"
 )
 
@@ -9,6 +11,10 @@ type Cliente struct {
 	Nome     string
 	Email    string
 	Telefone string
+	// Ativo false impede novos agendamentos; o histórico do cliente é mantido
+	Ativo bool
+	// AnonimizadoEm é preenchido quando os dados pessoais foram apagados a pedido do titular (LGPD)
+	AnonimizadoEm *time.Time `json:",omitempty"`
 }
 
 func NovoCliente(nome, email, telefone string) (*Cliente, error) {
@@ -17,5 +23,23 @@ func NovoCliente(nome, email, telefone string) (*Cliente, error) {
 		Nome:     nome,
 		Email:    email,
 		Telefone: telefone,
+		Ativo:    true,
 	}, nil
 }
+
+// Anonimizar substitui os dados pessoais por valores que não identificam o titular.
+// O ID é mantido para que os agendamentos continuem no histórico financeiro; o email
+// usa o ID para não violar a unicidade
+func (c *Cliente) Anonimizar(agora time.Time) error {
+	if c.AnonimizadoEm != nil {
+		return ErrClienteAnonimizado
+	}
+
+	c.Nome = "Cliente anonimizado"
+	c.Email = c.ID + "@anonimizado.invalid"
+	c.Telefone = "00000000"
+	c.Ativo = false
+	c.AnonimizadoEm = &agora
+
+	return nil
+}
@@ -2,29 +2,63 @@ package domain
 
 import "errors"
 
-type ErrorResponse struct {
-	Error string `json:"error"`
-}
-
 var (
 	//Valida Prestador
 	ErrAgendaDuplicada          = errors.New("agenda duplicada")
 	ErrPrestadorInativo         = errors.New("prestador inativo")
 	ErrPrestadorDeveTerCatalogo = errors.New("prestador deve ter ao menos um catálogo de serviços")
 	ErrAgendaNaoEncontrada = errors.New("agenda não encontrada para esta data")
+	ErrFusoHorarioInvalido = errors.New("fuso horário inválido, use um nome IANA (ex: America/Sao_Paulo)")
+	ErrCondicaoForaDoCatalogo = errors.New("preço e duração só podem ser definidos para serviços oferecidos pelo prestador")
+
+	//Valida Cliente
+	ErrEmailJaCadastrado  = errors.New("email já cadastrado para outro cliente")
+	ErrClienteAnonimizado = errors.New("cliente anonimizado a pedido do titular não pode ser alterado")
+
+	//Valida Usuario
+	ErrPapelInvalido          = errors.New("papel inválido, use admin, prestador ou cliente")
+	ErrVinculoUsuarioInvalido = errors.New("usuário cliente deve informar cliente_id, usuário prestador deve informar prestador_id e admin não tem vínculo")
+	ErrUsuarioJaCadastrado    = errors.New("email já cadastrado para outro usuário")
+
+	//Valida Solicitação LGPD
+	ErrSolicitanteObrigatorio = errors.New("informe quem está realizando a solicitação")
 
 	//Valida Catalogo
 	ErrDuracaoInvalida   = errors.New("duração padrão inválida")
 	ErrPrecoInvalido     = errors.New("preço inválido")
-	ErrNomeInvalido      = errors.New("Nome invalido")
-	ErrCategoriaInvalida = errors.New("Categoria invalida")
+	ErrNomeInvalido      = errors.New("nome inválido")
+	ErrCategoriaInvalida = errors.New("categoria inválida")
+
+	ErrTempoEntreAtendimentosInvalido = errors.New("tempo de preparo e de limpeza deve ser de 0 a 240 minutos")
 
 	//Validaa Agendamento
-	ErrHoraInicialMenorQueFinal = errors.New("horário início deve ser antes do fim")
+	ErrHoraInicialMenorQueFinal  = errors.New("horário início deve ser antes do fim")
+	ErrTransicaoStatusInvalida   = errors.New("transição de status do agendamento inválida")
+	ErrAgendamentoNaoReagendavel = errors.New("somente agendamentos pendentes ou confirmados podem ser reagendados")
+	ErrHorarioJaReservado        = errors.New("horário já reservado para o prestador")
+
+	//Valida Serie de Agendamentos
+	ErrIntervaloSerieInvalido    = errors.New("intervalo da série deve ser de 1 a 4 semanas")
+	ErrOcorrenciasSerieInvalidas = errors.New("série deve ter de 2 a 52 ocorrências")
+
+	//Valida Visita
+	ErrItensVisitaInvalidos    = errors.New("visita deve ter de 1 a 10 serviços")
+	ErrServicoRepetidoNaVisita = errors.New("a visita não pode repetir o mesmo serviço")
 
 	//Valida Agenda Diaria
 	ErrAgendaSemIntervalos      = errors.New("agenda deve conter ao menos um intervalo")
 	ErrIntervaloHorarioInvalido = errors.New("hora início deve ser menor que hora fim")
-	ErrDataEstaNoPassado        = errors.New("Esta data está passado")
+	ErrDataEstaNoPassado        = errors.New("a data está no passado")
 	ErrIntervalosSesobrepoe     = errors.New("intervalos de horário não podem se sobrepor")
+
+	//Valida Modelo de Agenda
+	ErrModeloSemDiasDaSemana = errors.New("modelo de agenda deve conter ao menos um dia da semana")
+	ErrDiaDaSemanaInvalido   = errors.New("dia da semana inválido ou repetido")
+	ErrVigenciaInvalida      = errors.New("início da vigência deve ser antes do fim")
+
+	//Valida Lista de Espera
+	ErrJanelaEsperaInvalida         = errors.New("início da janela de espera deve ser antes do fim")
+	ErrSemOfertaPendente            = errors.New("não há vaga oferecida para esta entrada da lista de espera")
+	ErrOfertaExpirada               = errors.New("prazo para aceitar a vaga oferecida expirou")
+	ErrTransicaoListaEsperaInvalida = errors.New("transição de status da lista de espera inválida")
 )
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+
+	// Embute a base IANA para que os fusos funcionem mesmo em imagens sem tzdata
+	_ "time/tzdata"
+)
+
+// ValidarFusoHorario aceita somente nomes IANA conhecidos. "Local" é recusado
+// porque dependeria da máquina onde o serviço roda
+func ValidarFusoHorario(nome string) error {
+	if nome == "" || nome == "Local" {
+		return ErrFusoHorarioInvalido
+	}
+
+	if _, err := time.LoadLocation(nome); err != nil {
+		return ErrFusoHorarioInvalido
+	}
+	return nil
+}
+
+// InicioDoDiaEm devolve a meia-noite local do dia de calendário de data no fuso
+func InicioDoDiaEm(data time.Time, loc *time.Location) time.Time {
+	return time.Date(data.Year(), data.Month(), data.Day(), 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// RegistroIdempotencia guarda a primeira resposta dada a uma chave Idempotency-Key.
+// Enquanto a requisição original está em andamento StatusCode é zero
+type RegistroIdempotencia struct {
+	Chave          string
+	HashRequisicao string
+	StatusCode     int
+	Corpo          []byte
+	CriadoEm       time.Time
+	ExpiraEm       time.Time
+}
+
+func NovoRegistroIdempotencia(chave, hashRequisicao string, ttl time.Duration) *RegistroIdempotencia {
+	agora := time.Now().UTC()
+	return &RegistroIdempotencia{
+		Chave:          chave,
+		HashRequisicao: hashRequisicao,
+		CriadoEm:       agora,
+		ExpiraEm:       agora.Add(ttl),
+	}
+}
+
+// EmAndamento indica que a requisição original ainda não terminou
+func (r *RegistroIdempotencia) EmAndamento() bool {
+	return r.StatusCode == 0
+}
+
+func (r *RegistroIdempotencia) Expirado(agora time.Time) bool {
+	return !agora.Before(r.ExpiraEm)
+}
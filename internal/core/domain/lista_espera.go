@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/rs/xid"
+)
+
+type StatusListaEspera int
+
+const (
+	AguardandoVaga StatusListaEspera = iota + 1
+	VagaOferecida
+	VagaAceita
+	EsperaCancelada
+	OfertaExpirada
+)
+
+// EntradaListaEspera representa um cliente aguardando um horário para o serviço dentro
+// da janela aceitável. Quando uma vaga compatível é liberada, ela é oferecida à entrada
+// mais antiga, que tem até Oferta.ExpiraEm para aceitá-la
+type EntradaListaEspera struct {
+	ID            string
+	ClienteID     string
+	CatalogoID    string
+	PrestadorID   string // vazio aceita qualquer prestador que ofereça o serviço
+	JanelaInicio  time.Time
+	JanelaFim     time.Time
+	Status        StatusListaEspera
+	CriadoEm      time.Time
+	Oferta        *OfertaVaga
+	AgendamentoID string // preenchido quando a oferta é aceita
+}
+
+// OfertaVaga é o horário reservado temporariamente para a entrada da lista de espera
+type OfertaVaga struct {
+	PrestadorID    string
+	DataHoraInicio time.Time
+	DataHoraFim    time.Time
+	ExpiraEm       time.Time
+}
+
+func NovaEntradaListaEspera(clienteID, catalogoID, prestadorID string, janelaInicio, janelaFim time.Time) (*EntradaListaEspera, error) {
+	if !janelaInicio.Before(janelaFim) {
+		return nil, ErrJanelaEsperaInvalida
+	}
+
+	agora := time.Now()
+	if !janelaFim.After(agora) {
+		return nil, ErrDataEstaNoPassado
+	}
+
+	return &EntradaListaEspera{
+		ID:           xid.New().String(),
+		ClienteID:    clienteID,
+		CatalogoID:   catalogoID,
+		PrestadorID:  prestadorID,
+		JanelaInicio: janelaInicio,
+		JanelaFim:    janelaFim,
+		Status:       AguardandoVaga,
+		CriadoEm:     agora.UTC(),
+	}, nil
+}
+
+// AceitaPrestador indica se a entrada pode receber vagas do prestador
+func (e *EntradaListaEspera) AceitaPrestador(prestadorID string) bool {
+	return e.PrestadorID == "" || e.PrestadorID == prestadorID
+}
+
+// Comporta indica se o período cabe inteiro na janela aceitável
+func (e *EntradaListaEspera) Comporta(inicio, fim time.Time) bool {
+	return !inicio.Before(e.JanelaInicio) && !fim.After(e.JanelaFim)
+}
+
+// Oferecer reserva o período para a entrada até expiraEm
+func (e *EntradaListaEspera) Oferecer(prestadorID string, inicio, fim, expiraEm time.Time) error {
+	if e.Status != AguardandoVaga {
+		return ErrTransicaoListaEsperaInvalida
+	}
+
+	e.Status = VagaOferecida
+	e.Oferta = &OfertaVaga{
+		PrestadorID:    prestadorID,
+		DataHoraInicio: inicio,
+		DataHoraFim:    fim,
+		ExpiraEm:       expiraEm,
+	}
+	return nil
+}
+
+// OfertaVencida indica que a vaga foi oferecida mas o prazo para aceitá-la acabou
+func (e *EntradaListaEspera) OfertaVencida(agora time.Time) bool {
+	return e.Status == VagaOferecida && !agora.Before(e.Oferta.ExpiraEm)
+}
+
+// ValidarAceite verifica se a oferta ainda pode ser aceita
+func (e *EntradaListaEspera) ValidarAceite(agora time.Time) error {
+	if e.Status != VagaOferecida {
+		return ErrSemOfertaPendente
+	}
+	if e.OfertaVencida(agora) {
+		return ErrOfertaExpirada
+	}
+	return nil
+}
+
+// ConfirmarAceite registra o agendamento criado a partir da oferta
+func (e *EntradaListaEspera) ConfirmarAceite(agendamentoID string) {
+	e.Status = VagaAceita
+	e.AgendamentoID = agendamentoID
+}
+
+// ExpirarOferta encerra a entrada cujo prazo para aceitar a vaga acabou.
+// O cliente precisa entrar na lista de novo para receber outras ofertas
+func (e *EntradaListaEspera) ExpirarOferta() error {
+	if e.Status != VagaOferecida {
+		return ErrTransicaoListaEsperaInvalida
+	}
+
+	e.Status = OfertaExpirada
+	return nil
+}
+
+// Cancelar retira o cliente da lista, liberando a vaga que estivesse oferecida
+func (e *EntradaListaEspera) Cancelar() error {
+	if e.Status != AguardandoVaga && e.Status != VagaOferecida {
+		return ErrTransicaoListaEsperaInvalida
+	}
+
+	e.Status = EsperaCancelada
+	return nil
+}
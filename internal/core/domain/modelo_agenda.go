@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/rs/xid"
+)
+
+// ModeloAgenda descreve a disponibilidade semanal recorrente de um prestador
+// (Ex: seg a sex, 08:00-12:00 e 13:00-18:00) dentro de um período de vigência
+type ModeloAgenda struct {
+	ID             string
+	PrestadorID    string
+	DiasDaSemana   []time.Weekday
+	Intervalos     []IntervaloDiario
+	VigenciaInicio time.Time
+	VigenciaFim    time.Time
+}
+
+func NovoModeloAgenda(prestadorID string, dias []time.Weekday, intervalos []IntervaloDiario, vigenciaInicio, vigenciaFim time.Time) (*ModeloAgenda, error) {
+	if len(dias) == 0 {
+		return nil, ErrModeloSemDiasDaSemana
+	}
+
+	vistos := make(map[time.Weekday]bool, len(dias))
+	for _, d := range dias {
+		if d < time.Sunday || d > time.Saturday || vistos[d] {
+			return nil, ErrDiaDaSemanaInvalido
+		}
+		vistos[d] = true
+	}
+
+	if len(intervalos) == 0 {
+		return nil, ErrAgendaSemIntervalos
+	}
+
+	for _, it := range intervalos {
+		if !it.HoraInicio.Before(it.HoraFim) {
+			return nil, ErrIntervaloHorarioInvalido
+		}
+	}
+
+	if err := validarSobreposicao(intervalos); err != nil {
+		return nil, err
+	}
+
+	if vigenciaFim.Before(vigenciaInicio) {
+		return nil, ErrVigenciaInvalida
+	}
+
+	return &ModeloAgenda{
+		ID:             xid.New().String(),
+		PrestadorID:    prestadorID,
+		DiasDaSemana:   dias,
+		Intervalos:     intervalos,
+		VigenciaInicio: vigenciaInicio,
+		VigenciaFim:    vigenciaFim,
+	}, nil
+}
+
+// AplicaEm informa se o modelo gera agenda para a data (dia da semana e vigência)
+func (m *ModeloAgenda) AplicaEm(data time.Time) bool {
+	dia := data.Format("2006-01-02")
+	if dia < m.VigenciaInicio.Format("2006-01-02") || dia > m.VigenciaFim.Format("2006-01-02") {
+		return false
+	}
+
+	for _, d := range m.DiasDaSemana {
+		if d == data.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// NovaAgendaPara cria a AgendaDiaria da data a partir do modelo, passando pelas
+// mesmas validações de NovaAgendaDiaria no fuso do prestador. Cada agenda recebe
+// intervalos com IDs próprios
+func (m *ModeloAgenda) NovaAgendaPara(data time.Time, loc *time.Location) (*AgendaDiaria, error) {
+	intervalos := make([]IntervaloDiario, 0, len(m.Intervalos))
+	for _, it := range m.Intervalos {
+		intervalo, err := NovoIntervaloDiario(it.HoraInicio, it.HoraFim)
+		if err != nil {
+			return nil, err
+		}
+		intervalos = append(intervalos, *intervalo)
+	}
+
+	return NovaAgendaDiariaNoFuso(data, intervalos, loc)
+}
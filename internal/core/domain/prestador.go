@@ -1,9 +1,14 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/rs/xid"
 )
 
+// FusoHorarioPadrao é usado por prestadores cadastrados antes do suporte a fusos
+const FusoHorarioPadrao = "UTC"
+
 type Prestador struct {
 	ID        string
 	Nome      string
@@ -14,6 +19,40 @@ type Prestador struct {
 	ImagemUrl string
 	Catalogo  []Catalogo
 	Agenda    []AgendaDiaria
+	// FusoHorario é o nome IANA (ex: America/Sao_Paulo) em que os intervalos
+	// da agenda são interpretados como horário de parede
+	FusoHorario string
+	// TempoPreparo e TempoLimpeza, quando preenchidos, substituem os do catálogo
+	// em todos os serviços do prestador
+	TempoPreparo *int
+	TempoLimpeza *int
+	// Condicoes guarda o preço e a duração que o prestador pratica em serviços
+	// específicos do catálogo
+	Condicoes []CondicaoDoServico
+}
+
+// CondicaoDoServico substitui, para um prestador, o preço e a duração de um serviço.
+// Campos nulos mantêm os valores do catálogo
+type CondicaoDoServico struct {
+	CatalogoID    string
+	Preco         *int
+	DuracaoPadrao *int
+}
+
+// NovaCondicaoDoServico aplica as mesmas regras de preço e duração do catálogo
+func NovaCondicaoDoServico(catalogoID string, preco, duracao *int) (*CondicaoDoServico, error) {
+	if preco != nil && *preco < 0 {
+		return nil, ErrPrecoInvalido
+	}
+	if duracao != nil && *duracao <= 1 {
+		return nil, ErrDuracaoInvalida
+	}
+
+	return &CondicaoDoServico{
+		CatalogoID:    catalogoID,
+		Preco:         preco,
+		DuracaoPadrao: duracao,
+	}, nil
 }
 
 func NovoPrestador(nome, cpf, email, telefone string, imagem string, catalogos []Catalogo) (*Prestador, error) {
@@ -22,18 +61,100 @@ func NovoPrestador(nome, cpf, email, telefone string, imagem string, catalogos [
 	}
 
 	return &Prestador{
-		ID:        xid.New().String(),
-		Nome:      nome,
-		Cpf:       cpf,
-		Email:     email,
-		Telefone:  telefone,
-		Ativo:     true,
-		ImagemUrl: imagem,
-		Catalogo:  catalogos,
-		Agenda:    []AgendaDiaria{},
+		ID:          xid.New().String(),
+		Nome:        nome,
+		Cpf:         cpf,
+		Email:       email,
+		Telefone:    telefone,
+		Ativo:       true,
+		ImagemUrl:   imagem,
+		Catalogo:    catalogos,
+		Agenda:      []AgendaDiaria{},
+		FusoHorario: FusoHorarioPadrao,
 	}, nil
 }
 
+// DefinirFusoHorario valida o nome IANA antes de atribuí-lo ao prestador
+func (p *Prestador) DefinirFusoHorario(nome string) error {
+	if err := ValidarFusoHorario(nome); err != nil {
+		return err
+	}
+
+	p.FusoHorario = nome
+	return nil
+}
+
+// DefinirTemposEntreAtendimentos define ou, com nil, remove a substituição dos tempos do catálogo
+func (p *Prestador) DefinirTemposEntreAtendimentos(preparo, limpeza *int) error {
+	if preparo != nil && !tempoEntreAtendimentosValido(*preparo) {
+		return ErrTempoEntreAtendimentosInvalido
+	}
+	if limpeza != nil && !tempoEntreAtendimentosValido(*limpeza) {
+		return ErrTempoEntreAtendimentosInvalido
+	}
+
+	p.TempoPreparo = preparo
+	p.TempoLimpeza = limpeza
+	return nil
+}
+
+// TemposEntreAtendimentos resolve o preparo e a limpeza do serviço, priorizando o prestador
+func (p *Prestador) TemposEntreAtendimentos(catalogo *Catalogo) (time.Duration, time.Duration) {
+	preparo, limpeza := 0, 0
+	if catalogo != nil {
+		preparo, limpeza = catalogo.TempoPreparo, catalogo.TempoLimpeza
+	}
+	if p != nil && p.TempoPreparo != nil {
+		preparo = *p.TempoPreparo
+	}
+	if p != nil && p.TempoLimpeza != nil {
+		limpeza = *p.TempoLimpeza
+	}
+
+	return time.Duration(preparo) * time.Minute, time.Duration(limpeza) * time.Minute
+}
+
+// DuracaoDoServico devolve, em minutos, a duração que o prestador pratica no serviço
+func (p *Prestador) DuracaoDoServico(catalogo *Catalogo) int {
+	if c := p.condicaoDoServico(catalogo.ID); c != nil && c.DuracaoPadrao != nil {
+		return *c.DuracaoPadrao
+	}
+	return catalogo.DuracaoPadrao
+}
+
+// PrecoDoServico devolve, em centavos, o preço que o prestador cobra pelo serviço
+func (p *Prestador) PrecoDoServico(catalogo *Catalogo) int {
+	if c := p.condicaoDoServico(catalogo.ID); c != nil && c.Preco != nil {
+		return *c.Preco
+	}
+	return catalogo.Preco
+}
+
+func (p *Prestador) condicaoDoServico(catalogoID string) *CondicaoDoServico {
+	if p == nil {
+		return nil
+	}
+	for i := range p.Condicoes {
+		if p.Condicoes[i].CatalogoID == catalogoID {
+			return &p.Condicoes[i]
+		}
+	}
+	return nil
+}
+
+// Localizacao devolve o fuso do prestador, caindo para UTC quando vazio
+func (p *Prestador) Localizacao() *time.Location {
+	if p == nil || p.FusoHorario == "" {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(p.FusoHorario)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
 func (p *Prestador) AdicionarAgenda(agenda *AgendaDiaria) error {
 	if !p.Ativo {
 		return ErrPrestadorInativo
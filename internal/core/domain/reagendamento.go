@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/rs/xid"
+)
+
+// Reagendamento guarda os horários anteriores de um agendamento que foi remarcado
+type Reagendamento struct {
+	ID                     string
+	AgendamentoID          string
+	DataHoraInicioAnterior time.Time
+	DataHoraFimAnterior    time.Time
+	DataHoraInicioNova     time.Time
+	DataHoraFimNova        time.Time
+	CriadoEm               time.Time
+}
+
+// Reagendar move o agendamento para o novo período e devolve o registro da alteração
+func (a *Agendamento) Reagendar(inicio, fim time.Time) (*Reagendamento, error) {
+	if a.Status != Pendente && a.Status != Confirmado {
+		return nil, ErrAgendamentoNaoReagendavel
+	}
+
+	if !inicio.Before(fim) {
+		return nil, ErrHoraInicialMenorQueFinal
+	}
+
+	historico := &Reagendamento{
+		ID:                     xid.New().String(),
+		AgendamentoID:          a.ID,
+		DataHoraInicioAnterior: a.DataHoraInicio,
+		DataHoraFimAnterior:    a.DataHoraFim,
+		DataHoraInicioNova:     inicio,
+		DataHoraFimNova:        fim,
+		CriadoEm:               time.Now(),
+	}
+
+	// O preparo e a limpeza acompanham o atendimento
+	ocupadoInicio, ocupadoFim := a.PeriodoOcupado()
+	preparo := a.DataHoraInicio.Sub(ocupadoInicio)
+	limpeza := ocupadoFim.Sub(a.DataHoraFim)
+
+	a.DataHoraInicio = inicio
+	a.DataHoraFim = fim
+	a.BloqueioInicio = inicio.Add(-preparo)
+	a.BloqueioFim = fim.Add(limpeza)
+
+	return historico, nil
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/rs/xid"
+)
+
+const (
+	MaxIntervaloSemanasSerie = 4
+	MinOcorrenciasSerie      = 2
+	MaxOcorrenciasSerie      = 52
+)
+
+// SerieAgendamento agrupa agendamentos recorrentes (Ex: toda terça às 10:00 por 8 semanas).
+// Cada ocorrência é um Agendamento comum que aponta para a série pelo SerieID
+type SerieAgendamento struct {
+	ID               string
+	Cliente          *Cliente
+	Prestador        *Prestador
+	Catalogo         *Catalogo
+	DataHoraInicio   time.Time // início da primeira ocorrência
+	IntervaloSemanas int
+	Ocorrencias      int
+	Notas            string
+}
+
+func NovaSerieAgendamento(
+	cliente *Cliente,
+	prestador *Prestador,
+	catalogo *Catalogo,
+	dataHoraInicio time.Time,
+	intervaloSemanas int,
+	ocorrencias int,
+	notas string,
+) (*SerieAgendamento, error) {
+	if intervaloSemanas < 1 || intervaloSemanas > MaxIntervaloSemanasSerie {
+		return nil, ErrIntervaloSerieInvalido
+	}
+
+	if ocorrencias < MinOcorrenciasSerie || ocorrencias > MaxOcorrenciasSerie {
+		return nil, ErrOcorrenciasSerieInvalidas
+	}
+
+	return &SerieAgendamento{
+		ID:               xid.New().String(),
+		Cliente:          cliente,
+		Prestador:        prestador,
+		Catalogo:         catalogo,
+		DataHoraInicio:   dataHoraInicio,
+		IntervaloSemanas: intervaloSemanas,
+		Ocorrencias:      ocorrencias,
+		Notas:            notas,
+	}, nil
+}
+
+// InicioDasOcorrencias devolve o horário de início de cada ocorrência da série.
+// As semanas são somadas no fuso do prestador, mantendo o mesmo horário de
+// parede mesmo quando o deslocamento UTC muda (horário de verão)
+func (s *SerieAgendamento) InicioDasOcorrencias() []time.Time {
+	inicioLocal := s.DataHoraInicio.In(s.Prestador.Localizacao())
+	inicios := make([]time.Time, s.Ocorrencias)
+	for i := range inicios {
+		inicios[i] = inicioLocal.AddDate(0, 0, 7*s.IntervaloSemanas*i)
+	}
+	return inicios
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+// TipoSolicitacaoLGPD identifica o direito do titular exercido na solicitação
+type TipoSolicitacaoLGPD string
+
+const (
+	SolicitacaoExportacao   TipoSolicitacaoLGPD = "exportacao"
+	SolicitacaoAnonimizacao TipoSolicitacaoLGPD = "anonimizacao"
+)
+
+// MaxObservacaoSolicitacaoLGPD é o tamanho máximo, em caracteres, da observação
+const MaxObservacaoSolicitacaoLGPD = 100
+
+// ResultadoSolicitacaoLGPD diz se o pedido foi atendido, negado por falta de permissão
+// ou interrompido por um erro
+type ResultadoSolicitacaoLGPD string
+
+const (
+	SolicitacaoAtendida ResultadoSolicitacaoLGPD = "atendida"
+	SolicitacaoNegada   ResultadoSolicitacaoLGPD = "negada"
+	SolicitacaoFalhou   ResultadoSolicitacaoLGPD = "falhou"
+)
+
+// SolicitacaoLGPD registra quem pediu o acesso ou a eliminação dos dados de um cliente,
+// quando e com que resultado. UsuarioID é o usuário autenticado; Observacao é uma nota
+// livre de quem atendeu o titular
+type SolicitacaoLGPD struct {
+	ID         string
+	ClienteID  string
+	Tipo       TipoSolicitacaoLGPD
+	UsuarioID  string
+	Observacao string
+	Resultado  ResultadoSolicitacaoLGPD
+	CriadoEm   time.Time
+}
+
+// NovaSolicitacaoLGPD nasce atendida; quem a registra muda o resultado se o pedido
+// for negado ou falhar. Observações longas demais são cortadas
+func NovaSolicitacaoLGPD(clienteID string, tipo TipoSolicitacaoLGPD, usuarioID, observacao string) (*SolicitacaoLGPD, error) {
+	if usuarioID == "" {
+		return nil, ErrSolicitanteObrigatorio
+	}
+
+	observacao = strings.TrimSpace(observacao)
+	if runas := []rune(observacao); len(runas) > MaxObservacaoSolicitacaoLGPD {
+		observacao = string(runas[:MaxObservacaoSolicitacaoLGPD])
+	}
+
+	return &SolicitacaoLGPD{
+		ID:         xid.New().String(),
+		ClienteID:  clienteID,
+		Tipo:       tipo,
+		UsuarioID:  usuarioID,
+		Observacao: observacao,
+		Resultado:  SolicitacaoAtendida,
+		CriadoEm:   time.Now(),
+	}, nil
+}
@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+// Papel define o que o usuário pode fazer na API
+type Papel string
+
+const (
+	PapelAdmin     Papel = "admin"
+	PapelPrestador Papel = "prestador"
+	PapelCliente   Papel = "cliente"
+)
+
+func (p Papel) Valido() bool {
+	switch p {
+	case PapelAdmin, PapelPrestador, PapelCliente:
+		return true
+	}
+	return false
+}
+
+// Usuario é a conta de acesso à API. Contas de cliente e de prestador apontam para o
+// cadastro correspondente; contas de admin não têm vínculo
+type Usuario struct {
+	ID          string
+	Email       string
+	SenhaHash   string
+	Papel       Papel
+	ClienteID   string
+	PrestadorID string
+	Ativo       bool
+	CriadoEm    time.Time
+}
+
+func NovoUsuario(email, senhaHash string, papel Papel, clienteID, prestadorID string) (*Usuario, error) {
+	if !papel.Valido() {
+		return nil, ErrPapelInvalido
+	}
+
+	// Cada papel aceita exatamente o vínculo que lhe corresponde
+	switch {
+	case papel == PapelCliente && (clienteID == "" || prestadorID != ""),
+		papel == PapelPrestador && (prestadorID == "" || clienteID != ""),
+		papel == PapelAdmin && (clienteID != "" || prestadorID != ""):
+		return nil, ErrVinculoUsuarioInvalido
+	}
+
+	return &Usuario{
+		ID:          xid.New().String(),
+		Email:       strings.ToLower(strings.TrimSpace(email)),
+		SenhaHash:   senhaHash,
+		Papel:       papel,
+		ClienteID:   clienteID,
+		PrestadorID: prestadorID,
+		Ativo:       true,
+		CriadoEm:    time.Now().UTC(),
+	}, nil
+}
+
+// Anonimizar troca o email de login por um que não identifica o titular e desativa a
+// conta. O email usa o ID para não violar a unicidade
+func (u *Usuario) Anonimizar() {
+	u.Email = u.ID + "@anonimizado.invalid"
+	u.Ativo = false
+}
+
+// Identidade é o que a API sabe sobre quem fez a requisição, extraído do token de acesso
+type Identidade struct {
+	UsuarioID   string
+	Papel       Papel
+	ClienteID   string
+	PrestadorID string
+}
+
+func (u *Usuario) Identidade() *Identidade {
+	return &Identidade{
+		UsuarioID:   u.ID,
+		Papel:       u.Papel,
+		ClienteID:   u.ClienteID,
+		PrestadorID: u.PrestadorID,
+	}
+}
+
+// Representa indica se a identidade é o cliente ou o prestador informado
+func (i *Identidade) Representa(clienteID, prestadorID string) bool {
+	return (i.ClienteID != "" && i.ClienteID == clienteID) ||
+		(i.PrestadorID != "" && i.PrestadorID == prestadorID)
+}
+
+// RefreshToken permite obter um novo token de acesso sem informar a senha. Só o hash
+// do valor entregue ao usuário é guardado
+type RefreshToken struct {
+	ID         string
+	UsuarioID  string
+	TokenHash  string
+	CriadoEm   time.Time
+	ExpiraEm   time.Time
+	RevogadoEm *time.Time
+}
+
+func NovoRefreshToken(usuarioID, tokenHash string, ttl time.Duration) *RefreshToken {
+	agora := time.Now().UTC()
+	return &RefreshToken{
+		ID:        xid.New().String(),
+		UsuarioID: usuarioID,
+		TokenHash: tokenHash,
+		CriadoEm:  agora,
+		ExpiraEm:  agora.Add(ttl),
+	}
+}
+
+// Valido indica que o token não foi revogado e ainda não venceu
+func (t *RefreshToken) Valido(agora time.Time) bool {
+	return t.RevogadoEm == nil && agora.Before(t.ExpiraEm)
+}
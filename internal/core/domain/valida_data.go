@@ -5,25 +5,31 @@ import (
 )
 
 func ValidarDataNoPassado(data time.Time) error {
+	return ValidarDataNoPassadoEm(data, time.UTC)
+}
+
+// ValidarDataNoPassadoEm compara somente a DATA, ambas no fuso informado,
+// para que o "hoje" vire à meia-noite local do prestador e não em UTC
+func ValidarDataNoPassadoEm(data time.Time, loc *time.Location) error {
 
-	// Normaliza a data recebida para UTC (somente DATA)
-	dataUTC := data.UTC()
+	// Normaliza a data recebida para o fuso (somente DATA)
+	dataLocal := data.In(loc)
 	dataRecebida := time.Date(
-		dataUTC.Year(),
-		dataUTC.Month(),
-		dataUTC.Day(),
+		dataLocal.Year(),
+		dataLocal.Month(),
+		dataLocal.Day(),
 		0, 0, 0, 0,
-		time.UTC,
+		loc,
 	)
 
-	// Hoje em UTC (somente DATA)
-	agoraUTC := time.Now().UTC()
+	// Hoje no fuso (somente DATA)
+	agora := time.Now().In(loc)
 	hoje := time.Date(
-		agoraUTC.Year(),
-		agoraUTC.Month(),
-		agoraUTC.Day(),
+		agora.Year(),
+		agora.Month(),
+		agora.Day(),
 		0, 0, 0, 0,
-		time.UTC,
+		loc,
 	)
 
 	if dataRecebida.Before(hoje) {
@@ -32,4 +38,3 @@ func ValidarDataNoPassado(data time.Time) error {
 
 	return nil
 }
-
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/rs/xid"
+)
+
+const MaxItensVisita = 10
+
+// Visita agrupa os serviços que o cliente faz em sequência (Ex: corte, escova e manicure).
+// Cada item é um Agendamento comum que aponta para a visita pelo VisitaID
+type Visita struct {
+	ID      string
+	Cliente *Cliente
+	Itens   []*Agendamento
+	Notas   string
+}
+
+// ItemVisita é um serviço pedido para a visita e quem vai atendê-lo
+type ItemVisita struct {
+	Prestador *Prestador
+	Catalogo  *Catalogo
+}
+
+// NovaVisita encaixa os itens na ordem pedida, cada um começando quando o anterior termina.
+// Se o prestador já atendeu um item anterior da visita, o item seguinte espera a limpeza
+// daquele atendimento e o preparo do novo
+func NovaVisita(cliente *Cliente, dataHoraInicio time.Time, itens []ItemVisita, notas string) (*Visita, error) {
+	if len(itens) == 0 || len(itens) > MaxItensVisita {
+		return nil, ErrItensVisitaInvalidos
+	}
+
+	visita := &Visita{
+		ID:      xid.New().String(),
+		Cliente: cliente,
+		Itens:   make([]*Agendamento, 0, len(itens)),
+		Notas:   notas,
+	}
+
+	servicos := make(map[string]bool, len(itens))
+	livreEm := make(map[string]time.Time)
+	inicio := dataHoraInicio
+
+	for _, item := range itens {
+		if servicos[item.Catalogo.ID] {
+			return nil, ErrServicoRepetidoNaVisita
+		}
+		servicos[item.Catalogo.ID] = true
+
+		preparo, _ := item.Prestador.TemposEntreAtendimentos(item.Catalogo)
+		if livre, ok := livreEm[item.Prestador.ID]; ok && inicio.Add(-preparo).Before(livre) {
+			inicio = livre.Add(preparo)
+		}
+
+		fim := inicio.Add(time.Duration(item.Prestador.DuracaoDoServico(item.Catalogo)) * time.Minute)
+
+		agendamento, err := NovoAgendamento(cliente, item.Prestador, item.Catalogo, inicio, fim, notas)
+		if err != nil {
+			return nil, err
+		}
+		agendamento.VisitaID = visita.ID
+		visita.Itens = append(visita.Itens, agendamento)
+
+		_, livreEm[item.Prestador.ID] = agendamento.PeriodoOcupado()
+		inicio = fim
+	}
+
+	return visita, nil
+}
+
+// DataHoraInicio é o início do primeiro serviço da visita
+func (v *Visita) DataHoraInicio() time.Time {
+	if len(v.Itens) == 0 {
+		return time.Time{}
+	}
+	return v.Itens[0].DataHoraInicio
+}
+
+// DataHoraFim é o fim do último serviço da visita
+func (v *Visita) DataHoraFim() time.Time {
+	if len(v.Itens) == 0 {
+		return time.Time{}
+	}
+	return v.Itens[len(v.Itens)-1].DataHoraFim
+}
+
+// PrecoTotal soma, em centavos, o preço contratado de cada serviço
+func (v *Visita) PrecoTotal() int {
+	total := 0
+	for _, item := range v.Itens {
+		total += item.Servico.Preco
+	}
+	return total
+}
+
+// DuracaoTotal é o tempo, em minutos, que o cliente passa na visita, incluindo
+// a espera entre serviços do mesmo prestador
+func (v *Visita) DuracaoTotal() int {
+	return int(v.DataHoraFim().Sub(v.DataHoraInicio()) / time.Minute)
+}
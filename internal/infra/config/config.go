@@ -0,0 +1,293 @@
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config reúne tudo o que muda entre ambientes. Os valores vêm das variáveis de
+// ambiente e, opcionalmente, de um arquivo CHAVE=valor indicado em CONFIG_FILE;
+// a variável de ambiente vence o arquivo
+type Config struct {
+	Database  Database
+	HTTP      HTTP
+	Auth      Auth
+	Paginacao Paginacao
+	// LogLevel aceita debug, info, warn ou error
+	LogLevel        string
+	IdempotenciaTTL time.Duration
+}
+
+type Database struct {
+	DSN             string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	// MigrarAoIniciar aplica as migrações pendentes antes de a API subir
+	MigrarAoIniciar bool
+}
+
+type HTTP struct {
+	Addr            string
+	ModoGin         string
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	IdleTimeout     time.Duration
+	ShutdownTimeout time.Duration
+	// RequestTimeout é o prazo de cada requisição, repassado até as consultas
+	// ao banco; precisa ser menor que o WriteTimeout para a resposta de erro sair
+	RequestTimeout time.Duration
+}
+
+type Auth struct {
+	JWTSegredo      string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	// AdminEmail e AdminSenha criam o primeiro admin quando ainda não existe nenhum
+	AdminEmail string
+	AdminSenha string
+}
+
+type Paginacao struct {
+	LimitePadrao int
+	LimiteMaximo int
+}
+
+// TamanhoMinimoSegredoJWT garante 256 bits para a assinatura HS256
+const TamanhoMinimoSegredoJWT = 32
+
+// Carregar lê a configuração e devolve todos os valores inválidos de uma vez
+func Carregar() (*Config, error) {
+	l, err := novoLeitor()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
+		Database: l.database(),
+		HTTP: HTTP{
+			Addr:            l.texto("HTTP_ADDR", ":8080"),
+			ModoGin:         l.texto("GIN_MODE", "release"),
+			ReadTimeout:     l.duracao("HTTP_READ_TIMEOUT", 10*time.Second),
+			WriteTimeout:    l.duracao("HTTP_WRITE_TIMEOUT", 30*time.Second),
+			IdleTimeout:     l.duracao("HTTP_IDLE_TIMEOUT", 60*time.Second),
+			ShutdownTimeout: l.duracao("HTTP_SHUTDOWN_TIMEOUT", 15*time.Second),
+			RequestTimeout:  l.duracao("HTTP_REQUEST_TIMEOUT", 10*time.Second),
+		},
+		Auth: Auth{
+			JWTSegredo:      l.texto("JWT_SEGREDO", ""),
+			AccessTokenTTL:  l.duracao("ACCESS_TOKEN_TTL", 15*time.Minute),
+			RefreshTokenTTL: l.duracao("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+			AdminEmail:      l.texto("ADMIN_EMAIL", ""),
+			AdminSenha:      l.texto("ADMIN_SENHA", ""),
+		},
+		Paginacao: Paginacao{
+			LimitePadrao: l.inteiro("PAGINACAO_LIMITE_PADRAO", 10),
+			LimiteMaximo: l.inteiro("PAGINACAO_LIMITE_MAXIMO", 100),
+		},
+		LogLevel:        strings.ToLower(l.texto("LOG_LEVEL", "info")),
+		IdempotenciaTTL: l.duracao("IDEMPOTENCIA_TTL", 24*time.Hour),
+	}
+
+	cfg.validar(l)
+
+	if err := l.resultado(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// CarregarDatabase lê só a parte do banco, para comandos como o migrate que
+// não precisam do restante da configuração da API
+func CarregarDatabase() (*Database, error) {
+	l, err := novoLeitor()
+	if err != nil {
+		return nil, err
+	}
+
+	db := l.database()
+	db.validar(l)
+
+	if err := l.resultado(); err != nil {
+		return nil, err
+	}
+	return &db, nil
+}
+
+func (l *leitor) database() Database {
+	return Database{
+		DSN:             l.texto("DATABASE_URL", "host=localhost port=5432 user=postgres password=postgres dbname=annygo sslmode=disable"),
+		MaxOpenConns:    l.inteiro("DB_MAX_OPEN_CONNS", 25),
+		MaxIdleConns:    l.inteiro("DB_MAX_IDLE_CONNS", 5),
+		ConnMaxLifetime: l.duracao("DB_CONN_MAX_LIFETIME", 30*time.Minute),
+		MigrarAoIniciar: l.booleano("DB_MIGRAR_AO_INICIAR", true),
+	}
+}
+
+func (d Database) validar(l *leitor) {
+	if d.DSN == "" {
+		l.invalido("DATABASE_URL", "não pode ser vazio")
+	}
+	if d.MaxOpenConns < 1 {
+		l.invalido("DB_MAX_OPEN_CONNS", "deve ser maior que zero")
+	}
+	if d.MaxIdleConns < 0 || d.MaxIdleConns > d.MaxOpenConns {
+		l.invalido("DB_MAX_IDLE_CONNS", "deve ser de 0 até DB_MAX_OPEN_CONNS")
+	}
+}
+
+func (c *Config) validar(l *leitor) {
+	c.Database.validar(l)
+
+	if c.HTTP.Addr == "" {
+		l.invalido("HTTP_ADDR", "não pode ser vazio")
+	}
+	switch c.HTTP.ModoGin {
+	case "debug", "release", "test":
+	default:
+		l.invalido("GIN_MODE", "use debug, release ou test")
+	}
+	if c.HTTP.RequestTimeout >= c.HTTP.WriteTimeout {
+		l.invalido("HTTP_REQUEST_TIMEOUT", "deve ser menor que HTTP_WRITE_TIMEOUT")
+	}
+
+	if len(c.Auth.JWTSegredo) < TamanhoMinimoSegredoJWT {
+		l.invalido("JWT_SEGREDO", fmt.Sprintf("é obrigatório e deve ter ao menos %d caracteres", TamanhoMinimoSegredoJWT))
+	}
+	if (c.Auth.AdminEmail == "") != (c.Auth.AdminSenha == "") {
+		l.invalido("ADMIN_EMAIL", "informe ADMIN_EMAIL e ADMIN_SENHA juntos")
+	}
+
+	if c.Paginacao.LimitePadrao < 1 {
+		l.invalido("PAGINACAO_LIMITE_PADRAO", "deve ser maior que zero")
+	}
+	if c.Paginacao.LimiteMaximo < c.Paginacao.LimitePadrao {
+		l.invalido("PAGINACAO_LIMITE_MAXIMO", "não pode ser menor que PAGINACAO_LIMITE_PADRAO")
+	}
+
+	switch c.LogLevel {
+	case "debug", "info", "warn", "error":
+	default:
+		l.invalido("LOG_LEVEL", "use debug, info, warn ou error")
+	}
+}
+
+// leitor busca cada chave no ambiente e depois no arquivo, acumulando os erros
+type leitor struct {
+	arquivo map[string]string
+	erros   []error
+}
+
+func novoLeitor() (*leitor, error) {
+	l := &leitor{arquivo: map[string]string{}}
+
+	if caminho := os.Getenv("CONFIG_FILE"); caminho != "" {
+		arquivo, err := lerArquivo(caminho)
+		if err != nil {
+			return nil, err
+		}
+		l.arquivo = arquivo
+	}
+	return l, nil
+}
+
+func (l *leitor) resultado() error {
+	if len(l.erros) > 0 {
+		return fmt.Errorf("configuração inválida:\n%w", errors.Join(l.erros...))
+	}
+	return nil
+}
+
+func (l *leitor) valor(chave string) (string, bool) {
+	if v, ok := os.LookupEnv(chave); ok {
+		return strings.TrimSpace(v), true
+	}
+	v, ok := l.arquivo[chave]
+	return v, ok
+}
+
+func (l *leitor) invalido(chave, motivo string) {
+	l.erros = append(l.erros, fmt.Errorf("%s %s", chave, motivo))
+}
+
+func (l *leitor) texto(chave, padrao string) string {
+	if v, ok := l.valor(chave); ok {
+		return v
+	}
+	return padrao
+}
+
+func (l *leitor) inteiro(chave string, padrao int) int {
+	v, ok := l.valor(chave)
+	if !ok {
+		return padrao
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		l.invalido(chave, fmt.Sprintf("deve ser um número inteiro, recebido %q", v))
+		return padrao
+	}
+	return n
+}
+
+// booleano aceita os formatos de strconv.ParseBool (Ex: true, false, 1, 0)
+func (l *leitor) booleano(chave string, padrao bool) bool {
+	v, ok := l.valor(chave)
+	if !ok {
+		return padrao
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		l.invalido(chave, fmt.Sprintf("deve ser true ou false, recebido %q", v))
+		return padrao
+	}
+	return b
+}
+
+// duracao aceita o formato de time.ParseDuration (Ex: 30s, 15m, 720h)
+func (l *leitor) duracao(chave string, padrao time.Duration) time.Duration {
+	v, ok := l.valor(chave)
+	if !ok {
+		return padrao
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		l.invalido(chave, fmt.Sprintf("deve ser uma duração positiva como 30s ou 15m, recebido %q", v))
+		return padrao
+	}
+	return d
+}
+
+// lerArquivo lê linhas CHAVE=valor; linhas vazias e iniciadas por # são ignoradas
+func lerArquivo(caminho string) (map[string]string, error) {
+	f, err := os.Open(caminho)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir CONFIG_FILE: %w", err)
+	}
+	defer f.Close()
+
+	valores := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for numero := 1; scanner.Scan(); numero++ {
+		linha := strings.TrimSpace(scanner.Text())
+		if linha == "" || strings.HasPrefix(linha, "#") {
+			continue
+		}
+
+		chave, valor, ok := strings.Cut(linha, "=")
+		if !ok {
+			return nil, fmt.Errorf("CONFIG_FILE linha %d: use o formato CHAVE=valor", numero)
+		}
+		valores[strings.TrimSpace(chave)] = strings.Trim(strings.TrimSpace(valor), `"`)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("erro ao ler CONFIG_FILE: %w", err)
+	}
+	return valores, nil
+}
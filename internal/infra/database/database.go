@@ -2,23 +2,28 @@ package database
 
 import (
 	"database/sql"
-	"log"
+	"log/slog"
+
+	"meu-servico-agenda/internal/infra/config"
 
 	_ "github.com/lib/pq"
 )
 
-func Connect() (*sql.DB, error) {
-	db, err := sql.Open("postgres", "host=localhost port=5432 user=postgres password=postgres dbname=annygo sslmode=disable")
+func Connect(cfg config.Database) (*sql.DB, error) {
+	db, err := sql.Open("postgres", cfg.DSN)
 	if err != nil {
 		return nil, err
 	}
 
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
 	if err := db.Ping(); err != nil {
 		db.Close()
-		log.Println("❌ erro ao conectar no banco")
 		return nil, err
 	}
 
-	log.Println("✅ conexão com o banco realizada com sucesso")
+	slog.Info("conexão com o banco realizada com sucesso")
 	return db, nil
 }
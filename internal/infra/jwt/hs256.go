@@ -0,0 +1,96 @@
+package jwt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/domain"
+)
+
+var errTokenInvalido = errors.New("token malformado, com assinatura inválida ou vencido")
+
+// cabecalho é fixo: só aceitamos tokens HS256 emitidos por esta API
+var cabecalho = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+type claims struct {
+	Sub         string       `json:"sub"`
+	Papel       domain.Papel `json:"papel"`
+	ClienteID   string       `json:"cliente_id,omitempty"`
+	PrestadorID string       `json:"prestador_id,omitempty"`
+	Iat         int64        `json:"iat"`
+	Exp         int64        `json:"exp"`
+}
+
+type EmissorHS256 struct {
+	segredo []byte
+	ttl     time.Duration
+}
+
+func NovoEmissorHS256(segredo []byte, ttl time.Duration) port.EmissorToken {
+	return &EmissorHS256{segredo: segredo, ttl: ttl}
+}
+
+func (e *EmissorHS256) Emitir(identidade *domain.Identidade) (string, time.Time, error) {
+	agora := time.Now()
+	expiraEm := agora.Add(e.ttl)
+
+	payload, err := json.Marshal(claims{
+		Sub:         identidade.UsuarioID,
+		Papel:       identidade.Papel,
+		ClienteID:   identidade.ClienteID,
+		PrestadorID: identidade.PrestadorID,
+		Iat:         agora.Unix(),
+		Exp:         expiraEm.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	conteudo := cabecalho + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return conteudo + "." + e.assinar(conteudo), expiraEm, nil
+}
+
+func (e *EmissorHS256) Validar(token string) (*domain.Identidade, error) {
+	partes := strings.Split(token, ".")
+	if len(partes) != 3 || partes[0] != cabecalho {
+		return nil, errTokenInvalido
+	}
+
+	esperada := e.assinar(partes[0] + "." + partes[1])
+	if !hmac.Equal([]byte(esperada), []byte(partes[2])) {
+		return nil, errTokenInvalido
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(partes[1])
+	if err != nil {
+		return nil, errTokenInvalido
+	}
+
+	var c claims
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return nil, errTokenInvalido
+	}
+
+	if c.Sub == "" || !c.Papel.Valido() || time.Now().Unix() >= c.Exp {
+		return nil, errTokenInvalido
+	}
+
+	return &domain.Identidade{
+		UsuarioID:   c.Sub,
+		Papel:       c.Papel,
+		ClienteID:   c.ClienteID,
+		PrestadorID: c.PrestadorID,
+	}, nil
+}
+
+func (e *EmissorHS256) assinar(conteudo string) string {
+	mac := hmac.New(sha256.New, e.segredo)
+	mac.Write([]byte(conteudo))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
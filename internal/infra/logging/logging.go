@@ -0,0 +1,60 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+type chaveRequestID struct{}
+
+// ComRequestID guarda o id da requisição no contexto; todo log feito com esse
+// contexto (slog.InfoContext, slog.ErrorContext...) sai com o campo request_id
+func ComRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, chaveRequestID{}, id)
+}
+
+// RequestID devolve o id guardado por ComRequestID ou vazio
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(chaveRequestID{}).(string)
+	return id
+}
+
+// Configurar instala um logger JSON no nível informado (debug, info, warn ou error)
+// como padrão do slog. Chamadas ao pacote log passam a sair pelo mesmo logger
+func Configurar(w io.Writer, nivel string) *slog.Logger {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.ToUpper(nivel))); err != nil {
+		level = slog.LevelInfo
+	}
+
+	logger := slog.New(handlerComRequestID{
+		Handler: slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}),
+	})
+	slog.SetDefault(logger)
+	return logger
+}
+
+// handlerComRequestID acrescenta o request_id do contexto a cada registro
+type handlerComRequestID struct {
+	slog.Handler
+}
+
+func (h handlerComRequestID) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h handlerComRequestID) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return handlerComRequestID{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h handlerComRequestID) WithGroup(nome string) slog.Handler {
+	return handlerComRequestID{Handler: h.Handler.WithGroup(nome)}
+}
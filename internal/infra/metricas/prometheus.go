@@ -0,0 +1,88 @@
+package metricas
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Prometheus guarda as métricas da API num registro próprio, servido em /metrics
+type Prometheus struct {
+	registro    *prometheus.Registry
+	requisicoes *prometheus.CounterVec
+	latencia    *prometheus.HistogramVec
+	criados     prometheus.Counter
+	rejeitados  *prometheus.CounterVec
+	cancelados  prometheus.Counter
+}
+
+// NovoPrometheus registra as métricas HTTP, de negócio, do runtime do Go e do
+// pool de conexões de db
+func NovoPrometheus(db *sql.DB) *Prometheus {
+	p := &Prometheus{
+		registro: prometheus.NewRegistry(),
+		requisicoes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Requisições HTTP atendidas, por método, rota e status.",
+		}, []string{"method", "route", "status"}),
+		latencia: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Tempo de resposta das requisições HTTP, por método e rota.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		criados: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "agendamentos_criados_total",
+			Help: "Agendamentos criados, incluindo ocorrências de séries e itens de visitas.",
+		}),
+		rejeitados: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "agendamentos_rejeitados_total",
+			Help: "Agendamentos recusados por regra de negócio, por motivo.",
+		}, []string{"motivo"}),
+		cancelados: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "agendamentos_cancelados_total",
+			Help: "Agendamentos cancelados.",
+		}),
+	}
+
+	p.registro.MustRegister(
+		p.requisicoes,
+		p.latencia,
+		p.criados,
+		p.rejeitados,
+		p.cancelados,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	if db != nil {
+		p.registro.MustRegister(collectors.NewDBStatsCollector(db, "annygo"))
+	}
+
+	return p
+}
+
+// Handler expõe as métricas no formato de texto do Prometheus
+func (p *Prometheus) Handler() http.Handler {
+	return promhttp.HandlerFor(p.registro, promhttp.HandlerOpts{Registry: p.registro})
+}
+
+func (p *Prometheus) RequisicaoAtendida(metodo, rota string, status int, duracao time.Duration) {
+	p.requisicoes.WithLabelValues(metodo, rota, strconv.Itoa(status)).Inc()
+	p.latencia.WithLabelValues(metodo, rota).Observe(duracao.Seconds())
+}
+
+func (p *Prometheus) AgendamentoCriado() {
+	p.criados.Inc()
+}
+
+func (p *Prometheus) AgendamentoRejeitado(motivo string) {
+	p.rejeitados.WithLabelValues(motivo).Inc()
+}
+
+func (p *Prometheus) AgendamentoCancelado() {
+	p.cancelados.Inc()
+}
@@ -0,0 +1,200 @@
+package migracao
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNomeScriptInvalido  = errors.New("nome de script de migração inválido")
+	ErrVersaoDuplicada     = errors.New("versão de migração duplicada")
+	ErrChecksumDivergente  = errors.New("script de migração alterado depois de aplicado")
+	ErrMigracaoFalhou      = errors.New("migração falhou anteriormente e precisa de correção manual")
+	ErrMigracaoAusente     = errors.New("migração aplicada no banco não existe no binário")
+	ErrMigracaoForaDeOrdem = errors.New("migração pendente com versão anterior à última aplicada")
+)
+
+// Migracao é um script versionado no padrão do Flyway: V<versao>__<descricao>.sql
+type Migracao struct {
+	Versao    string
+	Descricao string
+	Script    string
+	Checksum  int32
+	SQL       string
+}
+
+// Aplicada é uma linha da tabela de histórico do Flyway. Versao fica vazia nas
+// linhas sem versão, como as de criação de schema e as migrações repetíveis
+type Aplicada struct {
+	Rank        int
+	Versao      string
+	Descricao   string
+	Tipo        string
+	Script      string
+	Checksum    *int32
+	InstaladaEm time.Time
+	Sucesso     bool
+}
+
+const (
+	TipoSQL      = "SQL"
+	TipoBaseline = "BASELINE"
+)
+
+var nomeScript = regexp.MustCompile(`^V(\d+(?:[._]\d+)*)__(.+)\.sql$`)
+
+// Ler carrega e ordena por versão todos os scripts .sql de dir
+func Ler(fsys fs.FS, dir string) ([]Migracao, error) {
+	entradas, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler scripts de migração: %w", err)
+	}
+
+	var migracoes []Migracao
+	vistas := map[string]string{}
+	for _, e := range entradas {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".sql") {
+			continue
+		}
+
+		partes := nomeScript.FindStringSubmatch(e.Name())
+		if partes == nil {
+			return nil, fmt.Errorf("%w: %s", ErrNomeScriptInvalido, e.Name())
+		}
+
+		versao := strings.ReplaceAll(partes[1], "_", ".")
+		if outro, ok := vistas[normalizarVersao(versao)]; ok {
+			return nil, fmt.Errorf("%w: %s e %s", ErrVersaoDuplicada, outro, e.Name())
+		}
+		vistas[normalizarVersao(versao)] = e.Name()
+
+		conteudo, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler %s: %w", e.Name(), err)
+		}
+
+		migracoes = append(migracoes, Migracao{
+			Versao:    versao,
+			Descricao: strings.ReplaceAll(partes[2], "_", " "),
+			Script:    e.Name(),
+			Checksum:  Checksum(conteudo),
+			SQL:       string(conteudo),
+		})
+	}
+
+	sort.Slice(migracoes, func(i, j int) bool {
+		return CompararVersoes(migracoes[i].Versao, migracoes[j].Versao) < 0
+	})
+	return migracoes, nil
+}
+
+// Checksum calcula o CRC32 do mesmo jeito que o Flyway: linha a linha, sem as
+// quebras de linha e sem o BOM, para que os valores batam com o histórico existente
+func Checksum(conteudo []byte) int32 {
+	conteudo = bytes.TrimPrefix(conteudo, []byte("\xef\xbb\xbf"))
+
+	crc := crc32.NewIEEE()
+	scanner := bufio.NewScanner(bytes.NewReader(conteudo))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(conteudo)+1)
+	for scanner.Scan() {
+		crc.Write(bytes.TrimSuffix(scanner.Bytes(), []byte("\r")))
+	}
+	return int32(crc.Sum32())
+}
+
+// CompararVersoes compara versões numericamente por segmento (1.10 > 1.9)
+func CompararVersoes(a, b string) int {
+	pa, pb := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var na, nb int
+		if i < len(pa) {
+			na, _ = strconv.Atoi(pa[i])
+		}
+		if i < len(pb) {
+			nb, _ = strconv.Atoi(pb[i])
+		}
+		if na != nb {
+			if na < nb {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// normalizarVersao faz 1, 1.0 e 01 serem a mesma versão
+func normalizarVersao(v string) string {
+	partes := strings.Split(v, ".")
+	for i, p := range partes {
+		n, _ := strconv.Atoi(p)
+		partes[i] = strconv.Itoa(n)
+	}
+	for len(partes) > 1 && partes[len(partes)-1] == "0" {
+		partes = partes[:len(partes)-1]
+	}
+	return strings.Join(partes, ".")
+}
+
+// Planejar confronta os scripts do binário com o histórico do banco e devolve
+// as migrações pendentes. Assim como o validate do Flyway, recusa histórico com
+// falha, script alterado, script removido e pendência fora de ordem
+func Planejar(locais []Migracao, aplicadas []Aplicada) ([]Migracao, error) {
+	porVersao := make(map[string]Migracao, len(locais))
+	for _, m := range locais {
+		porVersao[normalizarVersao(m.Versao)] = m
+	}
+
+	var baseline, ultima string
+	jaAplicadas := map[string]bool{}
+	for _, a := range aplicadas {
+		if !a.Sucesso {
+			return nil, fmt.Errorf("%w: V%s %s", ErrMigracaoFalhou, a.Versao, a.Descricao)
+		}
+		if a.Versao == "" {
+			continue
+		}
+
+		if a.Tipo == TipoBaseline {
+			baseline = a.Versao
+		} else {
+			local, ok := porVersao[normalizarVersao(a.Versao)]
+			if !ok {
+				return nil, fmt.Errorf("%w: V%s %s", ErrMigracaoAusente, a.Versao, a.Descricao)
+			}
+			if a.Checksum != nil && *a.Checksum != local.Checksum {
+				return nil, fmt.Errorf("%w: %s (banco %d, binário %d)", ErrChecksumDivergente, local.Script, *a.Checksum, local.Checksum)
+			}
+		}
+
+		jaAplicadas[normalizarVersao(a.Versao)] = true
+		if ultima == "" || CompararVersoes(a.Versao, ultima) > 0 {
+			ultima = a.Versao
+		}
+	}
+
+	var pendentes []Migracao
+	for _, m := range locais {
+		if jaAplicadas[normalizarVersao(m.Versao)] {
+			continue
+		}
+		if baseline != "" && CompararVersoes(m.Versao, baseline) <= 0 {
+			continue
+		}
+		if ultima != "" && CompararVersoes(m.Versao, ultima) < 0 {
+			return nil, fmt.Errorf("%w: %s (última aplicada V%s)", ErrMigracaoForaDeOrdem, m.Script, ultima)
+		}
+		pendentes = append(pendentes, m)
+	}
+	return pendentes, nil
+}
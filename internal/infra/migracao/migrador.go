@@ -0,0 +1,245 @@
+package migracao
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// TabelaHistorico é a mesma tabela usada pelo Flyway, então bancos migrados
+// pelo container continuam válidos e vice-versa
+const TabelaHistorico = "flyway_schema_history"
+
+// chaveLock evita que duas instâncias da API migrem o banco ao mesmo tempo
+const chaveLock int64 = 7_311_018
+
+const descricaoBaseline = "<< Flyway Baseline >>"
+
+// Situacao é uma migração do binário acompanhada do que o banco sabe dela
+type Situacao struct {
+	Migracao
+	// Estado é aplicada, pendente ou baseline
+	Estado     string
+	AplicadaEm *time.Time
+}
+
+type Migrador struct {
+	db        *sql.DB
+	migracoes []Migracao
+}
+
+func NovoMigrador(db *sql.DB, migracoes []Migracao) *Migrador {
+	return &Migrador{
+		db:        db,
+		migracoes: migracoes,
+	}
+}
+
+// Aplicar executa as migrações pendentes, cada uma na própria transação junto
+// com sua linha no histórico. Com simular, só devolve o que seria executado
+func (m *Migrador) Aplicar(simular bool) ([]Migracao, error) {
+	ctx := context.Background()
+
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, chaveLock); err != nil {
+		return nil, fmt.Errorf("erro ao obter lock de migração: %w", err)
+	}
+	defer conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, chaveLock)
+
+	aplicadas, err := m.prepararHistorico(ctx, conn, simular)
+	if err != nil {
+		return nil, err
+	}
+
+	pendentes, err := Planejar(m.migracoes, aplicadas)
+	if err != nil || simular {
+		return pendentes, err
+	}
+
+	rank := 0
+	for _, a := range aplicadas {
+		rank = max(rank, a.Rank)
+	}
+
+	for i, p := range pendentes {
+		if err := m.executar(ctx, conn, p, rank+i+1); err != nil {
+			return pendentes[:i], err
+		}
+	}
+	return pendentes, nil
+}
+
+// Status lista todas as migrações do binário com o estado de cada uma no banco
+func (m *Migrador) Status() ([]Situacao, error) {
+	ctx := context.Background()
+
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	aplicadas, err := m.prepararHistorico(ctx, conn, true)
+	if err != nil {
+		return nil, err
+	}
+
+	pendentes, err := Planejar(m.migracoes, aplicadas)
+	if err != nil {
+		return nil, err
+	}
+
+	instaladas := map[string]time.Time{}
+	for _, a := range aplicadas {
+		if a.Versao != "" {
+			instaladas[normalizarVersao(a.Versao)] = a.InstaladaEm
+		}
+	}
+	ehPendente := map[string]bool{}
+	for _, p := range pendentes {
+		ehPendente[p.Script] = true
+	}
+
+	situacoes := make([]Situacao, 0, len(m.migracoes))
+	for _, mig := range m.migracoes {
+		s := Situacao{Migracao: mig, Estado: "baseline"}
+		if quando, ok := instaladas[normalizarVersao(mig.Versao)]; ok {
+			s.Estado = "aplicada"
+			s.AplicadaEm = &quando
+		} else if ehPendente[mig.Script] {
+			s.Estado = "pendente"
+		}
+		situacoes = append(situacoes, s)
+	}
+	return situacoes, nil
+}
+
+// prepararHistorico cria a tabela de histórico quando ela não existe. Um banco
+// que já tem tabelas mas nunca viu o Flyway recebe a baseline na versão 1,
+// como faz o baselineOnMigrate de flyway.conf. Em simulação nada é gravado
+func (m *Migrador) prepararHistorico(ctx context.Context, conn *sql.Conn, simular bool) ([]Aplicada, error) {
+	var existe bool
+	if err := conn.QueryRowContext(ctx,
+		`SELECT to_regclass($1) IS NOT NULL`, TabelaHistorico,
+	).Scan(&existe); err != nil {
+		return nil, fmt.Errorf("erro ao consultar histórico de migrações: %w", err)
+	}
+	if existe {
+		return lerHistorico(ctx, conn)
+	}
+
+	var schemaComTabelas bool
+	if err := conn.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM information_schema.tables
+			WHERE table_schema = current_schema()
+		)`,
+	).Scan(&schemaComTabelas); err != nil {
+		return nil, fmt.Errorf("erro ao inspecionar o schema: %w", err)
+	}
+
+	var historico []Aplicada
+	if schemaComTabelas {
+		historico = append(historico, Aplicada{
+			Rank:        1,
+			Versao:      "1",
+			Descricao:   descricaoBaseline,
+			Tipo:        TipoBaseline,
+			Script:      descricaoBaseline,
+			InstaladaEm: time.Now(),
+			Sucesso:     true,
+		})
+	}
+	if simular {
+		return historico, nil
+	}
+
+	if _, err := conn.ExecContext(ctx, `
+		CREATE TABLE `+TabelaHistorico+` (
+			installed_rank INT NOT NULL,
+			version VARCHAR(50),
+			description VARCHAR(200) NOT NULL,
+			type VARCHAR(20) NOT NULL,
+			script VARCHAR(1000) NOT NULL,
+			checksum INTEGER,
+			installed_by VARCHAR(100) NOT NULL,
+			installed_on TIMESTAMP NOT NULL DEFAULT now(),
+			execution_time INTEGER NOT NULL,
+			success BOOLEAN NOT NULL,
+			CONSTRAINT `+TabelaHistorico+`_pk PRIMARY KEY (installed_rank)
+		);
+		CREATE INDEX `+TabelaHistorico+`_s_idx ON `+TabelaHistorico+` (success);`,
+	); err != nil {
+		return nil, fmt.Errorf("erro ao criar histórico de migrações: %w", err)
+	}
+
+	for _, b := range historico {
+		if _, err := conn.ExecContext(ctx, `
+			INSERT INTO `+TabelaHistorico+`
+				(installed_rank, version, description, type, script, checksum, installed_by, execution_time, success)
+			VALUES ($1, $2, $3, $4, $5, NULL, current_user, 0, true)`,
+			b.Rank, b.Versao, b.Descricao, b.Tipo, b.Script,
+		); err != nil {
+			return nil, fmt.Errorf("erro ao registrar baseline: %w", err)
+		}
+	}
+	return historico, nil
+}
+
+func (m *Migrador) executar(ctx context.Context, conn *sql.Conn, mig Migracao, rank int) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	inicio := time.Now()
+	if _, err := tx.ExecContext(ctx, mig.SQL); err != nil {
+		return fmt.Errorf("erro ao aplicar %s: %w", mig.Script, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO `+TabelaHistorico+`
+			(installed_rank, version, description, type, script, checksum, installed_by, execution_time, success)
+		VALUES ($1, $2, $3, $4, $5, $6, current_user, $7, true)`,
+		rank, mig.Versao, mig.Descricao, TipoSQL, mig.Script, mig.Checksum, time.Since(inicio).Milliseconds(),
+	); err != nil {
+		return fmt.Errorf("erro ao registrar %s no histórico: %w", mig.Script, err)
+	}
+
+	return tx.Commit()
+}
+
+func lerHistorico(ctx context.Context, conn *sql.Conn) ([]Aplicada, error) {
+	rows, err := conn.QueryContext(ctx, `
+		SELECT installed_rank, version, description, type, script, checksum, installed_on, success
+		FROM `+TabelaHistorico+`
+		ORDER BY installed_rank`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler histórico de migrações: %w", err)
+	}
+	defer rows.Close()
+
+	var historico []Aplicada
+	for rows.Next() {
+		var a Aplicada
+		var versao sql.NullString
+		var checksum sql.NullInt32
+		if err := rows.Scan(&a.Rank, &versao, &a.Descricao, &a.Tipo, &a.Script, &checksum, &a.InstaladaEm, &a.Sucesso); err != nil {
+			return nil, err
+		}
+		a.Versao = versao.String
+		if checksum.Valid {
+			a.Checksum = &checksum.Int32
+		}
+		historico = append(historico, a)
+	}
+	return historico, rows.Err()
+}
@@ -0,0 +1,68 @@
+package teste
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"meu-servico-agenda/internal/adapters/http/agendamento/request_agendamento"
+	"meu-servico-agenda/internal/core/application/service"
+	"meu-servico-agenda/internal/core/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostAgendamento_ConcorrenteMesmoHorario(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+	agendaDiaria := SetupCriaAgendaDiaria(agendaDiariaRepo)
+	prestador.AdicionarAgenda(agendaDiaria)
+
+	// clientes diferentes para que apenas o conflito do prestador esteja em jogo
+	const total = 20
+	inputs := make([]request_agendamento.AgendamentoRequest, total)
+	for i := range inputs {
+		cli, _ := domain.NovoCliente(fmt.Sprintf("Cliente %d", i), fmt.Sprintf("cliente%d@gmail.com", i), "62999697581")
+		clienteRepo.Salvar(context.Background(), cli)
+
+		inputs[i] = request_agendamento.AgendamentoRequest{
+			ClienteID:      cli.ID,
+			PrestadorID:    prestador.ID,
+			CatalogoID:     catalogo.ID,
+			DataHoraInicio: "2030-01-03T08:00:00Z",
+		}
+	}
+
+	codigos := make([]int, total)
+	corpos := make([]string, total)
+
+	var wg sync.WaitGroup
+	inicio := make(chan struct{})
+	for i := range inputs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-inicio
+			rr := SetupPostAgendamentoRequest(router, inputs[i])
+			codigos[i] = rr.Code
+			corpos[i] = rr.Body.String()
+		}(i)
+	}
+	close(inicio)
+	wg.Wait()
+
+	criados := 0
+	for i, codigo := range codigos {
+		if codigo == http.StatusCreated {
+			criados++
+			continue
+		}
+		require.Equal(t, http.StatusConflict, codigo)
+		require.Contains(t, corpos[i], service.ErrPrestadorOcupado.Error())
+	}
+	require.Equal(t, 1, criados)
+}
@@ -0,0 +1,87 @@
+package teste
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"meu-servico-agenda/internal/adapters/http/agendamento/request_agendamento"
+	"meu-servico-agenda/internal/adapters/http/agendamento/response_agendamento"
+	"meu-servico-agenda/internal/adapters/http/prestador/request_prestador"
+	"meu-servico-agenda/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// SetupDuracaoDoPrestador faz o prestador atender o serviço com a duração informada
+func SetupDuracaoDoPrestador(t *testing.T, router *gin.Engine, prestador *domain.Prestador, catalogoID string, duracao int) {
+	body, _ := json.Marshal(request_prestador.PrestadorUpdateRequest{
+		Nome:        prestador.Nome,
+		Telefone:    prestador.Telefone,
+		ImagemUrl:   prestador.ImagemUrl,
+		CatalogoIDs: []string{catalogoID},
+		Condicoes: []request_prestador.CondicaoDoServicoRequest{
+			{CatalogoID: catalogoID, DuracaoPadrao: &duracao},
+		},
+	})
+
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/prestadores/"+prestador.ID, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNoContent, rr.Code)
+}
+
+func TestCondicoesDoServico_AgendamentoUsaDuracaoDoPrestador(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+	SetupAgendaNasDatas(agendaDiariaRepo, prestador, "2030-01-03")
+	SetupDuracaoDoPrestador(t, router, prestador, catalogo.ID, 90)
+
+	rr := SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+		ClienteID:      SetupNovoCliente(clienteRepo).ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T09:00:00Z",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var agendamento response_agendamento.AgendamentoResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &agendamento))
+	require.True(t, agendamento.DataFim.Equal(time.Date(2030, 1, 3, 10, 30, 0, 0, time.UTC)))
+
+	// 10:00 estaria livre com a duração do catálogo, mas o prestador atende até 10:30
+	rr = SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+		ClienteID:      SetupNovoCliente(clienteRepo).ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T10:00:00Z",
+	})
+	require.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestCondicoesDoServico_HorariosUsamDuracaoDoPrestador(t *testing.T) {
+	router, prestadorRepo, _, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+	SetupAgendaNasDatas(agendaDiariaRepo, prestador, "2030-01-03")
+	SetupDuracaoDoPrestador(t, router, prestador, catalogo.ID, 120)
+
+	rr := SetupGetHorariosRequest(router, prestador.ID, "data=2030-01-03&catalogo_id="+catalogo.ID+"&intervalo=60")
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response response_agendamento.HorariosDisponiveisResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+	// Agenda 08:00-12:00 com duas horas por atendimento: 08, 09 e 10
+	require.Equal(t, 120, response.Duracao)
+	require.Len(t, response.Horarios, 3)
+}
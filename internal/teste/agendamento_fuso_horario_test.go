@@ -0,0 +1,196 @@
+package teste
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"meu-servico-agenda/internal/adapters/http/agendamento/request_agendamento"
+	"meu-servico-agenda/internal/adapters/http/agendamento/response_agendamento"
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/application/service"
+	"meu-servico-agenda/internal/core/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+// SetupCriaPrestadorNoFuso cria um prestador cuja agenda é interpretada no fuso informado
+func SetupCriaPrestadorNoFuso(t *testing.T, p port.PrestadorRepositorio, catalogo []domain.Catalogo, fuso string) *domain.Prestador {
+	pres, err := domain.NovoPrestador("Eduardo", "04423258196", "caetasousa@gmail.com", "662999687481", "https://exemplo.com/img1.jpg", catalogo)
+	require.NoError(t, err)
+	require.NoError(t, pres.DefinirFusoHorario(fuso))
+	require.NoError(t, p.Salvar(context.Background(), pres))
+	return pres
+}
+
+func TestFusoHorario_IntervaloEmHorarioLocal(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	cliente := SetupNovoCliente(clienteRepo)
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestadorNoFuso(t, prestadorRepo, *listaDeCatalogos, "America/Sao_Paulo")
+	SetupAgendaNasDatas(agendaDiariaRepo, prestador, "2030-01-03")
+
+	// 08:00 UTC são 05:00 em São Paulo, antes do intervalo das 08:00
+	rr := SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+		ClienteID:      cliente.ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T08:00:00Z",
+	})
+	require.Equal(t, http.StatusConflict, rr.Code)
+	require.Contains(t, rr.Body.String(), service.ErrHorarioIndisponivel.Error())
+
+	rr = SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+		ClienteID:      cliente.ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T08:00:00-03:00",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var resp response_agendamento.AgendamentoResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Equal(t, "2030-01-03T08:00:00-03:00", resp.DataInicio.Format(time.RFC3339))
+	require.Equal(t, "2030-01-03T09:00:00-03:00", resp.DataFim.Format(time.RFC3339))
+	require.Equal(t, "America/Sao_Paulo", resp.Prestador.FusoHorario)
+}
+
+func TestFusoHorario_DiaLocalDiferenteDoDiaUTC(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	cliente := SetupNovoCliente(clienteRepo)
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestadorNoFuso(t, prestadorRepo, *listaDeCatalogos, "America/Sao_Paulo")
+
+	horaInicio, _ := time.Parse("15:04", "20:00")
+	horaFim, _ := time.Parse("15:04", "23:00")
+	data, _ := time.Parse("2006-01-02", "2030-01-03")
+	intervalo, _ := domain.NovoIntervaloDiario(horaInicio, horaFim)
+	agenda, _ := domain.NovaAgendaDiaria(data, []domain.IntervaloDiario{*intervalo})
+	agendaDiariaRepo.Salvar(context.Background(), agenda, prestador.ID)
+	prestador.AdicionarAgenda(agenda)
+
+	// 2030-01-04T00:30Z ainda é dia 03 às 21:30 em São Paulo
+	rr := SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+		ClienteID:      cliente.ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-04T00:30:00Z",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var resp response_agendamento.AgendamentoResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Equal(t, "2030-01-03T21:30:00-03:00", resp.DataInicio.Format(time.RFC3339))
+}
+
+func TestFusoHorario_MudancaDeHorarioDeVerao(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	cliente := SetupNovoCliente(clienteRepo)
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestadorNoFuso(t, prestadorRepo, *listaDeCatalogos, "America/New_York")
+
+	// Em 2030-03-10 Nova York passa de -05:00 para -04:00
+	SetupAgendaNasDatas(agendaDiariaRepo, prestador, "2030-03-09", "2030-03-10")
+
+	rr := SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+		ClienteID:      cliente.ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-03-09T13:00:00Z",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var antes response_agendamento.AgendamentoResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &antes))
+	require.Equal(t, "2030-03-09T08:00:00-05:00", antes.DataInicio.Format(time.RFC3339))
+
+	// O mesmo horário UTC do dia anterior agora cai às 09:00 locais; 12:00 UTC é 08:00
+	rr = SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+		ClienteID:      cliente.ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-03-10T11:30:00Z",
+	})
+	require.Equal(t, http.StatusConflict, rr.Code)
+	require.Contains(t, rr.Body.String(), service.ErrHorarioIndisponivel.Error())
+
+	rr = SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+		ClienteID:      cliente.ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-03-10T12:00:00Z",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var depois response_agendamento.AgendamentoResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &depois))
+	require.Equal(t, "2030-03-10T08:00:00-04:00", depois.DataInicio.Format(time.RFC3339))
+}
+
+func TestFusoHorario_HorariosLivresNoDiaDaMudanca(t *testing.T) {
+	router, prestadorRepo, _, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestadorNoFuso(t, prestadorRepo, *listaDeCatalogos, "America/New_York")
+	SetupAgendaNasDatas(agendaDiariaRepo, prestador, "2030-03-10")
+
+	rr := SetupGetHorariosRequest(router, prestador.ID, "data=2030-03-10&intervalo=60&catalogo_id="+catalogo.ID)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response response_agendamento.HorariosDisponiveisResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+	require.Equal(t, "America/New_York", response.FusoHorario)
+	require.Len(t, response.Horarios, 4)
+	for i, h := range response.Horarios {
+		esperado := time.Date(2030, 3, 10, 8+i, 0, 0, 0, time.FixedZone("EDT", -4*60*60))
+		require.True(t, esperado.Equal(h), "esperado %s, obtido %s", esperado, h)
+		_, offset := h.Zone()
+		require.Equal(t, -4*60*60, offset)
+	}
+}
+
+func TestFusoHorario_SerieMantemHorarioLocal(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	cliente := SetupNovoCliente(clienteRepo)
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestadorNoFuso(t, prestadorRepo, *listaDeCatalogos, "America/New_York")
+	SetupAgendaNasDatas(agendaDiariaRepo, prestador, "2030-03-03", "2030-03-10", "2030-03-17")
+
+	rr := SetupPostSerieRequest(router, request_agendamento.SerieAgendamentoRequest{
+		ClienteID:        cliente.ID,
+		PrestadorID:      prestador.ID,
+		CatalogoID:       catalogo.ID,
+		DataHoraInicio:   "2030-03-03T10:00:00-05:00",
+		IntervaloSemanas: 1,
+		Ocorrencias:      2,
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var serie response_agendamento.SerieAgendamentoResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &serie))
+	require.Len(t, serie.Agendados, 2)
+	require.Empty(t, serie.Falhas)
+	require.Equal(t, "2030-03-03T10:00:00-05:00", serie.Agendados[0].DataInicio.Format(time.RFC3339))
+	require.Equal(t, "2030-03-10T10:00:00-04:00", serie.Agendados[1].DataInicio.Format(time.RFC3339))
+
+	// Adiar uma semana mantém as 10:00 locais mesmo atravessando a mudança de horário
+	rr = SetupPutSerieRequest(router, serie.SerieID, "reagendar", request_agendamento.ReagendarSerieRequest{
+		DataHoraInicio: "2030-03-10T10:00:00-04:00",
+		Escopo:         "todas",
+	})
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var reagendada response_agendamento.SerieAgendamentoResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &reagendada))
+	require.Empty(t, reagendada.Falhas)
+	require.Len(t, reagendada.Agendados, 2)
+	require.Equal(t, "2030-03-10T10:00:00-04:00", reagendada.Agendados[0].DataInicio.Format(time.RFC3339))
+	require.Equal(t, "2030-03-17T10:00:00-04:00", reagendada.Agendados[1].DataInicio.Format(time.RFC3339))
+}
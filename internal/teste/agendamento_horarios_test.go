@@ -0,0 +1,127 @@
+package teste
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"meu-servico-agenda/internal/adapters/http/agendamento/request_agendamento"
+	"meu-servico-agenda/internal/adapters/http/agendamento/response_agendamento"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func SetupGetHorariosRequest(router *gin.Engine, prestadorID, query string) *httptest.ResponseRecorder {
+	url := fmt.Sprintf("/api/v1/prestadores/%s/horarios?%s", prestadorID, query)
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func TestGetHorarios_AgendaLivre(t *testing.T) {
+	router, prestadorRepo, _, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+	prestador.AdicionarAgenda(SetupCriaAgendaDiaria(agendaDiariaRepo))
+
+	rr := SetupGetHorariosRequest(router, prestador.ID, "data=2030-01-03&catalogo_id="+catalogo.ID+"&intervalo=60")
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response response_agendamento.HorariosDisponiveisResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+	// Agenda 08:00-12:00 com serviço de 60 minutos: 08, 09, 10 e 11
+	require.Equal(t, 60, response.Duracao)
+	require.Len(t, response.Horarios, 4)
+	require.True(t, response.Horarios[0].Equal(time.Date(2030, 1, 3, 8, 0, 0, 0, time.UTC)))
+	require.True(t, response.Horarios[3].Equal(time.Date(2030, 1, 3, 11, 0, 0, 0, time.UTC)))
+}
+
+func TestGetHorarios_GradePadraoDesconsideraOcupados(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	cliente := SetupNovoCliente(clienteRepo)
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+	prestador.AdicionarAgenda(SetupCriaAgendaDiaria(agendaDiariaRepo))
+
+	input := request_agendamento.AgendamentoRequest{
+		ClienteID:      cliente.ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T09:00:00Z",
+	}
+	require.Equal(t, http.StatusCreated, SetupPostAgendamentoRequest(router, input).Code)
+
+	rr := SetupGetHorariosRequest(router, prestador.ID, "data=2030-01-03&catalogo_id="+catalogo.ID)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response response_agendamento.HorariosDisponiveisResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+	// Grade de 15 minutos: 08:00 é o único início antes do agendamento das 09:00
+	// e 10:00-11:00 os possíveis depois dele
+	esperados := []string{"08:00", "10:00", "10:15", "10:30", "10:45", "11:00"}
+	require.Len(t, response.Horarios, len(esperados))
+	for i, h := range response.Horarios {
+		require.Equal(t, esperados[i], h.UTC().Format("15:04"))
+	}
+}
+
+func TestGetHorarios_DiaSemAgenda(t *testing.T) {
+	router, prestadorRepo, _, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+	prestador.AdicionarAgenda(SetupCriaAgendaDiaria(agendaDiariaRepo))
+
+	rr := SetupGetHorariosRequest(router, prestador.ID, "data=2030-01-04&catalogo_id="+catalogo.ID)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response response_agendamento.HorariosDisponiveisResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Empty(t, response.Horarios)
+}
+
+func TestGetHorarios_CatalogoInexistente(t *testing.T) {
+	router, prestadorRepo, _, catalogoRepo, _ := SetupRouterAgendamento()
+
+	_, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+
+	rr := SetupGetHorariosRequest(router, prestador.ID, "data=2030-01-03&catalogo_id=inexistente")
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestGetHorarios_PrestadorInexistente(t *testing.T) {
+	router, _, _, catalogoRepo, _ := SetupRouterAgendamento()
+
+	catalogo, _ := SetupNovoCatalogo(catalogoRepo)
+
+	rr := SetupGetHorariosRequest(router, "inexistente", "data=2030-01-03&catalogo_id="+catalogo.ID)
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestGetHorarios_ParametrosInvalidos(t *testing.T) {
+	router, prestadorRepo, _, catalogoRepo, _ := SetupRouterAgendamento()
+
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+
+	rr := SetupGetHorariosRequest(router, prestador.ID, "data=03-01-2030&catalogo_id="+catalogo.ID)
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = SetupGetHorariosRequest(router, prestador.ID, "data=2030-01-03")
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = SetupGetHorariosRequest(router, prestador.ID, "data=2020-01-03&catalogo_id="+catalogo.ID)
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
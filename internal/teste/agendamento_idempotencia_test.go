@@ -0,0 +1,259 @@
+package teste
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"meu-servico-agenda/internal/adapters/http/agendamento/request_agendamento"
+	"meu-servico-agenda/internal/adapters/http/agendamento/response_agendamento"
+	"meu-servico-agenda/internal/adapters/http/middleware"
+	"meu-servico-agenda/internal/adapters/http/resposta"
+	"meu-servico-agenda/internal/adapters/repository"
+	"meu-servico-agenda/internal/core/application/service"
+	"meu-servico-agenda/internal/core/domain"
+	"meu-servico-agenda/internal/infra/jwt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func SetupPostComChaveRequest(router *gin.Engine, url, chave string, input any) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(input)
+
+	req, _ := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.HeaderIdempotencyKey, chave)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func TestIdempotencia_RepeteRespostaOriginal(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	cliente := SetupNovoCliente(clienteRepo)
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+	SetupAgendaNasDatas(agendaDiariaRepo, prestador, "2030-01-03")
+
+	input := request_agendamento.AgendamentoRequest{
+		ClienteID:      cliente.ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T08:00:00Z",
+	}
+
+	primeira := SetupPostComChaveRequest(router, "/api/v1/agendamentos", "chave-1", input)
+	require.Equal(t, http.StatusCreated, primeira.Code)
+	require.Empty(t, primeira.Header().Get(middleware.HeaderIdempotentReplayed))
+
+	// A retentativa não chega ao serviço: sem a chave ela receberia 409 de agendamento duplo
+	repetida := SetupPostComChaveRequest(router, "/api/v1/agendamentos", "chave-1", input)
+	require.Equal(t, http.StatusCreated, repetida.Code)
+	require.Equal(t, "true", repetida.Header().Get(middleware.HeaderIdempotentReplayed))
+	require.JSONEq(t, primeira.Body.String(), repetida.Body.String())
+
+	var resp response_agendamento.AgendamentoResponse
+	require.NoError(t, json.Unmarshal(repetida.Body.Bytes(), &resp))
+	require.NotEmpty(t, resp.ID)
+
+	semChave := SetupPostAgendamentoRequest(router, input)
+	require.Equal(t, http.StatusConflict, semChave.Code)
+	require.Contains(t, semChave.Body.String(), service.ErrAgendamentoDuplo.Error())
+}
+
+func TestIdempotencia_MesmaChaveComOutroCorpo(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	cliente := SetupNovoCliente(clienteRepo)
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+	SetupAgendaNasDatas(agendaDiariaRepo, prestador, "2030-01-03")
+
+	input := request_agendamento.AgendamentoRequest{
+		ClienteID:      cliente.ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T08:00:00Z",
+	}
+
+	rr := SetupPostComChaveRequest(router, "/api/v1/agendamentos", "chave-1", input)
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	input.DataHoraInicio = "2030-01-03T10:00:00Z"
+	rr = SetupPostComChaveRequest(router, "/api/v1/agendamentos", "chave-1", input)
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	require.Contains(t, rr.Body.String(), middleware.ErrChaveIdempotenciaReutilizada.Error())
+}
+
+func TestIdempotencia_ChaveMuitoLonga(t *testing.T) {
+	router, _, _, _, _ := SetupRouterAgendamento()
+
+	chave := string(bytes.Repeat([]byte("a"), middleware.TamanhoMaximoChaveIdempotencia+1))
+	rr := SetupPostComChaveRequest(router, "/api/v1/agendamentos", chave, request_agendamento.AgendamentoRequest{})
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	require.Contains(t, rr.Body.String(), middleware.ErrChaveIdempotenciaInvalida.Error())
+}
+
+func TestIdempotencia_RequisicaoEmAndamento(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	iniciou := make(chan struct{})
+	liberar := make(chan struct{})
+	var chamadas int
+
+	router := gin.New()
+	router.POST("/lento", middleware.Idempotencia(repository.NovoFakeIdempotenciaRepositorio(), time.Minute), func(c *gin.Context) {
+		chamadas++
+		close(iniciou)
+		<-liberar
+		c.JSON(http.StatusCreated, gin.H{"ok": true})
+	})
+
+	var wg sync.WaitGroup
+	var primeira *httptest.ResponseRecorder
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		primeira = SetupPostComChaveRequest(router, "/lento", "chave-1", gin.H{"a": 1})
+	}()
+
+	<-iniciou
+	concorrente := SetupPostComChaveRequest(router, "/lento", "chave-1", gin.H{"a": 1})
+	require.Equal(t, http.StatusConflict, concorrente.Code)
+	require.Contains(t, concorrente.Body.String(), middleware.ErrRequisicaoEmAndamento.Error())
+
+	close(liberar)
+	wg.Wait()
+	require.Equal(t, http.StatusCreated, primeira.Code)
+
+	repetida := SetupPostComChaveRequest(router, "/lento", "chave-1", gin.H{"a": 1})
+	require.Equal(t, http.StatusCreated, repetida.Code)
+	require.Equal(t, 1, chamadas)
+}
+
+func TestIdempotencia_ErroInternoLiberaChave(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var chamadas int
+	router := gin.New()
+	router.POST("/instavel", middleware.Idempotencia(repository.NovoFakeIdempotenciaRepositorio(), time.Minute), func(c *gin.Context) {
+		chamadas++
+		if chamadas == 1 {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": service.ErrFalhaInfraestrutura.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"tentativa": chamadas})
+	})
+
+	rr := SetupPostComChaveRequest(router, "/instavel", "chave-1", gin.H{"a": 1})
+	require.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = SetupPostComChaveRequest(router, "/instavel", "chave-1", gin.H{"a": 1})
+	require.Equal(t, http.StatusCreated, rr.Code)
+	require.Empty(t, rr.Header().Get(middleware.HeaderIdempotentReplayed))
+	require.Equal(t, 2, chamadas)
+}
+
+func TestIdempotencia_ChaveExpirada(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	repo := repository.NovoFakeIdempotenciaRepositorio()
+	var chamadas int
+	router := gin.New()
+	// TTL zero: a chave expira assim que é gravada
+	router.POST("/recurso", middleware.Idempotencia(repo, 0), func(c *gin.Context) {
+		chamadas++
+		c.JSON(http.StatusCreated, gin.H{"tentativa": chamadas})
+	})
+
+	SetupPostComChaveRequest(router, "/recurso", "chave-1", gin.H{"a": 1})
+	rr := SetupPostComChaveRequest(router, "/recurso", "chave-1", gin.H{"a": 2})
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+	require.Equal(t, 2, chamadas)
+
+	removidas, err := repo.RemoverExpirados(context.Background(), time.Now().UTC())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), removidas)
+}
+
+func TestIdempotencia_ChaveValePorUsuario(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	emissor := jwt.NovoEmissorHS256([]byte("segredo-de-teste"), time.Minute)
+
+	var chamadas int
+	router := gin.New()
+	router.POST("/reservas", middleware.Autenticar(emissor), middleware.Idempotencia(repository.NovoFakeIdempotenciaRepositorio(), time.Minute), func(c *gin.Context) {
+		chamadas++
+		c.JSON(http.StatusCreated, gin.H{"usuario": middleware.IdentidadeDaRequisicao(c).UsuarioID})
+	})
+
+	enviar := func(usuarioID string) *httptest.ResponseRecorder {
+		token, _, err := emissor.Emitir(&domain.Identidade{UsuarioID: usuarioID, Papel: domain.PapelCliente, ClienteID: "c-" + usuarioID})
+		require.NoError(t, err)
+
+		req, _ := http.NewRequest(http.MethodPost, "/reservas", bytes.NewBufferString(`{"a":1}`))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set(middleware.HeaderIdempotencyKey, "chave-1")
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := enviar("ana")
+	require.Equal(t, http.StatusCreated, rr.Code)
+	require.Contains(t, rr.Body.String(), "ana")
+
+	// A mesma chave de outro usuário é outra requisição
+	rr = enviar("bia")
+	require.Equal(t, http.StatusCreated, rr.Code)
+	require.Empty(t, rr.Header().Get(middleware.HeaderIdempotentReplayed))
+	require.Contains(t, rr.Body.String(), "bia")
+
+	rr = enviar("ana")
+	require.Equal(t, "true", rr.Header().Get(middleware.HeaderIdempotentReplayed))
+	require.Contains(t, rr.Body.String(), "ana")
+	require.Equal(t, 2, chamadas)
+}
+
+func TestIdempotencia_RecusaNaoERepetida(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var chamadas int
+	router := gin.New()
+	router.POST("/reservas", middleware.Idempotencia(repository.NovoFakeIdempotenciaRepositorio(), time.Minute), func(c *gin.Context) {
+		chamadas++
+		resposta.Erro(c, service.ErrPrestadorOcupado)
+	})
+
+	enviar := func(idioma string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/reservas", bytes.NewBufferString(`{"a":1}`))
+		req.Header.Set(middleware.HeaderIdempotencyKey, "chave-1")
+		req.Header.Set("Accept-Language", idioma)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := enviar("pt-BR")
+	require.Equal(t, http.StatusConflict, rr.Code)
+	require.Contains(t, rr.Body.String(), service.ErrPrestadorOcupado.Error())
+
+	// A nova tentativa é atendida de novo e responde no idioma que pediu
+	rr = enviar("en")
+	require.Equal(t, http.StatusConflict, rr.Code)
+	require.Empty(t, rr.Header().Get(middleware.HeaderIdempotentReplayed))
+	require.Equal(t, "en", rr.Header().Get("Content-Language"))
+	require.NotContains(t, rr.Body.String(), service.ErrPrestadorOcupado.Error())
+	require.Equal(t, 2, chamadas)
+}
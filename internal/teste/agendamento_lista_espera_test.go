@@ -0,0 +1,422 @@
+package teste
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"meu-servico-agenda/internal/adapters/http/agendamento"
+	"meu-servico-agenda/internal/adapters/http/agendamento/request_agendamento"
+	"meu-servico-agenda/internal/adapters/http/agendamento/response_agendamento"
+	"meu-servico-agenda/internal/adapters/http/lista_espera"
+	"meu-servico-agenda/internal/adapters/http/lista_espera/request_lista_espera"
+	"meu-servico-agenda/internal/adapters/http/lista_espera/response_lista_espera"
+	"meu-servico-agenda/internal/adapters/http/prestador"
+	"meu-servico-agenda/internal/adapters/http/prestador/request_prestador"
+	"meu-servico-agenda/internal/adapters/repository"
+	"meu-servico-agenda/internal/core/application/input"
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/application/service"
+	"meu-servico-agenda/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// SetupRouterListaEspera monta as rotas da lista de espera com as de agendamento e de
+// agenda do prestador, que liberam as vagas oferecidas
+func SetupRouterListaEspera() (*gin.Engine, port.PrestadorRepositorio, port.ClienteRepositorio, port.CatalogoRepositorio, port.AgendaDiariaRepositorio, port.ListaEsperaRepositorio) {
+	gin.SetMode(gin.TestMode)
+
+	catalogoRepo := repository.NovoCatalogoFakeRepo()
+	prestadorRepo := repository.NovoFakePrestadorRepositorio(catalogoRepo)
+	clienteRepo := repository.NewFakeClienteRepositorio()
+	agendaDiariaRepo := repository.NovoFakeAgendaDiariaRepositorio()
+	agendamentoRepo := repository.NovoFakeAgendamentoRepositorio()
+	listaEsperaRepo := repository.NovoFakeListaEsperaRepositorio()
+
+	cadastroPrestador := service.NovaPrestadorService(prestadorRepo, catalogoRepo, agendaDiariaRepo)
+	cadastraAgendamento := service.NovaAgendamentoService(prestadorRepo, agendamentoRepo, catalogoRepo, clienteRepo)
+	listaEsperaService := service.NovaListaEsperaService(listaEsperaRepo, prestadorRepo, catalogoRepo, clienteRepo, cadastraAgendamento)
+	cadastroPrestador.DefinirObservadorDeVagas(listaEsperaService)
+	unidadeDeTrabalho := repository.NovaFakeUnidadeDeTrabalho(prestadorRepo, clienteRepo, agendaDiariaRepo, agendamentoRepo, listaEsperaRepo)
+	cadastroPrestador.DefinirUnidadeDeTrabalho(unidadeDeTrabalho)
+	cadastraAgendamento.DefinirUnidadeDeTrabalho(unidadeDeTrabalho)
+	listaEsperaService.DefinirUnidadeDeTrabalho(unidadeDeTrabalho)
+
+	router := gin.Default()
+	apiV1 := router.Group("/api/v1")
+	{
+		prestadorController := prestador.NovoPrestadorController(cadastroPrestador)
+		agendamentoController := agendamento.NovoAgendamentoController(cadastraAgendamento)
+		listaEsperaController := lista_espera.NovoListaEsperaController(listaEsperaService)
+
+		apiV1.PUT("/prestadores/:id/agenda", prestadorController.PutAgenda)
+		apiV1.POST("/agendamentos", agendamentoController.PostAgendamento)
+		apiV1.GET("/agendamentos/cliente/:id", agendamentoController.GetAgendamentoClienteData)
+		apiV1.PUT("/agendamentos/:id/cancelar", agendamentoController.PutCancelarAgendamento)
+		apiV1.POST("/lista-espera", listaEsperaController.PostListaEspera)
+		apiV1.GET("/lista-espera/:id", listaEsperaController.GetListaEspera)
+		apiV1.PUT("/lista-espera/:id/cancelar", listaEsperaController.PutCancelarListaEspera)
+		apiV1.PUT("/lista-espera/:id/aceitar", listaEsperaController.PutAceitarOferta)
+	}
+
+	return router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo, listaEsperaRepo
+}
+
+func SetupPostListaEsperaRequest(router *gin.Engine, input request_lista_espera.ListaEsperaRequest) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(input)
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/lista-espera", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func SetupPutListaEsperaRequest(router *gin.Engine, id string, acao string) *httptest.ResponseRecorder {
+	url := fmt.Sprintf("/api/v1/lista-espera/%s/%s", id, acao)
+	req, _ := http.NewRequest(http.MethodPut, url, nil)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+// SetupBuscaEntradaListaEspera lê a entrada pelo endpoint GET
+func SetupBuscaEntradaListaEspera(t *testing.T, router *gin.Engine, id string) response_lista_espera.ListaEsperaResponse {
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/lista-espera/"+id, nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var entrada response_lista_espera.ListaEsperaResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &entrada))
+
+	return entrada
+}
+
+// SetupEntradaListaEspera inclui o cliente na lista aguardando o prestador na janela informada
+func SetupEntradaListaEspera(t *testing.T, router *gin.Engine, clienteID, catalogoID, prestadorID, inicio, fim string) response_lista_espera.ListaEsperaResponse {
+	rr := SetupPostListaEsperaRequest(router, request_lista_espera.ListaEsperaRequest{
+		ClienteID:    clienteID,
+		PrestadorID:  prestadorID,
+		CatalogoID:   catalogoID,
+		JanelaInicio: inicio,
+		JanelaFim:    fim,
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var entrada response_lista_espera.ListaEsperaResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &entrada))
+	require.Equal(t, domain.AguardandoVaga, entrada.Status)
+
+	return entrada
+}
+
+type cenarioListaEspera struct {
+	router          *gin.Engine
+	clienteRepo     port.ClienteRepositorio
+	listaEsperaRepo port.ListaEsperaRepositorio
+	prestador       *domain.Prestador
+	catalogo        *domain.Catalogo
+	agendamentoID   string
+	primeiro        response_lista_espera.ListaEsperaResponse
+	segundo         response_lista_espera.ListaEsperaResponse
+}
+
+// SetupFilaParaHorarioOcupado agenda 2030-01-03 09:00 e coloca dois clientes na fila
+// aguardando exatamente esse horário
+func SetupFilaParaHorarioOcupado(t *testing.T) cenarioListaEspera {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo, listaEsperaRepo := SetupRouterListaEspera()
+
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+	SetupAgendaNasDatas(agendaDiariaRepo, prestador, "2030-01-03")
+
+	ocupante := SetupNovoCliente(clienteRepo)
+	rr := SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+		ClienteID:      ocupante.ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T09:00:00Z",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var agendamento response_agendamento.AgendamentoResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &agendamento))
+
+	primeiro := SetupEntradaListaEspera(t, router, SetupNovoCliente(clienteRepo).ID, catalogo.ID, prestador.ID, "2030-01-03T09:00:00Z", "2030-01-03T10:00:00Z")
+	segundo := SetupEntradaListaEspera(t, router, SetupNovoCliente(clienteRepo).ID, catalogo.ID, "", "2030-01-03T09:00:00Z", "2030-01-03T10:00:00Z")
+
+	return cenarioListaEspera{
+		router:          router,
+		clienteRepo:     clienteRepo,
+		listaEsperaRepo: listaEsperaRepo,
+		prestador:       prestador,
+		catalogo:        catalogo,
+		agendamentoID:   agendamento.ID,
+		primeiro:        primeiro,
+		segundo:         segundo,
+	}
+}
+
+func TestListaEspera_CancelamentoOfereceVagaAoPrimeiroDaFila(t *testing.T) {
+	c := SetupFilaParaHorarioOcupado(t)
+
+	rr := SetupPutStatusAgendamentoRequest(c.router, c.agendamentoID, "cancelar")
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	primeiro := SetupBuscaEntradaListaEspera(t, c.router, c.primeiro.ID)
+	require.Equal(t, domain.VagaOferecida, primeiro.Status)
+	require.NotNil(t, primeiro.Oferta)
+	require.Equal(t, c.prestador.ID, primeiro.Oferta.PrestadorID)
+	require.True(t, primeiro.Oferta.DataHoraInicio.Equal(time.Date(2030, 1, 3, 9, 0, 0, 0, time.UTC)))
+	require.True(t, primeiro.Oferta.DataHoraFim.Equal(time.Date(2030, 1, 3, 10, 0, 0, 0, time.UTC)))
+	require.True(t, primeiro.Oferta.ExpiraEm.After(time.Now()))
+
+	segundo := SetupBuscaEntradaListaEspera(t, c.router, c.segundo.ID)
+	require.Equal(t, domain.AguardandoVaga, segundo.Status)
+	require.Nil(t, segundo.Oferta)
+}
+
+func TestListaEspera_VagaOferecidaFicaReservada(t *testing.T) {
+	c := SetupFilaParaHorarioOcupado(t)
+
+	rr := SetupPutStatusAgendamentoRequest(c.router, c.agendamentoID, "cancelar")
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	outro := SetupNovoCliente(c.clienteRepo)
+	rr = SetupPostAgendamentoRequest(c.router, request_agendamento.AgendamentoRequest{
+		ClienteID:      outro.ID,
+		PrestadorID:    c.prestador.ID,
+		CatalogoID:     c.catalogo.ID,
+		DataHoraInicio: "2030-01-03T09:00:00Z",
+	})
+	require.Equal(t, http.StatusConflict, rr.Code)
+	require.Contains(t, rr.Body.String(), service.ErrVagaReservada.Error())
+
+	// Fora do horário oferecido o agendamento segue normal
+	rr = SetupPostAgendamentoRequest(c.router, request_agendamento.AgendamentoRequest{
+		ClienteID:      outro.ID,
+		PrestadorID:    c.prestador.ID,
+		CatalogoID:     c.catalogo.ID,
+		DataHoraInicio: "2030-01-03T10:00:00Z",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+}
+
+func TestListaEspera_AceitarOfertaCriaAgendamento(t *testing.T) {
+	c := SetupFilaParaHorarioOcupado(t)
+
+	rr := SetupPutStatusAgendamentoRequest(c.router, c.agendamentoID, "cancelar")
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	rr = SetupPutListaEsperaRequest(c.router, c.primeiro.ID, "aceitar")
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var aceita response_lista_espera.ListaEsperaResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &aceita))
+	require.Equal(t, domain.VagaAceita, aceita.Status)
+	require.NotEmpty(t, aceita.AgendamentoID)
+
+	rr = SetupGetAgendamentoClienteDataRequest(c.router, c.primeiro.ClienteID, "2030-01-03")
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response response_agendamento.BuscaDataResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Len(t, response.Data, 1)
+	require.Equal(t, aceita.AgendamentoID, response.Data[0].ID)
+
+	// A oferta só pode ser aceita uma vez
+	rr = SetupPutListaEsperaRequest(c.router, c.primeiro.ID, "aceitar")
+	require.Equal(t, http.StatusConflict, rr.Code)
+	require.Contains(t, rr.Body.String(), domain.ErrSemOfertaPendente.Error())
+}
+
+func TestListaEspera_AceitarSemOfertaRetornaConflito(t *testing.T) {
+	c := SetupFilaParaHorarioOcupado(t)
+
+	rr := SetupPutListaEsperaRequest(c.router, c.primeiro.ID, "aceitar")
+	require.Equal(t, http.StatusConflict, rr.Code)
+	require.Contains(t, rr.Body.String(), domain.ErrSemOfertaPendente.Error())
+}
+
+func TestListaEspera_CancelarEntradaComOfertaPassaVagaAoProximo(t *testing.T) {
+	c := SetupFilaParaHorarioOcupado(t)
+
+	rr := SetupPutStatusAgendamentoRequest(c.router, c.agendamentoID, "cancelar")
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	rr = SetupPutListaEsperaRequest(c.router, c.primeiro.ID, "cancelar")
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	primeiro := SetupBuscaEntradaListaEspera(t, c.router, c.primeiro.ID)
+	require.Equal(t, domain.EsperaCancelada, primeiro.Status)
+
+	segundo := SetupBuscaEntradaListaEspera(t, c.router, c.segundo.ID)
+	require.Equal(t, domain.VagaOferecida, segundo.Status)
+	require.True(t, segundo.Oferta.DataHoraInicio.Equal(time.Date(2030, 1, 3, 9, 0, 0, 0, time.UTC)))
+
+	rr = SetupPutListaEsperaRequest(c.router, c.primeiro.ID, "cancelar")
+	require.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestListaEspera_OfertaExpiradaPassaVagaAoProximo(t *testing.T) {
+	c := SetupFilaParaHorarioOcupado(t)
+
+	rr := SetupPutStatusAgendamentoRequest(c.router, c.agendamentoID, "cancelar")
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	// Simula o fim do prazo para aceitar
+	entrada, err := c.listaEsperaRepo.BuscarPorId(context.Background(), c.primeiro.ID)
+	require.NoError(t, err)
+	entrada.Oferta.ExpiraEm = time.Now().Add(-time.Minute)
+	require.NoError(t, c.listaEsperaRepo.Atualizar(context.Background(), entrada))
+
+	rr = SetupPutListaEsperaRequest(c.router, c.primeiro.ID, "aceitar")
+	require.Equal(t, http.StatusConflict, rr.Code)
+	require.Contains(t, rr.Body.String(), domain.ErrOfertaExpirada.Error())
+
+	primeiro := SetupBuscaEntradaListaEspera(t, c.router, c.primeiro.ID)
+	require.Equal(t, domain.OfertaExpirada, primeiro.Status)
+
+	segundo := SetupBuscaEntradaListaEspera(t, c.router, c.segundo.ID)
+	require.Equal(t, domain.VagaOferecida, segundo.Status)
+}
+
+func TestListaEspera_NovaAgendaOfereceVaga(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, _, _ := SetupRouterListaEspera()
+
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+	cliente := SetupNovoCliente(clienteRepo)
+
+	entrada := SetupEntradaListaEspera(t, router, cliente.ID, catalogo.ID, "", "2030-01-04T10:00:00Z", "2030-01-04T18:00:00Z")
+
+	body, _ := json.Marshal(request_prestador.AgendaDiariaRequest{
+		Data: "2030-01-04",
+		Intervalos: []request_prestador.IntervaloDiarioRequest{
+			{HoraInicio: "08:00", HoraFim: "12:00"},
+		},
+	})
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/prestadores/"+prestador.ID+"/agenda", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	oferecida := SetupBuscaEntradaListaEspera(t, router, entrada.ID)
+	require.Equal(t, domain.VagaOferecida, oferecida.Status)
+	require.Equal(t, prestador.ID, oferecida.Oferta.PrestadorID)
+	require.True(t, oferecida.Oferta.DataHoraInicio.Equal(time.Date(2030, 1, 4, 10, 0, 0, 0, time.UTC)))
+}
+
+func TestListaEspera_JanelaInvalida(t *testing.T) {
+	router, _, clienteRepo, catalogoRepo, _, _ := SetupRouterListaEspera()
+
+	catalogo, _ := SetupNovoCatalogo(catalogoRepo)
+	cliente := SetupNovoCliente(clienteRepo)
+
+	rr := SetupPostListaEsperaRequest(router, request_lista_espera.ListaEsperaRequest{
+		ClienteID:    cliente.ID,
+		CatalogoID:   catalogo.ID,
+		JanelaInicio: "2030-01-03T12:00:00Z",
+		JanelaFim:    "2030-01-03T09:00:00Z",
+	})
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	require.Contains(t, rr.Body.String(), domain.ErrJanelaEsperaInvalida.Error())
+}
+
+func TestListaEspera_ClienteInexistente(t *testing.T) {
+	router, _, _, catalogoRepo, _, _ := SetupRouterListaEspera()
+
+	catalogo, _ := SetupNovoCatalogo(catalogoRepo)
+
+	rr := SetupPostListaEsperaRequest(router, request_lista_espera.ListaEsperaRequest{
+		ClienteID:    "inexistente",
+		CatalogoID:   catalogo.ID,
+		JanelaInicio: "2030-01-03T09:00:00Z",
+		JanelaFim:    "2030-01-03T12:00:00Z",
+	})
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestListaEspera_VagaLiberadaVaiSoParaOPrimeiroDaFila(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, _, _ := SetupRouterListaEspera()
+
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+
+	// O dia inteiro é liberado e caberia os dois, mas só o primeiro recebe a oferta
+	primeiro := SetupEntradaListaEspera(t, router, SetupNovoCliente(clienteRepo).ID, catalogo.ID, "", "2030-01-04T08:00:00Z", "2030-01-04T12:00:00Z")
+	segundo := SetupEntradaListaEspera(t, router, SetupNovoCliente(clienteRepo).ID, catalogo.ID, "", "2030-01-04T08:00:00Z", "2030-01-04T12:00:00Z")
+
+	body, _ := json.Marshal(request_prestador.AgendaDiariaRequest{
+		Data: "2030-01-04",
+		Intervalos: []request_prestador.IntervaloDiarioRequest{
+			{HoraInicio: "08:00", HoraFim: "12:00"},
+		},
+	})
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/prestadores/"+prestador.ID+"/agenda", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	require.Equal(t, domain.VagaOferecida, SetupBuscaEntradaListaEspera(t, router, primeiro.ID).Status)
+
+	aguardando := SetupBuscaEntradaListaEspera(t, router, segundo.ID)
+	require.Equal(t, domain.AguardandoVaga, aguardando.Status)
+	require.Nil(t, aguardando.Oferta)
+}
+
+var errLeituraCliente = errors.New("falha ao ler cliente")
+
+// clienteQueFalha passa a falhar na leitura quando falhar é ligado
+type clienteQueFalha struct {
+	port.ClienteRepositorio
+	falhar bool
+}
+
+func (r *clienteQueFalha) BuscarPorId(ctx context.Context, id string) (*domain.Cliente, error) {
+	if r.falhar {
+		return nil, errLeituraCliente
+	}
+	return r.ClienteRepositorio.BuscarPorId(ctx, id)
+}
+
+func TestListaEspera_ErroAoLerClienteNaoViraFaltaDeVaga(t *testing.T) {
+	ctx := context.Background()
+	catalogoRepo := repository.NovoCatalogoFakeRepo()
+	catalogo, catalogos := SetupNovoCatalogo(catalogoRepo)
+	prestadorRepo := repository.NovoFakePrestadorRepositorio(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *catalogos)
+	SetupAgendaNasDatas(repository.NovoFakeAgendaDiariaRepositorio(), prestador, "2030-01-03")
+	clienteRepo := &clienteQueFalha{ClienteRepositorio: repository.NewFakeClienteRepositorio()}
+	cliente := SetupNovoCliente(clienteRepo)
+
+	agendamentoService := service.NovaAgendamentoService(prestadorRepo, repository.NovoFakeAgendamentoRepositorio(), catalogoRepo, clienteRepo)
+	listaEsperaService := service.NovaListaEsperaService(repository.NovoFakeListaEsperaRepositorio(), prestadorRepo, catalogoRepo, clienteRepo, agendamentoService)
+
+	_, err := listaEsperaService.Cadastrar(ctx, input.CadastrarListaEsperaInput{
+		ClienteID:    cliente.ID,
+		CatalogoID:   catalogo.ID,
+		JanelaInicio: time.Date(2030, 1, 3, 8, 0, 0, 0, time.UTC),
+		JanelaFim:    time.Date(2030, 1, 3, 12, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	clienteRepo.falhar = true
+	err = listaEsperaService.VagaLiberada(ctx, prestador.ID, time.Date(2030, 1, 3, 8, 0, 0, 0, time.UTC), time.Date(2030, 1, 3, 9, 0, 0, 0, time.UTC))
+	require.ErrorIs(t, err, errLeituraCliente)
+}
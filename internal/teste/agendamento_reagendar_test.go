@@ -0,0 +1,176 @@
+package teste
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"meu-servico-agenda/internal/adapters/http/agendamento/request_agendamento"
+	"meu-servico-agenda/internal/adapters/http/agendamento/response_agendamento"
+	"meu-servico-agenda/internal/core/application/service"
+	"meu-servico-agenda/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func SetupPutReagendarRequest(router *gin.Engine, id string, input request_agendamento.ReagendarAgendamentoRequest) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(input)
+
+	url := fmt.Sprintf("/api/v1/agendamentos/%s/reagendar", id)
+	req, _ := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func SetupGetReagendamentosRequest(router *gin.Engine, id string) *httptest.ResponseRecorder {
+	url := fmt.Sprintf("/api/v1/agendamentos/%s/reagendamentos", id)
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func TestReagendar_Sucesso(t *testing.T) {
+	router, agendamento := SetupAgendamentoCriado(t)
+
+	rr := SetupPutReagendarRequest(router, agendamento.ID, request_agendamento.ReagendarAgendamentoRequest{
+		DataHoraInicio: "2030-01-03T09:30:00Z",
+	})
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response response_agendamento.AgendamentoResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Equal(t, agendamento.ID, response.ID)
+	require.Equal(t, time.Date(2030, 1, 3, 9, 30, 0, 0, time.UTC), response.DataInicio.UTC())
+	require.Equal(t, time.Date(2030, 1, 3, 10, 30, 0, 0, time.UTC), response.DataFim.UTC())
+
+	rr = SetupGetReagendamentosRequest(router, agendamento.ID)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var historico []response_agendamento.ReagendamentoResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &historico))
+	require.Len(t, historico, 1)
+	require.Equal(t, time.Date(2030, 1, 3, 8, 0, 0, 0, time.UTC), historico[0].DataInicioAnterior.UTC())
+	require.Equal(t, time.Date(2030, 1, 3, 9, 0, 0, 0, time.UTC), historico[0].DataFimAnterior.UTC())
+	require.Equal(t, time.Date(2030, 1, 3, 9, 30, 0, 0, time.UTC), historico[0].DataInicioNova.UTC())
+}
+
+func TestReagendar_SobrepondoProprioHorario(t *testing.T) {
+	router, agendamento := SetupAgendamentoCriado(t)
+
+	// 08:30 sobrepõe o próprio agendamento (08:00-09:00), que deve ser ignorado
+	rr := SetupPutReagendarRequest(router, agendamento.ID, request_agendamento.ReagendarAgendamentoRequest{
+		DataHoraInicio: "2030-01-03T08:30:00Z",
+	})
+	require.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestReagendar_ForaDaAgenda(t *testing.T) {
+	router, agendamento := SetupAgendamentoCriado(t)
+
+	rr := SetupPutReagendarRequest(router, agendamento.ID, request_agendamento.ReagendarAgendamentoRequest{
+		DataHoraInicio: "2030-01-03T11:30:00Z",
+	})
+	require.Equal(t, http.StatusConflict, rr.Code)
+	require.Contains(t, rr.Body.String(), service.ErrHorarioIndisponivel.Error())
+
+	// o horário original permanece e nada entra no histórico
+	rr = SetupGetReagendamentosRequest(router, agendamento.ID)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, "[]", rr.Body.String())
+}
+
+func TestReagendar_PrestadorOcupado(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	cliente := SetupNovoCliente(clienteRepo)
+	outroCliente, _ := domain.NovoCliente("Maria", "maria@gmail.com", "62999697582")
+	clienteRepo.Salvar(context.Background(), outroCliente)
+
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+	agendaDiaria := SetupCriaAgendaDiaria(agendaDiariaRepo)
+	prestador.AdicionarAgenda(agendaDiaria)
+
+	rr := SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+		ClienteID:      cliente.ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T08:00:00Z",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var agendamento response_agendamento.AgendamentoResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &agendamento))
+
+	rr = SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+		ClienteID:      outroCliente.ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T10:00:00Z",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	rr = SetupPutReagendarRequest(router, agendamento.ID, request_agendamento.ReagendarAgendamentoRequest{
+		DataHoraInicio: "2030-01-03T10:30:00Z",
+	})
+	require.Equal(t, http.StatusConflict, rr.Code)
+	require.Contains(t, rr.Body.String(), service.ErrPrestadorOcupado.Error())
+}
+
+func TestReagendar_CanceladoNaoPermitido(t *testing.T) {
+	router, agendamento := SetupAgendamentoCriado(t)
+
+	require.Equal(t, http.StatusNoContent, SetupPutStatusAgendamentoRequest(router, agendamento.ID, "cancelar").Code)
+
+	rr := SetupPutReagendarRequest(router, agendamento.ID, request_agendamento.ReagendarAgendamentoRequest{
+		DataHoraInicio: "2030-01-03T10:00:00Z",
+	})
+	require.Equal(t, http.StatusConflict, rr.Code)
+	require.Contains(t, rr.Body.String(), domain.ErrAgendamentoNaoReagendavel.Error())
+}
+
+func TestReagendar_ClienteInativo(t *testing.T) {
+	router, agendamento := SetupAgendamentoCriado(t)
+
+	require.Equal(t, http.StatusNoContent, SetupPutStatusClienteRequest(router, agendamento.Cliente.ID, "inativar").Code)
+
+	rr := SetupPutReagendarRequest(router, agendamento.ID, request_agendamento.ReagendarAgendamentoRequest{
+		DataHoraInicio: "2030-01-03T10:00:00Z",
+	})
+	require.Equal(t, http.StatusConflict, rr.Code)
+	require.Contains(t, rr.Body.String(), service.ErrClienteInativo.Error())
+}
+
+func TestReagendar_NaoEncontrado(t *testing.T) {
+	router, _ := SetupAgendamentoCriado(t)
+
+	rr := SetupPutReagendarRequest(router, "agendamento-inexistente", request_agendamento.ReagendarAgendamentoRequest{
+		DataHoraInicio: "2030-01-03T10:00:00Z",
+	})
+	require.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = SetupGetReagendamentosRequest(router, "agendamento-inexistente")
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestReagendar_DadosInvalidos(t *testing.T) {
+	router, agendamento := SetupAgendamentoCriado(t)
+
+	rr := SetupPutReagendarRequest(router, agendamento.ID, request_agendamento.ReagendarAgendamentoRequest{
+		DataHoraInicio: "03/01/2030 10:00",
+	})
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
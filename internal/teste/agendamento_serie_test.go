@@ -0,0 +1,216 @@
+package teste
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"meu-servico-agenda/internal/adapters/http/agendamento/request_agendamento"
+	"meu-servico-agenda/internal/adapters/http/agendamento/response_agendamento"
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/application/service"
+	"meu-servico-agenda/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func SetupPostSerieRequest(router *gin.Engine, input request_agendamento.SerieAgendamentoRequest) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(input)
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/agendamentos/series", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func SetupPutSerieRequest(router *gin.Engine, serieID string, acao string, body any) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(body)
+
+	url := fmt.Sprintf("/api/v1/agendamentos/series/%s/%s", serieID, acao)
+	req, _ := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(payload))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+// SetupAgendaNasDatas adiciona ao prestador uma agenda das 08:00 às 12:00 em cada data
+func SetupAgendaNasDatas(p port.AgendaDiariaRepositorio, prestador *domain.Prestador, datas ...string) {
+	horaInicio, _ := time.Parse("15:04", "08:00")
+	horaFim, _ := time.Parse("15:04", "12:00")
+
+	for _, d := range datas {
+		data, _ := time.Parse("2006-01-02", d)
+		intervalo, _ := domain.NovoIntervaloDiario(horaInicio, horaFim)
+		agenda, _ := domain.NovaAgendaDiaria(data, []domain.IntervaloDiario{*intervalo})
+		p.Salvar(context.Background(), agenda, prestador.ID)
+		prestador.AdicionarAgenda(agenda)
+	}
+}
+
+// SetupSerieCriada cria uma série semanal de 4 ocorrências a partir de 2030-01-03 08:00,
+// com agenda apenas nas três primeiras quintas-feiras
+func SetupSerieCriada(t *testing.T) (*gin.Engine, port.ClienteRepositorio, *domain.Prestador, *domain.Catalogo, response_agendamento.SerieAgendamentoResponse) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	cliente := SetupNovoCliente(clienteRepo)
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+	SetupAgendaNasDatas(agendaDiariaRepo, prestador, "2030-01-03", "2030-01-10", "2030-01-17")
+
+	rr := SetupPostSerieRequest(router, request_agendamento.SerieAgendamentoRequest{
+		ClienteID:        cliente.ID,
+		PrestadorID:      prestador.ID,
+		CatalogoID:       catalogo.ID,
+		DataHoraInicio:   "2030-01-03T08:00:00Z",
+		IntervaloSemanas: 1,
+		Ocorrencias:      4,
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var serie response_agendamento.SerieAgendamentoResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &serie))
+
+	return router, clienteRepo, prestador, catalogo, serie
+}
+
+func TestSerie_CriaInformandoFalhas(t *testing.T) {
+	_, _, _, _, serie := SetupSerieCriada(t)
+
+	require.NotEmpty(t, serie.SerieID)
+	require.Len(t, serie.Agendados, 3)
+	for i, ag := range serie.Agendados {
+		require.Equal(t, serie.SerieID, ag.SerieID)
+		require.Equal(t, time.Date(2030, 1, 3+7*i, 8, 0, 0, 0, time.UTC), ag.DataInicio.UTC())
+	}
+
+	require.Len(t, serie.Falhas, 1)
+	require.Equal(t, time.Date(2030, 1, 24, 8, 0, 0, 0, time.UTC), serie.Falhas[0].DataInicio.UTC())
+	require.Equal(t, service.ErrDiaIndisponivel.Error(), serie.Falhas[0].Motivo)
+}
+
+func TestSerie_NenhumaOcorrenciaDisponivel(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, _ := SetupRouterAgendamento()
+
+	cliente := SetupNovoCliente(clienteRepo)
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+
+	rr := SetupPostSerieRequest(router, request_agendamento.SerieAgendamentoRequest{
+		ClienteID:        cliente.ID,
+		PrestadorID:      prestador.ID,
+		CatalogoID:       catalogo.ID,
+		DataHoraInicio:   "2030-01-03T08:00:00Z",
+		IntervaloSemanas: 2,
+		Ocorrencias:      2,
+	})
+	require.Equal(t, http.StatusConflict, rr.Code)
+
+	var serie response_agendamento.SerieAgendamentoResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &serie))
+	require.Empty(t, serie.SerieID)
+	require.Empty(t, serie.Agendados)
+	require.Len(t, serie.Falhas, 2)
+}
+
+func TestSerie_DadosInvalidos(t *testing.T) {
+	router, _, _, _, _ := SetupRouterAgendamento()
+
+	rr := SetupPostSerieRequest(router, request_agendamento.SerieAgendamentoRequest{
+		ClienteID:        "c",
+		PrestadorID:      "p",
+		CatalogoID:       "s",
+		DataHoraInicio:   "2030-01-03T08:00:00Z",
+		IntervaloSemanas: 5,
+		Ocorrencias:      4,
+	})
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestSerie_CancelarTodas(t *testing.T) {
+	router, _, _, _, serie := SetupSerieCriada(t)
+
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/agendamentos/series/"+serie.SerieID+"/cancelar?escopo=todas", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resultado response_agendamento.SerieAgendamentoResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resultado))
+	require.Len(t, resultado.Agendados, 3)
+	require.Empty(t, resultado.Falhas)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/agendamentos/series/"+serie.SerieID, nil)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var ocorrencias response_agendamento.BuscaDataResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &ocorrencias))
+	require.Len(t, ocorrencias.Data, 3)
+	for _, ag := range ocorrencias.Data {
+		require.Equal(t, domain.Cancelado, ag.Status)
+	}
+}
+
+func TestSerie_CancelarEscopoInvalido(t *testing.T) {
+	router, _, _, _, serie := SetupSerieCriada(t)
+
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/agendamentos/series/"+serie.SerieID+"/cancelar?escopo=algumas", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestSerie_ReagendarInformandoFalhas(t *testing.T) {
+	router, clienteRepo, prestador, catalogo, serie := SetupSerieCriada(t)
+
+	// outro cliente ocupa o novo horário da segunda ocorrência
+	outroCliente, _ := domain.NovoCliente("Maria", "maria@gmail.com", "62999697582")
+	clienteRepo.Salvar(context.Background(), outroCliente)
+	rr := SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+		ClienteID:      outroCliente.ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-10T10:00:00Z",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	rr = SetupPutSerieRequest(router, serie.SerieID, "reagendar", request_agendamento.ReagendarSerieRequest{
+		DataHoraInicio: "2030-01-03T10:00:00Z",
+		Escopo:         "todas",
+	})
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resultado response_agendamento.SerieAgendamentoResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resultado))
+
+	require.Len(t, resultado.Agendados, 2)
+	require.Equal(t, time.Date(2030, 1, 3, 10, 0, 0, 0, time.UTC), resultado.Agendados[0].DataInicio.UTC())
+	require.Equal(t, time.Date(2030, 1, 17, 10, 0, 0, 0, time.UTC), resultado.Agendados[1].DataInicio.UTC())
+
+	require.Len(t, resultado.Falhas, 1)
+	require.Equal(t, time.Date(2030, 1, 10, 10, 0, 0, 0, time.UTC), resultado.Falhas[0].DataInicio.UTC())
+	require.Equal(t, service.ErrPrestadorOcupado.Error(), resultado.Falhas[0].Motivo)
+}
+
+func TestSerie_NaoEncontrada(t *testing.T) {
+	router, _, _, _, _ := SetupRouterAgendamento()
+
+	rr := SetupPutSerieRequest(router, "serie-inexistente", "reagendar", request_agendamento.ReagendarSerieRequest{
+		DataHoraInicio: "2030-01-03T10:00:00Z",
+		Escopo:         "restantes",
+	})
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,94 @@
+package teste
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"meu-servico-agenda/internal/adapters/http/agendamento/request_agendamento"
+	"meu-servico-agenda/internal/adapters/http/agendamento/response_agendamento"
+	"meu-servico-agenda/internal/adapters/http/catalogo/request_catalogo"
+	"meu-servico-agenda/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func SetupPutCatalogoRequest(router *gin.Engine, id string, input request_catalogo.CatalogoUpdateRequest) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(input)
+
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/catalogos/"+id, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func TestServicoAgendado_AlteracaoNoCatalogoNaoMudaAgendamento(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	cliente := SetupNovoCliente(clienteRepo)
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+	SetupAgendaNasDatas(agendaDiariaRepo, prestador, "2030-01-03")
+
+	rr := SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+		ClienteID:      cliente.ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T09:00:00Z",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	rr = SetupPutCatalogoRequest(router, catalogo.ID, request_catalogo.CatalogoUpdateRequest{
+		Nome:          "Manutenção Completa",
+		DuracaoPadrao: 90,
+		Preco:         35000,
+		ImagemUrl:     catalogo.ImagemUrl,
+		Categoria:     "Estética",
+	})
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	rr = SetupGetAgendamentoClienteDataRequest(router, cliente.ID, "2030-01-03")
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response response_agendamento.BuscaDataResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Len(t, response.Data, 1)
+
+	// O agendamento mantém o serviço como foi contratado
+	servico := response.Data[0].Servico
+	require.Equal(t, catalogo.ID, servico.ID)
+	require.Equal(t, "Manutenção", servico.Nome)
+	require.Equal(t, 60, servico.Duracao)
+	require.Equal(t, 20000, servico.Preco)
+	require.Equal(t, "Beleza", servico.Categoria)
+}
+
+func TestServicoAgendado_UsaPrecoDoPrestador(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+	SetupAgendaNasDatas(agendaDiariaRepo, prestador, "2030-01-03")
+
+	preco := 25000
+	prestador.Condicoes = []domain.CondicaoDoServico{{CatalogoID: catalogo.ID, Preco: &preco}}
+
+	rr := SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+		ClienteID:      SetupNovoCliente(clienteRepo).ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T09:00:00Z",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var agendamento response_agendamento.AgendamentoResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &agendamento))
+	require.Equal(t, 25000, agendamento.Servico.Preco)
+	require.Equal(t, 60, agendamento.Servico.Duracao)
+}
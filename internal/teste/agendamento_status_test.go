@@ -0,0 +1,124 @@
+package teste
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"meu-servico-agenda/internal/adapters/http/agendamento/request_agendamento"
+	"meu-servico-agenda/internal/adapters/http/agendamento/response_agendamento"
+	"meu-servico-agenda/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func SetupPutStatusAgendamentoRequest(router *gin.Engine, id string, acao string) *httptest.ResponseRecorder {
+	url := fmt.Sprintf("/api/v1/agendamentos/%s/%s", id, acao)
+	req, _ := http.NewRequest(http.MethodPut, url, nil)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+// SetupAgendamentoCriado cria cliente, catálogo, prestador com agenda e um agendamento às 08:00
+func SetupAgendamentoCriado(t *testing.T) (*gin.Engine, response_agendamento.AgendamentoResponse) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	cliente := SetupNovoCliente(clienteRepo)
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+	agendaDiaria := SetupCriaAgendaDiaria(agendaDiariaRepo)
+	prestador.AdicionarAgenda(agendaDiaria)
+
+	input := request_agendamento.AgendamentoRequest{
+		ClienteID:      cliente.ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T08:00:00Z",
+	}
+	rr := SetupPostAgendamentoRequest(router, input)
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var agendamento response_agendamento.AgendamentoResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &agendamento))
+
+	return router, agendamento
+}
+
+func TestStatusAgendamento_FluxoCompleto(t *testing.T) {
+	router, agendamento := SetupAgendamentoCriado(t)
+
+	rr := SetupPutStatusAgendamentoRequest(router, agendamento.ID, "confirmar")
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	rr = SetupPutStatusAgendamentoRequest(router, agendamento.ID, "concluir")
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	rr = SetupGetAgendamentoClienteDataRequest(router, agendamento.Cliente.ID, "2030-01-03")
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response response_agendamento.BuscaDataResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Len(t, response.Data, 1)
+	require.Equal(t, domain.Concluido, response.Data[0].Status)
+}
+
+func TestStatusAgendamento_ConcluirPendenteNaoPermitido(t *testing.T) {
+	router, agendamento := SetupAgendamentoCriado(t)
+
+	rr := SetupPutStatusAgendamentoRequest(router, agendamento.ID, "concluir")
+	require.Equal(t, http.StatusConflict, rr.Code)
+	require.Contains(t, rr.Body.String(), domain.ErrTransicaoStatusInvalida.Error())
+}
+
+func TestStatusAgendamento_ConcluirCanceladoNaoPermitido(t *testing.T) {
+	router, agendamento := SetupAgendamentoCriado(t)
+
+	rr := SetupPutStatusAgendamentoRequest(router, agendamento.ID, "cancelar")
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	rr = SetupPutStatusAgendamentoRequest(router, agendamento.ID, "confirmar")
+	require.Equal(t, http.StatusConflict, rr.Code)
+
+	rr = SetupPutStatusAgendamentoRequest(router, agendamento.ID, "concluir")
+	require.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestStatusAgendamento_CancelarConcluidoNaoPermitido(t *testing.T) {
+	router, agendamento := SetupAgendamentoCriado(t)
+
+	require.Equal(t, http.StatusNoContent, SetupPutStatusAgendamentoRequest(router, agendamento.ID, "confirmar").Code)
+	require.Equal(t, http.StatusNoContent, SetupPutStatusAgendamentoRequest(router, agendamento.ID, "concluir").Code)
+
+	rr := SetupPutStatusAgendamentoRequest(router, agendamento.ID, "cancelar")
+	require.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestStatusAgendamento_NaoEncontrado(t *testing.T) {
+	router, _ := SetupAgendamentoCriado(t)
+
+	rr := SetupPutStatusAgendamentoRequest(router, "agendamento-inexistente", "confirmar")
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestStatusAgendamento_CancelamentoLiberaHorario(t *testing.T) {
+	router, agendamento := SetupAgendamentoCriado(t)
+
+	rr := SetupPutStatusAgendamentoRequest(router, agendamento.ID, "cancelar")
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	// mesmo cliente, mesmo horário: o agendamento cancelado não deve bloquear
+	input := request_agendamento.AgendamentoRequest{
+		ClienteID:      agendamento.Cliente.ID,
+		PrestadorID:    agendamento.Prestador.ID,
+		CatalogoID:     agendamento.Servico.ID,
+		DataHoraInicio: "2030-01-03T08:00:00Z",
+	}
+	rr = SetupPostAgendamentoRequest(router, input)
+	require.Equal(t, http.StatusCreated, rr.Code)
+}
@@ -0,0 +1,196 @@
+package teste
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"meu-servico-agenda/internal/adapters/http/agendamento/request_agendamento"
+	"meu-servico-agenda/internal/adapters/http/agendamento/response_agendamento"
+	"meu-servico-agenda/internal/adapters/http/prestador/request_prestador"
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/application/service"
+	"meu-servico-agenda/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func SetupPutTemposEntreAtendimentosRequest(router *gin.Engine, prestadorID string, input any) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(input)
+
+	url := fmt.Sprintf("/api/v1/prestadores/%s/tempos-entre-atendimentos", prestadorID)
+	req, _ := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+// SetupCatalogoComTempos cria um serviço de 60 minutos com preparo e limpeza
+func SetupCatalogoComTempos(t *testing.T, p port.CatalogoRepositorio, preparo, limpeza int) (*domain.Catalogo, *[]domain.Catalogo) {
+	catalogo, catalogos := SetupNovoCatalogo(p)
+	require.NoError(t, catalogo.DefinirTemposEntreAtendimentos(preparo, limpeza))
+	(*catalogos)[0] = *catalogo
+	return catalogo, catalogos
+}
+
+func TestTempoEntreAtendimentos_LimpezaBloqueiaHorarioSeguinte(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	catalogo, listaDeCatalogos := SetupCatalogoComTempos(t, catalogoRepo, 0, 15)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+	SetupAgendaNasDatas(agendaDiariaRepo, prestador, "2030-01-03")
+
+	rr := SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+		ClienteID:      SetupNovoCliente(clienteRepo).ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T09:00:00Z",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	// O cliente continua vendo apenas o atendimento
+	var agendamento response_agendamento.AgendamentoResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &agendamento))
+	require.True(t, agendamento.DataInicio.Equal(time.Date(2030, 1, 3, 9, 0, 0, 0, time.UTC)))
+	require.True(t, agendamento.DataFim.Equal(time.Date(2030, 1, 3, 10, 0, 0, 0, time.UTC)))
+
+	// 10:00 cai dentro da limpeza do atendimento anterior
+	rr = SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+		ClienteID:      SetupNovoCliente(clienteRepo).ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T10:00:00Z",
+	})
+	require.Equal(t, http.StatusConflict, rr.Code)
+	require.Contains(t, rr.Body.String(), service.ErrPrestadorOcupado.Error())
+
+	rr = SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+		ClienteID:      SetupNovoCliente(clienteRepo).ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T10:15:00Z",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+}
+
+func TestTempoEntreAtendimentos_HorariosConsideramPreparoELimpeza(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	catalogo, listaDeCatalogos := SetupCatalogoComTempos(t, catalogoRepo, 15, 15)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+	SetupAgendaNasDatas(agendaDiariaRepo, prestador, "2030-01-03")
+
+	rr := SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+		ClienteID:      SetupNovoCliente(clienteRepo).ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T09:00:00Z",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	rr = SetupGetHorariosRequest(router, prestador.ID, "data=2030-01-03&catalogo_id="+catalogo.ID)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response response_agendamento.HorariosDisponiveisResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+	// O agendamento das 09:00 bloqueia de 08:45 a 10:15; cada candidato precisa
+	// de 15 minutos livres antes e depois do atendimento
+	esperados := []string{"10:30", "10:45", "11:00"}
+	require.Len(t, response.Horarios, len(esperados))
+	for i, h := range response.Horarios {
+		require.Equal(t, esperados[i], h.UTC().Format("15:04"))
+	}
+}
+
+func TestTempoEntreAtendimentos_PrestadorSubstituiCatalogo(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	catalogo, listaDeCatalogos := SetupCatalogoComTempos(t, catalogoRepo, 0, 30)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+	SetupAgendaNasDatas(agendaDiariaRepo, prestador, "2030-01-03")
+
+	semLimpeza := 0
+	rr := SetupPutTemposEntreAtendimentosRequest(router, prestador.ID, request_prestador.TemposEntreAtendimentosRequest{
+		TempoLimpeza: &semLimpeza,
+	})
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	for _, inicio := range []string{"2030-01-03T09:00:00Z", "2030-01-03T10:00:00Z"} {
+		rr = SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+			ClienteID:      SetupNovoCliente(clienteRepo).ID,
+			PrestadorID:    prestador.ID,
+			CatalogoID:     catalogo.ID,
+			DataHoraInicio: inicio,
+		})
+		require.Equal(t, http.StatusCreated, rr.Code)
+	}
+}
+
+func TestTempoEntreAtendimentos_ReagendarLevaLimpeza(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	catalogo, listaDeCatalogos := SetupCatalogoComTempos(t, catalogoRepo, 0, 30)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+	SetupAgendaNasDatas(agendaDiariaRepo, prestador, "2030-01-03")
+
+	rr := SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+		ClienteID:      SetupNovoCliente(clienteRepo).ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T08:00:00Z",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	rr = SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+		ClienteID:      SetupNovoCliente(clienteRepo).ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T11:00:00Z",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var segundo response_agendamento.AgendamentoResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &segundo))
+
+	// Às 09:00 a limpeza do primeiro atendimento ainda não terminou
+	rr = SetupPutReagendarRequest(router, segundo.ID, request_agendamento.ReagendarAgendamentoRequest{
+		DataHoraInicio: "2030-01-03T09:00:00Z",
+	})
+	require.Equal(t, http.StatusConflict, rr.Code)
+
+	rr = SetupPutReagendarRequest(router, segundo.ID, request_agendamento.ReagendarAgendamentoRequest{
+		DataHoraInicio: "2030-01-03T09:30:00Z",
+	})
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	// Movido para 09:30, a limpeza bloqueia até 11:00
+	rr = SetupGetHorariosRequest(router, prestador.ID, "data=2030-01-03&catalogo_id="+catalogo.ID)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response response_agendamento.HorariosDisponiveisResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Len(t, response.Horarios, 1)
+	require.Equal(t, "11:00", response.Horarios[0].UTC().Format("15:04"))
+}
+
+func TestTempoEntreAtendimentos_ValorInvalido(t *testing.T) {
+	router, prestadorRepo, _, catalogoRepo, _ := SetupRouterAgendamento()
+
+	_, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+
+	rr := SetupPutTemposEntreAtendimentosRequest(router, prestador.ID, map[string]int{"tempo_preparo": 300})
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = SetupPutTemposEntreAtendimentosRequest(router, "inexistente", map[string]int{"tempo_preparo": 10})
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -2,6 +2,7 @@ package teste
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -15,6 +16,7 @@ import (
 	"meu-servico-agenda/internal/adapters/http/agendamento/response_agendamento"
 	"meu-servico-agenda/internal/adapters/http/catalogo"
 	"meu-servico-agenda/internal/adapters/http/cliente"
+	"meu-servico-agenda/internal/adapters/http/middleware"
 
 	"meu-servico-agenda/internal/adapters/http/prestador"
 
@@ -36,11 +38,15 @@ func SetupRouterAgendamento() (*gin.Engine, port.PrestadorRepositorio, port.Clie
 	clienteRepo := repository.NewFakeClienteRepositorio()
 	agendaDiariaRepo := repository.NovoFakeAgendaDiariaRepositorio()
 	agendamentoRepo := repository.NovoFakeAgendamentoRepositorio()
+	idempotente := middleware.Idempotencia(repository.NovoFakeIdempotenciaRepositorio(), middleware.TTLPadraoIdempotencia)
 
 	cadastroCliente := service.NovoServiceCliente(clienteRepo)
 	cadastroPrestador := service.NovaPrestadorService(prestadorRepo, catalogoRepo, agendaDiariaRepo)
 	cadastraCatalogo := service.NovoCatalogoService(catalogoRepo)
 	cadastraAgendamento := service.NovaAgendamentoService(prestadorRepo, agendamentoRepo, catalogoRepo, clienteRepo)
+	unidadeDeTrabalho := repository.NovaFakeUnidadeDeTrabalho(prestadorRepo, clienteRepo, agendaDiariaRepo, agendamentoRepo)
+	cadastroPrestador.DefinirUnidadeDeTrabalho(unidadeDeTrabalho)
+	cadastraAgendamento.DefinirUnidadeDeTrabalho(unidadeDeTrabalho)
 
 	router := gin.Default()
 	apiV1 := router.Group("/api/v1")
@@ -51,11 +57,28 @@ func SetupRouterAgendamento() (*gin.Engine, port.PrestadorRepositorio, port.Clie
 		agendamentoController := agendamento.NovoAgendamentoController(cadastraAgendamento)
 
 		apiV1.POST("/clientes", clienteController.PostCliente)
+		apiV1.PUT("/clientes/:id/inativar", clienteController.InativarCliente)
+		apiV1.PUT("/clientes/:id/ativar", clienteController.AtivarCliente)
 		apiV1.POST("/prestadores", prestadorController.PostPrestador)
 		apiV1.PUT("/prestadores/:id/agenda", prestadorController.PutAgenda)
+		apiV1.PUT("/prestadores/:id", prestadorController.UpdatePrestador)
+		apiV1.PUT("/prestadores/:id/tempos-entre-atendimentos", prestadorController.PutTemposEntreAtendimentos)
 		apiV1.POST("/catalogos", catalogoController.PostCatalogo)
-		apiV1.POST("/agendamentos", agendamentoController.PostAgendamento)
+		apiV1.PUT("/catalogos/:id", catalogoController.Atualizar)
+		apiV1.POST("/agendamentos", idempotente, agendamentoController.PostAgendamento)
 		apiV1.GET("/agendamentos/cliente/:id", agendamentoController.GetAgendamentoClienteData)
+		apiV1.PUT("/agendamentos/:id/confirmar", agendamentoController.PutConfirmarAgendamento)
+		apiV1.PUT("/agendamentos/:id/cancelar", agendamentoController.PutCancelarAgendamento)
+		apiV1.PUT("/agendamentos/:id/concluir", agendamentoController.PutConcluirAgendamento)
+		apiV1.PUT("/agendamentos/:id/reagendar", agendamentoController.PutReagendarAgendamento)
+		apiV1.GET("/agendamentos/:id/reagendamentos", agendamentoController.GetReagendamentos)
+		apiV1.POST("/agendamentos/series", idempotente, agendamentoController.PostSerieAgendamento)
+		apiV1.GET("/agendamentos/series/:id", agendamentoController.GetSerieAgendamento)
+		apiV1.PUT("/agendamentos/series/:id/cancelar", agendamentoController.PutCancelarSerie)
+		apiV1.PUT("/agendamentos/series/:id/reagendar", agendamentoController.PutReagendarSerie)
+		apiV1.POST("/agendamentos/visitas", idempotente, agendamentoController.PostVisita)
+		apiV1.GET("/agendamentos/visitas/:id", agendamentoController.GetVisita)
+		apiV1.GET("/prestadores/:id/horarios", agendamentoController.GetHorariosDisponiveis)
 	}
 
 	return router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo
@@ -74,21 +97,21 @@ func SetupPostAgendamentoRequest(router *gin.Engine, input request_agendamento.A
 
 func SetupNovoCliente(p port.ClienteRepositorio) *domain.Cliente {
 	cli, _ := domain.NovoCliente("Eduardo", "caetasousa@gmail.com", "62999697581")
-	p.Salvar(cli)
-	cliente, _ := p.BuscarPorId(cli.ID)
+	p.Salvar(context.Background(), cli)
+	cliente, _ := p.BuscarPorId(context.Background(), cli.ID)
 	return cliente
 }
 
 func SetupNovoCatalogo(p port.CatalogoRepositorio) (*domain.Catalogo, *[]domain.Catalogo) {
 	cat, _ := domain.NovoCatalogo("Manutenção", 60, 20000, "Beleza", "https://tdfuderuzpylkctxbysu.supabase.co/storage/v1/object/public/imagens/b094865b92ed1821.avif")
-	p.Salvar(cat)
+	p.Salvar(context.Background(), cat)
 	catalogos := []domain.Catalogo{*cat}
 	return cat, &catalogos
 }
 
 func SetupCriaPrestador(p port.PrestadorRepositorio, catalogo []domain.Catalogo) *domain.Prestador {
 	pres, _ := domain.NovoPrestador("Eduardo", "04423258196", "caetasousa@gmail.com", "662999687481", "https://exemplo.com/img1.jpg", catalogo)
-	p.Salvar(pres)
+	p.Salvar(context.Background(), pres)
 	return pres
 }
 
@@ -100,7 +123,7 @@ func SetupCriaAgendaDiaria(p port.AgendaDiariaRepositorio) *domain.AgendaDiaria
 	intervalo, _ := domain.NovoIntervaloDiario(horaInicio, horaFim)
 	intervalos := []domain.IntervaloDiario{*intervalo}
 	agendaDiaria, _ := domain.NovaAgendaDiaria(data, intervalos)
-	p.Salvar(agendaDiaria, "dasdf")
+	p.Salvar(context.Background(), agendaDiaria, "dasdf")
 
 	return agendaDiaria
 }
@@ -303,7 +326,7 @@ func TestPostAgendamento_PrestadorOcupado(t *testing.T) {
 
 	// cliente 2
 	cliente2, _ := domain.NovoCliente("Maria", "maria@email.com", "62999999999")
-	clienteRepo.Salvar(cliente2)
+	clienteRepo.Salvar(context.Background(), cliente2)
 
 	// catálogo
 	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
@@ -359,7 +382,7 @@ func TestPostAgendamento_ClienteOcupado(t *testing.T) {
 		"https://exemplo.com/img1.jpg",
 		*listaDeCatalogos,
 	)
-	prestadorRepo.Salvar(prestador2)
+	prestadorRepo.Salvar(context.Background(), prestador2)
 	agenda2 := SetupCriaAgendaDiaria(agendaDiariaRepo)
 	prestador2.AdicionarAgenda(agenda2)
 
@@ -429,7 +452,8 @@ func TestPostAgendamento_AgendamentoDuploMesmaCategoriaMesmoDia(t *testing.T) {
 	var response map[string]interface{}
 	err := json.Unmarshal(rr2.Body.Bytes(), &response)
 	require.NoError(t, err)
-	require.Contains(t, response["error"], "Ja existe um agendamento para essa categoria neste dia")
+	require.Equal(t, "agendamento_duplo", response["code"])
+	require.Contains(t, response["detail"], "já existe um agendamento para essa categoria neste dia")
 }
 
 func TestPostAgendamento_CategoriasDiferentesMesmoDia_Permitido(t *testing.T) {
@@ -449,7 +473,7 @@ func TestPostAgendamento_CategoriasDiferentesMesmoDia_Permitido(t *testing.T) {
 		"Beleza",
 		"https://exemplo.com/img2.jpg",
 	)
-	catalogoRepo.Salvar(catalogo2)
+	catalogoRepo.Salvar(context.Background(), catalogo2)
 
 	// adiciona o segundo catálogo à lista de catálogos do prestador
 	catalogosCompletos := append(*listaDeCatalogos1, *catalogo2)
@@ -510,7 +534,7 @@ func TestPostAgendamento_MesmaCategoriaDiasDiferentes_Permitido(t *testing.T) {
 	intervalo, _ := domain.NovoIntervaloDiario(horaInicio, horaFim)
 	intervalos := []domain.IntervaloDiario{*intervalo}
 	agendaDiaria2, _ := domain.NovaAgendaDiaria(data2, intervalos)
-	agendaDiariaRepo.Salvar(agendaDiaria2, prestador.ID)
+	agendaDiariaRepo.Salvar(context.Background(), agendaDiaria2, prestador.ID)
 	prestador.AdicionarAgenda(agendaDiaria2)
 
 	// primeiro agendamento - dia 03
@@ -0,0 +1,163 @@
+package teste
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"meu-servico-agenda/internal/adapters/http/agendamento/request_agendamento"
+	"meu-servico-agenda/internal/adapters/http/agendamento/response_agendamento"
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/application/service"
+	"meu-servico-agenda/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func SetupPostVisitaRequest(router *gin.Engine, input request_agendamento.VisitaRequest) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(input)
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/agendamentos/visitas", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+// SetupCatalogoManicure cria um segundo serviço de 45 minutos
+func SetupCatalogoManicure(p port.CatalogoRepositorio) *domain.Catalogo {
+	cat, _ := domain.NovoCatalogo("Manicure", 45, 5000, "Unhas", "https://exemplo.com/manicure.jpg")
+	p.Salvar(context.Background(), cat)
+	return cat
+}
+
+func TestVisita_PrestadoresDiferentesEmSequencia(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	cliente := SetupNovoCliente(clienteRepo)
+	corte, listaCorte := SetupNovoCatalogo(catalogoRepo)
+	manicure := SetupCatalogoManicure(catalogoRepo)
+	cabeleireiro := SetupCriaPrestador(prestadorRepo, *listaCorte)
+	manicurista := SetupCriaPrestador(prestadorRepo, []domain.Catalogo{*manicure})
+	SetupAgendaNasDatas(agendaDiariaRepo, cabeleireiro, "2030-01-03")
+	SetupAgendaNasDatas(agendaDiariaRepo, manicurista, "2030-01-03")
+
+	rr := SetupPostVisitaRequest(router, request_agendamento.VisitaRequest{
+		ClienteID:      cliente.ID,
+		DataHoraInicio: "2030-01-03T09:00:00Z",
+		Itens: []request_agendamento.ItemVisitaRequest{
+			{PrestadorID: cabeleireiro.ID, CatalogoID: corte.ID},
+			{PrestadorID: manicurista.ID, CatalogoID: manicure.ID},
+		},
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var visita response_agendamento.VisitaResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &visita))
+	require.NotEmpty(t, visita.ID)
+	require.Equal(t, 25000, visita.PrecoTotal)
+	require.Equal(t, 105, visita.DuracaoTotal)
+	require.Len(t, visita.Itens, 2)
+	require.Equal(t, "10:00", visita.Itens[1].DataInicio.UTC().Format("15:04"))
+	require.Equal(t, visita.ID, visita.Itens[1].VisitaID)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/agendamentos/visitas/"+visita.ID, nil)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var buscada response_agendamento.VisitaResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &buscada))
+	require.Len(t, buscada.Itens, 2)
+	require.Equal(t, corte.ID, buscada.Itens[0].Servico.ID)
+	require.Equal(t, 25000, buscada.PrecoTotal)
+}
+
+func TestVisita_MesmoPrestadorAguardaLimpeza(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	corte, _ := SetupCatalogoComTempos(t, catalogoRepo, 0, 15)
+	manicure := SetupCatalogoManicure(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, []domain.Catalogo{*corte, *manicure})
+	SetupAgendaNasDatas(agendaDiariaRepo, prestador, "2030-01-03")
+
+	rr := SetupPostVisitaRequest(router, request_agendamento.VisitaRequest{
+		ClienteID:      SetupNovoCliente(clienteRepo).ID,
+		DataHoraInicio: "2030-01-03T09:00:00Z",
+		Itens: []request_agendamento.ItemVisitaRequest{
+			{PrestadorID: prestador.ID, CatalogoID: corte.ID},
+			{PrestadorID: prestador.ID, CatalogoID: manicure.ID},
+		},
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var visita response_agendamento.VisitaResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &visita))
+	require.Equal(t, "10:15", visita.Itens[1].DataInicio.UTC().Format("15:04"))
+	require.Equal(t, 120, visita.DuracaoTotal)
+}
+
+func TestVisita_NenhumItemCriadoQuandoUmFalha(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	cliente := SetupNovoCliente(clienteRepo)
+	corte, listaCorte := SetupNovoCatalogo(catalogoRepo)
+	manicure := SetupCatalogoManicure(catalogoRepo)
+	cabeleireiro := SetupCriaPrestador(prestadorRepo, *listaCorte)
+	manicurista := SetupCriaPrestador(prestadorRepo, []domain.Catalogo{*manicure})
+	SetupAgendaNasDatas(agendaDiariaRepo, cabeleireiro, "2030-01-03")
+	SetupAgendaNasDatas(agendaDiariaRepo, manicurista, "2030-01-03")
+
+	rr := SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+		ClienteID:      SetupNovoCliente(clienteRepo).ID,
+		PrestadorID:    manicurista.ID,
+		CatalogoID:     manicure.ID,
+		DataHoraInicio: "2030-01-03T10:00:00Z",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	rr = SetupPostVisitaRequest(router, request_agendamento.VisitaRequest{
+		ClienteID:      cliente.ID,
+		DataHoraInicio: "2030-01-03T09:00:00Z",
+		Itens: []request_agendamento.ItemVisitaRequest{
+			{PrestadorID: cabeleireiro.ID, CatalogoID: corte.ID},
+			{PrestadorID: manicurista.ID, CatalogoID: manicure.ID},
+		},
+	})
+	require.Equal(t, http.StatusConflict, rr.Code)
+	require.Contains(t, rr.Body.String(), "item 2")
+	require.Contains(t, rr.Body.String(), service.ErrPrestadorOcupado.Error())
+
+	// O corte, que cabia na agenda, também não foi criado
+	rr = SetupGetAgendamentoClienteDataRequest(router, cliente.ID, "2030-01-03")
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response response_agendamento.BuscaDataResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Empty(t, response.Data)
+}
+
+func TestVisita_ServicoRepetido(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	corte, listaCorte := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaCorte)
+	SetupAgendaNasDatas(agendaDiariaRepo, prestador, "2030-01-03")
+
+	rr := SetupPostVisitaRequest(router, request_agendamento.VisitaRequest{
+		ClienteID:      SetupNovoCliente(clienteRepo).ID,
+		DataHoraInicio: "2030-01-03T09:00:00Z",
+		Itens: []request_agendamento.ItemVisitaRequest{
+			{PrestadorID: prestador.ID, CatalogoID: corte.ID},
+			{PrestadorID: prestador.ID, CatalogoID: corte.ID},
+		},
+	})
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	require.Contains(t, rr.Body.String(), domain.ErrServicoRepetidoNaVisita.Error())
+}
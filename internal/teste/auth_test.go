@@ -0,0 +1,381 @@
+package teste
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"meu-servico-agenda/internal/adapters/http/agendamento"
+	"meu-servico-agenda/internal/adapters/http/agendamento/request_agendamento"
+	"meu-servico-agenda/internal/adapters/http/auth"
+	"meu-servico-agenda/internal/adapters/http/auth/request_auth"
+	"meu-servico-agenda/internal/adapters/http/auth/response_auth"
+	"meu-servico-agenda/internal/adapters/http/catalogo"
+	"meu-servico-agenda/internal/adapters/http/catalogo/request_catalogo"
+	"meu-servico-agenda/internal/adapters/http/middleware"
+	"meu-servico-agenda/internal/adapters/http/prestador"
+	"meu-servico-agenda/internal/adapters/http/prestador/request_prestador"
+	"meu-servico-agenda/internal/adapters/repository"
+	"meu-servico-agenda/internal/core/application/input"
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/application/service"
+	"meu-servico-agenda/internal/core/domain"
+	"meu-servico-agenda/internal/infra/jwt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type cenarioAuth struct {
+	router           *gin.Engine
+	authService      *service.AuthService
+	usuarioRepo      port.UsuarioRepositorio
+	refreshTokenRepo port.RefreshTokenRepositorio
+	prestadorRepo    port.PrestadorRepositorio
+	clienteRepo      port.ClienteRepositorio
+	catalogoRepo     port.CatalogoRepositorio
+	agendaDiariaRepo port.AgendaDiariaRepositorio
+}
+
+// SetupRouterAuth monta as rotas com as mesmas regras de acesso de cmd/api/main.go
+func SetupRouterAuth() cenarioAuth {
+	gin.SetMode(gin.TestMode)
+
+	catalogoRepo := repository.NovoCatalogoFakeRepo()
+	prestadorRepo := repository.NovoFakePrestadorRepositorio(catalogoRepo)
+	clienteRepo := repository.NewFakeClienteRepositorio()
+	agendaDiariaRepo := repository.NovoFakeAgendaDiariaRepositorio()
+	agendamentoRepo := repository.NovoFakeAgendamentoRepositorio()
+	emissorToken := jwt.NovoEmissorHS256([]byte("segredo-de-teste"), 15*time.Minute)
+
+	usuarioRepo := repository.NovoFakeUsuarioRepositorio()
+	refreshTokenRepo := repository.NovoFakeRefreshTokenRepositorio()
+	authService := service.NovaAuthService(usuarioRepo, refreshTokenRepo, clienteRepo, prestadorRepo, emissorToken, service.TTLPadraoRefreshToken)
+	cadastroPrestador := service.NovaPrestadorService(prestadorRepo, catalogoRepo, agendaDiariaRepo)
+	cadastraCatalogo := service.NovoCatalogoService(catalogoRepo)
+	cadastraAgendamento := service.NovaAgendamentoService(prestadorRepo, agendamentoRepo, catalogoRepo, clienteRepo)
+
+	autenticado := middleware.Autenticar(emissorToken)
+	somenteAdmin := middleware.ExigirPapel(domain.PapelAdmin)
+	reservaDoCliente := middleware.ExigirPapel(domain.PapelAdmin, domain.PapelCliente)
+	atendimento := middleware.ExigirPapel(domain.PapelAdmin, domain.PapelPrestador)
+	clienteDoCorpo := middleware.ExigirClienteDoCorpo()
+	proprioCliente := middleware.ExigirDono(middleware.DonoCliente)
+	proprioPrestador := middleware.ExigirDono(middleware.DonoPrestador)
+	donoAgendamento := middleware.ExigirDono(middleware.DonoAgendamento(agendamentoRepo))
+
+	router := gin.Default()
+	apiV1 := router.Group("/api/v1")
+	{
+		authController := auth.NovoAuthController(authService)
+		prestadorController := prestador.NovoPrestadorController(cadastroPrestador)
+		catalogoController := catalogo.NovoCatalogoController(cadastraCatalogo)
+		agendamentoController := agendamento.NovoAgendamentoController(cadastraAgendamento)
+
+		apiV1.POST("/auth/login", authController.PostLogin)
+		apiV1.POST("/auth/refresh", authController.PostRefresh)
+		apiV1.POST("/auth/logout", authController.PostLogout)
+		apiV1.POST("/usuarios", autenticado, somenteAdmin, authController.PostUsuario)
+		apiV1.GET("/catalogos", catalogoController.GetCatalogos)
+		apiV1.POST("/catalogos", autenticado, somenteAdmin, catalogoController.PostCatalogo)
+		apiV1.PUT("/prestadores/:id/tempos-entre-atendimentos", autenticado, proprioPrestador, prestadorController.PutTemposEntreAtendimentos)
+		apiV1.POST("/agendamentos", autenticado, reservaDoCliente, clienteDoCorpo, agendamentoController.PostAgendamento)
+		apiV1.GET("/agendamentos/cliente/:id", autenticado, proprioCliente, agendamentoController.GetAgendamentoClienteData)
+		apiV1.PUT("/agendamentos/:id/confirmar", autenticado, atendimento, donoAgendamento, agendamentoController.PutConfirmarAgendamento)
+		apiV1.PUT("/agendamentos/:id/cancelar", autenticado, donoAgendamento, agendamentoController.PutCancelarAgendamento)
+	}
+
+	return cenarioAuth{
+		router:           router,
+		authService:      authService,
+		usuarioRepo:      usuarioRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		prestadorRepo:    prestadorRepo,
+		clienteRepo:      clienteRepo,
+		catalogoRepo:     catalogoRepo,
+		agendaDiariaRepo: agendaDiariaRepo,
+	}
+}
+
+func SetupRequestAutenticado(router *gin.Engine, method, url, token string, input any) *httptest.ResponseRecorder {
+	var body []byte
+	if input != nil {
+		body, _ = json.Marshal(input)
+	}
+
+	req, _ := http.NewRequest(method, url, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func SetupLogin(t *testing.T, router *gin.Engine, email, senha string) response_auth.TokensResponse {
+	rr := SetupRequestAutenticado(router, http.MethodPost, "/api/v1/auth/login", "", request_auth.LoginRequest{Email: email, Senha: senha})
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var tokens response_auth.TokensResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &tokens))
+	return tokens
+}
+
+// SetupUsuarioLogado cadastra o usuário e devolve o token de acesso
+func SetupUsuarioLogado(t *testing.T, c cenarioAuth, email string, papel domain.Papel, clienteID, prestadorID string) string {
+	_, err := c.authService.CadastrarUsuario(context.Background(), input.CadastrarUsuarioInput{
+		Email:       email,
+		Senha:       "senha-segura",
+		Papel:       string(papel),
+		ClienteID:   clienteID,
+		PrestadorID: prestadorID,
+	})
+	require.NoError(t, err)
+
+	return SetupLogin(t, c.router, email, "senha-segura").AccessToken
+}
+
+func TestLogin_CredenciaisInvalidas(t *testing.T) {
+	c := SetupRouterAuth()
+	require.NoError(t, c.authService.GarantirAdmin(context.Background(), "admin@salao.com", "senha-segura"))
+
+	tokens := SetupLogin(t, c.router, "ADMIN@salao.com", "senha-segura")
+	require.NotEmpty(t, tokens.AccessToken)
+	require.NotEmpty(t, tokens.RefreshToken)
+	require.Equal(t, "Bearer", tokens.TokenType)
+
+	for _, credenciais := range []request_auth.LoginRequest{
+		{Email: "admin@salao.com", Senha: "senha-errada"},
+		{Email: "ninguem@salao.com", Senha: "senha-segura"},
+	} {
+		rr := SetupRequestAutenticado(c.router, http.MethodPost, "/api/v1/auth/login", "", credenciais)
+		require.Equal(t, http.StatusUnauthorized, rr.Code)
+		require.Contains(t, rr.Body.String(), service.ErrCredenciaisInvalidas.Error())
+	}
+
+	// O admin só é criado uma vez
+	require.NoError(t, c.authService.GarantirAdmin(context.Background(), "outro@salao.com", "senha-segura"))
+	rr := SetupRequestAutenticado(c.router, http.MethodPost, "/api/v1/auth/login", "", request_auth.LoginRequest{Email: "outro@salao.com", Senha: "senha-segura"})
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestRefresh_TrocaTokensEDetectaReuso(t *testing.T) {
+	c := SetupRouterAuth()
+	require.NoError(t, c.authService.GarantirAdmin(context.Background(), "admin@salao.com", "senha-segura"))
+	login := SetupLogin(t, c.router, "admin@salao.com", "senha-segura")
+
+	rr := SetupRequestAutenticado(c.router, http.MethodPost, "/api/v1/auth/refresh", "", request_auth.RefreshTokenRequest{RefreshToken: login.RefreshToken})
+	require.Equal(t, http.StatusOK, rr.Code)
+	var renovado response_auth.TokensResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &renovado))
+	require.NotEqual(t, login.RefreshToken, renovado.RefreshToken)
+
+	// O token novo funciona nas rotas protegidas
+	rr = SetupRequestAutenticado(c.router, http.MethodPost, "/api/v1/usuarios", renovado.AccessToken, request_auth.UsuarioRequest{
+		Email: "gerente@salao.com",
+		Senha: "senha-segura",
+		Papel: "admin",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	// Reusar o refresh token já trocado encerra todas as sessões do usuário
+	rr = SetupRequestAutenticado(c.router, http.MethodPost, "/api/v1/auth/refresh", "", request_auth.RefreshTokenRequest{RefreshToken: login.RefreshToken})
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	rr = SetupRequestAutenticado(c.router, http.MethodPost, "/api/v1/auth/refresh", "", request_auth.RefreshTokenRequest{RefreshToken: renovado.RefreshToken})
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestLogout_RevogaRefreshToken(t *testing.T) {
+	c := SetupRouterAuth()
+	require.NoError(t, c.authService.GarantirAdmin(context.Background(), "admin@salao.com", "senha-segura"))
+	login := SetupLogin(t, c.router, "admin@salao.com", "senha-segura")
+
+	rr := SetupRequestAutenticado(c.router, http.MethodPost, "/api/v1/auth/logout", "", request_auth.RefreshTokenRequest{RefreshToken: login.RefreshToken})
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	rr = SetupRequestAutenticado(c.router, http.MethodPost, "/api/v1/auth/refresh", "", request_auth.RefreshTokenRequest{RefreshToken: login.RefreshToken})
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestPostUsuario_ValidaVinculo(t *testing.T) {
+	c := SetupRouterAuth()
+	tokenAdmin := SetupUsuarioLogado(t, c, "admin@salao.com", domain.PapelAdmin, "", "")
+	cliente := SetupNovoCliente(c.clienteRepo)
+
+	rr := SetupRequestAutenticado(c.router, http.MethodPost, "/api/v1/usuarios", tokenAdmin, request_auth.UsuarioRequest{
+		Email:     "cliente@example.com",
+		Senha:     "senha-segura",
+		Papel:     "cliente",
+		ClienteID: cliente.ID,
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+	require.NotContains(t, rr.Body.String(), "senha")
+
+	rr = SetupRequestAutenticado(c.router, http.MethodPost, "/api/v1/usuarios", tokenAdmin, request_auth.UsuarioRequest{
+		Email:     "cliente@example.com",
+		Senha:     "senha-segura",
+		Papel:     "cliente",
+		ClienteID: cliente.ID,
+	})
+	require.Equal(t, http.StatusConflict, rr.Code)
+
+	// Conta de prestador sem o prestador_id
+	rr = SetupRequestAutenticado(c.router, http.MethodPost, "/api/v1/usuarios", tokenAdmin, request_auth.UsuarioRequest{
+		Email: "prestador@example.com",
+		Senha: "senha-segura",
+		Papel: "prestador",
+	})
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	require.Contains(t, rr.Body.String(), domain.ErrVinculoUsuarioInvalido.Error())
+
+	rr = SetupRequestAutenticado(c.router, http.MethodPost, "/api/v1/usuarios", tokenAdmin, request_auth.UsuarioRequest{
+		Email:     "fantasma@example.com",
+		Senha:     "senha-segura",
+		Papel:     "cliente",
+		ClienteID: "id-inexistente",
+	})
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestRotasProtegidas_ExigemTokenEPapel(t *testing.T) {
+	c := SetupRouterAuth()
+	cliente := SetupNovoCliente(c.clienteRepo)
+	tokenCliente := SetupUsuarioLogado(t, c, "cliente@example.com", domain.PapelCliente, cliente.ID, "")
+	tokenAdmin := SetupUsuarioLogado(t, c, "admin@salao.com", domain.PapelAdmin, "", "")
+
+	novoCatalogo := request_catalogo.CatalogoRequest{Nome: "Corte", DuracaoPadrao: 30, Preco: 5000, ImagemUrl: "https://exemplo.com/corte.jpg", Categoria: "Cabelo"}
+
+	// Rotas de leitura do catálogo continuam públicas
+	rr := SetupRequestAutenticado(c.router, http.MethodGet, "/api/v1/catalogos", "", nil)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	rr = SetupRequestAutenticado(c.router, http.MethodPost, "/api/v1/catalogos", "", novoCatalogo)
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	rr = SetupRequestAutenticado(c.router, http.MethodPost, "/api/v1/catalogos", "token-forjado", novoCatalogo)
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	rr = SetupRequestAutenticado(c.router, http.MethodPost, "/api/v1/catalogos", tokenCliente, novoCatalogo)
+	require.Equal(t, http.StatusForbidden, rr.Code)
+
+	rr = SetupRequestAutenticado(c.router, http.MethodPost, "/api/v1/catalogos", tokenAdmin, novoCatalogo)
+	require.Equal(t, http.StatusCreated, rr.Code)
+}
+
+func TestCliente_SoAgendaEConsultaOsPropriosAgendamentos(t *testing.T) {
+	c := SetupRouterAuth()
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(c.catalogoRepo)
+	prestadorDaAgenda := SetupCriaPrestador(c.prestadorRepo, *listaDeCatalogos)
+	SetupAgendaNasDatas(c.agendaDiariaRepo, prestadorDaAgenda, "2030-01-03")
+
+	ana := SetupNovoCliente(c.clienteRepo)
+	bruno, _ := domain.NovoCliente("Bruno", "bruno@example.com", "62999990000")
+	require.NoError(t, c.clienteRepo.Salvar(context.Background(), bruno))
+
+	tokenAna := SetupUsuarioLogado(t, c, "ana@example.com", domain.PapelCliente, ana.ID, "")
+	tokenPrestador := SetupUsuarioLogado(t, c, "eduardo@salao.com", domain.PapelPrestador, "", prestadorDaAgenda.ID)
+
+	agendamentoDoBruno := request_agendamento.AgendamentoRequest{
+		ClienteID:      bruno.ID,
+		PrestadorID:    prestadorDaAgenda.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T09:00:00Z",
+	}
+	rr := SetupRequestAutenticado(c.router, http.MethodPost, "/api/v1/agendamentos", tokenAna, agendamentoDoBruno)
+	require.Equal(t, http.StatusForbidden, rr.Code)
+
+	// Prestador não agenda em nome de clientes
+	rr = SetupRequestAutenticado(c.router, http.MethodPost, "/api/v1/agendamentos", tokenPrestador, agendamentoDoBruno)
+	require.Equal(t, http.StatusForbidden, rr.Code)
+
+	rr = SetupRequestAutenticado(c.router, http.MethodPost, "/api/v1/agendamentos", tokenAna, request_agendamento.AgendamentoRequest{
+		ClienteID:      ana.ID,
+		PrestadorID:    prestadorDaAgenda.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T09:00:00Z",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+	var criado struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &criado))
+
+	rr = SetupRequestAutenticado(c.router, http.MethodGet, "/api/v1/agendamentos/cliente/"+ana.ID+"?data=2030-01-01", tokenAna, nil)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	rr = SetupRequestAutenticado(c.router, http.MethodGet, "/api/v1/agendamentos/cliente/"+bruno.ID+"?data=2030-01-01", tokenAna, nil)
+	require.Equal(t, http.StatusForbidden, rr.Code)
+
+	// Só quem atende confirma; o cliente pode cancelar o próprio agendamento
+	rr = SetupRequestAutenticado(c.router, http.MethodPut, "/api/v1/agendamentos/"+criado.ID+"/confirmar", tokenAna, nil)
+	require.Equal(t, http.StatusForbidden, rr.Code)
+
+	rr = SetupRequestAutenticado(c.router, http.MethodPut, "/api/v1/agendamentos/"+criado.ID+"/confirmar", tokenPrestador, nil)
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	rr = SetupRequestAutenticado(c.router, http.MethodPut, "/api/v1/agendamentos/"+criado.ID+"/cancelar", tokenAna, nil)
+	require.Equal(t, http.StatusNoContent, rr.Code)
+}
+
+func TestPrestador_SoAlteraAPropriaAgenda(t *testing.T) {
+	c := SetupRouterAuth()
+	_, listaDeCatalogos := SetupNovoCatalogo(c.catalogoRepo)
+	eduardo := SetupCriaPrestador(c.prestadorRepo, *listaDeCatalogos)
+	outro := SetupCriaPrestador(c.prestadorRepo, *listaDeCatalogos)
+
+	tokenEduardo := SetupUsuarioLogado(t, c, "eduardo@salao.com", domain.PapelPrestador, "", eduardo.ID)
+
+	limpeza := 10
+	tempos := request_prestador.TemposEntreAtendimentosRequest{TempoLimpeza: &limpeza}
+
+	rr := SetupRequestAutenticado(c.router, http.MethodPut, "/api/v1/prestadores/"+outro.ID+"/tempos-entre-atendimentos", tokenEduardo, tempos)
+	require.Equal(t, http.StatusForbidden, rr.Code)
+
+	rr = SetupRequestAutenticado(c.router, http.MethodPut, "/api/v1/prestadores/"+eduardo.ID+"/tempos-entre-atendimentos", tokenEduardo, tempos)
+	require.Equal(t, http.StatusNoContent, rr.Code)
+}
+
+func TestLogin_FalhaDepoisDaAnonimizacaoDoCliente(t *testing.T) {
+	c := SetupRouterAuth()
+	ctx := context.Background()
+	cliente := SetupNovoCliente(c.clienteRepo)
+	_, err := c.authService.CadastrarUsuario(ctx, input.CadastrarUsuarioInput{
+		Email:     "ana@exemplo.com",
+		Senha:     "senha-segura",
+		Papel:     string(domain.PapelCliente),
+		ClienteID: cliente.ID,
+	})
+	require.NoError(t, err)
+	login := SetupLogin(t, c.router, "ana@exemplo.com", "senha-segura")
+
+	lgpdService := service.NovaLGPDService(c.clienteRepo, repository.NovoFakeAgendamentoRepositorio(),
+		repository.NovoFakeListaEsperaRepositorio(), repository.NovoFakeSolicitacaoLGPDRepositorio(),
+		repository.NovoFakeTokenCalendarioRepositorio(), c.usuarioRepo, c.refreshTokenRepo)
+	require.NoError(t, lgpdService.Anonimizar(ctx, cliente.ID, adminLGPD, ""))
+
+	rr := SetupRequestAutenticado(c.router, http.MethodPost, "/api/v1/auth/login", "", request_auth.LoginRequest{Email: "ana@exemplo.com", Senha: "senha-segura"})
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	// A sessão aberta antes da anonimização também foi encerrada
+	rr = SetupRequestAutenticado(c.router, http.MethodPost, "/api/v1/auth/refresh", "", request_auth.RefreshTokenRequest{RefreshToken: login.RefreshToken})
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	// O email de login não fica guardado
+	usuario, err := c.usuarioRepo.BuscarPorEmail(ctx, "ana@exemplo.com")
+	require.NoError(t, err)
+	require.Nil(t, usuario)
+
+	usuarios, err := c.usuarioRepo.BuscarPorCliente(ctx, cliente.ID)
+	require.NoError(t, err)
+	require.Len(t, usuarios, 1)
+	require.False(t, usuarios[0].Ativo)
+	require.Equal(t, usuarios[0].ID+"@anonimizado.invalid", usuarios[0].Email)
+}
@@ -0,0 +1,324 @@
+package teste
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"meu-servico-agenda/internal/adapters/http/agendamento"
+	"meu-servico-agenda/internal/adapters/http/agendamento/request_agendamento"
+	"meu-servico-agenda/internal/adapters/http/agendamento/response_agendamento"
+	"meu-servico-agenda/internal/adapters/http/calendario"
+	"meu-servico-agenda/internal/adapters/http/calendario/response_calendario"
+	"meu-servico-agenda/internal/adapters/http/cliente"
+	"meu-servico-agenda/internal/adapters/http/lgpd"
+	"meu-servico-agenda/internal/adapters/http/middleware"
+	"meu-servico-agenda/internal/adapters/http/prestador"
+	"meu-servico-agenda/internal/adapters/http/resposta"
+	"meu-servico-agenda/internal/adapters/repository"
+	"meu-servico-agenda/internal/core/application/output"
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/application/service"
+	"meu-servico-agenda/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// SetupRouterCalendario monta as rotas do feed e as que invalidam a URL: inativar o
+// dono e anonimizar o cliente
+func SetupRouterCalendario() (*gin.Engine, port.PrestadorRepositorio, port.ClienteRepositorio, port.CatalogoRepositorio, port.AgendaDiariaRepositorio) {
+	gin.SetMode(gin.TestMode)
+
+	catalogoRepo := repository.NovoCatalogoFakeRepo()
+	prestadorRepo := repository.NovoFakePrestadorRepositorio(catalogoRepo)
+	clienteRepo := repository.NewFakeClienteRepositorio()
+	agendaDiariaRepo := repository.NovoFakeAgendaDiariaRepositorio()
+	agendamentoRepo := repository.NovoFakeAgendamentoRepositorio()
+	listaEsperaRepo := repository.NovoFakeListaEsperaRepositorio()
+	solicitacaoLGPDRepo := repository.NovoFakeSolicitacaoLGPDRepositorio()
+	tokenCalendarioRepo := repository.NovoFakeTokenCalendarioRepositorio()
+	usuarioRepo := repository.NovoFakeUsuarioRepositorio()
+	refreshTokenRepo := repository.NovoFakeRefreshTokenRepositorio()
+
+	cadastroCliente := service.NovoServiceCliente(clienteRepo)
+	cadastroPrestador := service.NovaPrestadorService(prestadorRepo, catalogoRepo, agendaDiariaRepo)
+	cadastraAgendamento := service.NovaAgendamentoService(prestadorRepo, agendamentoRepo, catalogoRepo, clienteRepo)
+	lgpdService := service.NovaLGPDService(clienteRepo, agendamentoRepo, listaEsperaRepo, solicitacaoLGPDRepo, tokenCalendarioRepo, usuarioRepo, refreshTokenRepo)
+	calendarioService := service.NovaCalendarioService(clienteRepo, prestadorRepo, agendamentoRepo, tokenCalendarioRepo)
+	cadastroCliente.DefinirTokensCalendario(tokenCalendarioRepo)
+	cadastroPrestador.DefinirTokensCalendario(tokenCalendarioRepo)
+	unidadeDeTrabalho := repository.NovaFakeUnidadeDeTrabalho(prestadorRepo, clienteRepo, agendaDiariaRepo, agendamentoRepo, listaEsperaRepo, solicitacaoLGPDRepo, tokenCalendarioRepo, usuarioRepo, refreshTokenRepo)
+	cadastroPrestador.DefinirUnidadeDeTrabalho(unidadeDeTrabalho)
+	cadastraAgendamento.DefinirUnidadeDeTrabalho(unidadeDeTrabalho)
+	lgpdService.DefinirUnidadeDeTrabalho(unidadeDeTrabalho)
+
+	autenticado := middleware.Autenticar(emissorDeTeste)
+	somenteAdmin := middleware.ExigirPapel(domain.PapelAdmin)
+
+	router := gin.Default()
+	apiV1 := router.Group("/api/v1")
+	{
+		clienteController := cliente.NovoClienteController(cadastroCliente)
+		prestadorController := prestador.NovoPrestadorController(cadastroPrestador)
+		agendamentoController := agendamento.NovoAgendamentoController(cadastraAgendamento)
+		lgpdController := lgpd.NovoLGPDController(lgpdService)
+		calendarioController := calendario.NovoCalendarioController(calendarioService)
+
+		apiV1.PUT("/clientes/:id/inativar", clienteController.InativarCliente)
+		apiV1.PUT("/clientes/:id/ativar", clienteController.AtivarCliente)
+		apiV1.POST("/clientes/:id/lgpd/anonimizar", autenticado, somenteAdmin, lgpdController.PostAnonimizar)
+		apiV1.PUT("/prestadores/:id/inativar", prestadorController.InativarPrestador)
+		apiV1.POST("/agendamentos", agendamentoController.PostAgendamento)
+		apiV1.PUT("/agendamentos/:id/cancelar", agendamentoController.PutCancelarAgendamento)
+		apiV1.POST("/clientes/:id/calendario", calendarioController.PostCalendarioCliente)
+		apiV1.DELETE("/clientes/:id/calendario", calendarioController.DeleteCalendarioCliente)
+		apiV1.POST("/prestadores/:id/calendario", calendarioController.PostCalendarioPrestador)
+		apiV1.DELETE("/prestadores/:id/calendario", calendarioController.DeleteCalendarioPrestador)
+		apiV1.GET("/calendario/:token", calendarioController.GetFeed)
+	}
+
+	return router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo
+}
+
+func SetupPostCalendarioRequest(router *gin.Engine, dono, id string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/"+dono+"/"+id+"/calendario", nil)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+// SetupGerarCalendario gera a URL do feed e devolve o caminho a ser assinado
+func SetupGerarCalendario(t *testing.T, router *gin.Engine, dono, id string) string {
+	t.Helper()
+	rr := SetupPostCalendarioRequest(router, dono, id)
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var resp response_calendario.TokenCalendarioResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.NotEmpty(t, resp.Token)
+	require.Equal(t, "/api/v1/calendario/"+resp.Token+".ics", resp.URL)
+	return resp.URL
+}
+
+// SetupAgendamentosDoCalendario cria dois agendamentos entre o mesmo cliente e
+// prestador e cancela o segundo
+func SetupAgendamentosDoCalendario(t *testing.T) (*gin.Engine, *domain.Cliente, *domain.Prestador, []string) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterCalendario()
+
+	cliente := SetupNovoCliente(clienteRepo)
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+	prestador.Nome = "Marina"
+	SetupAgendaNasDatas(agendaDiariaRepo, prestador, "2030-01-03", "2030-01-10")
+
+	var ids []string
+	for _, inicio := range []string{"2030-01-03T09:00:00Z", "2030-01-10T09:00:00Z"} {
+		rr := SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+			ClienteID:      cliente.ID,
+			PrestadorID:    prestador.ID,
+			CatalogoID:     catalogo.ID,
+			DataHoraInicio: inicio,
+			Notas:          "trazer referência; cor, tamanho",
+		})
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		var agendamento response_agendamento.AgendamentoResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &agendamento))
+		ids = append(ids, agendamento.ID)
+	}
+
+	rr := SetupPutStatusAgendamentoRequest(router, ids[1], "cancelar")
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	return router, cliente, prestador, ids
+}
+
+// eventos separa o feed em VEVENTs, já desdobrando as linhas
+func eventos(t *testing.T, feed string) []string {
+	t.Helper()
+	require.True(t, strings.HasPrefix(feed, "BEGIN:VCALENDAR\r\n"))
+	require.True(t, strings.HasSuffix(feed, "END:VCALENDAR\r\n"))
+
+	feed = strings.ReplaceAll(feed, "\r\n ", "")
+	partes := strings.Split(feed, "BEGIN:VEVENT\r\n")[1:]
+	for i, parte := range partes {
+		partes[i], _, _ = strings.Cut(parte, "END:VEVENT\r\n")
+	}
+	return partes
+}
+
+func SetupDeleteCalendarioRequest(router *gin.Engine, dono, id string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/"+dono+"/"+id+"/calendario", nil)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func SetupGetFeedRequest(router *gin.Engine, url string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func TestCalendario_FeedDoPrestadorTrazClienteECancelamentos(t *testing.T) {
+	router, cliente, prestador, ids := SetupAgendamentosDoCalendario(t)
+	url := SetupGerarCalendario(t, router, "prestadores", prestador.ID)
+
+	rr := SetupGetFeedRequest(router, url)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, response_calendario.ContentTypeICalendar, rr.Header().Get("Content-Type"))
+
+	evs := eventos(t, rr.Body.String())
+	require.Len(t, evs, 2)
+
+	require.Contains(t, evs[0], "UID:"+ids[0]+"@meu-servico-agenda\r\n")
+	require.Contains(t, evs[0], "DTSTART:20300103T090000Z\r\n")
+	require.Contains(t, evs[0], "DTEND:20300103T100000Z\r\n")
+	require.Contains(t, evs[0], "SUMMARY:Manutenção - "+cliente.Nome+"\r\n")
+	require.Contains(t, evs[0], "DESCRIPTION:trazer referência\\; cor\\, tamanho\r\n")
+	require.Contains(t, evs[0], "STATUS:TENTATIVE\r\n")
+
+	require.Contains(t, evs[1], "UID:"+ids[1]+"@meu-servico-agenda\r\n")
+	require.Contains(t, evs[1], "STATUS:CANCELLED\r\n")
+}
+
+func TestCalendario_FeedDoClienteTrazPrestador(t *testing.T) {
+	router, cliente, prestador, _ := SetupAgendamentosDoCalendario(t)
+	url := SetupGerarCalendario(t, router, "clientes", cliente.ID)
+
+	// A extensão .ics é opcional
+	rr := SetupGetFeedRequest(router, strings.TrimSuffix(url, ".ics"))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	evs := eventos(t, rr.Body.String())
+	require.Len(t, evs, 2)
+	require.Contains(t, evs[0], "SUMMARY:Manutenção - "+prestador.Nome+"\r\n")
+}
+
+func TestCalendario_NovaURLInvalidaAAnterior(t *testing.T) {
+	router, cliente, _, _ := SetupAgendamentosDoCalendario(t)
+	anterior := SetupGerarCalendario(t, router, "clientes", cliente.ID)
+	atual := SetupGerarCalendario(t, router, "clientes", cliente.ID)
+	require.NotEqual(t, anterior, atual)
+
+	rr := SetupGetFeedRequest(router, anterior)
+	require.Equal(t, http.StatusNotFound, rr.Code)
+
+	var corpo resposta.Problema
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &corpo))
+	require.Equal(t, "calendario_nao_encontrado", corpo.Code)
+
+	rr = SetupGetFeedRequest(router, atual)
+	require.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestCalendario_ClienteInexistente(t *testing.T) {
+	router, _, _, _ := SetupAgendamentosDoCalendario(t)
+
+	rr := SetupPostCalendarioRequest(router, "clientes", "inexistente")
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestCalendario_RevogarInvalidaURLSemCriarOutra(t *testing.T) {
+	router, cliente, prestador, _ := SetupAgendamentosDoCalendario(t)
+
+	for _, dono := range []struct{ rota, id string }{{"clientes", cliente.ID}, {"prestadores", prestador.ID}} {
+		url := SetupGerarCalendario(t, router, dono.rota, dono.id)
+
+		rr := SetupDeleteCalendarioRequest(router, dono.rota, dono.id)
+		require.Equal(t, http.StatusNoContent, rr.Code, dono.rota)
+
+		rr = SetupGetFeedRequest(router, url)
+		require.Equal(t, http.StatusNotFound, rr.Code, dono.rota)
+
+		// Revogar de novo não é erro: o dono só fica sem URL
+		rr = SetupDeleteCalendarioRequest(router, dono.rota, dono.id)
+		require.Equal(t, http.StatusNoContent, rr.Code, dono.rota)
+	}
+
+	rr := SetupDeleteCalendarioRequest(router, "clientes", "inexistente")
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestCalendario_InativarDonoInvalidaURL(t *testing.T) {
+	router, cliente, prestador, _ := SetupAgendamentosDoCalendario(t)
+	urlCliente := SetupGerarCalendario(t, router, "clientes", cliente.ID)
+	urlPrestador := SetupGerarCalendario(t, router, "prestadores", prestador.ID)
+
+	rr := SetupPutStatusClienteRequest(router, cliente.ID, "inativar")
+	require.Equal(t, http.StatusNoContent, rr.Code)
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/prestadores/"+prestador.ID+"/inativar", nil)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	require.Equal(t, http.StatusNotFound, SetupGetFeedRequest(router, urlCliente).Code)
+	require.Equal(t, http.StatusNotFound, SetupGetFeedRequest(router, urlPrestador).Code)
+
+	// O token foi apagado: reativar o cliente não traz a URL antiga de volta
+	rr = SetupPutStatusClienteRequest(router, cliente.ID, "ativar")
+	require.Equal(t, http.StatusNoContent, rr.Code)
+	require.Equal(t, http.StatusNotFound, SetupGetFeedRequest(router, urlCliente).Code)
+}
+
+func TestCalendario_AnonimizarInvalidaURL(t *testing.T) {
+	router, cliente, _, _ := SetupAgendamentosDoCalendario(t)
+	url := SetupGerarCalendario(t, router, "clientes", cliente.ID)
+
+	rr := SetupLGPDRequest(router, http.MethodPost, cliente.ID, "anonimizar", "atendente@salao")
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	rr = SetupGetFeedRequest(router, url)
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestCalendario_DonoInativoNaoAbreFeedMesmoComToken(t *testing.T) {
+	router, _, clienteRepo, _, _ := SetupRouterCalendario()
+	cliente := SetupNovoCliente(clienteRepo)
+	url := SetupGerarCalendario(t, router, "clientes", cliente.ID)
+
+	// Inativado direto no repositório, sem passar pelo serviço que apaga o token
+	require.NoError(t, clienteRepo.AtualizarStatus(context.Background(), cliente.ID, false))
+
+	rr := SetupGetFeedRequest(router, url)
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestICalendar_DobraLinhasLongasSemPartirCaracteres(t *testing.T) {
+	feed := string(response_calendario.NovoICalendar(&output.CalendarioOutput{
+		PrestadorID: "p1",
+		Agendamentos: []*output.AgendamentoOutput{{
+			ID:             "a1",
+			Servico:        domain.ServicoAgendado{Nome: "Corte"},
+			DataHoraInicio: time.Date(2030, 1, 3, 9, 0, 0, 0, time.UTC),
+			DataHoraFim:    time.Date(2030, 1, 3, 10, 0, 0, 0, time.UTC),
+			Status:         domain.Concluido,
+			Notas:          strings.Repeat("ção ", 40) + "\nsegunda linha",
+		}},
+	}, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	for _, linha := range strings.Split(strings.TrimSuffix(feed, "\r\n"), "\r\n") {
+		require.LessOrEqual(t, len(linha), 75)
+		require.True(t, utf8.ValidString(linha), linha)
+	}
+
+	evs := eventos(t, feed)
+	require.Len(t, evs, 1)
+	require.Contains(t, evs[0], "DESCRIPTION:"+strings.Repeat("ção ", 40)+"\\nsegunda linha\r\n")
+	require.Contains(t, evs[0], "SUMMARY:Corte\r\n")
+	require.Contains(t, evs[0], "STATUS:CONFIRMED\r\n")
+	require.Contains(t, evs[0], "DTSTAMP:20300101T000000Z\r\n")
+}
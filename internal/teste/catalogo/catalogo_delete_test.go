@@ -35,11 +35,11 @@ func TestDeletarCatalogo_Sucesso(t *testing.T) {
 	rrGet := SetupGetCatalogoRequest(router, id)
 	assert.Equal(t, http.StatusNotFound, rrGet.Code)
 
-	var resp map[string]string
+	var resp map[string]any
 	err = json.Unmarshal(rrGet.Body.Bytes(), &resp)
 	assert.NoError(t, err)
-	_, exists := resp["error"]
-	assert.True(t, exists, "Resposta deve conter campo 'error'")
+	_, exists := resp["code"]
+	assert.True(t, exists, "Resposta deve conter campo 'code'")
 }
 
 func TestDeletarCatalogo_NaoEncontrado(t *testing.T) {
@@ -48,11 +48,11 @@ func TestDeletarCatalogo_NaoEncontrado(t *testing.T) {
 	rr := SetupDeleteCatalogoRequest(router, "id-inexistente")
 	assert.Equal(t, http.StatusNotFound, rr.Code)
 
-	var resp map[string]string
+	var resp map[string]any
 	err := json.Unmarshal(rr.Body.Bytes(), &resp)
 	assert.NoError(t, err)
-	_, exists := resp["error"]
-	assert.True(t, exists, "Resposta deve conter campo 'error'")
+	_, exists := resp["code"]
+	assert.True(t, exists, "Resposta deve conter campo 'code'")
 }
 
 func TestDeletarCatalogo_VerificaListagemAposDelete(t *testing.T) {
@@ -1,10 +1,13 @@
 package teste
 
 import (
+	"bytes"
 	"encoding/json"
 	"meu-servico-agenda/internal/adapters/http/catalogo/request_catalogo"
+	"meu-servico-agenda/internal/adapters/http/middleware"
 	"meu-servico-agenda/internal/core/domain"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -51,10 +54,10 @@ func TestPostCatalogo_NomeVazio_DeveRetornar400(t *testing.T) {
 	rr := SetupPostCatalogoRequest(router, input)
 
 	assert.Equal(t, http.StatusBadRequest, rr.Code, "Esperado 400 Bad Request quando nome está vazio")
-	var resp map[string]string
+	var resp map[string]any
 	_ = json.Unmarshal(rr.Body.Bytes(), &resp)
-	_, exists := resp["error"]
-	assert.True(t, exists, "Resposta deve conter campo 'error'")
+	_, exists := resp["code"]
+	assert.True(t, exists, "Resposta deve conter campo 'code'")
 }
 
 func TestPostCatalogo_DuracaoInvalida_DeveRetornar400(t *testing.T) {
@@ -70,10 +73,10 @@ func TestPostCatalogo_DuracaoInvalida_DeveRetornar400(t *testing.T) {
 	rr := PostJSONFromMap(router, input)
 
 	assert.Equal(t, http.StatusBadRequest, rr.Code, "Esperado 400 Bad Request para tipo inválido de duracao_padrao")
-	var resp map[string]string
+	var resp map[string]any
 	_ = json.Unmarshal(rr.Body.Bytes(), &resp)
-	_, exists := resp["error"]
-	assert.True(t, exists, "Resposta deve conter campo 'error'")
+	_, exists := resp["code"]
+	assert.True(t, exists, "Resposta deve conter campo 'code'")
 }
 
 func TestPostCatalogo_PrecoInvalido_DeveRetornar400(t *testing.T) {
@@ -89,10 +92,10 @@ func TestPostCatalogo_PrecoInvalido_DeveRetornar400(t *testing.T) {
 	rr := PostJSONFromMap(router, input)
 
 	assert.Equal(t, http.StatusBadRequest, rr.Code, "Esperado 400 Bad Request para tipo inválido de preco")
-	var resp map[string]string
+	var resp map[string]any
 	_ = json.Unmarshal(rr.Body.Bytes(), &resp)
-	_, exists := resp["error"]
-	assert.True(t, exists, "Resposta deve conter campo 'error'")
+	_, exists := resp["code"]
+	assert.True(t, exists, "Resposta deve conter campo 'code'")
 }
 
 func TestPostCatalogo_NomeMuitoCurto_DeveRetornar400(t *testing.T) {
@@ -108,10 +111,10 @@ func TestPostCatalogo_NomeMuitoCurto_DeveRetornar400(t *testing.T) {
 	rr := SetupPostCatalogoRequest(router, input)
 
 	assert.Equal(t, http.StatusBadRequest, rr.Code, "Esperado 400 para nome com < 3 caracteres")
-	var resp map[string]string
+	var resp map[string]any
 	_ = json.Unmarshal(rr.Body.Bytes(), &resp)
-	_, exists := resp["error"]
-	assert.True(t, exists, "Resposta deve conter campo 'error'")
+	_, exists := resp["code"]
+	assert.True(t, exists, "Resposta deve conter campo 'code'")
 }
 
 func TestPostCatalogo_CategoriaMuitoCurta_DeveRetornar400(t *testing.T) {
@@ -127,8 +130,54 @@ func TestPostCatalogo_CategoriaMuitoCurta_DeveRetornar400(t *testing.T) {
 	rr := SetupPostCatalogoRequest(router, input)
 
 	assert.Equal(t, http.StatusBadRequest, rr.Code, "Esperado 400 para categoria com < 3 caracteres")
-	var resp map[string]string
+	var resp map[string]any
 	_ = json.Unmarshal(rr.Body.Bytes(), &resp)
-	_, exists := resp["error"]
-	assert.True(t, exists, "Resposta deve conter campo 'error'")
-}
\ No newline at end of file
+	_, exists := resp["code"]
+	assert.True(t, exists, "Resposta deve conter campo 'code'")
+}
+func TestPostCatalogo_IdempotencyKeyRepeteResposta(t *testing.T) {
+	router, _ := SetupRouterCatalogo()
+
+	input := request_catalogo.CatalogoRequest{
+		Nome:          "Corte de Cabelo",
+		DuracaoPadrao: 30,
+		Preco:         3500.0,
+		Categoria:     "Beleza",
+		ImagemUrl:     "https://tdfuderuzpylkctxbysu.supabase.co/storage/v1/object/public/imagens/b094865b92ed1821.avif",
+	}
+	body, _ := json.Marshal(input)
+
+	enviar := func() *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/catalogos", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(middleware.HeaderIdempotencyKey, "catalogo-1")
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	primeira := enviar()
+	repetida := enviar()
+
+	assert.Equal(t, http.StatusCreated, primeira.Code)
+	assert.Equal(t, http.StatusCreated, repetida.Code)
+	assert.Equal(t, "true", repetida.Header().Get(middleware.HeaderIdempotentReplayed))
+	assert.JSONEq(t, primeira.Body.String(), repetida.Body.String())
+}
+
+func TestPostCatalogo_TempoEntreAtendimentosInvalido_DeveRetornar400(t *testing.T) {
+	router, _ := SetupRouterCatalogo()
+
+	input := request_catalogo.CatalogoRequest{
+		Nome:          "Corte de Cabelo",
+		DuracaoPadrao: 30,
+		Preco:         3500,
+		Categoria:     "Beleza",
+		ImagemUrl:     "https://tdfuderuzpylkctxbysu.supabase.co/storage/v1/object/public/imagens/b094865b92ed1821.avif",
+		TempoLimpeza:  300,
+	}
+
+	rr := SetupPostCatalogoRequest(router, input)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code, "Esperado 400 Bad Request para limpeza acima de 240 minutos")
+}
@@ -115,11 +115,11 @@ func TestAtualizarCatalogo_CatalogoNaoEncontrado(t *testing.T) {
 	rr := SetupPutCatalogoRequest(router, "id-inexistente", inputPut)
 	assert.Equal(t, http.StatusNotFound, rr.Code)
 
-	var resp map[string]string
+	var resp map[string]any
 	err := json.Unmarshal(rr.Body.Bytes(), &resp)
 	assert.NoError(t, err)
-	_, exists := resp["error"]
-	assert.True(t, exists, "Resposta deve conter campo 'error'")
+	_, exists := resp["code"]
+	assert.True(t, exists, "Resposta deve conter campo 'code'")
 }
 
 func TestAtualizarCatalogo_NomeVazio_DeveRetornar400(t *testing.T) {
@@ -147,11 +147,11 @@ func TestAtualizarCatalogo_NomeVazio_DeveRetornar400(t *testing.T) {
 	rr := SetupPutCatalogoRequest(router, criado.ID, inputPut)
 	assert.Equal(t, http.StatusBadRequest, rr.Code)
 
-	var resp map[string]string
+	var resp map[string]any
 	err := json.Unmarshal(rr.Body.Bytes(), &resp)
 	assert.NoError(t, err)
-	_, exists := resp["error"]
-	assert.True(t, exists, "Resposta deve conter campo 'error'")
+	_, exists := resp["code"]
+	assert.True(t, exists, "Resposta deve conter campo 'code'")
 }
 
 func TestAtualizarCatalogo_DuracaoInvalida_DeveRetornar400(t *testing.T) {
@@ -179,11 +179,11 @@ func TestAtualizarCatalogo_DuracaoInvalida_DeveRetornar400(t *testing.T) {
 	rr := SetupPutCatalogoRequest(router, criado.ID, inputPut)
 	assert.Equal(t, http.StatusBadRequest, rr.Code)
 
-	var resp map[string]string
+	var resp map[string]any
 	err := json.Unmarshal(rr.Body.Bytes(), &resp)
 	assert.NoError(t, err)
-	_, exists := resp["error"]
-	assert.True(t, exists, "Resposta deve conter campo 'error'")
+	_, exists := resp["code"]
+	assert.True(t, exists, "Resposta deve conter campo 'code'")
 }
 
 func TestAtualizarCatalogo_PrecoNegativo_DeveRetornar400(t *testing.T) {
@@ -211,11 +211,11 @@ func TestAtualizarCatalogo_PrecoNegativo_DeveRetornar400(t *testing.T) {
 	rr := SetupPutCatalogoRequest(router, criado.ID, inputPut)
 	assert.Equal(t, http.StatusBadRequest, rr.Code)
 
-	var resp map[string]string
+	var resp map[string]any
 	err := json.Unmarshal(rr.Body.Bytes(), &resp)
 	assert.NoError(t, err)
-	_, exists := resp["error"]
-	assert.True(t, exists, "Resposta deve conter campo 'error'")
+	_, exists := resp["code"]
+	assert.True(t, exists, "Resposta deve conter campo 'code'")
 }
 
 func TestAtualizarCatalogo_CategoriaMuitoCurta_DeveRetornar400(t *testing.T) {
@@ -243,11 +243,11 @@ func TestAtualizarCatalogo_CategoriaMuitoCurta_DeveRetornar400(t *testing.T) {
 	rr := SetupPutCatalogoRequest(router, criado.ID, inputPut)
 	assert.Equal(t, http.StatusBadRequest, rr.Code)
 
-	var resp map[string]string
+	var resp map[string]any
 	err := json.Unmarshal(rr.Body.Bytes(), &resp)
 	assert.NoError(t, err)
-	_, exists := resp["error"]
-	assert.True(t, exists, "Resposta deve conter campo 'error'")
+	_, exists := resp["code"]
+	assert.True(t, exists, "Resposta deve conter campo 'code'")
 }
 
 func TestAtualizarCatalogo_JSONInvalido_DeveRetornar400(t *testing.T) {
@@ -269,9 +269,54 @@ func TestAtualizarCatalogo_JSONInvalido_DeveRetornar400(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, rr.Code)
 
-	var resp map[string]string
+	var resp map[string]any
 	err := json.Unmarshal(rr.Body.Bytes(), &resp)
 	assert.NoError(t, err)
-	_, exists := resp["error"]
-	assert.True(t, exists, "Resposta deve conter campo 'error'")
+	_, exists := resp["code"]
+	assert.True(t, exists, "Resposta deve conter campo 'code'")
+}
+
+func TestAtualizarCatalogo_TemposEntreAtendimentos_MantemQuandoAusentes(t *testing.T) {
+	router, _ := SetupRouterCatalogo()
+
+	inputPost := request_catalogo.CatalogoRequest{
+		Nome:          "Corte de Cabelo",
+		DuracaoPadrao: 30,
+		Preco:         3500,
+		Categoria:     "Beleza",
+		ImagemUrl:     "https://exemplo.com/img1.jpg",
+		TempoPreparo:  10,
+		TempoLimpeza:  15,
+	}
+	rrPost := SetupPostCatalogoRequest(router, inputPost)
+	assert.Equal(t, http.StatusCreated, rrPost.Code)
+
+	var criado map[string]interface{}
+	err := json.Unmarshal(rrPost.Body.Bytes(), &criado)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(10), criado["tempo_preparo"])
+	assert.Equal(t, float64(15), criado["tempo_limpeza"])
+
+	id := criado["id"].(string)
+
+	// Sem tempo_preparo o valor atual é mantido
+	body, _ := json.Marshal(map[string]interface{}{
+		"nome":           "Corte de Cabelo",
+		"duracao_padrao": 30,
+		"preco":          3500,
+		"categoria":      "Beleza",
+		"image_url":      "https://exemplo.com/img1.jpg",
+		"tempo_limpeza":  0,
+	})
+	rrPut := PutRawJSON(router, id, body)
+	assert.Equal(t, http.StatusNoContent, rrPut.Code)
+
+	rrGet := SetupGetCatalogoRequest(router, id)
+	assert.Equal(t, http.StatusOK, rrGet.Code)
+
+	var atualizado map[string]interface{}
+	err = json.Unmarshal(rrGet.Body.Bytes(), &atualizado)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(10), atualizado["tempo_preparo"])
+	assert.Equal(t, float64(0), atualizado["tempo_limpeza"])
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"meu-servico-agenda/internal/adapters/http/catalogo"
 	"meu-servico-agenda/internal/adapters/http/catalogo/request_catalogo"
+	"meu-servico-agenda/internal/adapters/http/middleware"
 	"meu-servico-agenda/internal/adapters/repository"
 	"meu-servico-agenda/internal/core/application/port"
 	"meu-servico-agenda/internal/core/application/service"
@@ -21,11 +22,12 @@ func SetupRouterCatalogo() (*gin.Engine, port.CatalogoRepositorio) {
 	catalogoRepo := repository.NovoCatalogoFakeRepo()
 	cadastroService := service.NovoCatalogoService(catalogoRepo)
 	catalogoController := catalogo.NovoCatalogoController(cadastroService)
+	idempotente := middleware.Idempotencia(repository.NovoFakeIdempotenciaRepositorio(), middleware.TTLPadraoIdempotencia)
 
 	router := gin.Default()
 	apiV1 := router.Group("/api/v1")
 	{
-		apiV1.POST("/catalogos", catalogoController.PostCatalogo)
+		apiV1.POST("/catalogos", idempotente, catalogoController.PostCatalogo)
 		apiV1.GET("/catalogos/:id", catalogoController.GetCatalogoPorID)
 		apiV1.GET("/catalogos", catalogoController.GetCatalogos)
 		apiV1.PUT("/catalogos/:id", catalogoController.Atualizar)
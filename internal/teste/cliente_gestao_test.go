@@ -0,0 +1,208 @@
+package teste
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"meu-servico-agenda/internal/adapters/http/agendamento/request_agendamento"
+	"meu-servico-agenda/internal/adapters/http/cliente/request"
+	"meu-servico-agenda/internal/adapters/http/cliente/response"
+	"meu-servico-agenda/internal/core/application/service"
+	"meu-servico-agenda/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func SetupPutClienteRequest(router *gin.Engine, id string, input interface{}) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(input)
+
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/clientes/"+id, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func SetupPutStatusClienteRequest(router *gin.Engine, id string, acao string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("/api/v1/clientes/%s/%s", id, acao), nil)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func SetupGetClientesRequest(router *gin.Engine, query string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/clientes?"+query, nil)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func SetupClienteCadastrado(t *testing.T, router *gin.Engine, nome, email, telefone string) domain.Cliente {
+	rr := SetupPostClienteRequest(router, request.ClienteRequest{Nome: nome, Email: email, Telefone: telefone})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var cliente domain.Cliente
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &cliente))
+	return cliente
+}
+
+func TestPostCliente_EmailDuplicado_DeveRetornar409(t *testing.T) {
+	router, _ := SetupRouterCliente()
+
+	SetupClienteCadastrado(t, router, "Ana", "ana@example.com", "6299697481")
+
+	rr := SetupPostClienteRequest(router, request.ClienteRequest{
+		Nome:     "Ana Maria",
+		Email:    "ana@example.com",
+		Telefone: "6299697482",
+	})
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Contains(t, rr.Body.String(), domain.ErrEmailJaCadastrado.Error())
+}
+
+func TestPutCliente_AtualizaContato(t *testing.T) {
+	router, _ := SetupRouterCliente()
+
+	cliente := SetupClienteCadastrado(t, router, "Ana", "ana@example.com", "6299697481")
+
+	rr := SetupPutClienteRequest(router, cliente.ID, request.ClienteUpdateRequest{
+		Nome:     "Ana Souza",
+		Email:    "ana.souza@example.com",
+		Telefone: "62999990000",
+	})
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	rr = SetupGetClienteRequest(router, cliente.ID)
+	var buscado domain.Cliente
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &buscado))
+	assert.Equal(t, "Ana Souza", buscado.Nome)
+	assert.Equal(t, "ana.souza@example.com", buscado.Email)
+	assert.Equal(t, "62999990000", buscado.Telefone)
+	assert.True(t, buscado.Ativo)
+}
+
+func TestPutCliente_EmailDeOutroCliente_DeveRetornar409(t *testing.T) {
+	router, _ := SetupRouterCliente()
+
+	SetupClienteCadastrado(t, router, "Ana", "ana@example.com", "6299697481")
+	bruno := SetupClienteCadastrado(t, router, "Bruno", "bruno@example.com", "6299697482")
+
+	rr := SetupPutClienteRequest(router, bruno.ID, request.ClienteUpdateRequest{
+		Nome:     "Bruno",
+		Email:    "ana@example.com",
+		Telefone: "6299697482",
+	})
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	// Manter o próprio email não é conflito
+	rr = SetupPutClienteRequest(router, bruno.ID, request.ClienteUpdateRequest{
+		Nome:     "Bruno Lima",
+		Email:    "bruno@example.com",
+		Telefone: "6299697482",
+	})
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestPutCliente_NaoEncontrado(t *testing.T) {
+	router, _ := SetupRouterCliente()
+
+	rr := SetupPutClienteRequest(router, "id-inexistente", request.ClienteUpdateRequest{
+		Nome:     "Ana",
+		Email:    "ana@example.com",
+		Telefone: "6299697481",
+	})
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestGetClientes_BuscaEPaginacao(t *testing.T) {
+	router, _ := SetupRouterCliente()
+
+	SetupClienteCadastrado(t, router, "Carla Dias", "carla@example.com", "62911110000")
+	SetupClienteCadastrado(t, router, "Ana Dias", "ana@example.com", "62922220000")
+	SetupClienteCadastrado(t, router, "Bruno Lima", "bruno@outro.com", "62933330000")
+
+	rr := SetupGetClientesRequest(router, "busca=dias&limit=1&page=2")
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var pagina response.ClienteListResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &pagina))
+	assert.Equal(t, 2, pagina.Total)
+	assert.Equal(t, 2, pagina.Page)
+	require.Len(t, pagina.Data, 1)
+	assert.Equal(t, "Carla Dias", pagina.Data[0].Nome)
+
+	// A busca também considera email e telefone
+	rr = SetupGetClientesRequest(router, "busca=outro.com")
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &pagina))
+	require.Len(t, pagina.Data, 1)
+	assert.Equal(t, "Bruno Lima", pagina.Data[0].Nome)
+
+	rr = SetupGetClientesRequest(router, "busca=922220")
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &pagina))
+	require.Len(t, pagina.Data, 1)
+	assert.Equal(t, "Ana Dias", pagina.Data[0].Nome)
+
+	rr = SetupGetClientesRequest(router, "limit=500")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestInativarCliente_FiltraListagemEReativa(t *testing.T) {
+	router, _ := SetupRouterCliente()
+
+	ana := SetupClienteCadastrado(t, router, "Ana", "ana@example.com", "6299697481")
+	SetupClienteCadastrado(t, router, "Bruno", "bruno@example.com", "6299697482")
+
+	rr := SetupPutStatusClienteRequest(router, ana.ID, "inativar")
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	rr = SetupGetClientesRequest(router, "ativo=false")
+	var inativos response.ClienteListResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &inativos))
+	require.Len(t, inativos.Data, 1)
+	assert.Equal(t, ana.ID, inativos.Data[0].ID)
+
+	rr = SetupPutStatusClienteRequest(router, ana.ID, "ativar")
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	rr = SetupGetClientesRequest(router, "ativo=true")
+	var ativos response.ClienteListResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &ativos))
+	assert.Equal(t, 2, ativos.Total)
+
+	rr = SetupPutStatusClienteRequest(router, "id-inexistente", "inativar")
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestPostAgendamento_ClienteInativo(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterAgendamento()
+
+	cliente := SetupNovoCliente(clienteRepo)
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+	SetupAgendaNasDatas(agendaDiariaRepo, prestador, "2030-01-03")
+
+	rr := SetupPutStatusClienteRequest(router, cliente.ID, "inativar")
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	rr = SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+		ClienteID:      cliente.ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T09:00:00Z",
+	})
+	require.Equal(t, http.StatusConflict, rr.Code)
+	require.Contains(t, rr.Body.String(), service.ErrClienteInativo.Error())
+}
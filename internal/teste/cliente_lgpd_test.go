@@ -0,0 +1,288 @@
+package teste
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"meu-servico-agenda/internal/adapters/http/agendamento"
+	"meu-servico-agenda/internal/adapters/http/agendamento/request_agendamento"
+	"meu-servico-agenda/internal/adapters/http/cliente"
+	"meu-servico-agenda/internal/adapters/http/lgpd"
+	"meu-servico-agenda/internal/adapters/http/lgpd/response_lgpd"
+	"meu-servico-agenda/internal/adapters/http/lista_espera"
+	"meu-servico-agenda/internal/adapters/http/middleware"
+	"meu-servico-agenda/internal/adapters/repository"
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/application/service"
+	"meu-servico-agenda/internal/core/domain"
+	"meu-servico-agenda/internal/infra/jwt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// adminLGPD é o usuário com que SetupLGPDRequest faz os pedidos
+const adminLGPD = "admin-lgpd"
+
+// emissorDeTeste assina os tokens das rotas autenticadas de SetupRouterLGPD e SetupRouterCalendario
+var emissorDeTeste = jwt.NovoEmissorHS256([]byte("segredo-de-teste"), 15*time.Minute)
+
+// SetupRouterLGPD monta as rotas LGPD, com as mesmas regras de acesso de cmd/api/main.go,
+// e as rotas que criam os dados exportados e anonimizados
+func SetupRouterLGPD() (*gin.Engine, port.PrestadorRepositorio, port.ClienteRepositorio, port.CatalogoRepositorio, port.AgendaDiariaRepositorio) {
+	gin.SetMode(gin.TestMode)
+
+	catalogoRepo := repository.NovoCatalogoFakeRepo()
+	prestadorRepo := repository.NovoFakePrestadorRepositorio(catalogoRepo)
+	clienteRepo := repository.NewFakeClienteRepositorio()
+	agendaDiariaRepo := repository.NovoFakeAgendaDiariaRepositorio()
+	agendamentoRepo := repository.NovoFakeAgendamentoRepositorio()
+	listaEsperaRepo := repository.NovoFakeListaEsperaRepositorio()
+	solicitacaoLGPDRepo := repository.NovoFakeSolicitacaoLGPDRepositorio()
+	tokenCalendarioRepo := repository.NovoFakeTokenCalendarioRepositorio()
+	usuarioRepo := repository.NovoFakeUsuarioRepositorio()
+	refreshTokenRepo := repository.NovoFakeRefreshTokenRepositorio()
+
+	cadastroCliente := service.NovoServiceCliente(clienteRepo)
+	cadastraAgendamento := service.NovaAgendamentoService(prestadorRepo, agendamentoRepo, catalogoRepo, clienteRepo)
+	listaEsperaService := service.NovaListaEsperaService(listaEsperaRepo, prestadorRepo, catalogoRepo, clienteRepo, cadastraAgendamento)
+	lgpdService := service.NovaLGPDService(clienteRepo, agendamentoRepo, listaEsperaRepo, solicitacaoLGPDRepo, tokenCalendarioRepo, usuarioRepo, refreshTokenRepo)
+	unidadeDeTrabalho := repository.NovaFakeUnidadeDeTrabalho(clienteRepo, agendamentoRepo, listaEsperaRepo, solicitacaoLGPDRepo, tokenCalendarioRepo, usuarioRepo, refreshTokenRepo)
+	cadastraAgendamento.DefinirUnidadeDeTrabalho(unidadeDeTrabalho)
+	listaEsperaService.DefinirUnidadeDeTrabalho(unidadeDeTrabalho)
+	lgpdService.DefinirUnidadeDeTrabalho(unidadeDeTrabalho)
+
+	autenticado := middleware.Autenticar(emissorDeTeste)
+	somenteAdmin := middleware.ExigirPapel(domain.PapelAdmin)
+
+	router := gin.Default()
+	apiV1 := router.Group("/api/v1")
+	{
+		clienteController := cliente.NovoClienteController(cadastroCliente)
+		agendamentoController := agendamento.NovoAgendamentoController(cadastraAgendamento)
+		listaEsperaController := lista_espera.NovoListaEsperaController(listaEsperaService)
+		lgpdController := lgpd.NovoLGPDController(lgpdService)
+
+		negacaoExportacao := middleware.RegistrarNegacao(lgpdController.RegistrarNegacao(domain.SolicitacaoExportacao))
+		negacaoAnonimizacao := middleware.RegistrarNegacao(lgpdController.RegistrarNegacao(domain.SolicitacaoAnonimizacao))
+
+		apiV1.GET("/clientes/:id", clienteController.GetCliente)
+		apiV1.PUT("/clientes/:id/ativar", clienteController.AtivarCliente)
+		apiV1.GET("/clientes/:id/lgpd/exportacao", autenticado, negacaoExportacao, somenteAdmin, lgpdController.GetExportacao)
+		apiV1.POST("/clientes/:id/lgpd/anonimizar", autenticado, negacaoAnonimizacao, somenteAdmin, lgpdController.PostAnonimizar)
+		apiV1.GET("/clientes/:id/lgpd/solicitacoes", autenticado, somenteAdmin, lgpdController.GetSolicitacoes)
+		apiV1.POST("/agendamentos", agendamentoController.PostAgendamento)
+		apiV1.POST("/agendamentos/series", agendamentoController.PostSerieAgendamento)
+		apiV1.POST("/agendamentos/visitas", agendamentoController.PostVisita)
+		apiV1.POST("/lista-espera", listaEsperaController.PostListaEspera)
+	}
+
+	return router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo
+}
+
+func SetupLGPDRequest(router *gin.Engine, method, clienteID, acao, observacao string) *httptest.ResponseRecorder {
+	return SetupLGPDRequestComo(router, &domain.Identidade{UsuarioID: adminLGPD, Papel: domain.PapelAdmin}, method, clienteID, acao, observacao)
+}
+
+func SetupLGPDRequestComo(router *gin.Engine, identidade *domain.Identidade, method, clienteID, acao, observacao string) *httptest.ResponseRecorder {
+	token, _, _ := emissorDeTeste.Emitir(identidade)
+
+	req, _ := http.NewRequest(method, "/api/v1/clientes/"+clienteID+"/lgpd/"+acao, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	if observacao != "" {
+		req.Header.Set("X-Solicitante", observacao)
+	}
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+// SetupClienteComAgendamento cria um cliente com um agendamento que tem notas
+func SetupClienteComAgendamento(t *testing.T) (*gin.Engine, *domain.Cliente) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterLGPD()
+
+	cliente := SetupNovoCliente(clienteRepo)
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+	SetupAgendaNasDatas(agendaDiariaRepo, prestador, "2030-01-03")
+
+	rr := SetupPostAgendamentoRequest(router, request_agendamento.AgendamentoRequest{
+		ClienteID:      cliente.ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T09:00:00Z",
+		Notas:          "alergia a esmalte",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	return router, cliente
+}
+
+func TestGetExportacao_DevolveCadastroEAgendamentos(t *testing.T) {
+	router, cliente := SetupClienteComAgendamento(t)
+
+	rr := SetupLGPDRequest(router, http.MethodGet, cliente.ID, "exportacao", "atendente@salao")
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var exportacao response_lgpd.ExportacaoClienteResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &exportacao))
+	require.Equal(t, cliente.ID, exportacao.Cliente.ID)
+	require.Equal(t, cliente.Email, exportacao.Cliente.Email)
+	require.Equal(t, cliente.Telefone, exportacao.Cliente.Telefone)
+	require.Len(t, exportacao.Agendamentos, 1)
+	require.Equal(t, "alergia a esmalte", exportacao.Agendamentos[0].Notas)
+	require.False(t, exportacao.ExportadoEm.IsZero())
+
+	// A observação é opcional, mas tem tamanho máximo
+	rr = SetupLGPDRequest(router, http.MethodGet, cliente.ID, "exportacao", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+	rr = SetupLGPDRequest(router, http.MethodGet, cliente.ID, "exportacao", strings.Repeat("a", 101))
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestGetExportacao_IncluiSeriesVisitasEListaEspera(t *testing.T) {
+	router, prestadorRepo, clienteRepo, catalogoRepo, agendaDiariaRepo := SetupRouterLGPD()
+
+	cliente := SetupNovoCliente(clienteRepo)
+	corte, listaCorte := SetupNovoCatalogo(catalogoRepo)
+	manicure := SetupCatalogoManicure(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, append(*listaCorte, *manicure))
+	SetupAgendaNasDatas(agendaDiariaRepo, prestador, "2030-01-03", "2030-01-04", "2030-01-10")
+
+	rr := SetupPostSerieRequest(router, request_agendamento.SerieAgendamentoRequest{
+		ClienteID:        cliente.ID,
+		PrestadorID:      prestador.ID,
+		CatalogoID:       corte.ID,
+		DataHoraInicio:   "2030-01-03T08:00:00Z",
+		IntervaloSemanas: 1,
+		Ocorrencias:      2,
+		Notas:            "prefere máquina 2",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	rr = SetupPostVisitaRequest(router, request_agendamento.VisitaRequest{
+		ClienteID:      cliente.ID,
+		DataHoraInicio: "2030-01-04T09:00:00Z",
+		Itens: []request_agendamento.ItemVisitaRequest{
+			{PrestadorID: prestador.ID, CatalogoID: corte.ID},
+			{PrestadorID: prestador.ID, CatalogoID: manicure.ID},
+		},
+		Notas: "vem com a filha",
+	})
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	entrada := SetupEntradaListaEspera(t, router, cliente.ID, manicure.ID, prestador.ID, "2030-01-10T08:00:00Z", "2030-01-10T18:00:00Z")
+
+	rr = SetupLGPDRequest(router, http.MethodGet, cliente.ID, "exportacao", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var exportacao response_lgpd.ExportacaoClienteResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &exportacao))
+	require.Len(t, exportacao.Agendamentos, 4)
+	require.Len(t, exportacao.Series, 1)
+	require.Equal(t, "prefere máquina 2", exportacao.Series[0].Notas)
+	require.Equal(t, 2, exportacao.Series[0].Ocorrencias)
+	require.Len(t, exportacao.Visitas, 1)
+	require.Equal(t, "vem com a filha", exportacao.Visitas[0].Notas)
+	require.Len(t, exportacao.ListaEspera, 1)
+	require.Equal(t, entrada.ID, exportacao.ListaEspera[0].ID)
+}
+
+func TestPostAnonimizar_ApagaDadosPessoaisEMantemAgendamentos(t *testing.T) {
+	router, cliente := SetupClienteComAgendamento(t)
+	// O repositório fake devolve o mesmo ponteiro, então guardamos os dados originais
+	original := *cliente
+
+	rr := SetupLGPDRequest(router, http.MethodPost, cliente.ID, "anonimizar", "atendente@salao")
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	rr = SetupGetClienteRequest(router, cliente.ID)
+	require.Equal(t, http.StatusOK, rr.Code)
+	var anonimizado domain.Cliente
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &anonimizado))
+	require.NotEqual(t, original.Nome, anonimizado.Nome)
+	require.NotEqual(t, original.Email, anonimizado.Email)
+	require.NotEqual(t, original.Telefone, anonimizado.Telefone)
+	require.False(t, anonimizado.Ativo)
+	require.NotNil(t, anonimizado.AnonimizadoEm)
+
+	// O agendamento continua no histórico, mas sem as notas
+	rr = SetupLGPDRequest(router, http.MethodGet, cliente.ID, "exportacao", "atendente@salao")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var exportacao response_lgpd.ExportacaoClienteResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &exportacao))
+	require.Len(t, exportacao.Agendamentos, 1)
+	require.Empty(t, exportacao.Agendamentos[0].Notas)
+
+	rr = SetupLGPDRequest(router, http.MethodPost, cliente.ID, "anonimizar", "atendente@salao")
+	require.Equal(t, http.StatusConflict, rr.Code)
+
+	rr = SetupPutStatusClienteRequest(router, cliente.ID, "ativar")
+	require.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestGetSolicitacoes_RegistraQuemPediuEQuando(t *testing.T) {
+	router, cliente := SetupClienteComAgendamento(t)
+
+	rr := SetupLGPDRequest(router, http.MethodGet, cliente.ID, "exportacao", "atendente@salao")
+	require.Equal(t, http.StatusOK, rr.Code)
+	rr = SetupLGPDRequest(router, http.MethodPost, cliente.ID, "anonimizar", "gerente@salao")
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	rr = SetupLGPDRequest(router, http.MethodGet, cliente.ID, "solicitacoes", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var solicitacoes []response_lgpd.SolicitacaoLGPDResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &solicitacoes))
+	require.Len(t, solicitacoes, 2)
+	require.Equal(t, string(domain.SolicitacaoAnonimizacao), solicitacoes[0].Tipo)
+	require.Equal(t, adminLGPD, solicitacoes[0].UsuarioID)
+	require.Equal(t, "gerente@salao", solicitacoes[0].Observacao)
+	require.Equal(t, string(domain.SolicitacaoAtendida), solicitacoes[0].Resultado)
+	require.Equal(t, string(domain.SolicitacaoExportacao), solicitacoes[1].Tipo)
+	require.Equal(t, "atendente@salao", solicitacoes[1].Observacao)
+	require.False(t, solicitacoes[1].CriadoEm.IsZero())
+}
+
+func TestGetSolicitacoes_RegistraPedidosNegadosEFalhos(t *testing.T) {
+	router, cliente := SetupClienteComAgendamento(t)
+
+	// O próprio cliente não pode pedir pela API: o pedido é negado e registrado
+	titular := &domain.Identidade{UsuarioID: "usuario-cliente", Papel: domain.PapelCliente, ClienteID: cliente.ID}
+	rr := SetupLGPDRequestComo(router, titular, http.MethodGet, cliente.ID, "exportacao", "")
+	require.Equal(t, http.StatusForbidden, rr.Code)
+
+	rr = SetupLGPDRequest(router, http.MethodPost, cliente.ID, "anonimizar", "")
+	require.Equal(t, http.StatusNoContent, rr.Code)
+	rr = SetupLGPDRequest(router, http.MethodPost, cliente.ID, "anonimizar", "repetido")
+	require.Equal(t, http.StatusConflict, rr.Code)
+
+	rr = SetupLGPDRequest(router, http.MethodGet, cliente.ID, "solicitacoes", "")
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var solicitacoes []response_lgpd.SolicitacaoLGPDResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &solicitacoes))
+	require.Len(t, solicitacoes, 3)
+	require.Equal(t, string(domain.SolicitacaoFalhou), solicitacoes[0].Resultado)
+	require.Equal(t, "repetido", solicitacoes[0].Observacao)
+	require.Equal(t, string(domain.SolicitacaoAtendida), solicitacoes[1].Resultado)
+	require.Equal(t, string(domain.SolicitacaoNegada), solicitacoes[2].Resultado)
+	require.Equal(t, string(domain.SolicitacaoExportacao), solicitacoes[2].Tipo)
+	require.Equal(t, "usuario-cliente", solicitacoes[2].UsuarioID)
+}
+
+func TestLGPD_ClienteNaoEncontrado(t *testing.T) {
+	router, _, _, _, _ := SetupRouterLGPD()
+
+	rr := SetupLGPDRequest(router, http.MethodGet, "id-inexistente", "exportacao", "atendente@salao")
+	require.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = SetupLGPDRequest(router, http.MethodPost, "id-inexistente", "anonimizar", "atendente@salao")
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
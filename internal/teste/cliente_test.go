@@ -7,6 +7,7 @@ import (
 
 	Http "meu-servico-agenda/internal/adapters/http/cliente"
 	"meu-servico-agenda/internal/adapters/http/cliente/request"
+	"meu-servico-agenda/internal/adapters/http/resposta"
 	"meu-servico-agenda/internal/adapters/repository"
 	"meu-servico-agenda/internal/core/application/port"
 	"meu-servico-agenda/internal/core/application/service"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func SetupRouterCliente() (*gin.Engine, port.ClienteRepositorio) {
@@ -31,7 +33,11 @@ func SetupRouterCliente() (*gin.Engine, port.ClienteRepositorio) {
 	apiV1 := router.Group("/api/v1")
 	{
 		apiV1.POST("/clientes", clienteController.PostCliente)
+		apiV1.GET("/clientes", clienteController.GetClientes)
 		apiV1.GET("/clientes/:id", clienteController.GetCliente)
+		apiV1.PUT("/clientes/:id", clienteController.PutCliente)
+		apiV1.PUT("/clientes/:id/inativar", clienteController.InativarCliente)
+		apiV1.PUT("/clientes/:id/ativar", clienteController.AtivarCliente)
 	}
 
 	return router, clienteRepo
@@ -95,13 +101,14 @@ func TestPostCliente_EmailInvalido(t *testing.T) {
 
 	// === Validações === //
 	assert.Equal(t, http.StatusBadRequest, rr.Code, "Esperado 400 Bad Request para email inválido")
-	var resp map[string]string
+	var resp resposta.Problema
 	err := json.Unmarshal(rr.Body.Bytes(), &resp)
 	assert.NoError(t, err, "Resposta deve ser um JSON válido")
-	// Verifica se a mensagem de erro contém informações sobre o email inválido
-	errorMsg, exists := resp["error"]
-	assert.True(t, exists, "Deve conter campo 'error' na resposta")
-	assert.Equal(t, errorMsg, "Dados inválidos: Key: 'ClienteRequest.Email' Error:Field validation for 'Email' failed on the 'email' tag")
+	// O campo rejeitado vem em invalid_params com o nome usado no JSON
+	assert.Equal(t, "dados_invalidos", resp.Code)
+	require.Len(t, resp.InvalidParams, 1)
+	assert.Equal(t, "email", resp.InvalidParams[0].Name)
+	assert.Equal(t, "email", resp.InvalidParams[0].Code)
 }
 
 func TestPostCliente_NomeRequerido(t *testing.T) {
@@ -117,13 +124,14 @@ func TestPostCliente_NomeRequerido(t *testing.T) {
 	// === Validações === //
 
 	assert.Equal(t, http.StatusBadRequest, rr.Code, "Esperado 400 Bad Request para email inválido")
-	var resp map[string]string
+	var resp resposta.Problema
 	err := json.Unmarshal(rr.Body.Bytes(), &resp)
 	assert.NoError(t, err, "Resposta deve ser um JSON válido")
-
-	errorMsg, exists := resp["error"]
-	assert.True(t, exists, "Deve conter campo 'error' na resposta")
-	assert.Equal(t, errorMsg, "Dados inválidos: Key: 'ClienteRequest.Nome' Error:Field validation for 'Nome' failed on the 'required' tag")
+	// O campo rejeitado vem em invalid_params com o nome usado no JSON
+	assert.Equal(t, "dados_invalidos", resp.Code)
+	require.Len(t, resp.InvalidParams, 1)
+	assert.Equal(t, "nome", resp.InvalidParams[0].Name)
+	assert.Equal(t, "required", resp.InvalidParams[0].Code)
 }
 
 func TestPostCliente_TelefoneReequerido(t *testing.T) {
@@ -139,13 +147,14 @@ func TestPostCliente_TelefoneReequerido(t *testing.T) {
 	// === Validações === //
 
 	assert.Equal(t, http.StatusBadRequest, rr.Code, "Esperado 400 Bad Request para email inválido")
-	var resp map[string]string
+	var resp resposta.Problema
 	err := json.Unmarshal(rr.Body.Bytes(), &resp)
 	assert.NoError(t, err, "Resposta deve ser um JSON válido")
-
-	errorMsg, exists := resp["error"]
-	assert.True(t, exists, "Deve conter campo 'error' na resposta")
-	assert.Equal(t, errorMsg, "Dados inválidos: Key: 'ClienteRequest.Telefone' Error:Field validation for 'Telefone' failed on the 'required' tag")
+	// O campo rejeitado vem em invalid_params com o nome usado no JSON
+	assert.Equal(t, "dados_invalidos", resp.Code)
+	require.Len(t, resp.InvalidParams, 1)
+	assert.Equal(t, "telefone", resp.InvalidParams[0].Name)
+	assert.Equal(t, "required", resp.InvalidParams[0].Code)
 }
 
 func SetupGetClienteRequest(router *gin.Engine, id string) *httptest.ResponseRecorder {
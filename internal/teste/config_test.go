@@ -0,0 +1,126 @@
+package teste
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"meu-servico-agenda/internal/adapters/http/catalogo"
+	"meu-servico-agenda/internal/adapters/repository"
+	"meu-servico-agenda/internal/core/application/service"
+	"meu-servico-agenda/internal/core/domain"
+	"meu-servico-agenda/internal/infra/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+const segredoJWTDeTeste = "segredo-de-teste-com-mais-de-32-caracteres"
+
+func TestConfig_ValoresPadrao(t *testing.T) {
+	t.Setenv("JWT_SEGREDO", segredoJWTDeTeste)
+
+	cfg, err := config.Carregar()
+	require.NoError(t, err)
+
+	require.Equal(t, ":8080", cfg.HTTP.Addr)
+	require.Equal(t, "release", cfg.HTTP.ModoGin)
+	require.Equal(t, 10*time.Second, cfg.HTTP.ReadTimeout)
+	require.Equal(t, 10*time.Second, cfg.HTTP.RequestTimeout)
+	require.Equal(t, 25, cfg.Database.MaxOpenConns)
+	require.Contains(t, cfg.Database.DSN, "dbname=annygo")
+	require.True(t, cfg.Database.MigrarAoIniciar)
+	require.Equal(t, 15*time.Minute, cfg.Auth.AccessTokenTTL)
+	require.Equal(t, 10, cfg.Paginacao.LimitePadrao)
+	require.Equal(t, 100, cfg.Paginacao.LimiteMaximo)
+	require.Equal(t, "info", cfg.LogLevel)
+}
+
+func TestConfig_ListaTodosOsValoresInvalidos(t *testing.T) {
+	t.Setenv("JWT_SEGREDO", "curto")
+	t.Setenv("DB_MAX_OPEN_CONNS", "muitas")
+	t.Setenv("HTTP_READ_TIMEOUT", "10")
+	t.Setenv("GIN_MODE", "producao")
+	t.Setenv("HTTP_REQUEST_TIMEOUT", "45s")
+	t.Setenv("LOG_LEVEL", "verbose")
+	t.Setenv("PAGINACAO_LIMITE_PADRAO", "50")
+	t.Setenv("PAGINACAO_LIMITE_MAXIMO", "20")
+
+	_, err := config.Carregar()
+	require.Error(t, err)
+
+	for _, chave := range []string{"JWT_SEGREDO", "DB_MAX_OPEN_CONNS", "HTTP_READ_TIMEOUT", "GIN_MODE", "HTTP_REQUEST_TIMEOUT", "LOG_LEVEL", "PAGINACAO_LIMITE_MAXIMO"} {
+		require.Contains(t, err.Error(), chave)
+	}
+}
+
+func TestConfig_ArquivoComAmbienteTendoPrioridade(t *testing.T) {
+	arquivo := filepath.Join(t.TempDir(), "api.env")
+	require.NoError(t, os.WriteFile(arquivo, []byte(`
+# configuração local
+HTTP_ADDR=:9090
+PAGINACAO_LIMITE_PADRAO=20
+JWT_SEGREDO="`+segredoJWTDeTeste+`"
+`), 0o600))
+
+	t.Setenv("CONFIG_FILE", arquivo)
+	t.Setenv("HTTP_ADDR", ":7070")
+
+	cfg, err := config.Carregar()
+	require.NoError(t, err)
+
+	require.Equal(t, ":7070", cfg.HTTP.Addr)
+	require.Equal(t, 20, cfg.Paginacao.LimitePadrao)
+	require.Equal(t, segredoJWTDeTeste, cfg.Auth.JWTSegredo)
+}
+
+func TestPaginacao_UsaLimitesConfigurados(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	catalogoRepo := repository.NovoCatalogoFakeRepo()
+	for _, nome := range []string{"Corte", "Barba", "Escova"} {
+		c, err := domain.NovoCatalogo(nome, 30, 5000, "Cabelo", "https://exemplo.com/img.jpg")
+		require.NoError(t, err)
+		require.NoError(t, catalogoRepo.Salvar(context.Background(), c))
+	}
+
+	catalogoService := service.NovoCatalogoService(catalogoRepo)
+	catalogoService.DefinirPaginacao(service.Paginacao{LimitePadrao: 2, LimiteMaximo: 2})
+
+	router := gin.Default()
+	router.GET("/api/v1/catalogos", catalogo.NovoCatalogoController(catalogoService).GetCatalogos)
+
+	for _, url := range []string{"/api/v1/catalogos", "/api/v1/catalogos?limit=50"} {
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var resp struct {
+			Limit int               `json:"limit"`
+			Data  []json.RawMessage `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		require.Equal(t, 2, resp.Limit)
+		require.Len(t, resp.Data, 2)
+	}
+}
+
+func TestConfig_DatabaseSemConfiguracaoDaAPI(t *testing.T) {
+	t.Setenv("JWT_SEGREDO", "")
+	t.Setenv("DB_MIGRAR_AO_INICIAR", "talvez")
+
+	_, err := config.CarregarDatabase()
+	require.ErrorContains(t, err, "DB_MIGRAR_AO_INICIAR")
+	require.NotContains(t, err.Error(), "JWT_SEGREDO")
+
+	t.Setenv("DB_MIGRAR_AO_INICIAR", "false")
+	db, err := config.CarregarDatabase()
+	require.NoError(t, err)
+	require.False(t, db.MigrarAoIniciar)
+}
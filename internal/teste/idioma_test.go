@@ -0,0 +1,135 @@
+package teste
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"meu-servico-agenda/internal/adapters/http/cliente/request"
+	"meu-servico-agenda/internal/adapters/http/middleware"
+	"meu-servico-agenda/internal/adapters/http/resposta"
+	"meu-servico-agenda/internal/core/application/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func SetupRequestComIdioma(router *gin.Engine, metodo, url, idioma string, corpo any) *httptest.ResponseRecorder {
+	var req *http.Request
+	if corpo != nil {
+		dados, _ := json.Marshal(corpo)
+		req = httptest.NewRequest(metodo, url, bytes.NewReader(dados))
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		req = httptest.NewRequest(metodo, url, nil)
+	}
+	if idioma != "" {
+		req.Header.Set("Accept-Language", idioma)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestIdioma_CatalogosTemAsMesmasChaves(t *testing.T) {
+	require.Equal(t, resposta.Chaves(language.BrazilianPortuguese), resposta.Chaves(language.English))
+}
+
+func TestIdioma_TodoCodigoMapeadoTemMensagem(t *testing.T) {
+	arquivo, err := parser.ParseFile(token.NewFileSet(), "../adapters/http/resposta/erros.go", nil, 0)
+	require.NoError(t, err)
+
+	var codigos []string
+	ast.Inspect(arquivo, func(n ast.Node) bool {
+		item, ok := n.(*ast.CompositeLit)
+		if !ok || len(item.Elts) != 3 {
+			return true
+		}
+		if lit, ok := item.Elts[2].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			codigo, _ := strconv.Unquote(lit.Value)
+			codigos = append(codigos, codigo)
+		}
+		return true
+	})
+	require.NotEmpty(t, codigos)
+
+	for _, codigo := range codigos {
+		for _, idioma := range []language.Tag{language.BrazilianPortuguese, language.English} {
+			_, ok := resposta.Traduzir(idioma, codigo)
+			require.True(t, ok, "%s sem mensagem em %s", codigo, idioma)
+		}
+	}
+}
+
+func TestIdioma_EscolhidoPeloAcceptLanguage(t *testing.T) {
+	router, _ := SetupRouterCliente()
+
+	casos := []struct {
+		acceptLanguage string
+		idioma         string
+		detalhe        string
+	}{
+		{"", "pt-BR", service.ErrClienteNaoEncontrado.Error()},
+		{"en", "en", "customer not found"},
+		{"en-US,en;q=0.9,pt;q=0.8", "en", "customer not found"},
+		{"fr-FR, pt-BR;q=0.5", "pt-BR", service.ErrClienteNaoEncontrado.Error()},
+		{"de", "pt-BR", service.ErrClienteNaoEncontrado.Error()},
+		{"isto não é um idioma;;", "pt-BR", service.ErrClienteNaoEncontrado.Error()},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.acceptLanguage, func(t *testing.T) {
+			rr := SetupRequestComIdioma(router, http.MethodGet, "/api/v1/clientes/inexistente", caso.acceptLanguage, nil)
+			require.Equal(t, http.StatusNotFound, rr.Code)
+			require.Equal(t, caso.idioma, rr.Header().Get("Content-Language"))
+
+			var corpo resposta.Problema
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &corpo))
+			require.Equal(t, caso.detalhe, corpo.Detail)
+			require.Equal(t, "cliente_nao_encontrado", corpo.Code)
+		})
+	}
+}
+
+func TestIdioma_MensagensDeValidacaoEmIngles(t *testing.T) {
+	router, _ := SetupRouterCliente()
+
+	rr := SetupRequestComIdioma(router, http.MethodPost, "/api/v1/clientes", "en",
+		request.ClienteRequest{Nome: "Ana", Email: "email-invalido"})
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var corpo resposta.Problema
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &corpo))
+	require.Equal(t, resposta.CodigoDadosInvalidos, corpo.Code)
+	require.Equal(t, "one or more fields are invalid", corpo.Detail)
+
+	motivos := map[string]resposta.ParametroInvalido{}
+	for _, p := range corpo.InvalidParams {
+		motivos[p.Name] = p
+	}
+	require.Equal(t, resposta.ParametroInvalido{Name: "email", Code: "email", Reason: "must be a valid email"}, motivos["email"])
+	require.Equal(t, resposta.ParametroInvalido{Name: "telefone", Code: "required", Reason: "field is required"}, motivos["telefone"])
+}
+
+func TestIdioma_ErrosDosMiddlewaresEmIngles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/privado", middleware.Autenticar(nil), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	rr := SetupRequestComIdioma(router, http.MethodGet, "/privado", "en", nil)
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	var corpo resposta.Problema
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &corpo))
+	require.Equal(t, "nao_autenticado", corpo.Code)
+	require.Equal(t, "send the access token in the Authorization header: Bearer <token>", corpo.Detail)
+}
@@ -0,0 +1,165 @@
+package teste
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"meu-servico-agenda/internal/adapters/http/middleware"
+	"meu-servico-agenda/internal/adapters/http/resposta"
+	"meu-servico-agenda/internal/adapters/repository"
+	"meu-servico-agenda/internal/core/application/input"
+	"meu-servico-agenda/internal/core/application/service"
+	"meu-servico-agenda/internal/infra/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// SetupLogEmMemoria direciona o slog para um buffer durante o teste
+func SetupLogEmMemoria(t *testing.T) *bytes.Buffer {
+	anterior := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(anterior) })
+
+	var saida bytes.Buffer
+	logging.Configurar(&saida, "debug")
+	return &saida
+}
+
+func SetupRouterComLog() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(middleware.RequestID(), middleware.LogRequisicoes())
+	router.GET("/ok", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"request_id": logging.RequestID(c.Request.Context())})
+	})
+	router.GET("/falha", func(c *gin.Context) {
+		resposta.FalhaInterna(c, errors.New("pq: conexão encerrada"))
+	})
+	return router
+}
+
+func linhasDeLog(t *testing.T, saida *bytes.Buffer) []map[string]any {
+	var linhas []map[string]any
+	for _, linha := range strings.Split(strings.TrimSpace(saida.String()), "\n") {
+		var registro map[string]any
+		require.NoError(t, json.Unmarshal([]byte(linha), &registro), linha)
+		linhas = append(linhas, registro)
+	}
+	return linhas
+}
+
+func TestRequestID_GeradoOuReaproveitado(t *testing.T) {
+	SetupLogEmMemoria(t)
+	router := SetupRouterComLog()
+
+	rr := SetupGetRequest(router, "/ok")
+	gerado := rr.Header().Get(middleware.HeaderRequestID)
+	require.NotEmpty(t, gerado)
+	require.Contains(t, rr.Body.String(), gerado)
+
+	req, _ := http.NewRequest(http.MethodGet, "/ok", nil)
+	req.Header.Set(middleware.HeaderRequestID, "lb-123")
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, "lb-123", rr.Header().Get(middleware.HeaderRequestID))
+
+	// Valores com espaços ou quebras de linha não vão para o log
+	req.Header.Set(middleware.HeaderRequestID, "id com espaço")
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.NotEqual(t, "id com espaço", rr.Header().Get(middleware.HeaderRequestID))
+}
+
+func TestFalhaInterna_RegistraCausaERespondeRequestID(t *testing.T) {
+	saida := SetupLogEmMemoria(t)
+	router := SetupRouterComLog()
+
+	rr := SetupGetRequest(router, "/falha")
+	require.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	var body resposta.Problema
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	require.Equal(t, service.ErrFalhaInfraestrutura.Error(), body.Detail)
+	require.Equal(t, resposta.CodigoFalhaInterna, body.Code)
+	require.Equal(t, rr.Header().Get(middleware.HeaderRequestID), body.RequestID)
+	require.NotContains(t, rr.Body.String(), "pq: conexão encerrada")
+
+	linhas := linhasDeLog(t, saida)
+	require.Len(t, linhas, 2)
+
+	falha, requisicao := linhas[0], linhas[1]
+	require.Equal(t, "ERROR", falha["level"])
+	require.Equal(t, "pq: conexão encerrada", falha["erro"])
+	require.Equal(t, body.RequestID, falha["request_id"])
+
+	require.Equal(t, "requisição atendida", requisicao["msg"])
+	require.Equal(t, "/falha", requisicao["route"])
+	require.EqualValues(t, http.StatusInternalServerError, requisicao["status"])
+	require.Equal(t, body.RequestID, requisicao["request_id"])
+}
+
+func TestLogRequisicoes_NaoRegistraSegredoDaURL(t *testing.T) {
+	saida := SetupLogEmMemoria(t)
+	router := SetupRouterComLog()
+	router.GET("/calendario/:token", middleware.CaminhoSecreto(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	rr := SetupGetRequest(router, "/calendario/segredo.ics")
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	linhas := linhasDeLog(t, saida)
+	require.Len(t, linhas, 1)
+	require.Equal(t, "/calendario/:token", linhas[0]["path"])
+	require.NotContains(t, saida.String(), "segredo")
+}
+
+// observadorQueFalha não consegue oferecer as vagas liberadas
+type observadorQueFalha struct{}
+
+func (observadorQueFalha) VagaLiberada(ctx context.Context, prestadorID string, inicio, fim time.Time) error {
+	return errGravacao
+}
+
+func (observadorQueFalha) VagaReservadaParaOutro(ctx context.Context, prestadorID, clienteID string, inicio, fim time.Time) (bool, error) {
+	return false, nil
+}
+
+func TestLogServico_FalhaAoOferecerVagaLevaRequestID(t *testing.T) {
+	catalogoRepo := repository.NovoCatalogoFakeRepo()
+	catalogo, catalogos := SetupNovoCatalogo(catalogoRepo)
+	prestadorRepo := repository.NovoFakePrestadorRepositorio(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *catalogos)
+	clienteRepo := repository.NewFakeClienteRepositorio()
+	cliente := SetupNovoCliente(clienteRepo)
+	SetupAgendaNasDatas(repository.NovoFakeAgendaDiariaRepositorio(), prestador, "2030-01-03")
+
+	agendamentoService := service.NovaAgendamentoService(prestadorRepo, repository.NovoFakeAgendamentoRepositorio(), catalogoRepo, clienteRepo)
+	agendamentoService.DefinirObservadorDeVagas(observadorQueFalha{})
+
+	ctx := logging.ComRequestID(context.Background(), "req-vaga")
+	agendamento, err := agendamentoService.Agendar(ctx, input.CadastrarAgendamentoInput{
+		ClienteID:      cliente.ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: time.Date(2030, 1, 3, 9, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	saida := SetupLogEmMemoria(t)
+	require.NoError(t, agendamentoService.CancelarAgendamento(ctx, agendamento.ID))
+
+	linhas := linhasDeLog(t, saida)
+	require.Len(t, linhas, 1)
+	require.Equal(t, "erro ao oferecer vaga liberada", linhas[0]["msg"])
+	require.Equal(t, "req-vaga", linhas[0]["request_id"])
+}
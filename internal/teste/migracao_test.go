@@ -0,0 +1,112 @@
+package teste
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"meu-servico-agenda/flyway"
+	"meu-servico-agenda/internal/infra/migracao"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigracao_ChecksumCompativelComFlyway(t *testing.T) {
+	// Valores calculados pelo algoritmo do Flyway: CRC32 por linha, sem quebras nem BOM
+	require.Equal(t, int32(815136518), migracao.Checksum([]byte("CREATE TABLE a (id INT);\r\nSELECT 1;\n")))
+	require.Equal(t, int32(815136518), migracao.Checksum([]byte("\xef\xbb\xbfCREATE TABLE a (id INT);\nSELECT 1;")))
+}
+
+func TestMigracao_LeScriptsEmbutidosEmOrdem(t *testing.T) {
+	migracoes, err := migracao.Ler(flyway.Scripts, "sql")
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(migracoes), 19)
+
+	require.Equal(t, "1", migracoes[0].Versao)
+	require.Equal(t, "create cliente", migracoes[0].Descricao)
+	require.Equal(t, "V1__create_cliente.sql", migracoes[0].Script)
+	require.Equal(t, int32(96318097), migracoes[0].Checksum)
+
+	for i := 1; i < len(migracoes); i++ {
+		require.Negative(t, migracao.CompararVersoes(migracoes[i-1].Versao, migracoes[i].Versao))
+	}
+}
+
+func TestMigracao_NomeInvalidoOuVersaoDuplicada(t *testing.T) {
+	_, err := migracao.Ler(fstest.MapFS{
+		"sql/create_cliente.sql": {Data: []byte("SELECT 1;")},
+	}, "sql")
+	require.ErrorIs(t, err, migracao.ErrNomeScriptInvalido)
+
+	_, err = migracao.Ler(fstest.MapFS{
+		"sql/V1__a.sql":   {Data: []byte("SELECT 1;")},
+		"sql/V1.0__b.sql": {Data: []byte("SELECT 2;")},
+	}, "sql")
+	require.ErrorIs(t, err, migracao.ErrVersaoDuplicada)
+}
+
+func TestMigracao_Planejar(t *testing.T) {
+	locais, err := migracao.Ler(fstest.MapFS{
+		"sql/V1__a.sql":  {Data: []byte("SELECT 1;")},
+		"sql/V2__b.sql":  {Data: []byte("SELECT 2;")},
+		"sql/V10__c.sql": {Data: []byte("SELECT 10;")},
+	}, "sql")
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2", "10"}, []string{locais[0].Versao, locais[1].Versao, locais[2].Versao})
+
+	aplicada := func(m migracao.Migracao) migracao.Aplicada {
+		checksum := m.Checksum
+		return migracao.Aplicada{Versao: m.Versao, Tipo: migracao.TipoSQL, Script: m.Script, Checksum: &checksum, Sucesso: true}
+	}
+
+	t.Run("banco vazio recebe todas", func(t *testing.T) {
+		pendentes, err := migracao.Planejar(locais, nil)
+		require.NoError(t, err)
+		require.Len(t, pendentes, 3)
+	})
+
+	t.Run("só as que faltam", func(t *testing.T) {
+		pendentes, err := migracao.Planejar(locais, []migracao.Aplicada{
+			{Rank: 0, Tipo: "SCHEMA", Script: `"public"`, Sucesso: true},
+			aplicada(locais[0]), aplicada(locais[1]),
+		})
+		require.NoError(t, err)
+		require.Len(t, pendentes, 1)
+		require.Equal(t, "V10__c.sql", pendentes[0].Script)
+	})
+
+	t.Run("baseline pula as versões anteriores", func(t *testing.T) {
+		pendentes, err := migracao.Planejar(locais, []migracao.Aplicada{
+			{Versao: "2", Tipo: migracao.TipoBaseline, Script: "<< Flyway Baseline >>", Sucesso: true},
+		})
+		require.NoError(t, err)
+		require.Len(t, pendentes, 1)
+		require.Equal(t, "10", pendentes[0].Versao)
+	})
+
+	t.Run("script alterado", func(t *testing.T) {
+		alterada := aplicada(locais[0])
+		outro := *alterada.Checksum + 1
+		alterada.Checksum = &outro
+
+		_, err := migracao.Planejar(locais, []migracao.Aplicada{alterada})
+		require.ErrorIs(t, err, migracao.ErrChecksumDivergente)
+	})
+
+	t.Run("migração com falha", func(t *testing.T) {
+		falhou := aplicada(locais[0])
+		falhou.Sucesso = false
+
+		_, err := migracao.Planejar(locais, []migracao.Aplicada{falhou})
+		require.ErrorIs(t, err, migracao.ErrMigracaoFalhou)
+	})
+
+	t.Run("script removido do binário", func(t *testing.T) {
+		_, err := migracao.Planejar(locais[:1], []migracao.Aplicada{aplicada(locais[0]), aplicada(locais[1])})
+		require.ErrorIs(t, err, migracao.ErrMigracaoAusente)
+	})
+
+	t.Run("pendência fora de ordem", func(t *testing.T) {
+		_, err := migracao.Planejar(locais, []migracao.Aplicada{aplicada(locais[0]), aplicada(locais[2])})
+		require.ErrorIs(t, err, migracao.ErrMigracaoForaDeOrdem)
+	})
+}
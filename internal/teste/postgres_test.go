@@ -0,0 +1,207 @@
+package teste
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"meu-servico-agenda/flyway"
+	"meu-servico-agenda/internal/adapters/repository"
+	"meu-servico-agenda/internal/core/application/input"
+	"meu-servico-agenda/internal/core/application/service"
+	"meu-servico-agenda/internal/core/domain"
+	"meu-servico-agenda/internal/infra/migracao"
+
+	_ "github.com/lib/pq"
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/require"
+)
+
+// VariavelPostgresDeTeste aponta para um banco descartável. Sem ela os testes que
+// dependem do Postgres são pulados
+const VariavelPostgresDeTeste = "TESTE_DATABASE_URL"
+
+// SetupPostgres cria um schema só para o teste, aplica as migrações nele e o
+// apaga no fim. As conexões devolvidas já usam esse schema
+func SetupPostgres(t *testing.T) *sql.DB {
+	t.Helper()
+	db := SetupPostgresVazio(t)
+
+	migracoes, err := migracao.Ler(flyway.Scripts, "sql")
+	require.NoError(t, err)
+	_, err = migracao.NovoMigrador(db, migracoes).Aplicar(false)
+	require.NoError(t, err)
+
+	return db
+}
+
+// SetupPostgresVazio é o SetupPostgres sem aplicar nenhuma migração
+func SetupPostgresVazio(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv(VariavelPostgresDeTeste)
+	if dsn == "" {
+		t.Skipf("%s não definida", VariavelPostgresDeTeste)
+	}
+
+	admin, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { admin.Close() })
+
+	schema := "teste_" + xid.New().String()
+	_, err = admin.Exec(`CREATE SCHEMA ` + schema)
+	require.NoError(t, err)
+	t.Cleanup(func() { admin.Exec(`DROP SCHEMA ` + schema + ` CASCADE`) })
+
+	db, err := sql.Open("postgres", comSearchPath(t, dsn, schema))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+// comSearchPath acrescenta o schema ao DSN, no formato de URL ou de chave=valor
+func comSearchPath(t *testing.T, dsn, schema string) string {
+	if !strings.HasPrefix(dsn, "postgres://") && !strings.HasPrefix(dsn, "postgresql://") {
+		return dsn + " search_path=" + schema
+	}
+
+	u, err := url.Parse(dsn)
+	require.NoError(t, err)
+	q := u.Query()
+	q.Set("search_path", schema)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+func TestPostgres_AgendarComPrestadorDeClienteAnonimizado(t *testing.T) {
+	db := SetupPostgres(t)
+	ctx := context.Background()
+
+	clienteRepo := repository.NovoClientePostgresRepositorio(db)
+	catalogoRepo := repository.NovoCatalogoPostgresRepositorio(db)
+	prestadorRepo := repository.NewPrestadorPostgresRepository(db)
+	agendaDiariaRepo := repository.NovoAgendaDiariaPostgresRepository(db)
+	agendamentoRepo := repository.NovoAgendamentoPostgresRepository(db)
+	unidade := repository.NovaUnidadeDeTrabalhoPostgres(db)
+
+	agendamentoService := service.NovaAgendamentoService(prestadorRepo, agendamentoRepo, catalogoRepo, clienteRepo)
+	agendamentoService.DefinirUnidadeDeTrabalho(unidade)
+	lgpdService := service.NovaLGPDService(clienteRepo, agendamentoRepo,
+		repository.NovoListaEsperaPostgresRepository(db),
+		repository.NovoSolicitacaoLGPDPostgresRepository(db),
+		repository.NovoTokenCalendarioPostgresRepository(db),
+		repository.NovoUsuarioPostgresRepository(db),
+		repository.NovoRefreshTokenPostgresRepository(db))
+	lgpdService.DefinirUnidadeDeTrabalho(unidade)
+
+	catalogo, catalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *catalogos)
+	SetupAgendaNasDatas(agendaDiariaRepo, prestador, "2030-01-03")
+
+	anonimizado := SetupNovoCliente(clienteRepo)
+	outro, err := domain.NovoCliente("Ana", "ana@exemplo.com", "62999990000")
+	require.NoError(t, err)
+	require.NoError(t, clienteRepo.Salvar(ctx, outro))
+
+	agendar := func(clienteID, inicio string) error {
+		dataHora, err := time.Parse(time.RFC3339, inicio)
+		require.NoError(t, err)
+		_, err = agendamentoService.Agendar(ctx, input.CadastrarAgendamentoInput{
+			ClienteID:      clienteID,
+			PrestadorID:    prestador.ID,
+			CatalogoID:     catalogo.ID,
+			DataHoraInicio: dataHora,
+			Notas:          "alergia a esmalte",
+		})
+		return err
+	}
+
+	require.NoError(t, agendar(anonimizado.ID, "2030-01-03T08:00:00Z"))
+	require.NoError(t, lgpdService.Anonimizar(ctx, anonimizado.ID, adminLGPD, ""))
+
+	// As consultas de conflito leem o agendamento com as notas apagadas
+	require.ErrorIs(t, agendar(outro.ID, "2030-01-03T08:00:00Z"), service.ErrPrestadorOcupado)
+	require.NoError(t, agendar(outro.ID, "2030-01-03T10:00:00Z"))
+
+	agendamentos, err := agendamentoRepo.BuscarAgendamentoClienteAPartirDaData(ctx, anonimizado.ID, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, agendamentos, 1)
+	require.Empty(t, agendamentos[0].Notas)
+}
+
+func TestPostgres_MigracaoDaExclusaoFalhaComAgendamentosSobrepostos(t *testing.T) {
+	db := SetupPostgresVazio(t)
+
+	migracoes, err := migracao.Ler(flyway.Scripts, "sql")
+	require.NoError(t, err)
+	var ateV6 []migracao.Migracao
+	for _, m := range migracoes {
+		if migracao.CompararVersoes(m.Versao, "7") < 0 {
+			ateV6 = append(ateV6, m)
+		}
+	}
+	_, err = migracao.NovoMigrador(db, ateV6).Aplicar(false)
+	require.NoError(t, err)
+
+	_, err = db.Exec(`
+		INSERT INTO clientes (id, nome, email, telefone) VALUES ('c1', 'Eduardo', 'c1@exemplo.com', '62999990000');
+		INSERT INTO catalogos (id, nome, duracao_padrao, preco, categoria) VALUES ('s1', 'Corte', 60, 5000, 'Cabelo');
+		INSERT INTO prestadores (id, nome, cpf, telefone) VALUES ('p1', 'Marina', '12345678901', '62999990001');
+		INSERT INTO agendamentos (id, cliente_id, prestador_id, catalogo_id, data_hora_inicio, data_hora_fim, status) VALUES
+			('a1', 'c1', 'p1', 's1', '2030-01-03 09:00', '2030-01-03 10:00', 1),
+			('a2', 'c1', 'p1', 's1', '2030-01-03 09:30', '2030-01-03 10:30', 1),
+			('a3', 'c1', 'p1', 's1', '2030-01-03 09:00', '2030-01-03 10:00', 3);
+	`)
+	require.NoError(t, err)
+
+	// Os agendamentos em conflito aparecem no erro e nenhum é cancelado
+	_, err = migracao.NovoMigrador(db, migracoes).Aplicar(false)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "agendamento a1")
+	require.Contains(t, err.Error(), "sobrepõe a2")
+	require.NotContains(t, err.Error(), "a3")
+
+	var ativos int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM agendamentos WHERE status <> 3`).Scan(&ativos))
+	require.Equal(t, 2, ativos)
+}
+
+func TestPostgres_ReservarChaveLiberadaAoMesmoTempo(t *testing.T) {
+	repo := repository.NovoIdempotenciaPostgresRepository(SetupPostgres(t))
+	ctx := context.Background()
+	hash := strings.Repeat("a", 64)
+
+	// Cada goroutine reserva e libera a mesma chave: quem perde o INSERT pode ver o
+	// registro sumir antes de lê-lo, e isso não deve virar erro
+	var wg sync.WaitGroup
+	erros := make(chan error, 8*50)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				existente, err := repo.Reservar(ctx, domain.NovoRegistroIdempotencia("chave-disputada", hash, time.Minute))
+				if err != nil {
+					erros <- err
+					continue
+				}
+				if existente == nil {
+					if err := repo.Liberar(ctx, "chave-disputada"); err != nil {
+						erros <- err
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(erros)
+
+	for err := range erros {
+		require.NoError(t, err)
+	}
+}
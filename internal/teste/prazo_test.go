@@ -0,0 +1,97 @@
+package teste
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"meu-servico-agenda/internal/adapters/http/cliente"
+	"meu-servico-agenda/internal/adapters/http/middleware"
+	"meu-servico-agenda/internal/adapters/http/resposta"
+	"meu-servico-agenda/internal/adapters/repository"
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/application/service"
+	"meu-servico-agenda/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// clienteRepoLento simula uma consulta que só termina quando o contexto acaba
+type clienteRepoLento struct {
+	port.ClienteRepositorio
+	recebeuPrazo bool
+}
+
+func (r *clienteRepoLento) BuscarPorId(ctx context.Context, id string) (*domain.Cliente, error) {
+	_, r.recebeuPrazo = ctx.Deadline()
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func SetupRouterComPrazo(repo port.ClienteRepositorio, prazo time.Duration) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(middleware.RequestID(), middleware.Prazo(prazo))
+
+	clienteController := cliente.NovoClienteController(service.NovoServiceCliente(repo))
+	router.GET("/clientes/:id", clienteController.GetCliente)
+	router.GET("/nao-encontrado", func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		c.JSON(http.StatusNotFound, gin.H{"error": service.ErrClienteNaoEncontrado.Error()})
+	})
+	return router
+}
+
+func TestPrazo_ConsultaLentaRespondeGatewayTimeout(t *testing.T) {
+	SetupLogEmMemoria(t)
+	repo := &clienteRepoLento{ClienteRepositorio: repository.NewFakeClienteRepositorio()}
+	router := SetupRouterComPrazo(repo, 20*time.Millisecond)
+
+	rr := SetupGetRequest(router, "/clientes/qualquer")
+	require.Equal(t, http.StatusGatewayTimeout, rr.Code)
+	require.True(t, repo.recebeuPrazo)
+
+	var corpo resposta.Problema
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &corpo))
+	require.Equal(t, service.ErrTempoEsgotado.Error(), corpo.Detail)
+	require.Equal(t, "tempo_esgotado", corpo.Code)
+	require.Equal(t, rr.Header().Get(middleware.HeaderRequestID), corpo.RequestID)
+}
+
+func TestPrazo_ErroEscritoDepoisDoPrazoViraGatewayTimeout(t *testing.T) {
+	SetupLogEmMemoria(t)
+	router := SetupRouterComPrazo(repository.NewFakeClienteRepositorio(), 20*time.Millisecond)
+
+	rr := SetupGetRequest(router, "/nao-encontrado")
+	require.Equal(t, http.StatusGatewayTimeout, rr.Code)
+	require.Contains(t, rr.Body.String(), service.ErrTempoEsgotado.Error())
+	require.NotContains(t, rr.Body.String(), service.ErrClienteNaoEncontrado.Error())
+}
+
+func TestPrazo_ClienteDesistiuRespondeServiceUnavailable(t *testing.T) {
+	SetupLogEmMemoria(t)
+	repo := &clienteRepoLento{ClienteRepositorio: repository.NewFakeClienteRepositorio()}
+	router := SetupRouterComPrazo(repo, time.Minute)
+
+	ctx, cancelar := context.WithCancel(context.Background())
+	cancelar()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/clientes/qualquer", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	require.Contains(t, rr.Body.String(), service.ErrRequisicaoCancelada.Error())
+}
+
+func TestPrazo_DentroDoPrazoNaoAlteraResposta(t *testing.T) {
+	repo := repository.NewFakeClienteRepositorio()
+	router := SetupRouterComPrazo(repo, time.Second)
+
+	rr := SetupGetRequest(router, "/clientes/inexistente")
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
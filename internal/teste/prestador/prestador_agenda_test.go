@@ -1,6 +1,7 @@
 package teste
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"testing"
@@ -74,7 +75,7 @@ func TestPutAgenda_PrestadorInativo(t *testing.T) {
 	router, prestadorResp, repo := CriarPrestadorValidoParaTeste(t)
 
 	prestadorResp.Ativo = false
-	err := repo.Salvar(&prestadorResp)
+	err := repo.Salvar(context.Background(), &prestadorResp)
 	require.NoError(t, err)
 
 	agendaInput := request_prestador.AgendaDiariaRequest{
@@ -1,6 +1,7 @@
 package teste
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"meu-servico-agenda/internal/adapters/http/prestador/request_prestador"
@@ -66,7 +67,7 @@ func TestDeleteAgenda_PrestadorInativo(t *testing.T) {
 
 	// Inativar prestador
 	prestadorResp.Ativo = false
-	repo.Salvar(&prestadorResp)
+	repo.Salvar(context.Background(), &prestadorResp)
 
 	// Tentar deletar
 	req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/prestadores/%s/agenda?data=2030-01-03", prestadorResp.ID), nil)
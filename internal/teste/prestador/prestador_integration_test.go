@@ -1,6 +1,7 @@
 package teste
 
 import (
+	"context"
 	"encoding/json"
 	"meu-servico-agenda/internal/adapters/http/prestador/request_prestador"
 	"meu-servico-agenda/internal/adapters/http/prestador/response_prestador"
@@ -59,7 +60,7 @@ func TestPrestadorInativo_NaoPermiteNovaAgenda(t *testing.T) {
 
 	// Desativar prestador
 	prestadorResp.Ativo = false
-	repo.Salvar(&prestadorResp)
+	repo.Salvar(context.Background(), &prestadorResp)
 
 	// Tentar adicionar agenda
 	agendaInput := request_prestador.AgendaDiariaRequest{
@@ -434,7 +434,7 @@ func TestGetPrestadoresPorData_LimiteMaximo(t *testing.T) {
 	router.ServeHTTP(rr, req)
 
 	require.Equal(t, http.StatusBadRequest, rr.Code)
-	require.Contains(t, rr.Body.String(), "Limit")
+	require.Contains(t, rr.Body.String(), `"limit"`)
 
 	// Limite exatamente 100 funciona
 	url2 := fmt.Sprintf("/api/v1/prestadores/disponiveis?data=%s&limit=100", dataFutura)
@@ -158,13 +158,13 @@ func TestGetPrestadores_ParametrosInvalidos(t *testing.T) {
 			name:           "Page negativo",
 			url:            "/api/v1/prestadores?page=-1&ativo=true", // ✅ Adicionado ativo
 			expectedStatus: http.StatusBadRequest,
-			expectedMsg:    "Page",
+			expectedMsg:    "\"page\"",
 		},
 		{
 			name:           "Limit negativo",
 			url:            "/api/v1/prestadores?limit=-5&ativo=true", // ✅ Adicionado ativo
 			expectedStatus: http.StatusBadRequest,
-			expectedMsg:    "Limit",
+			expectedMsg:    "\"limit\"",
 		},
 		{
 			name:           "Page não numérico",
@@ -230,7 +230,7 @@ func TestGetPrestadores_LimiteMaximo(t *testing.T) {
 	router.ServeHTTP(rr, req)
 
 	require.Equal(t, http.StatusBadRequest, rr.Code)
-	require.Contains(t, rr.Body.String(), "Limit")
+	require.Contains(t, rr.Body.String(), `"limit"`)
 
 	// Teste 2: Limit exatamente 100 funciona
 	req2, _ := http.NewRequest(http.MethodGet, "/api/v1/prestadores?limit=100&ativo=true", nil) // ✅ Adicionado ativo
@@ -0,0 +1,225 @@
+package teste
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"meu-servico-agenda/internal/adapters/http/modelo_agenda/request_modelo_agenda"
+	"meu-servico-agenda/internal/adapters/http/modelo_agenda/response_modelo_agenda"
+	"meu-servico-agenda/internal/adapters/http/prestador/request_prestador"
+	"meu-servico-agenda/internal/adapters/http/prestador/response_prestador"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// SetupPostModeloAgendaRequest executa request de criação de modelo de agenda
+func SetupPostModeloAgendaRequest(router *gin.Engine, prestadorID string, input request_modelo_agenda.ModeloAgendaRequest) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(input)
+	url := "/api/v1/prestadores/" + prestadorID + "/modelos-agenda"
+	req, _ := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+// SetupPostGerarAgendasRequest executa request de geração de agendas a partir de um modelo
+func SetupPostGerarAgendasRequest(router *gin.Engine, prestadorID, modeloID string, input request_modelo_agenda.GerarAgendasRequest) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(input)
+	url := "/api/v1/prestadores/" + prestadorID + "/modelos-agenda/" + modeloID + "/gerar"
+	req, _ := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+func modeloSegundaASexta(vigenciaInicio, vigenciaFim string) request_modelo_agenda.ModeloAgendaRequest {
+	return request_modelo_agenda.ModeloAgendaRequest{
+		DiasDaSemana: []int{1, 2, 3, 4, 5},
+		Intervalos: []request_modelo_agenda.IntervaloModeloRequest{
+			{HoraInicio: "08:00", HoraFim: "12:00"},
+			{HoraInicio: "13:00", HoraFim: "18:00"},
+		},
+		VigenciaInicio: vigenciaInicio,
+		VigenciaFim:    vigenciaFim,
+	}
+}
+
+// CriarModeloAgendaValido cria um modelo de seg a sex para o prestador
+func CriarModeloAgendaValido(t *testing.T, router *gin.Engine, prestadorID, vigenciaInicio, vigenciaFim string) response_modelo_agenda.ModeloAgendaResponse {
+	rr := SetupPostModeloAgendaRequest(router, prestadorID, modeloSegundaASexta(vigenciaInicio, vigenciaFim))
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	var modelo response_modelo_agenda.ModeloAgendaResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &modelo))
+	return modelo
+}
+
+func TestModeloAgenda_CadastroELista(t *testing.T) {
+	router, prestadorResp, _ := CriarPrestadorValidoParaTeste(t)
+
+	modelo := CriarModeloAgendaValido(t, router, prestadorResp.ID, "2030-01-01", "2030-12-31")
+	assert.Equal(t, prestadorResp.ID, modelo.PrestadorID)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, modelo.DiasDaSemana)
+	assert.Len(t, modelo.Intervalos, 2)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/prestadores/"+prestadorResp.ID+"/modelos-agenda", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var modelos []response_modelo_agenda.ModeloAgendaResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &modelos))
+	require.Len(t, modelos, 1)
+	assert.Equal(t, modelo.ID, modelos[0].ID)
+}
+
+func TestModeloAgenda_CadastroInvalido(t *testing.T) {
+	router, prestadorResp, _ := CriarPrestadorValidoParaTeste(t)
+
+	sobreposto := modeloSegundaASexta("2030-01-01", "2030-12-31")
+	sobreposto.Intervalos[1].HoraInicio = "11:00"
+	rr := SetupPostModeloAgendaRequest(router, prestadorResp.ID, sobreposto)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	diaInvalido := modeloSegundaASexta("2030-01-01", "2030-12-31")
+	diaInvalido.DiasDaSemana = []int{1, 7}
+	rr = SetupPostModeloAgendaRequest(router, prestadorResp.ID, diaInvalido)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	vigenciaInvertida := modeloSegundaASexta("2030-12-31", "2030-01-01")
+	rr = SetupPostModeloAgendaRequest(router, prestadorResp.ID, vigenciaInvertida)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = SetupPostModeloAgendaRequest(router, "id-inexistente", modeloSegundaASexta("2030-01-01", "2030-12-31"))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestModeloAgenda_GerarSomenteDiasDoModelo(t *testing.T) {
+	router, prestadorResp, _ := CriarPrestadorValidoParaTeste(t)
+	modelo := CriarModeloAgendaValido(t, router, prestadorResp.ID, "2030-01-01", "2030-12-31")
+
+	// 2030-01-06 é domingo e 2030-01-12 é sábado
+	rr := SetupPostGerarAgendasRequest(router, prestadorResp.ID, modelo.ID, request_modelo_agenda.GerarAgendasRequest{
+		DataInicio: "2030-01-06",
+		DataFim:    "2030-01-12",
+	})
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resultado response_modelo_agenda.GeracaoAgendaResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resultado))
+	assert.Equal(t, []string{"2030-01-07", "2030-01-08", "2030-01-09", "2030-01-10", "2030-01-11"}, resultado.Criadas)
+	assert.Empty(t, resultado.Ignoradas)
+
+	rrGet := SetupGetPrestadorRequest(router, prestadorResp.ID)
+	var prestador response_prestador.PrestadorResponse
+	require.NoError(t, json.Unmarshal(rrGet.Body.Bytes(), &prestador))
+	require.Len(t, prestador.Agenda, 5)
+	assert.Len(t, prestador.Agenda[0].Intervalos, 2)
+}
+
+func TestModeloAgenda_GerarNaoSobrescreveDiaManual(t *testing.T) {
+	router, prestadorResp, _ := CriarPrestadorValidoParaTeste(t)
+	modelo := CriarModeloAgendaValido(t, router, prestadorResp.ID, "2030-01-01", "2030-12-31")
+
+	manual := request_prestador.AgendaDiariaRequest{
+		Data: "2030-01-08",
+		Intervalos: []request_prestador.IntervaloDiarioRequest{
+			{HoraInicio: "09:00", HoraFim: "10:00"},
+		},
+	}
+	require.Equal(t, http.StatusNoContent, SetupPutAgendaRequest(router, prestadorResp.ID, manual).Code)
+
+	periodo := request_modelo_agenda.GerarAgendasRequest{DataInicio: "2030-01-07", DataFim: "2030-01-09"}
+	rr := SetupPostGerarAgendasRequest(router, prestadorResp.ID, modelo.ID, periodo)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resultado response_modelo_agenda.GeracaoAgendaResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resultado))
+	assert.Equal(t, []string{"2030-01-07", "2030-01-09"}, resultado.Criadas)
+	assert.Equal(t, []string{"2030-01-08"}, resultado.Ignoradas)
+
+	rrGet := SetupGetPrestadorRequest(router, prestadorResp.ID)
+	var prestador response_prestador.PrestadorResponse
+	require.NoError(t, json.Unmarshal(rrGet.Body.Bytes(), &prestador))
+	for _, agenda := range prestador.Agenda {
+		if agenda.Data == "2030-01-08" {
+			require.Len(t, agenda.Intervalos, 1)
+			assert.Equal(t, "09:00", agenda.Intervalos[0].HoraInicio)
+		}
+	}
+
+	// gerar de novo não cria nada
+	rr = SetupPostGerarAgendasRequest(router, prestadorResp.ID, modelo.ID, periodo)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resultado))
+	assert.Empty(t, resultado.Criadas)
+	assert.Len(t, resultado.Ignoradas, 3)
+}
+
+func TestModeloAgenda_GerarRespeitaVigencia(t *testing.T) {
+	router, prestadorResp, _ := CriarPrestadorValidoParaTeste(t)
+	modelo := CriarModeloAgendaValido(t, router, prestadorResp.ID, "2030-01-08", "2030-01-09")
+
+	rr := SetupPostGerarAgendasRequest(router, prestadorResp.ID, modelo.ID, request_modelo_agenda.GerarAgendasRequest{
+		DataInicio: "2030-01-06",
+		DataFim:    "2030-01-12",
+	})
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resultado response_modelo_agenda.GeracaoAgendaResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resultado))
+	assert.Equal(t, []string{"2030-01-08", "2030-01-09"}, resultado.Criadas)
+}
+
+func TestModeloAgenda_GerarErros(t *testing.T) {
+	router, prestadorResp, _ := CriarPrestadorValidoParaTeste(t)
+	modelo := CriarModeloAgendaValido(t, router, prestadorResp.ID, "2030-01-01", "2030-12-31")
+
+	rr := SetupPostGerarAgendasRequest(router, prestadorResp.ID, "modelo-inexistente", request_modelo_agenda.GerarAgendasRequest{
+		DataInicio: "2030-01-06",
+		DataFim:    "2030-01-12",
+	})
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = SetupPostGerarAgendasRequest(router, prestadorResp.ID, modelo.ID, request_modelo_agenda.GerarAgendasRequest{
+		DataInicio: "2030-01-12",
+		DataFim:    "2030-01-06",
+	})
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = SetupPostGerarAgendasRequest(router, prestadorResp.ID, modelo.ID, request_modelo_agenda.GerarAgendasRequest{
+		DataInicio: "2030-01-01",
+		DataFim:    "2031-06-01",
+	})
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = SetupPostGerarAgendasRequest(router, prestadorResp.ID, modelo.ID, request_modelo_agenda.GerarAgendasRequest{
+		DataInicio: "2020-01-01",
+		DataFim:    "2020-01-10",
+	})
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestModeloAgenda_Deletar(t *testing.T) {
+	router, prestadorResp, _ := CriarPrestadorValidoParaTeste(t)
+	modelo := CriarModeloAgendaValido(t, router, prestadorResp.ID, "2030-01-01", "2030-12-31")
+
+	url := "/api/v1/prestadores/" + prestadorResp.ID + "/modelos-agenda/" + modelo.ID
+
+	req, _ := http.NewRequest(http.MethodDelete, url, nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	req, _ = http.NewRequest(http.MethodDelete, url, nil)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
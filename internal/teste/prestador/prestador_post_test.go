@@ -40,8 +40,8 @@ func TestPostPrestador_FalhaCatalogoInexistente(t *testing.T) {
 
 	rr := SetupPostPrestadorRequest(router, prestadorInput)
 
-	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
-	require.Contains(t, rr.Body.String(), "não existe")
+	require.Equal(t, http.StatusNotFound, rr.Code)
+	require.Contains(t, rr.Body.String(), "catalogo_nao_existe")
 }
 
 func TestPostPrestador_CPFDuplicado(t *testing.T) {
@@ -82,7 +82,7 @@ func TestPostPrestador_CamposObrigatorios(t *testing.T) {
 				ImagemUrl:   "https://exemplo.com/img1.jpg",
 				CatalogoIDs: []string{catalogoResp.ID},
 			},
-			expectedMsg: "Nome",
+			expectedMsg: "\"nome\"",
 		},
 		{
 			name: "CPF inválido",
@@ -106,7 +106,7 @@ func TestPostPrestador_CamposObrigatorios(t *testing.T) {
 				ImagemUrl:   "https://exemplo.com/img1.jpg",
 				CatalogoIDs: []string{catalogoResp.ID},
 			},
-			expectedMsg: "Email",
+			expectedMsg: "\"email\"",
 		},
 		{
 			name: "Telefone muito curto",
@@ -118,7 +118,7 @@ func TestPostPrestador_CamposObrigatorios(t *testing.T) {
 				ImagemUrl:   "https://exemplo.com/img1.jpg",
 				CatalogoIDs: []string{catalogoResp.ID},
 			},
-			expectedMsg: "Telefone",
+			expectedMsg: "\"telefone\"",
 		},
 		{
 			name: "Sem catálogos",
@@ -130,7 +130,7 @@ func TestPostPrestador_CamposObrigatorios(t *testing.T) {
 				ImagemUrl:   "https://exemplo.com/img1.jpg",
 				CatalogoIDs: []string{},
 			},
-			expectedMsg: "CatalogoIDs", // ✅ Mensagem real do binding
+			expectedMsg: "\"catalogo_ids\"",
 		},
 	}
 
@@ -141,4 +141,60 @@ func TestPostPrestador_CamposObrigatorios(t *testing.T) {
 			require.Contains(t, rr.Body.String(), tc.expectedMsg)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestPostPrestador_ComFusoHorario(t *testing.T) {
+	router, _ := SetupPostPrestador()
+	catalogoResp := CriarCatalogoValido(t, router)
+
+	prestadorInput := request_prestador.PrestadorRequest{
+		Nome:        "João da Silva",
+		Email:       "joao@email.com",
+		Cpf:         "04423258196",
+		Telefone:    "62999677481",
+		ImagemUrl:   "https://exemplo.com/img1.jpg",
+		CatalogoIDs: []string{catalogoResp.ID},
+		FusoHorario: "America/Sao_Paulo",
+	}
+
+	rr := SetupPostPrestadorRequest(router, prestadorInput)
+	require.Equal(t, http.StatusCreated, rr.Code)
+	require.Contains(t, rr.Body.String(), `"fuso_horario":"America/Sao_Paulo"`)
+}
+
+func TestPostPrestador_FusoHorarioPadraoUTC(t *testing.T) {
+	router, _ := SetupPostPrestador()
+	catalogoResp := CriarCatalogoValido(t, router)
+
+	prestadorInput := request_prestador.PrestadorRequest{
+		Nome:        "João da Silva",
+		Email:       "joao@email.com",
+		Cpf:         "04423258196",
+		Telefone:    "62999677481",
+		ImagemUrl:   "https://exemplo.com/img1.jpg",
+		CatalogoIDs: []string{catalogoResp.ID},
+	}
+
+	rr := SetupPostPrestadorRequest(router, prestadorInput)
+	require.Equal(t, http.StatusCreated, rr.Code)
+	require.Contains(t, rr.Body.String(), `"fuso_horario":"UTC"`)
+}
+
+func TestPostPrestador_FusoHorarioInvalido(t *testing.T) {
+	router, _ := SetupPostPrestador()
+	catalogoResp := CriarCatalogoValido(t, router)
+
+	prestadorInput := request_prestador.PrestadorRequest{
+		Nome:        "João da Silva",
+		Email:       "joao@email.com",
+		Cpf:         "04423258196",
+		Telefone:    "62999677481",
+		ImagemUrl:   "https://exemplo.com/img1.jpg",
+		CatalogoIDs: []string{catalogoResp.ID},
+		FusoHorario: "America/Atlantida",
+	}
+
+	rr := SetupPostPrestadorRequest(router, prestadorInput)
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	require.Contains(t, rr.Body.String(), "fuso horário inválido")
+}
@@ -1,6 +1,7 @@
 package teste
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -94,9 +95,8 @@ func TestUpdatePrestador_CatalogoInexistente(t *testing.T) {
 
 	rr := SetupPutPrestadorRequest(router, prestadorResp.ID, updateInput)
 
-	require.Equal(t, http.StatusBadRequest, rr.Code)
-	require.Contains(t, rr.Body.String(), "catálogo")
-	require.Contains(t, rr.Body.String(), "não existe")
+	require.Equal(t, http.StatusNotFound, rr.Code)
+	require.Contains(t, rr.Body.String(), "catalogo_nao_existe")
 }
 
 func TestUpdatePrestador_AtualizarCatalogos(t *testing.T) {
@@ -175,7 +175,7 @@ func TestUpdatePrestador_DadosInvalidos(t *testing.T) {
 				ImagemUrl:   "https://exemplo.com/img1.jpg",
 				CatalogoIDs: []string{catalogoResp.ID},
 			},
-			expectedMsg: "Nome",
+			expectedMsg: "\"nome\"",
 		},
 		{
 			name: "Email inválido",
@@ -186,7 +186,7 @@ func TestUpdatePrestador_DadosInvalidos(t *testing.T) {
 				ImagemUrl:   "https://exemplo.com/img1.jpg",
 				CatalogoIDs: []string{catalogoResp.ID},
 			},
-			expectedMsg: "Email",
+			expectedMsg: "\"email\"",
 		},
 		{
 			name: "Telefone muito curto",
@@ -197,7 +197,7 @@ func TestUpdatePrestador_DadosInvalidos(t *testing.T) {
 				ImagemUrl:   "https://exemplo.com/img1.jpg",
 				CatalogoIDs: []string{catalogoResp.ID},
 			},
-			expectedMsg: "Telefone",
+			expectedMsg: "\"telefone\"",
 		},
 		{
 			name: "URL inválida",
@@ -208,7 +208,7 @@ func TestUpdatePrestador_DadosInvalidos(t *testing.T) {
 				ImagemUrl:   "url-invalida",
 				CatalogoIDs: []string{catalogoResp.ID},
 			},
-			expectedMsg: "ImagemUrl",
+			expectedMsg: "\"image_url\"",
 		},
 	}
 
@@ -249,7 +249,7 @@ func TestUpdatePrestador_CPFNaoMuda_Isolado(t *testing.T) {
 	prestadorResp := CriarPrestadorValido(t, router, catalogoResp.ID, "04423258196")
 
 	// 2. Buscar diretamente do repo (sem HTTP)
-	prestadorRepo, err := repo.BuscarPorId(prestadorResp.ID)
+	prestadorRepo, err := repo.BuscarPorId(context.Background(), prestadorResp.ID)
 	require.NoError(t, err)
 	assert.Equal(t, "04423258196", prestadorRepo.Cpf)
 
@@ -265,7 +265,7 @@ func TestUpdatePrestador_CPFNaoMuda_Isolado(t *testing.T) {
 	require.Equal(t, http.StatusNoContent, rrUpdate.Code)
 
 	// 4. Buscar novamente do repo
-	prestadorRepo2, err := repo.BuscarPorId(prestadorResp.ID)
+	prestadorRepo2, err := repo.BuscarPorId(context.Background(), prestadorResp.ID)
 	require.NoError(t, err)
 	assert.Equal(t, "04423258196", prestadorRepo2.Cpf, "CPF não deve mudar no repo")
 
@@ -337,7 +337,7 @@ func TestAtivarPrestador_Sucesso(t *testing.T) {
 
 	// Inativar primeiro
 	prestadorResp.Ativo = false
-	repo.Salvar(&prestadorResp)
+	repo.Salvar(context.Background(), &prestadorResp)
 
 	// Ativar
 	req, _ := http.NewRequest(http.MethodPut, "/api/v1/prestadores/"+prestadorResp.ID+"/ativar", nil)
@@ -353,3 +353,90 @@ func TestAtivarPrestador_Sucesso(t *testing.T) {
 
 	assert.True(t, prestador.Ativo)
 }
+
+func TestUpdatePrestador_CondicoesDoServico(t *testing.T) {
+	router, _ := SetupPostPrestador()
+	catalogoResp := CriarCatalogoValido(t, router)
+	prestadorResp := CriarPrestadorValido(t, router, catalogoResp.ID, "04423258196")
+
+	preco := 5000
+	duracao := 45
+	updateInput := request_prestador.PrestadorUpdateRequest{
+		Nome:        "João da Silva",
+		Telefone:    "62999677481",
+		ImagemUrl:   "https://exemplo.com/img1.jpg",
+		CatalogoIDs: []string{catalogoResp.ID},
+		Condicoes: []request_prestador.CondicaoDoServicoRequest{
+			{CatalogoID: catalogoResp.ID, Preco: &preco, DuracaoPadrao: &duracao},
+		},
+	}
+	rrUpdate := SetupPutPrestadorRequest(router, prestadorResp.ID, updateInput)
+	require.Equal(t, http.StatusNoContent, rrUpdate.Code)
+
+	rrGet := SetupGetPrestadorRequest(router, prestadorResp.ID)
+	require.Equal(t, http.StatusOK, rrGet.Code)
+
+	var prestadorAtualizado response_prestador.PrestadorResponse
+	require.NoError(t, json.Unmarshal(rrGet.Body.Bytes(), &prestadorAtualizado))
+
+	// O catálogo continua com os valores originais
+	require.Len(t, prestadorAtualizado.Catalogo, 1)
+	assert.Equal(t, 30, prestadorAtualizado.Catalogo[0].DuracaoPadrao)
+
+	require.Len(t, prestadorAtualizado.Condicoes, 1)
+	assert.Equal(t, catalogoResp.ID, prestadorAtualizado.Condicoes[0].CatalogoID)
+	assert.Equal(t, 5000, *prestadorAtualizado.Condicoes[0].Preco)
+	assert.Equal(t, 45, *prestadorAtualizado.Condicoes[0].DuracaoPadrao)
+
+	// Sem condicoes_servico o prestador volta aos valores do catálogo
+	updateInput.Condicoes = nil
+	rrUpdate = SetupPutPrestadorRequest(router, prestadorResp.ID, updateInput)
+	require.Equal(t, http.StatusNoContent, rrUpdate.Code)
+
+	rrGet = SetupGetPrestadorRequest(router, prestadorResp.ID)
+	require.NoError(t, json.Unmarshal(rrGet.Body.Bytes(), &prestadorAtualizado))
+	assert.Empty(t, prestadorAtualizado.Condicoes)
+}
+
+func TestUpdatePrestador_CondicaoForaDoCatalogo(t *testing.T) {
+	router, _ := SetupPostPrestador()
+	catalogoResp := CriarCatalogoValido(t, router)
+	prestadorResp := CriarPrestadorValido(t, router, catalogoResp.ID, "04423258196")
+
+	preco := 5000
+	updateInput := request_prestador.PrestadorUpdateRequest{
+		Nome:        "João da Silva",
+		Telefone:    "62999677481",
+		ImagemUrl:   "https://exemplo.com/img1.jpg",
+		CatalogoIDs: []string{catalogoResp.ID},
+		Condicoes: []request_prestador.CondicaoDoServicoRequest{
+			{CatalogoID: "outro-catalogo", Preco: &preco},
+		},
+	}
+
+	rr := SetupPutPrestadorRequest(router, prestadorResp.ID, updateInput)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	require.Contains(t, rr.Body.String(), "serviços oferecidos pelo prestador")
+}
+
+func TestUpdatePrestador_CondicaoDuracaoInvalida(t *testing.T) {
+	router, _ := SetupPostPrestador()
+	catalogoResp := CriarCatalogoValido(t, router)
+	prestadorResp := CriarPrestadorValido(t, router, catalogoResp.ID, "04423258196")
+
+	duracao := 1
+	updateInput := request_prestador.PrestadorUpdateRequest{
+		Nome:        "João da Silva",
+		Telefone:    "62999677481",
+		ImagemUrl:   "https://exemplo.com/img1.jpg",
+		CatalogoIDs: []string{catalogoResp.ID},
+		Condicoes: []request_prestador.CondicaoDoServicoRequest{
+			{CatalogoID: catalogoResp.ID, DuracaoPadrao: &duracao},
+		},
+	}
+
+	rr := SetupPutPrestadorRequest(router, prestadorResp.ID, updateInput)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
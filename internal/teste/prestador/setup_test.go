@@ -10,6 +10,8 @@ import (
 	"meu-servico-agenda/internal/adapters/http/catalogo"
 	"meu-servico-agenda/internal/adapters/http/catalogo/request_catalogo"
 	"meu-servico-agenda/internal/adapters/http/catalogo/response_catalogo"
+	"meu-servico-agenda/internal/adapters/http/middleware"
+	"meu-servico-agenda/internal/adapters/http/modelo_agenda"
 	"meu-servico-agenda/internal/adapters/http/prestador"
 	"meu-servico-agenda/internal/adapters/http/prestador/request_prestador"
 	"meu-servico-agenda/internal/adapters/http/prestador/response_prestador"
@@ -29,6 +31,7 @@ func SetupPostPrestador() (*gin.Engine, port.PrestadorRepositorio) {
 	catalogoRepo := repository.NovoCatalogoFakeRepo()
 	prestadorRepo := repository.NovoFakePrestadorRepositorio(catalogoRepo)
 	agendaRepo := repository.NovoFakeAgendaDiariaRepositorio()
+	modeloAgendaRepo := repository.NovoFakeModeloAgendaRepositorio()
 	cadastroService := service.NovoCatalogoService(catalogoRepo)
 
 	prestadorService := service.NovaPrestadorService(
@@ -36,14 +39,20 @@ func SetupPostPrestador() (*gin.Engine, port.PrestadorRepositorio) {
 		catalogoRepo,
 		agendaRepo,
 	)
+	prestadorService.DefinirUnidadeDeTrabalho(repository.NovaFakeUnidadeDeTrabalho(prestadorRepo, agendaRepo))
+
+	modeloAgendaService := service.NovoModeloAgendaService(prestadorRepo, modeloAgendaRepo, agendaRepo)
+	modeloAgendaService.DefinirUnidadeDeTrabalho(repository.NovaFakeUnidadeDeTrabalho(prestadorRepo, agendaRepo))
 
 	prestadorController := prestador.NovoPrestadorController(prestadorService)
+	modeloAgendaController := modelo_agenda.NovoModeloAgendaController(modeloAgendaService)
 	catalogoController := catalogo.NovoCatalogoController(cadastroService)
+	idempotente := middleware.Idempotencia(repository.NovoFakeIdempotenciaRepositorio(), middleware.TTLPadraoIdempotencia)
 
 	router := gin.Default()
 	apiV1 := router.Group("/api/v1")
 	{
-		apiV1.POST("/prestadores", prestadorController.PostPrestador)
+		apiV1.POST("/prestadores", idempotente, prestadorController.PostPrestador)
 		apiV1.GET("/prestadores", prestadorController.GetPrestadores)
 		apiV1.GET("/prestadores/disponiveis", prestadorController.GetPrestadoresPorData)
 		apiV1.GET("/prestadores/:id", prestadorController.GetPrestador)
@@ -52,6 +61,10 @@ func SetupPostPrestador() (*gin.Engine, port.PrestadorRepositorio) {
 		apiV1.PUT("/prestadores/:id/inativar", prestadorController.InativarPrestador) 
 		apiV1.PUT("/prestadores/:id/ativar", prestadorController.AtivarPrestador)
 		apiV1.DELETE("/prestadores/:id/agenda", prestadorController.DeleteAgenda)
+		apiV1.POST("/prestadores/:id/modelos-agenda", modeloAgendaController.PostModeloAgenda)
+		apiV1.GET("/prestadores/:id/modelos-agenda", modeloAgendaController.GetModelosAgenda)
+		apiV1.DELETE("/prestadores/:id/modelos-agenda/:modeloId", modeloAgendaController.DeleteModeloAgenda)
+		apiV1.POST("/prestadores/:id/modelos-agenda/:modeloId/gerar", modeloAgendaController.PostGerarAgendas)
 
 		apiV1.POST("/catalogos", catalogoController.PostCatalogo)
 	}
@@ -0,0 +1,134 @@
+package teste
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"strings"
+	"testing"
+
+	"meu-servico-agenda/internal/adapters/http/cliente/request"
+	"meu-servico-agenda/internal/adapters/http/resposta"
+	"meu-servico-agenda/internal/core/application/service"
+	"meu-servico-agenda/internal/core/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+// sentinelas devolve os nomes das variáveis Err* declaradas no arquivo
+func sentinelas(t *testing.T, caminho string) []string {
+	t.Helper()
+	arquivo, err := parser.ParseFile(token.NewFileSet(), caminho, nil, 0)
+	require.NoError(t, err)
+
+	var nomes []string
+	for _, decl := range arquivo.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			for _, nome := range spec.(*ast.ValueSpec).Names {
+				if strings.HasPrefix(nome.Name, "Err") {
+					nomes = append(nomes, nome.Name)
+				}
+			}
+		}
+	}
+	return nomes
+}
+
+func TestProblema_TodoSentinelaTemCodigo(t *testing.T) {
+	mapeamento, err := parser.ParseFile(token.NewFileSet(), "../adapters/http/resposta/erros.go", nil, 0)
+	require.NoError(t, err)
+
+	mapeados := map[string]bool{}
+	ast.Inspect(mapeamento, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if pacote, ok := sel.X.(*ast.Ident); ok {
+				mapeados[pacote.Name+"."+sel.Sel.Name] = true
+			}
+		}
+		return true
+	})
+
+	for pacote, caminho := range map[string]string{
+		"domain":  "../core/domain/errors.go",
+		"service": "../core/application/service/errors.go",
+	} {
+		for _, nome := range sentinelas(t, caminho) {
+			require.True(t, mapeados[pacote+"."+nome], "%s.%s sem código em resposta/erros.go", pacote, nome)
+		}
+	}
+}
+
+func TestProblema_ClassificarErroEmbrulhado(t *testing.T) {
+	status, codigo, ok := resposta.Classificar(fmt.Errorf("%w: 123", service.ErrCPFJaCadastrado))
+	require.True(t, ok)
+	require.Equal(t, http.StatusConflict, status)
+	require.Equal(t, "cpf_ja_cadastrado", codigo)
+
+	status, _, _ = resposta.Classificar(domain.ErrDataEstaNoPassado)
+	require.Equal(t, http.StatusBadRequest, status)
+
+	_, _, ok = resposta.Classificar(fmt.Errorf("pq: conexão encerrada"))
+	require.False(t, ok)
+}
+
+func TestProblema_RecursoNaoEncontrado(t *testing.T) {
+	router, _ := SetupRouterCliente()
+
+	rr := SetupGetClienteRequest(router, "inexistente")
+	require.Equal(t, http.StatusNotFound, rr.Code)
+	require.Equal(t, resposta.ContentTypeProblema, rr.Header().Get("Content-Type"))
+
+	var corpo resposta.Problema
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &corpo))
+	require.Equal(t, resposta.Problema{
+		Type:     "about:blank",
+		Title:    "Not Found",
+		Status:   http.StatusNotFound,
+		Detail:   service.ErrClienteNaoEncontrado.Error(),
+		Instance: "/api/v1/clientes/inexistente",
+		Code:     "cliente_nao_encontrado",
+	}, corpo)
+}
+
+func TestProblema_ValidacaoListaCadaCampo(t *testing.T) {
+	router, _ := SetupRouterCliente()
+
+	rr := SetupPostClienteRequest(router, request.ClienteRequest{Email: "email-invalido"})
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	require.Equal(t, resposta.ContentTypeProblema, rr.Header().Get("Content-Type"))
+
+	var corpo resposta.Problema
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &corpo))
+	require.Equal(t, resposta.CodigoDadosInvalidos, corpo.Code)
+
+	campos := map[string]string{}
+	for _, p := range corpo.InvalidParams {
+		require.NotEmpty(t, p.Reason)
+		campos[p.Name] = p.Code
+	}
+	require.Equal(t, map[string]string{
+		"nome":     "required",
+		"email":    "email",
+		"telefone": "required",
+	}, campos)
+}
+
+func TestProblema_CorpoMalformado(t *testing.T) {
+	router, _ := SetupRouterCliente()
+
+	rr := SetupPostClienteRequest(router, json.RawMessage(`{"nome": 1}`))
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var corpo resposta.Problema
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &corpo))
+	require.Equal(t, resposta.CodigoDadosInvalidos, corpo.Code)
+	require.Len(t, corpo.InvalidParams, 1)
+	require.Equal(t, "nome", corpo.InvalidParams[0].Name)
+}
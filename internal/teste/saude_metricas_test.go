@@ -0,0 +1,135 @@
+package teste
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"meu-servico-agenda/internal/adapters/http/agendamento"
+	"meu-servico-agenda/internal/adapters/http/agendamento/request_agendamento"
+	"meu-servico-agenda/internal/adapters/http/middleware"
+	"meu-servico-agenda/internal/adapters/http/saude"
+	"meu-servico-agenda/internal/adapters/repository"
+	"meu-servico-agenda/internal/core/application/service"
+	"meu-servico-agenda/internal/infra/metricas"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type bancoFake struct {
+	err error
+}
+
+func (b bancoFake) PingContext(ctx context.Context) error {
+	return b.err
+}
+
+func SetupGetRequest(router *gin.Engine, url string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func TestSaude_HealthzNaoDependeDoBanco(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	controller := saude.NovoSaudeController(bancoFake{err: errors.New("conexão recusada")})
+	router.GET("/healthz", controller.GetHealthz)
+	router.GET("/readyz", controller.GetReadyz)
+
+	rr := SetupGetRequest(router, "/healthz")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, `{"status":"ok"}`, rr.Body.String())
+
+	rr = SetupGetRequest(router, "/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	require.JSONEq(t, `{"status":"indisponivel","verificacoes":{"banco":"indisponivel"}}`, rr.Body.String())
+}
+
+func TestSaude_ReadyzComBancoDisponivel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.GET("/readyz", saude.NovoSaudeController(bancoFake{}).GetReadyz)
+
+	rr := SetupGetRequest(router, "/readyz")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, `{"status":"ok","verificacoes":{"banco":"ok"}}`, rr.Body.String())
+}
+
+func TestMetricas_RequisicoesEAgendamentos(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	catalogoRepo := repository.NovoCatalogoFakeRepo()
+	prestadorRepo := repository.NovoFakePrestadorRepositorio(catalogoRepo)
+	clienteRepo := repository.NewFakeClienteRepositorio()
+	agendaDiariaRepo := repository.NovoFakeAgendaDiariaRepositorio()
+	agendamentoService := service.NovaAgendamentoService(prestadorRepo, repository.NovoFakeAgendamentoRepositorio(), catalogoRepo, clienteRepo)
+
+	prometheus := metricas.NovoPrometheus(nil)
+	agendamentoService.DefinirMetricas(prometheus)
+
+	router := gin.Default()
+	router.Use(middleware.Metricas(prometheus))
+	agendamentoController := agendamento.NovoAgendamentoController(agendamentoService)
+	router.POST("/api/v1/agendamentos", agendamentoController.PostAgendamento)
+	router.PUT("/api/v1/agendamentos/:id/cancelar", agendamentoController.PutCancelarAgendamento)
+	router.GET("/metrics", gin.WrapH(prometheus.Handler()))
+
+	cliente := SetupNovoCliente(clienteRepo)
+	catalogo, listaDeCatalogos := SetupNovoCatalogo(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *listaDeCatalogos)
+	prestador.AdicionarAgenda(SetupCriaAgendaDiaria(agendaDiariaRepo))
+
+	input := request_agendamento.AgendamentoRequest{
+		ClienteID:      cliente.ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: "2030-01-03T08:00:00Z",
+	}
+	rr := SetupPostAgendamentoRequest(router, input)
+	require.Equal(t, http.StatusCreated, rr.Code)
+	var criado struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &criado))
+
+	// Mesma categoria no mesmo dia é recusada
+	input.DataHoraInicio = "2030-01-03T10:00:00Z"
+	rr = SetupPostAgendamentoRequest(router, input)
+	require.Equal(t, http.StatusConflict, rr.Code)
+
+	rr = SetupPutStatusAgendamentoRequest(router, criado.ID, "cancelar")
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	SetupGetRequest(router, "/api/v1/nao-existe/123")
+	for _, metodo := range []string{"FOO", "BAR"} {
+		req, _ := http.NewRequest(metodo, "/api/v1/nao-existe", nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	rr = SetupGetRequest(router, "/metrics")
+	require.Equal(t, http.StatusOK, rr.Code)
+	corpo, err := io.ReadAll(rr.Body)
+	require.NoError(t, err)
+
+	for _, linha := range []string{
+		`agendamentos_criados_total 1`,
+		`agendamentos_rejeitados_total{motivo="agendamento_duplo"} 1`,
+		`agendamentos_cancelados_total 1`,
+		`http_requests_total{method="POST",route="/api/v1/agendamentos",status="201"} 1`,
+		`http_requests_total{method="PUT",route="/api/v1/agendamentos/:id/cancelar",status="204"} 1`,
+		`http_requests_total{method="GET",route="nao_encontrada",status="404"} 1`,
+		`http_requests_total{method="OTHER",route="nao_encontrada",status="404"} 2`,
+		`http_request_duration_seconds_count{method="POST",route="/api/v1/agendamentos"} 2`,
+	} {
+		require.Contains(t, string(corpo), linha)
+	}
+}
@@ -0,0 +1,412 @@
+package teste
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"meu-servico-agenda/internal/adapters/repository"
+	"meu-servico-agenda/internal/core/application/input"
+	"meu-servico-agenda/internal/core/application/output"
+	"meu-servico-agenda/internal/core/application/port"
+	"meu-servico-agenda/internal/core/application/service"
+	"meu-servico-agenda/internal/core/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errGravacao = errors.New("falha ao gravar")
+
+// agendaDiariaQueFalha grava a agenda e depois falha, como uma transação que
+// perde a conexão no meio do caminho
+type agendaDiariaQueFalha struct {
+	port.AgendaDiariaRepositorio
+}
+
+func (r agendaDiariaQueFalha) Salvar(ctx context.Context, agenda *domain.AgendaDiaria, prestadorID string) error {
+	if err := r.AgendaDiariaRepositorio.Salvar(ctx, agenda, prestadorID); err != nil {
+		return err
+	}
+	return errGravacao
+}
+
+func TestUnidadeDeTrabalho_ErroDesfazAlteracoes(t *testing.T) {
+	ctx := context.Background()
+	catalogoRepo := repository.NovoCatalogoFakeRepo()
+	_, catalogos := SetupNovoCatalogo(catalogoRepo)
+	prestadorRepo := repository.NovoFakePrestadorRepositorio(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *catalogos)
+	agendaRepo := repository.NovoFakeAgendaDiariaRepositorio()
+
+	unidade := repository.NovaFakeUnidadeDeTrabalho(prestadorRepo, agendaRepo)
+	err := unidade.Executar(ctx, func(ctx context.Context) error {
+		agenda := SetupCriaAgendaDiaria(agendaRepo)
+		require.NoError(t, prestador.AdicionarAgenda(agenda))
+		require.NoError(t, prestadorRepo.AtualizarStatus(ctx, prestador.ID, false))
+		return errGravacao
+	})
+	require.ErrorIs(t, err, errGravacao)
+
+	salvo, err := prestadorRepo.BuscarPorId(ctx, prestador.ID)
+	require.NoError(t, err)
+	require.Same(t, prestador, salvo)
+	require.True(t, salvo.Ativo)
+	require.Empty(t, salvo.Agenda)
+
+	_, err = agendaRepo.BuscarAgendaDoDia(ctx, "dasdf", "2030-01-03")
+	require.Error(t, err)
+}
+
+func TestUnidadeDeTrabalho_SucessoMantemAlteracoesEAninhadaReaproveita(t *testing.T) {
+	ctx := context.Background()
+	catalogoRepo := repository.NovoCatalogoFakeRepo()
+	_, catalogos := SetupNovoCatalogo(catalogoRepo)
+	prestadorRepo := repository.NovoFakePrestadorRepositorio(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *catalogos)
+
+	unidade := repository.NovaFakeUnidadeDeTrabalho(prestadorRepo)
+	err := unidade.Executar(ctx, func(ctx context.Context) error {
+		// A unidade aninhada não pode travar esperando a externa
+		return unidade.Executar(ctx, func(ctx context.Context) error {
+			return prestadorRepo.AtualizarStatus(ctx, prestador.ID, false)
+		})
+	})
+	require.NoError(t, err)
+
+	salvo, err := prestadorRepo.BuscarPorId(ctx, prestador.ID)
+	require.NoError(t, err)
+	require.False(t, salvo.Ativo)
+}
+
+func TestSalvarAgenda_FalhaNaGravacaoNaoDeixaAgendaPelaMetade(t *testing.T) {
+	ctx := context.Background()
+	catalogoRepo := repository.NovoCatalogoFakeRepo()
+	_, catalogos := SetupNovoCatalogo(catalogoRepo)
+	prestadorRepo := repository.NovoFakePrestadorRepositorio(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *catalogos)
+	agendaRepo := repository.NovoFakeAgendaDiariaRepositorio()
+
+	prestadorService := service.NovaPrestadorService(prestadorRepo, catalogoRepo, agendaDiariaQueFalha{agendaRepo})
+	prestadorService.DefinirUnidadeDeTrabalho(repository.NovaFakeUnidadeDeTrabalho(prestadorRepo, agendaRepo))
+
+	err := prestadorService.SalvarAgenda(ctx, &input.AdicionarAgendaInput{
+		PrestadorID: prestador.ID,
+		Data:        time.Date(2030, 1, 3, 0, 0, 0, 0, time.UTC),
+		Intervalos: []input.IntervaloInput{{
+			Inicio: time.Date(0, 1, 1, 8, 0, 0, 0, time.UTC),
+			Fim:    time.Date(0, 1, 1, 12, 0, 0, 0, time.UTC),
+		}},
+	})
+	require.ErrorIs(t, err, errGravacao)
+
+	require.Empty(t, prestador.Agenda)
+	_, err = agendaRepo.BuscarAgendaDoDia(ctx, prestador.ID, "2030-01-03")
+	require.Error(t, err)
+}
+
+// agendaDiariaQueFalhaNaTerceira grava as duas primeiras agendas e falha na terceira
+type agendaDiariaQueFalhaNaTerceira struct {
+	port.AgendaDiariaRepositorio
+	gravadas *int
+}
+
+func (r agendaDiariaQueFalhaNaTerceira) Salvar(ctx context.Context, agenda *domain.AgendaDiaria, prestadorID string) error {
+	if *r.gravadas == 2 {
+		return errGravacao
+	}
+	*r.gravadas++
+	return r.AgendaDiariaRepositorio.Salvar(ctx, agenda, prestadorID)
+}
+
+func TestGerarAgendas_FalhaNoMeioDoPeriodoNaoGravaNenhumDia(t *testing.T) {
+	ctx := context.Background()
+	catalogoRepo := repository.NovoCatalogoFakeRepo()
+	_, catalogos := SetupNovoCatalogo(catalogoRepo)
+	prestadorRepo := repository.NovoFakePrestadorRepositorio(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *catalogos)
+	agendaRepo := repository.NovoFakeAgendaDiariaRepositorio()
+	modeloRepo := repository.NovoFakeModeloAgendaRepositorio()
+
+	intervalo, err := domain.NovoIntervaloDiario(time.Date(0, 1, 1, 8, 0, 0, 0, time.UTC), time.Date(0, 1, 1, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	modelo, err := domain.NovoModeloAgenda(prestador.ID,
+		[]time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		[]domain.IntervaloDiario{*intervalo},
+		time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2030, 12, 31, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.NoError(t, modeloRepo.Salvar(ctx, modelo))
+
+	var gravadas int
+	modeloService := service.NovoModeloAgendaService(prestadorRepo, modeloRepo, agendaDiariaQueFalhaNaTerceira{agendaRepo, &gravadas})
+	modeloService.DefinirUnidadeDeTrabalho(repository.NovaFakeUnidadeDeTrabalho(prestadorRepo, agendaRepo))
+
+	_, err = modeloService.GerarAgendas(ctx, &input.GerarAgendasInput{
+		PrestadorID: prestador.ID,
+		ModeloID:    modelo.ID,
+		DataInicio:  time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC),
+		DataFim:     time.Date(2030, 1, 11, 0, 0, 0, 0, time.UTC),
+	})
+	require.ErrorIs(t, err, errGravacao)
+	require.Equal(t, 2, gravadas)
+
+	require.Empty(t, prestador.Agenda)
+	for _, dia := range []string{"2030-01-07", "2030-01-08"} {
+		_, err = agendaRepo.BuscarAgendaDoDia(ctx, prestador.ID, dia)
+		require.Error(t, err, dia)
+	}
+}
+
+// agendamentoQueFalhaNaSegunda grava a primeira ocorrência e falha na segunda
+type agendamentoQueFalhaNaSegunda struct {
+	port.AgendamentoRepositorio
+	gravados *int
+}
+
+func (r agendamentoQueFalhaNaSegunda) CriaAgendamento(ctx context.Context, a *domain.Agendamento) error {
+	if *r.gravados == 1 {
+		return errGravacao
+	}
+	*r.gravados++
+	return r.AgendamentoRepositorio.CriaAgendamento(ctx, a)
+}
+
+func TestCadastraSerie_FalhaNoMeioNaoDeixaSeriePelaMetade(t *testing.T) {
+	ctx := context.Background()
+	catalogoRepo := repository.NovoCatalogoFakeRepo()
+	catalogo, catalogos := SetupNovoCatalogo(catalogoRepo)
+	prestadorRepo := repository.NovoFakePrestadorRepositorio(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *catalogos)
+	clienteRepo := repository.NewFakeClienteRepositorio()
+	cliente := SetupNovoCliente(clienteRepo)
+	agendaRepo := repository.NovoFakeAgendaDiariaRepositorio()
+	SetupAgendaNasDatas(agendaRepo, prestador, "2030-01-03", "2030-01-10", "2030-01-17")
+	agendamentoRepo := repository.NovoFakeAgendamentoRepositorio()
+
+	var gravados int
+	agendamentoService := service.NovaAgendamentoService(prestadorRepo, agendamentoQueFalhaNaSegunda{agendamentoRepo, &gravados}, catalogoRepo, clienteRepo)
+	agendamentoService.DefinirUnidadeDeTrabalho(repository.NovaFakeUnidadeDeTrabalho(prestadorRepo, agendaRepo, agendamentoRepo))
+
+	_, err := agendamentoService.CadastraSerie(ctx, input.CadastrarSerieInput{
+		ClienteID:        cliente.ID,
+		PrestadorID:      prestador.ID,
+		CatalogoID:       catalogo.ID,
+		DataHoraInicio:   time.Date(2030, 1, 3, 8, 0, 0, 0, time.UTC),
+		IntervaloSemanas: 1,
+		Ocorrencias:      3,
+	})
+	require.ErrorIs(t, err, errGravacao)
+	require.Equal(t, 1, gravados)
+
+	agendamentos, err := agendamentoRepo.BuscarAgendamentoClienteAPartirDaData(ctx, cliente.ID, time.Time{})
+	require.NoError(t, err)
+	require.Empty(t, agendamentos)
+}
+
+// solicitacaoQueFalhaAoAtender não consegue registrar os pedidos atendidos
+type solicitacaoQueFalhaAoAtender struct {
+	port.SolicitacaoLGPDRepositorio
+	falhar *bool
+}
+
+func (r solicitacaoQueFalhaAoAtender) Registrar(ctx context.Context, s *domain.SolicitacaoLGPD) error {
+	if *r.falhar && s.Resultado == domain.SolicitacaoAtendida {
+		return errGravacao
+	}
+	return r.SolicitacaoLGPDRepositorio.Registrar(ctx, s)
+}
+
+func TestAnonimizar_FalhaNoRegistroNaoAnonimizaEPodeSerRepetido(t *testing.T) {
+	ctx := context.Background()
+	clienteRepo := repository.NewFakeClienteRepositorio()
+	cliente := SetupNovoCliente(clienteRepo)
+	nome := cliente.Nome
+	agendamentoRepo := repository.NovoFakeAgendamentoRepositorio()
+	solicitacaoRepo := repository.NovoFakeSolicitacaoLGPDRepositorio()
+
+	falhar := true
+	lgpdService := service.NovaLGPDService(clienteRepo, agendamentoRepo, repository.NovoFakeListaEsperaRepositorio(), solicitacaoQueFalhaAoAtender{solicitacaoRepo, &falhar}, repository.NovoFakeTokenCalendarioRepositorio(), repository.NovoFakeUsuarioRepositorio(), repository.NovoFakeRefreshTokenRepositorio())
+	lgpdService.DefinirUnidadeDeTrabalho(repository.NovaFakeUnidadeDeTrabalho(clienteRepo, agendamentoRepo, solicitacaoRepo))
+
+	err := lgpdService.Anonimizar(ctx, cliente.ID, "admin", "")
+	require.ErrorIs(t, err, errGravacao)
+
+	atual, err := clienteRepo.BuscarPorId(ctx, cliente.ID)
+	require.NoError(t, err)
+	require.Equal(t, nome, atual.Nome)
+	require.Nil(t, atual.AnonimizadoEm)
+
+	solicitacoes, err := solicitacaoRepo.ListarPorCliente(ctx, cliente.ID)
+	require.NoError(t, err)
+	require.Len(t, solicitacoes, 1)
+	require.Equal(t, domain.SolicitacaoFalhou, solicitacoes[0].Resultado)
+
+	// Como nada foi gravado, o pedido pode ser repetido
+	falhar = false
+	require.NoError(t, lgpdService.Anonimizar(ctx, cliente.ID, "admin", ""))
+
+	solicitacoes, err = solicitacaoRepo.ListarPorCliente(ctx, cliente.ID)
+	require.NoError(t, err)
+	require.Len(t, solicitacoes, 2)
+	require.Equal(t, domain.SolicitacaoAtendida, solicitacoes[0].Resultado)
+}
+
+// chaveEmTransacao marca o contexto das funções executadas por unidadeQueMarca
+type chaveEmTransacao struct{}
+
+// unidadeQueMarca executa a unidade de trabalho de verdade e marca o contexto, para
+// que os repositórios saibam se foram chamados dentro dela
+type unidadeQueMarca struct {
+	port.UnidadeDeTrabalho
+}
+
+func (u unidadeQueMarca) Executar(ctx context.Context, fn func(ctx context.Context) error) error {
+	return u.UnidadeDeTrabalho.Executar(ctx, func(ctx context.Context) error {
+		return fn(context.WithValue(ctx, chaveEmTransacao{}, true))
+	})
+}
+
+var errForaDaTransacao = errors.New("chamado fora da unidade de trabalho")
+
+func exigirTransacao(ctx context.Context) error {
+	if ctx.Value(chaveEmTransacao{}) == nil {
+		return errForaDaTransacao
+	}
+	return nil
+}
+
+// agendamentoQueExigeTransacao recusa as travas, as consultas de conflito e as
+// gravações feitas fora da unidade de trabalho
+type agendamentoQueExigeTransacao struct {
+	port.AgendamentoRepositorio
+}
+
+func (r agendamentoQueExigeTransacao) BuscarPorIdParaAtualizar(ctx context.Context, id string) (*domain.Agendamento, error) {
+	if err := exigirTransacao(ctx); err != nil {
+		return nil, err
+	}
+	return r.AgendamentoRepositorio.BuscarPorIdParaAtualizar(ctx, id)
+}
+
+func (r agendamentoQueExigeTransacao) TravarCliente(ctx context.Context, clienteID string) error {
+	if err := exigirTransacao(ctx); err != nil {
+		return err
+	}
+	return r.AgendamentoRepositorio.TravarCliente(ctx, clienteID)
+}
+
+func (r agendamentoQueExigeTransacao) BuscarPorClienteEPeriodo(ctx context.Context, clienteID string, inicio, fim time.Time) ([]*domain.Agendamento, error) {
+	if err := exigirTransacao(ctx); err != nil {
+		return nil, err
+	}
+	return r.AgendamentoRepositorio.BuscarPorClienteEPeriodo(ctx, clienteID, inicio, fim)
+}
+
+func (r agendamentoQueExigeTransacao) BuscarPorPrestadorEPeriodo(ctx context.Context, prestadorID string, inicio, fim time.Time) ([]*domain.Agendamento, error) {
+	if err := exigirTransacao(ctx); err != nil {
+		return nil, err
+	}
+	return r.AgendamentoRepositorio.BuscarPorPrestadorEPeriodo(ctx, prestadorID, inicio, fim)
+}
+
+func (r agendamentoQueExigeTransacao) CriaAgendamento(ctx context.Context, a *domain.Agendamento) error {
+	if err := exigirTransacao(ctx); err != nil {
+		return err
+	}
+	return r.AgendamentoRepositorio.CriaAgendamento(ctx, a)
+}
+
+func (r agendamentoQueExigeTransacao) Reagendar(ctx context.Context, a *domain.Agendamento, historico *domain.Reagendamento) error {
+	if err := exigirTransacao(ctx); err != nil {
+		return err
+	}
+	return r.AgendamentoRepositorio.Reagendar(ctx, a, historico)
+}
+
+func (r agendamentoQueExigeTransacao) AtualizarStatus(ctx context.Context, id string, status domain.StatusDoAgendamento) error {
+	if err := exigirTransacao(ctx); err != nil {
+		return err
+	}
+	return r.AgendamentoRepositorio.AtualizarStatus(ctx, id, status)
+}
+
+func (r agendamentoQueExigeTransacao) TravarPrestador(ctx context.Context, prestadorID string) error {
+	if err := exigirTransacao(ctx); err != nil {
+		return err
+	}
+	return r.AgendamentoRepositorio.TravarPrestador(ctx, prestadorID)
+}
+
+func (r agendamentoQueExigeTransacao) CriaVisita(ctx context.Context, visita *domain.Visita) error {
+	if err := exigirTransacao(ctx); err != nil {
+		return err
+	}
+	return r.AgendamentoRepositorio.CriaVisita(ctx, visita)
+}
+
+// SetupAgendamentoEmTransacao cria um agendamento em 2030-01-03 08:00 por um serviço
+// cujo repositório só aceita chamadas dentro da unidade de trabalho
+func SetupAgendamentoEmTransacao(t *testing.T) (*service.AgendamentoService, port.AgendamentoRepositorio, *domain.Cliente, *domain.Prestador, *domain.Catalogo, *output.AgendamentoOutput) {
+	ctx := context.Background()
+	catalogoRepo := repository.NovoCatalogoFakeRepo()
+	catalogo, catalogos := SetupNovoCatalogo(catalogoRepo)
+	prestadorRepo := repository.NovoFakePrestadorRepositorio(catalogoRepo)
+	prestador := SetupCriaPrestador(prestadorRepo, *catalogos)
+	clienteRepo := repository.NewFakeClienteRepositorio()
+	cliente := SetupNovoCliente(clienteRepo)
+	agendaRepo := repository.NovoFakeAgendaDiariaRepositorio()
+	SetupAgendaNasDatas(agendaRepo, prestador, "2030-01-03")
+	agendamentoRepo := repository.NovoFakeAgendamentoRepositorio()
+
+	agendamentoService := service.NovaAgendamentoService(prestadorRepo, agendamentoQueExigeTransacao{agendamentoRepo}, catalogoRepo, clienteRepo)
+	agendamentoService.DefinirUnidadeDeTrabalho(unidadeQueMarca{repository.NovaFakeUnidadeDeTrabalho(prestadorRepo, clienteRepo, agendaRepo, agendamentoRepo)})
+
+	agendamento, err := agendamentoService.Agendar(ctx, input.CadastrarAgendamentoInput{
+		ClienteID:      cliente.ID,
+		PrestadorID:    prestador.ID,
+		CatalogoID:     catalogo.ID,
+		DataHoraInicio: time.Date(2030, 1, 3, 8, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	return agendamentoService, agendamentoRepo, cliente, prestador, catalogo, agendamento
+}
+
+func TestReagendar_LeituraValidacaoEGravacaoNaMesmaTransacao(t *testing.T) {
+	agendamentoService, agendamentoRepo, _, _, _, agendamento := SetupAgendamentoEmTransacao(t)
+
+	reagendado, err := agendamentoService.ReagendarAgendamento(context.Background(), input.ReagendarAgendamentoInput{
+		AgendamentoID:  agendamento.ID,
+		DataHoraInicio: time.Date(2030, 1, 3, 10, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2030, 1, 3, 10, 0, 0, 0, time.UTC), reagendado.DataHoraInicio)
+
+	historico, err := agendamentoRepo.ListarReagendamentos(context.Background(), agendamento.ID)
+	require.NoError(t, err)
+	require.Len(t, historico, 1)
+}
+
+func TestAlterarStatus_LeituraEGravacaoNaMesmaTransacao(t *testing.T) {
+	agendamentoService, agendamentoRepo, _, _, _, agendamento := SetupAgendamentoEmTransacao(t)
+
+	require.NoError(t, agendamentoService.ConfirmarAgendamento(context.Background(), agendamento.ID))
+	require.NoError(t, agendamentoService.CancelarAgendamento(context.Background(), agendamento.ID))
+
+	salvo, err := agendamentoRepo.BuscarPorId(context.Background(), agendamento.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.Cancelado, salvo.Status)
+}
+
+func TestCadastraVisita_ValidacoesEGravacaoNaMesmaTransacao(t *testing.T) {
+	agendamentoService, _, cliente, prestador, catalogo, _ := SetupAgendamentoEmTransacao(t)
+
+	visita, err := agendamentoService.CadastraVisita(context.Background(), input.CadastrarVisitaInput{
+		ClienteID:      cliente.ID,
+		DataHoraInicio: time.Date(2030, 1, 3, 10, 0, 0, 0, time.UTC),
+		Itens:          []input.ItemVisitaInput{{PrestadorID: prestador.ID, CatalogoID: catalogo.ID}},
+	})
+	// O setup já agendou o mesmo serviço no dia: a regra da categoria roda na transação
+	require.ErrorIs(t, err, service.ErrAgendamentoDuplo)
+	require.Nil(t, visita)
+}